@@ -18,6 +18,8 @@ import (
 	"github.com/relab/hotstuff/crypto/keygen"
 	"github.com/relab/hotstuff/eventloop"
 	"github.com/relab/hotstuff/internal/testutil"
+	"github.com/relab/hotstuff/synchronizer"
+	"golang.org/x/exp/slices"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
@@ -135,6 +137,220 @@ func TestTimeout(t *testing.T) {
 
 }
 
+func TestFetchRange(t *testing.T) {
+	run := func(t *testing.T, setup setupFunc) {
+		const n = 4
+		ctrl := gomock.NewController(t)
+		td := setup(t, ctrl, n)
+
+		serverTeardown := createServers(t, td, ctrl)
+		defer serverTeardown()
+
+		cfg := NewConfig(td.creds, gorums.WithDialTimeout(time.Second))
+		td.builders[0].Add(cfg)
+		hl := td.builders.Build()
+
+		err := cfg.Connect(td.replicas)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer cfg.Close()
+
+		genesis := hotstuff.GetGenesis()
+		want := make([]*hotstuff.Block, 0, 5)
+		parent := genesis
+		for view := hotstuff.View(1); view <= 5; view++ {
+			block := hotstuff.NewBlock(parent.Hash(), hotstuff.NewQuorumCert(nil, parent.View(), parent.Hash()), "foo", view, 1)
+			want = append(want, block)
+			parent = block
+		}
+		for _, hs := range hl[1:] {
+			var blockChain modules.BlockChain
+			hs.Get(&blockChain)
+			for _, block := range want {
+				blockChain.Store(block)
+			}
+		}
+
+		got, ok := cfg.FetchRange(context.Background(), want[1].Hash(), want[4].Hash())
+		if !ok {
+			t.Fatal("failed to fetch blocks")
+		}
+		if len(got) != 3 {
+			t.Fatalf("got %d blocks, want 3", len(got))
+		}
+		for i, block := range got {
+			if block.Hash() != want[i+2].Hash() {
+				t.Errorf("block %d does not match", i)
+			}
+		}
+	}
+	runBoth(t, run)
+}
+
+func TestReconfigure(t *testing.T) {
+	run := func(t *testing.T, setup setupFunc) {
+		const n = 5
+		ctrl := gomock.NewController(t)
+		td := setup(t, ctrl, n)
+
+		serverTeardown := createServers(t, td, ctrl)
+		defer serverTeardown()
+
+		cfg := NewConfig(td.creds, gorums.WithDialTimeout(time.Second))
+		td.builders[0].Add(cfg)
+		hl := td.builders.Build()
+
+		// replica 5 is not part of the initial configuration.
+		err := cfg.Connect(td.replicas[:4])
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer cfg.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var wg sync.WaitGroup
+		for i, hs := range hl {
+			var eventLoop *eventloop.EventLoop
+			hs.Get(&eventLoop)
+			id := hotstuff.ID(i + 1)
+			eventLoop.RegisterHandler(hotstuff.ProposeMsg{}, func(_ any) {
+				if id == 2 {
+					t.Error("replica 2 received a proposal after it was removed")
+				}
+				wg.Done()
+			})
+			go eventLoop.Run(ctx)
+		}
+
+		if err := cfg.Reconfigure(10, td.replicas[4:], []hotstuff.ID{2}); err != nil {
+			t.Fatal(err)
+		}
+		if err := cfg.Reconfigure(5, nil, []hotstuff.ID{3}); err == nil {
+			t.Error("expected reconfiguration of an old view to fail")
+		}
+
+		if got, want := cfg.Members(9), []hotstuff.ID{1, 2, 3, 4}; !slices.Equal(got, want) {
+			t.Errorf("got members %v in view 9, want %v", got, want)
+		}
+		if got, want := cfg.Members(10), []hotstuff.ID{1, 3, 4, 5}; !slices.Equal(got, want) {
+			t.Errorf("got members %v in view 10, want %v", got, want)
+		}
+		if got, want := modules.QuorumSizeAt(cfg, 10), 3; got != want {
+			t.Errorf("got quorum size %d in view 10, want %d", got, want)
+		}
+
+		// wait until the connection to replica 5 has been opened.
+		for connected := false; !connected; {
+			time.Sleep(10 * time.Millisecond)
+			cfg.mut.RLock()
+			connected = cfg.replicas[5].(*Replica).node != nil
+			cfg.mut.RUnlock()
+		}
+
+		block := hotstuff.NewBlock(
+			hotstuff.GetGenesis().Hash(),
+			hotstuff.NewQuorumCert(nil, 0, hotstuff.GetGenesis().Hash()),
+			"foo", 10, 1,
+		)
+		wg.Add(3)
+		cfg.Propose(hotstuff.ProposeMsg{ID: 1, Block: block})
+		wg.Wait()
+	}
+	runBoth(t, run)
+}
+
+func TestRotateKey(t *testing.T) {
+	run := func(t *testing.T, setup setupFunc) {
+		const n = 4
+		ctrl := gomock.NewController(t)
+		td := setup(t, ctrl, n)
+
+		serverTeardown := createServers(t, td, ctrl)
+		defer serverTeardown()
+
+		cfg := NewConfig(td.creds, gorums.WithDialTimeout(time.Second))
+		td.builders[0].Add(cfg)
+		hl := td.builders.Build()
+
+		err := cfg.Connect(td.replicas)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer cfg.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		for _, hs := range hl {
+			var eventLoop *eventloop.EventLoop
+			hs.Get(&eventLoop)
+			go eventLoop.Run(ctx)
+		}
+
+		oldKey := td.keys[1].Public().(*ecdsa.PublicKey)
+		newKey := testutil.GenerateECDSAKey(t)
+		if err := cfg.RotateKey(10, 2, newKey.Public()); err != nil {
+			t.Fatal(err)
+		}
+		if err := cfg.RotateKey(5, 3, newKey.Public()); err == nil {
+			t.Error("expected key rotation in an old view to fail")
+		}
+
+		if got := cfg.Epoch(9); got != 0 {
+			t.Errorf("got epoch %d in view 9, want 0", got)
+		}
+		if got := cfg.Epoch(10); got != 1 {
+			t.Errorf("got epoch %d in view 10, want 1", got)
+		}
+		if key, ok := cfg.PublicKey(2, 0); !ok || !oldKey.Equal(key) {
+			t.Error("expected the old key to be used in epoch 0")
+		}
+		if key, ok := cfg.PublicKey(2, 1); !ok || !newKey.Public().(*ecdsa.PublicKey).Equal(key) {
+			t.Error("expected the new key to be used in epoch 1")
+		}
+		if _, ok := cfg.PublicKey(2, 2); ok {
+			t.Error("expected no key for an unknown epoch")
+		}
+
+		// replica 2 signs a message with its old key, and then with its new key.
+		var (
+			signer   modules.Crypto
+			opts     *modules.Options
+			verifier modules.Crypto
+		)
+		hl[1].Get(&signer, &opts)
+		hl[0].Get(&verifier)
+		message := []byte("foo")
+		oldSig, err := signer.Sign(message)
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts.SetPrivateKey(newKey)
+		newSig, err := signer.Sign(message)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !verifier.VerifyInEpoch(0, oldSig, message) || verifier.VerifyInEpoch(1, oldSig, message) {
+			t.Error("expected the signature by the old key to be valid only in epoch 0")
+		}
+		if !verifier.VerifyInEpoch(1, newSig, message) || verifier.VerifyInEpoch(0, newSig, message) {
+			t.Error("expected the signature by the new key to be valid only in epoch 1")
+		}
+
+		// the key returned by Replica changes when the replica enters the new epoch.
+		var eventLoop *eventloop.EventLoop
+		hl[0].Get(&eventLoop)
+		eventLoop.AddEvent(synchronizer.ViewChangeEvent{View: 10})
+		for rotated := false; !rotated; {
+			time.Sleep(10 * time.Millisecond)
+			replica, _ := cfg.Replica(2)
+			rotated = newKey.Public().(*ecdsa.PublicKey).Equal(replica.PublicKey())
+		}
+	}
+	runBoth(t, run)
+}
+
 type testData struct {
 	n         int
 	creds     credentials.TransportCredentials
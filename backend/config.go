@@ -5,11 +5,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/relab/hotstuff/eventloop"
 	"github.com/relab/hotstuff/logging"
 	"github.com/relab/hotstuff/modules"
+	"github.com/relab/hotstuff/synchronizer"
+	"golang.org/x/exp/slices"
 
 	"github.com/relab/gorums"
 	"github.com/relab/hotstuff"
@@ -71,12 +75,47 @@ func (r *Replica) Metadata() map[string]string {
 
 // Config holds information about the current configuration of replicas that participate in the protocol,
 // and some information about the local replica. It also provides methods to send messages to the other replicas.
+//
+// The set of replicas can be changed by Reconfigure, and the key of a replica can be changed by RotateKey.
+// Both start a new epoch in a given view.
+// Config implements modules.Membership, such that other modules can find the replicas and keys of any epoch.
+// Replica also returns replicas that have left the configuration,
+// so that the signatures they created in earlier epochs can still be verified.
+// The public key of a replica returned by Replica is the key that it uses in the current epoch.
 type Config struct {
 	opts      []gorums.ManagerOption
 	connected bool
 
-	mgr *hotstuffpb.Manager
+	mgrMut sync.Mutex // serializes changes to the nodes of the manager
+	mgr    *hotstuffpb.Manager
+
+	// subConfig holds every replica that is, or has been, part of the configuration.
+	// It is protected by mut after the configuration has connected.
 	subConfig
+
+	mut    sync.RWMutex
+	view   hotstuff.View // the current view
+	epochs []*epoch      // ordered by start view
+}
+
+// epoch is a range of views in which the set of replicas and their keys do not change.
+type epoch struct {
+	number  hotstuff.Epoch // the index of the epoch in Config.epochs
+	start   hotstuff.View
+	members []hotstuff.ID                      // in ascending order
+	keys    map[hotstuff.ID]hotstuff.PublicKey // the keys of replicas that no longer use the key they joined with
+	config  *subConfig                         // created when the epoch is first used
+}
+
+// epochReplica is a replica whose key in an epoch differs from the key that it joined with.
+type epochReplica struct {
+	*Replica
+	pubKey hotstuff.PublicKey
+}
+
+// PublicKey returns the replica's public key in the epoch.
+func (r epochReplica) PublicKey() hotstuff.PublicKey {
+	return r.pubKey
 }
 
 type subConfig struct {
@@ -106,6 +145,18 @@ func (cfg *Config) InitModule(mods *modules.Core) {
 		}
 		cfg.replicaConnected(event.(replicaConnected))
 	})
+
+	cfg.eventLoop.RegisterHandler(replicaAdded{}, func(event any) {
+		cfg.replicaAdded(event.(replicaAdded))
+	})
+
+	cfg.eventLoop.RegisterObserver(synchronizer.ViewChangeEvent{}, func(event any) {
+		cfg.mut.Lock()
+		defer cfg.mut.Unlock()
+		if view := event.(synchronizer.ViewChangeEvent).View; cfg.view < view {
+			cfg.view = view
+		}
+	})
 }
 
 // NewConfig creates a new configuration.
@@ -143,6 +194,9 @@ func (cfg *Config) replicaConnected(c replicaConnected) {
 		return
 	}
 
+	cfg.mut.Lock()
+	defer cfg.mut.Unlock()
+
 	replica, ok := cfg.replicas[id]
 	if !ok {
 		cfg.logger.Warnf("Replica with id %d was not found", id)
@@ -202,20 +256,23 @@ func (cfg *Config) Connect(replicas []ReplicaInfo) (err error) {
 
 	// set up an ID mapping to give to gorums
 	idMapping := make(map[string]uint32, len(replicas))
+	members := make([]hotstuff.ID, 0, len(replicas))
 	for _, replica := range replicas {
 		// also initialize Replica structures
-		cfg.replicas[replica.ID] = &Replica{
-			id:            replica.ID,
-			pubKey:        replica.PubKey,
-			newViewCancel: func() {},
-			voteCancel:    func() {},
-			md:            make(map[string]string),
-		}
+		cfg.replicas[replica.ID] = newReplica(replica)
+		members = append(members, replica.ID)
 		// we do not want to connect to ourself
 		if replica.ID != cfg.subConfig.opts.ID() {
 			idMapping[replica.Address] = uint32(replica.ID)
 		}
 	}
+	slices.Sort(members)
+	cfg.epochs = []*epoch{{
+		number:  0,
+		start:   0,
+		members: members,
+		keys:    make(map[hotstuff.ID]hotstuff.PublicKey),
+	}}
 
 	// this will connect to the replicas
 	cfg.cfg, err = cfg.mgr.NewConfiguration(qspec{}, gorums.WithNodeMap(idMapping))
@@ -224,6 +281,7 @@ func (cfg *Config) Connect(replicas []ReplicaInfo) (err error) {
 	}
 
 	// now we need to update the "node" field of each replica we connected to
+	cfg.mut.Lock()
 	for _, node := range cfg.cfg.Nodes() {
 		// the node ID should correspond with the replica ID
 		// because we already configured an ID mapping for gorums to use.
@@ -231,6 +289,12 @@ func (cfg *Config) Connect(replicas []ReplicaInfo) (err error) {
 		replica := cfg.replicas[id].(*Replica)
 		replica.node = node
 	}
+	// messages from replicas that connected to us while we were waiting for them may have created
+	// configurations without the nodes.
+	for _, e := range cfg.epochs {
+		e.config = nil
+	}
+	cfg.mut.Unlock()
 
 	cfg.connected = true
 
@@ -240,6 +304,333 @@ func (cfg *Config) Connect(replicas []ReplicaInfo) (err error) {
 	return nil
 }
 
+func newReplica(info ReplicaInfo) *Replica {
+	return &Replica{
+		id:            info.ID,
+		pubKey:        info.PubKey,
+		newViewCancel: func() {},
+		voteCancel:    func() {},
+		md:            make(map[string]string),
+	}
+}
+
+// Reconfigure changes the set of replicas, starting from the given view.
+// The replicas in add join the configuration, and the replicas whose IDs are in remove leave it.
+// Adding a replica that is already a member, or removing a replica that is not a member, has no effect.
+// The view must not be older than the start of the latest epoch.
+// If it is equal to the start of the latest epoch, that epoch is changed instead of starting a new one.
+// Connections to new replicas are opened in the background.
+func (cfg *Config) Reconfigure(view hotstuff.View, add []ReplicaInfo, remove []hotstuff.ID) error {
+	cfg.mut.Lock()
+	defer cfg.mut.Unlock()
+
+	if len(cfg.epochs) == 0 {
+		return errors.New("configuration is not connected")
+	}
+	latest := cfg.epochs[len(cfg.epochs)-1]
+	if view < latest.start {
+		return fmt.Errorf("view %d is older than the latest epoch, which starts in view %d", view, latest.start)
+	}
+
+	members := make(map[hotstuff.ID]struct{}, len(latest.members)+len(add))
+	for _, id := range latest.members {
+		members[id] = struct{}{}
+	}
+	for _, replica := range add {
+		members[replica.ID] = struct{}{}
+	}
+	for _, id := range remove {
+		delete(members, id)
+	}
+	if len(members) == 0 {
+		return errors.New("cannot remove all replicas")
+	}
+
+	var newReplicas []ReplicaInfo
+	for _, info := range add {
+		if _, ok := cfg.replicas[info.ID]; ok {
+			continue
+		}
+		cfg.replicas[info.ID] = newReplica(info)
+		if info.ID != cfg.subConfig.opts.ID() {
+			newReplicas = append(newReplicas, info)
+		}
+	}
+
+	ids := make([]hotstuff.ID, 0, len(members))
+	for id := range members {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	e := cfg.startEpoch(view)
+	e.members = ids
+	e.config = nil
+	cfg.logger.Infof("Replicas %v will participate from view %d", ids, view)
+
+	if len(newReplicas) > 0 {
+		go cfg.connectReplicas(newReplicas)
+	}
+	return nil
+}
+
+// RotateKey changes the public key of a replica, starting from the given view.
+// Signatures created by the replica in earlier views are still verified with its previous key.
+// The view must not be older than the start of the latest epoch.
+// If it is equal to the start of the latest epoch, that epoch is changed instead of starting a new one.
+func (cfg *Config) RotateKey(view hotstuff.View, id hotstuff.ID, pubKey hotstuff.PublicKey) error {
+	cfg.mut.Lock()
+	defer cfg.mut.Unlock()
+
+	if len(cfg.epochs) == 0 {
+		return errors.New("configuration is not connected")
+	}
+	latest := cfg.epochs[len(cfg.epochs)-1]
+	if view < latest.start {
+		return fmt.Errorf("view %d is older than the latest epoch, which starts in view %d", view, latest.start)
+	}
+	if _, ok := cfg.replicas[id]; !ok {
+		return fmt.Errorf("replica %d is not part of the configuration", id)
+	}
+
+	e := cfg.startEpoch(view)
+	e.keys[id] = pubKey
+	e.config = nil
+	cfg.logger.Infof("Replica %d will use a new key from view %d", id, view)
+	return nil
+}
+
+// startEpoch returns the epoch that starts in the given view,
+// which is either the latest epoch, or a new epoch that is a copy of the latest one.
+// The view must not be older than the start of the latest epoch.
+func (cfg *Config) startEpoch(view hotstuff.View) *epoch {
+	latest := cfg.epochs[len(cfg.epochs)-1]
+	if view == latest.start {
+		return latest
+	}
+	keys := make(map[hotstuff.ID]hotstuff.PublicKey, len(latest.keys))
+	for id, key := range latest.keys {
+		keys[id] = key
+	}
+	e := &epoch{
+		number:  latest.number + 1,
+		start:   view,
+		members: latest.members,
+		keys:    keys,
+	}
+	cfg.epochs = append(cfg.epochs, e)
+	return e
+}
+
+// connectReplicas opens connections to replicas that were added by a reconfiguration.
+func (cfg *Config) connectReplicas(replicas []ReplicaInfo) {
+	cfg.mgrMut.Lock()
+	defer cfg.mgrMut.Unlock()
+
+	for _, replica := range replicas {
+		node, err := gorums.NewRawNodeWithID(replica.Address, uint32(replica.ID))
+		if err == nil {
+			err = cfg.mgr.AddNode(node)
+		}
+		if err != nil {
+			cfg.logger.Warnf("Failed to connect to replica %d: %v", replica.ID, err)
+			continue
+		}
+		cfg.eventLoop.AddEvent(replicaAdded{id: replica.ID, node: &hotstuffpb.Node{RawNode: node}})
+	}
+}
+
+// replicaAdded is sent when a connection to a replica that was added by a reconfiguration has been opened.
+type replicaAdded struct {
+	id   hotstuff.ID
+	node *hotstuffpb.Node
+}
+
+func (cfg *Config) replicaAdded(event replicaAdded) {
+	cfg.mut.Lock()
+	defer cfg.mut.Unlock()
+
+	cfg.replicas[event.id].(*Replica).node = event.node
+	// the new node must be included in the configurations of the epochs that it is a member of.
+	for _, e := range cfg.epochs {
+		e.config = nil
+	}
+	cfg.logger.Debugf("Connected to replica %d", event.id)
+}
+
+// Members returns the IDs of the replicas that participate in the given view, in ascending order.
+func (cfg *Config) Members(view hotstuff.View) []hotstuff.ID {
+	cfg.mut.RLock()
+	defer cfg.mut.RUnlock()
+	if e := cfg.epochAt(view); e != nil {
+		return e.members
+	}
+	return nil
+}
+
+// Epoch returns the epoch that the view belongs to.
+func (cfg *Config) Epoch(view hotstuff.View) hotstuff.Epoch {
+	cfg.mut.RLock()
+	defer cfg.mut.RUnlock()
+	if e := cfg.epochAt(view); e != nil {
+		return e.number
+	}
+	return 0
+}
+
+// CurrentEpoch returns the epoch of the current view.
+func (cfg *Config) CurrentEpoch() hotstuff.Epoch {
+	cfg.mut.RLock()
+	defer cfg.mut.RUnlock()
+	if e := cfg.epochAt(cfg.view); e != nil {
+		return e.number
+	}
+	return 0
+}
+
+// PublicKey returns the public key that the replica uses in the given epoch.
+func (cfg *Config) PublicKey(id hotstuff.ID, epoch hotstuff.Epoch) (hotstuff.PublicKey, bool) {
+	cfg.mut.RLock()
+	defer cfg.mut.RUnlock()
+	replica, ok := cfg.replicas[id]
+	if !ok {
+		return nil, false
+	}
+	if int(epoch) < len(cfg.epochs) {
+		if key, ok := cfg.epochs[epoch].keys[id]; ok {
+			return key, true
+		}
+	} else if len(cfg.epochs) > 0 {
+		// we do not know the keys of future epochs yet.
+		return nil, false
+	}
+	return replica.PublicKey(), true
+}
+
+// epochAt returns the epoch that the view belongs to, or nil if the configuration is not connected.
+func (cfg *Config) epochAt(view hotstuff.View) *epoch {
+	i := sort.Search(len(cfg.epochs), func(i int) bool { return cfg.epochs[i].start > view })
+	if i == 0 {
+		return nil
+	}
+	return cfg.epochs[i-1]
+}
+
+// configAt returns the configuration of the replicas that participate in the given view.
+func (cfg *Config) configAt(view hotstuff.View) *subConfig {
+	cfg.mut.Lock()
+	defer cfg.mut.Unlock()
+
+	e := cfg.epochAt(view)
+	if e == nil {
+		return &cfg.subConfig
+	}
+	if e.config == nil {
+		e.config = cfg.newEpochConfig(e)
+	}
+	return e.config
+}
+
+// current returns the configuration of the replicas that participate in the current view.
+func (cfg *Config) current() *subConfig {
+	cfg.mut.RLock()
+	view := cfg.view
+	cfg.mut.RUnlock()
+	return cfg.configAt(view)
+}
+
+// newEpochConfig returns a configuration containing the members of the epoch, with the keys of that epoch.
+// Members that we have not connected to yet are not sent any messages.
+func (cfg *Config) newEpochConfig(e *epoch) *subConfig {
+	sub := &subConfig{
+		eventLoop:    cfg.eventLoop,
+		logger:       cfg.logger,
+		opts:         cfg.subConfig.opts,
+		synchronizer: cfg.synchronizer,
+		replicas:     make(map[hotstuff.ID]modules.Replica, len(e.members)),
+	}
+	nids := make([]uint32, 0, len(e.members))
+	for _, id := range e.members {
+		replica := cfg.replicas[id].(*Replica)
+		if key, ok := e.keys[id]; ok {
+			sub.replicas[id] = epochReplica{Replica: replica, pubKey: key}
+		} else {
+			sub.replicas[id] = replica
+		}
+		if replica.node != nil {
+			nids = append(nids, uint32(id))
+		}
+	}
+	if len(nids) == 0 {
+		return sub
+	}
+	cfg.mgrMut.Lock()
+	defer cfg.mgrMut.Unlock()
+	var err error
+	sub.cfg, err = cfg.mgr.NewConfiguration(qspec{}, gorums.WithNodeIDs(nids))
+	if err != nil {
+		cfg.logger.Errorf("Failed to create configuration: %v", err)
+	}
+	return sub
+}
+
+// Replicas returns the replicas that participate in the current view.
+func (cfg *Config) Replicas() map[hotstuff.ID]modules.Replica {
+	return cfg.current().Replicas()
+}
+
+// Replica returns a replica if it is, or has been, part of the configuration.
+// The replica's public key is the key that it uses in the current epoch.
+func (cfg *Config) Replica(id hotstuff.ID) (replica modules.Replica, ok bool) {
+	cfg.mut.RLock()
+	defer cfg.mut.RUnlock()
+	replica, ok = cfg.subConfig.Replica(id)
+	if !ok {
+		return nil, false
+	}
+	if e := cfg.epochAt(cfg.view); e != nil {
+		if key, ok := e.keys[id]; ok {
+			return epochReplica{Replica: replica.(*Replica), pubKey: key}, true
+		}
+	}
+	return replica, true
+}
+
+// Len returns the number of replicas that participate in the current view.
+func (cfg *Config) Len() int {
+	return cfg.current().Len()
+}
+
+// QuorumSize returns the size of a quorum in the current view.
+func (cfg *Config) QuorumSize() int {
+	return cfg.current().QuorumSize()
+}
+
+// Propose sends the block to the replicas that participate in the block's view.
+func (cfg *Config) Propose(proposal hotstuff.ProposeMsg) {
+	cfg.configAt(proposal.Block.View()).Propose(proposal)
+}
+
+// Timeout sends the timeout message to the replicas that participate in the timeout's view.
+func (cfg *Config) Timeout(msg hotstuff.TimeoutMsg) {
+	cfg.configAt(msg.View).Timeout(msg)
+}
+
+// Vote sends the partial certificate to the replicas that participate in the current view.
+func (cfg *Config) Vote(cert hotstuff.PartialCert) {
+	cfg.current().Vote(cert)
+}
+
+// Fetch requests a block from the replicas that participate in the current view.
+func (cfg *Config) Fetch(ctx context.Context, hash hotstuff.Hash) (*hotstuff.Block, bool) {
+	return cfg.current().Fetch(ctx, hash)
+}
+
+// FetchRange requests a chain of blocks from the replicas that participate in the current view.
+func (cfg *Config) FetchRange(ctx context.Context, ancestor, target hotstuff.Hash) ([]*hotstuff.Block, bool) {
+	return cfg.current().FetchRange(ctx, ancestor, target)
+}
+
 // Replicas returns all of the replicas in the configuration.
 func (cfg *subConfig) Replicas() map[hotstuff.ID]modules.Replica {
 	return cfg.replicas
@@ -255,11 +646,15 @@ func (cfg *subConfig) Replica(id hotstuff.ID) (replica modules.Replica, ok bool)
 func (cfg *Config) SubConfig(ids []hotstuff.ID) (sub modules.Configuration, err error) {
 	replicas := make(map[hotstuff.ID]modules.Replica)
 	nids := make([]uint32, len(ids))
+	cfg.mut.RLock()
 	for i, id := range ids {
 		nids[i] = uint32(id)
 		replicas[id] = cfg.replicas[id]
 	}
-	newCfg, err := cfg.mgr.NewConfiguration(gorums.WithNodeIDs(nids))
+	cfg.mut.RUnlock()
+	cfg.mgrMut.Lock()
+	newCfg, err := cfg.mgr.NewConfiguration(qspec{}, gorums.WithNodeIDs(nids))
+	cfg.mgrMut.Unlock()
 	if err != nil {
 		return nil, err
 	}
@@ -311,8 +706,23 @@ func (cfg *subConfig) Timeout(msg hotstuff.TimeoutMsg) {
 	)
 }
 
+// Vote sends the partial certificate to all replicas.
+func (cfg *subConfig) Vote(cert hotstuff.PartialCert) {
+	if cfg.cfg == nil {
+		return
+	}
+	cfg.cfg.VoteAll(
+		cfg.synchronizer.ViewContext(),
+		hotstuffpb.PartialCertToProto(cert),
+		gorums.WithNoSendWaiting(),
+	)
+}
+
 // Fetch requests a block from all the replicas in the configuration
 func (cfg *subConfig) Fetch(ctx context.Context, hash hotstuff.Hash) (*hotstuff.Block, bool) {
+	if cfg.cfg == nil {
+		return nil, false
+	}
 	protoBlock, err := cfg.cfg.Fetch(ctx, &hotstuffpb.BlockHash{Hash: hash[:]})
 	if err != nil {
 		qcErr, ok := err.(gorums.QuorumCallError)
@@ -325,12 +735,40 @@ func (cfg *subConfig) Fetch(ctx context.Context, hash hotstuff.Hash) (*hotstuff.
 	return hotstuffpb.BlockFromProto(protoBlock), true
 }
 
+// FetchRange requests a chain of blocks from all the replicas in the configuration.
+func (cfg *subConfig) FetchRange(ctx context.Context, ancestor, target hotstuff.Hash) ([]*hotstuff.Block, bool) {
+	if cfg.cfg == nil {
+		return nil, false
+	}
+	protoBlocks, err := cfg.cfg.FetchRange(ctx, &hotstuffpb.BlockRange{
+		Target:    target[:],
+		Ancestor:  ancestor[:],
+		MaxBlocks: maxFetchRange,
+	})
+	if err != nil {
+		qcErr, ok := err.(gorums.QuorumCallError)
+		// filter out context errors
+		if !ok || (qcErr.Reason != context.Canceled.Error() && qcErr.Reason != context.DeadlineExceeded.Error()) {
+			cfg.logger.Infof("Failed to fetch blocks: %v", err)
+		}
+		return nil, false
+	}
+	blocks := make([]*hotstuff.Block, 0, len(protoBlocks.GetBlocks()))
+	for _, b := range protoBlocks.GetBlocks() {
+		blocks = append(blocks, hotstuffpb.BlockFromProto(b))
+	}
+	return blocks, true
+}
+
 // Close closes all connections made by this configuration.
 func (cfg *Config) Close() {
 	cfg.mgr.Close()
 }
 
-var _ modules.Configuration = (*Config)(nil)
+var (
+	_ modules.Configuration = (*Config)(nil)
+	_ modules.Membership    = (*Config)(nil)
+)
 
 type qspec struct{}
 
@@ -348,5 +786,33 @@ func (q qspec) FetchQF(in *hotstuffpb.BlockHash, replies map[uint32]*hotstuffpb.
 	return nil, false
 }
 
+// FetchRangeQF is the quorum function for the FetchRange quorum call method.
+// It returns the first reply that contains a non-empty chain of blocks ending with the requested block.
+func (q qspec) FetchRangeQF(in *hotstuffpb.BlockRange, replies map[uint32]*hotstuffpb.Blocks) (*hotstuffpb.Blocks, bool) {
+	var target hotstuff.Hash
+	copy(target[:], in.GetTarget())
+	for _, reply := range replies {
+		if isChain(reply.GetBlocks(), target) {
+			return reply, true
+		}
+	}
+	return nil, false
+}
+
+// isChain checks that each block is the parent of the next block, and that the last block matches the hash.
+func isChain(blocks []*hotstuffpb.Block, hash hotstuff.Hash) bool {
+	if len(blocks) == 0 {
+		return false
+	}
+	for i := len(blocks) - 1; i >= 0; i-- {
+		block := hotstuffpb.BlockFromProto(blocks[i])
+		if block.Hash() != hash {
+			return false
+		}
+		hash = block.Parent()
+	}
+	return true
+}
+
 // ConnectedEvent is sent when the configuration has connected to the other replicas.
 type ConnectedEvent struct{}
@@ -161,6 +161,11 @@ func (impl *serviceImpl) Vote(ctx gorums.ServerCtx, cert *hotstuffpb.PartialCert
 	})
 }
 
+// VoteAll handles an incoming vote that was sent to all replicas.
+func (impl *serviceImpl) VoteAll(ctx gorums.ServerCtx, cert *hotstuffpb.PartialCert) {
+	impl.Vote(ctx, cert)
+}
+
 // NewView handles the leader's response to receiving a NewView rpc from a replica.
 func (impl *serviceImpl) NewView(ctx gorums.ServerCtx, msg *hotstuffpb.SyncInfo) {
 	id, err := GetPeerIDFromContext(ctx, impl.srv.configuration)
@@ -190,6 +195,38 @@ func (impl *serviceImpl) Fetch(ctx gorums.ServerCtx, pb *hotstuffpb.BlockHash) (
 	return hotstuffpb.BlockToProto(block), nil
 }
 
+// maxFetchRange is the maximum number of blocks that are returned by a single FetchRange call.
+const maxFetchRange = 100
+
+// FetchRange handles an incoming request for a chain of blocks.
+// The chain is found by following the parent hashes from the target block back to the ancestor.
+// If a block is missing, or if there are more than MaxBlocks blocks in the chain,
+// only the newest part of the chain is returned.
+func (impl *serviceImpl) FetchRange(ctx gorums.ServerCtx, req *hotstuffpb.BlockRange) (*hotstuffpb.Blocks, error) {
+	var target, ancestor hotstuff.Hash
+	copy(target[:], req.GetTarget())
+	copy(ancestor[:], req.GetAncestor())
+
+	maxBlocks := int(req.GetMaxBlocks())
+	if maxBlocks == 0 || maxBlocks > maxFetchRange {
+		maxBlocks = maxFetchRange
+	}
+
+	chain := modules.LocalRange(impl.srv.blockChain, ancestor, target, maxBlocks)
+	if len(chain) == 0 {
+		return nil, status.Errorf(codes.NotFound, "requested block was not found")
+	}
+
+	blocks := make([]*hotstuffpb.Block, 0, len(chain))
+	for _, block := range chain {
+		blocks = append(blocks, hotstuffpb.BlockToProto(block))
+	}
+
+	impl.srv.logger.Debugf("OnFetchRange: %.8s (%d blocks)", target, len(blocks))
+
+	return &hotstuffpb.Blocks{Blocks: blocks}, nil
+}
+
 // Timeout handles an incoming TimeoutMsg.
 func (impl *serviceImpl) Timeout(ctx gorums.ServerCtx, msg *hotstuffpb.TimeoutMsg) {
 	var err error
@@ -15,22 +15,39 @@ type Block struct {
 	cmd      Command
 	cert     QuorumCert
 	view     View
+	epoch    Epoch
 }
 
 // NewBlock creates a new Block
 func NewBlock(parent Hash, cert QuorumCert, cmd Command, view View, proposer ID) *Block {
+	return NewBlockWithEpoch(parent, cert, cmd, view, proposer, 0)
+}
+
+// NewBlockWithEpoch creates a new Block that belongs to the given epoch.
+// Votes for the block must be signed with the keys of that epoch.
+func NewBlockWithEpoch(parent Hash, cert QuorumCert, cmd Command, view View, proposer ID, epoch Epoch) *Block {
 	b := &Block{
 		parent:   parent,
 		cert:     cert,
 		cmd:      cmd,
 		view:     view,
 		proposer: proposer,
+		epoch:    epoch,
 	}
 	// cache the hash immediately because it is too racy to do it in Hash()
 	b.hash = sha256.Sum256(b.ToBytes())
 	return b
 }
 
+// WithCommand returns a copy of the block with a different command, such as the commands that a mempool resolves
+// from the certificates of a committed block. The copy keeps the hash of the original block,
+// such that the executors can still identify the block that was committed.
+func (b *Block) WithCommand(cmd Command) *Block {
+	c := *b
+	c.cmd = cmd
+	return &c
+}
+
 func (b *Block) String() string {
 	return fmt.Sprintf(
 		"Block{ hash: %.6s parent: %.6s, proposer: %d, view: %d , cert: %v }",
@@ -72,6 +89,11 @@ func (b *Block) View() View {
 	return b.view
 }
 
+// Epoch returns the epoch that the Block belongs to
+func (b *Block) Epoch() Epoch {
+	return b.epoch
+}
+
 // ToBytes returns the raw byte form of the Block, to be used for hashing, etc.
 func (b *Block) ToBytes() []byte {
 	buf := b.parent[:]
@@ -81,6 +103,7 @@ func (b *Block) ToBytes() []byte {
 	var viewBuf [8]byte
 	binary.LittleEndian.PutUint64(viewBuf[:], uint64(b.view))
 	buf = append(buf, viewBuf[:]...)
+	buf = append(buf, b.epoch.ToBytes()...)
 	buf = append(buf, []byte(b.cmd)...)
 	buf = append(buf, b.cert.ToBytes()...)
 	return buf
@@ -23,6 +23,9 @@ type blockChain struct {
 	blocks        map[hotstuff.Hash]*hotstuff.Block
 	blockAtHeight map[hotstuff.View]*hotstuff.Block
 	pendingFetch  map[hotstuff.Hash]context.CancelFunc // allows a pending fetch operation to be cancelled
+	checkpoint    *hotstuff.CheckpointCert             // the latest checkpoint certificate
+
+	log *segmentLog // if not nil, blocks and the prune height are written to disk
 }
 
 func (chain *blockChain) InitModule(mods *modules.Core) {
@@ -51,6 +54,9 @@ func (chain *blockChain) Store(block *hotstuff.Block) {
 	chain.mut.Lock()
 	defer chain.mut.Unlock()
 
+	if _, ok := chain.blocks[block.Hash()]; !ok {
+		chain.persistBlock(block)
+	}
 	chain.blocks[block.Hash()] = block
 	chain.blockAtHeight[block.View()] = block
 
@@ -105,6 +111,7 @@ func (chain *blockChain) Get(hash hotstuff.Hash) (block *hotstuff.Block, ok bool
 
 	chain.logger.Debugf("Successfully fetched block: %.8s", hash)
 
+	chain.persistBlock(block)
 	chain.blocks[hash] = block
 	chain.blockAtHeight[block.View()] = block
 
@@ -159,7 +166,53 @@ func (chain *blockChain) PruneToHeight(height hotstuff.View) (forkedBlocks []*ho
 		delete(chain.blockAtHeight, h)
 	}
 	chain.pruneHeight = height
+	chain.persistPruneHeight(height)
 	return forkedBlocks
 }
 
-var _ modules.BlockChain = (*blockChain)(nil)
+// StoreCheckpoint stores a checkpoint certificate that is newer than the current one,
+// and discards the pruned blocks that precede the block of the previous checkpoint.
+// The blocks between the two checkpoints are kept, such that replicas that lag behind can still fetch them.
+// If the blockchain is stored on disk, the log is compacted such that it only contains the remaining blocks.
+func (chain *blockChain) StoreCheckpoint(cert hotstuff.CheckpointCert) {
+	chain.mut.Lock()
+	defer chain.mut.Unlock()
+
+	previous := chain.checkpoint
+	if previous != nil && previous.Checkpoint().Height() >= cert.Checkpoint().Height() {
+		return
+	}
+	chain.checkpoint = &cert
+	if previous != nil {
+		chain.discardBefore(previous.Checkpoint().View())
+	}
+	chain.compact()
+}
+
+// LatestCheckpoint returns the latest checkpoint certificate, if any.
+func (chain *blockChain) LatestCheckpoint() (hotstuff.CheckpointCert, bool) {
+	chain.mut.Lock()
+	defer chain.mut.Unlock()
+
+	if chain.checkpoint == nil {
+		return hotstuff.CheckpointCert{}, false
+	}
+	return *chain.checkpoint, true
+}
+
+// discardBefore deletes the blocks whose view is lower than the given view.
+// The last committed block, and the blocks that have not been pruned yet, are kept,
+// since the blocks that are committed next must extend them.
+// The caller must hold the mutex.
+func (chain *blockChain) discardBefore(view hotstuff.View) {
+	for hash, block := range chain.blocks {
+		if block.View() < view && block.View() < chain.pruneHeight {
+			delete(chain.blocks, hash)
+		}
+	}
+}
+
+var (
+	_ modules.BlockChain      = (*blockChain)(nil)
+	_ modules.CheckpointStore = (*blockChain)(nil)
+)
@@ -0,0 +1,155 @@
+package blockchain
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	"github.com/relab/hotstuff/modules"
+	"google.golang.org/protobuf/proto"
+)
+
+// NewPersistent creates a new blockChain that stores its blocks and prune height in segment files in the given directory.
+// If the directory already contains a blockchain, it is loaded such that blocks that were stored before a restart
+// can still be served to other replicas.
+// Whenever a new checkpoint certificate is stored, the log is rewritten to contain only the blocks that remain,
+// such that the size of the log is bounded by the checkpoint interval.
+//
+// The returned blockchain implements io.Closer, and should be closed when it is no longer in use.
+func NewPersistent(dir string) (modules.BlockChain, error) {
+	bc := New().(*blockChain)
+
+	log, err := openSegmentLog(dir, defaultSegmentSize, bc.replay)
+	if err != nil {
+		return nil, fmt.Errorf("blockchain: failed to open log: %w", err)
+	}
+	bc.log = log
+
+	return bc, nil
+}
+
+// replay restores a record from the log.
+func (chain *blockChain) replay(typ recordType, payload []byte) error {
+	switch typ {
+	case recordBlock:
+		pb := new(hotstuffpb.Block)
+		err := proto.Unmarshal(payload, pb)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal block: %w", err)
+		}
+		block := hotstuffpb.BlockFromProto(pb)
+		chain.blocks[block.Hash()] = block
+		if block.View() > chain.pruneHeight {
+			chain.blockAtHeight[block.View()] = block
+		}
+	case recordPrune:
+		if len(payload) != 8 {
+			return fmt.Errorf("invalid prune height record")
+		}
+		height := hotstuff.View(binary.LittleEndian.Uint64(payload))
+		for h := range chain.blockAtHeight {
+			if h <= height {
+				delete(chain.blockAtHeight, h)
+			}
+		}
+		chain.pruneHeight = height
+	case recordCheckpoint:
+		pb := new(hotstuffpb.CheckpointCert)
+		err := proto.Unmarshal(payload, pb)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal checkpoint: %w", err)
+		}
+		cert := hotstuffpb.CheckpointCertFromProto(pb)
+		if chain.checkpoint != nil {
+			chain.discardBefore(chain.checkpoint.Checkpoint().View())
+		}
+		chain.checkpoint = &cert
+	default:
+		return fmt.Errorf("unknown record type: %d", typ)
+	}
+	return nil
+}
+
+// persistBlock writes the block to the log, if there is one.
+// The caller must hold the mutex.
+func (chain *blockChain) persistBlock(block *hotstuff.Block) {
+	if chain.log == nil {
+		return
+	}
+	b, err := proto.Marshal(hotstuffpb.BlockToProto(block))
+	if err == nil {
+		err = chain.log.append(recordBlock, b)
+	}
+	if err != nil {
+		chain.logger.Errorf("Failed to persist block %.8s: %v", block.Hash(), err)
+	}
+}
+
+// persistPruneHeight writes the prune height to the log, if there is one.
+// The caller must hold the mutex.
+func (chain *blockChain) persistPruneHeight(height hotstuff.View) {
+	if chain.log == nil {
+		return
+	}
+	err := chain.log.append(recordPrune, height.ToBytes())
+	if err != nil {
+		chain.logger.Errorf("Failed to persist prune height %d: %v", height, err)
+	}
+}
+
+// compact rewrites the prune height, the remaining blocks, and the checkpoint to new segments,
+// and then removes the old segments. If the replica crashes before the old segments are removed,
+// both the old and the new records are replayed, which leads to the same state.
+// The caller must hold the mutex.
+func (chain *blockChain) compact() {
+	if chain.log == nil || chain.checkpoint == nil {
+		return
+	}
+	first, err := chain.log.startSegment()
+	if err != nil {
+		chain.logger.Errorf("Failed to compact log: %v", err)
+		return
+	}
+
+	// blocks are written in view order, such that the chain can be replayed from the oldest block.
+	blocks := make([]*hotstuff.Block, 0, len(chain.blocks))
+	for _, block := range chain.blocks {
+		blocks = append(blocks, block)
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].View() < blocks[j].View()
+	})
+
+	chain.persistPruneHeight(chain.pruneHeight)
+	for _, block := range blocks {
+		chain.persistBlock(block)
+	}
+	b, err := proto.Marshal(hotstuffpb.CheckpointCertToProto(*chain.checkpoint))
+	if err == nil {
+		err = chain.log.append(recordCheckpoint, b)
+	}
+	if err == nil {
+		err = chain.log.removeBefore(first)
+	}
+	if err != nil {
+		chain.logger.Errorf("Failed to compact log: %v", err)
+	}
+}
+
+// Close closes the log files used by the blockchain.
+func (chain *blockChain) Close() error {
+	chain.mut.Lock()
+	defer chain.mut.Unlock()
+
+	if chain.log == nil {
+		return nil
+	}
+	err := chain.log.Close()
+	chain.log = nil
+	return err
+}
+
+var _ io.Closer = (*blockChain)(nil)
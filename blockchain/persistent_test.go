@@ -0,0 +1,186 @@
+package blockchain_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/blockchain"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+)
+
+func createChain(t *testing.T, n int) []*hotstuff.Block {
+	t.Helper()
+	blocks := make([]*hotstuff.Block, 0, n)
+	parent := hotstuff.GetGenesis()
+	for i := 1; i <= n; i++ {
+		block := hotstuff.NewBlock(parent.Hash(), hotstuff.NewQuorumCert(nil, parent.View(), parent.Hash()), "foo", hotstuff.View(i), 1)
+		blocks = append(blocks, block)
+		parent = block
+	}
+	return blocks
+}
+
+func openChain(t *testing.T, ctrl *gomock.Controller, dir string, committed *hotstuff.Block) modules.BlockChain {
+	t.Helper()
+	chain, err := blockchain.NewPersistent(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs := mocks.NewMockConsensus(ctrl)
+	cs.EXPECT().CommittedBlock().AnyTimes().Return(committed)
+	builder := modules.NewBuilder(1, nil)
+	builder.Add(
+		chain,
+		cs,
+		mocks.NewMockConfiguration(ctrl),
+		mocks.NewMockSynchronizer(ctrl),
+		logging.New("test"),
+	)
+	builder.Build()
+	return chain
+}
+
+func closeChain(t *testing.T, chain modules.BlockChain) {
+	t.Helper()
+	if err := chain.(io.Closer).Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPersistentReload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	dir := t.TempDir()
+	blocks := createChain(t, 5)
+
+	chain := openChain(t, ctrl, dir, blocks[4])
+	for _, block := range blocks {
+		chain.Store(block)
+	}
+	chain.PruneToHeight(blocks[2].View())
+	closeChain(t, chain)
+
+	chain = openChain(t, ctrl, dir, blocks[4])
+	defer closeChain(t, chain)
+	for _, want := range blocks {
+		got, ok := chain.LocalGet(want.Hash())
+		if !ok {
+			t.Fatalf("block in view %d was not restored", want.View())
+		}
+		if got.Hash() != want.Hash() {
+			t.Errorf("restored block in view %d has wrong hash", want.View())
+		}
+	}
+	if !chain.Extends(blocks[4], blocks[0]) {
+		t.Error("restored chain does not extend the first block")
+	}
+	// blocks at or below the restored prune height must not be reported as forked again.
+	if forked := chain.PruneToHeight(blocks[3].View()); len(forked) != 0 {
+		t.Errorf("got %d forked blocks, want 0", len(forked))
+	}
+}
+
+func TestPersistentTruncatedRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	dir := t.TempDir()
+	blocks := createChain(t, 3)
+
+	chain := openChain(t, ctrl, dir, hotstuff.GetGenesis())
+	for _, block := range blocks {
+		chain.Store(block)
+	}
+	closeChain(t, chain)
+
+	// simulate a crash in the middle of writing a record
+	segment := filepath.Join(dir, "00000000.seg")
+	info, err := os.Stat(segment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(segment, info.Size()-3); err != nil {
+		t.Fatal(err)
+	}
+
+	chain = openChain(t, ctrl, dir, hotstuff.GetGenesis())
+	if _, ok := chain.LocalGet(blocks[1].Hash()); !ok {
+		t.Error("complete record was not restored")
+	}
+	if _, ok := chain.LocalGet(blocks[2].Hash()); ok {
+		t.Error("incomplete record should have been discarded")
+	}
+	// the log must remain usable after the incomplete record was discarded
+	chain.Store(blocks[2])
+	closeChain(t, chain)
+
+	chain = openChain(t, ctrl, dir, hotstuff.GetGenesis())
+	defer closeChain(t, chain)
+	if _, ok := chain.LocalGet(blocks[2].Hash()); !ok {
+		t.Error("block stored after recovery was not restored")
+	}
+}
+
+func TestPersistentCheckpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	dir := t.TempDir()
+	blocks := createChain(t, 5)
+
+	chain := openChain(t, ctrl, dir, blocks[3])
+	for _, block := range blocks {
+		chain.Store(block)
+	}
+	chain.PruneToHeight(blocks[3].View())
+	// the blocks are discarded up to the previous checkpoint.
+	first := hotstuff.NewCheckpoint(2, blocks[1].View(), blocks[1].Hash(), hotstuff.Hash{1})
+	chain.(modules.CheckpointStore).StoreCheckpoint(hotstuff.NewCheckpointCert(first, nil, 0))
+	if _, ok := chain.LocalGet(blocks[0].Hash()); !ok {
+		t.Error("block before the first checkpoint was discarded")
+	}
+	cp := hotstuff.NewCheckpoint(3, blocks[2].View(), blocks[2].Hash(), hotstuff.Hash{1})
+	chain.(modules.CheckpointStore).StoreCheckpoint(hotstuff.NewCheckpointCert(cp, nil, 0))
+	closeChain(t, chain)
+
+	// the log must only contain the compacted segment.
+	segments, err := filepath.Glob(filepath.Join(dir, "*.seg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) != 1 {
+		t.Errorf("got %d segments, want 1", len(segments))
+	}
+
+	chain = openChain(t, ctrl, dir, blocks[3])
+	defer closeChain(t, chain)
+	for i, block := range blocks {
+		_, ok := chain.LocalGet(block.Hash())
+		if want := i >= 1; ok != want {
+			t.Errorf("block in view %d: stored = %v, want %v", block.View(), ok, want)
+		}
+	}
+	cert, ok := chain.(modules.CheckpointStore).LatestCheckpoint()
+	if !ok {
+		t.Fatal("checkpoint was not restored")
+	}
+	if cert.Checkpoint() != cp {
+		t.Errorf("restored checkpoint %v, want %v", cert.Checkpoint(), cp)
+	}
+	// blocks that have not been pruned yet must be kept, even if they precede a checkpoint.
+	later := hotstuff.NewCheckpoint(5, blocks[4].View(), blocks[4].Hash(), hotstuff.Hash{2})
+	chain.(modules.CheckpointStore).StoreCheckpoint(hotstuff.NewCheckpointCert(later, nil, 0))
+	if _, ok := chain.LocalGet(blocks[4].Hash()); !ok {
+		t.Error("block of the checkpoint was discarded")
+	}
+	if _, ok := chain.LocalGet(blocks[3].Hash()); !ok {
+		t.Error("block at the prune height was discarded")
+	}
+	if _, ok := chain.LocalGet(blocks[2].Hash()); !ok {
+		t.Error("block of the previous checkpoint was discarded")
+	}
+	if _, ok := chain.LocalGet(blocks[1].Hash()); ok {
+		t.Error("pruned block before the previous checkpoint was not discarded")
+	}
+}
@@ -0,0 +1,214 @@
+package blockchain
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// recordType identifies the kind of record stored in a segment.
+type recordType uint8
+
+const (
+	recordBlock      recordType = iota + 1 // a marshalled block
+	recordPrune                            // the new prune height
+	recordCheckpoint                       // a marshalled checkpoint certificate
+)
+
+const (
+	segmentExt = ".seg"
+	// record header: type (1 byte), payload length (4 bytes), checksum (4 bytes)
+	headerSize = 9
+	// defaultSegmentSize is the size at which a new segment file is started.
+	defaultSegmentSize = 64 << 20
+)
+
+var errCorruptRecord = errors.New("corrupt record")
+
+// segmentLog is an append-only log of records split across a set of segment files.
+// Each record is checksummed so that a partially written record at the tail of the log can be detected
+// and discarded when the log is reopened after a crash.
+type segmentLog struct {
+	dir         string
+	segmentSize int64
+
+	file  *os.File
+	index int   // index of the current segment
+	size  int64 // size of the current segment
+}
+
+// openSegmentLog opens the log in the given directory, creating it if necessary.
+// All existing records are passed to replay in the order they were written.
+func openSegmentLog(dir string, segmentSize int64, replay func(typ recordType, payload []byte) error) (*segmentLog, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	indices, err := listSegments(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &segmentLog{dir: dir, segmentSize: segmentSize}
+
+	for i, index := range indices {
+		last := i == len(indices)-1
+		valid, err := replaySegment(l.segmentPath(index), replay)
+		if err != nil && !(last && errors.Is(err, errCorruptRecord)) {
+			return nil, fmt.Errorf("failed to read segment %d: %w", index, err)
+		}
+		if last {
+			// Truncate any partially written record at the end of the log.
+			err = os.Truncate(l.segmentPath(index), valid)
+			if err != nil {
+				return nil, fmt.Errorf("failed to truncate segment %d: %w", index, err)
+			}
+			l.index = index
+			l.size = valid
+		}
+	}
+
+	l.file, err = os.OpenFile(l.segmentPath(l.index), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open segment: %w", err)
+	}
+	return l, nil
+}
+
+func (l *segmentLog) segmentPath(index int) string {
+	return filepath.Join(l.dir, fmt.Sprintf("%08d%s", index, segmentExt))
+}
+
+// append writes a record to the log and syncs it to stable storage.
+func (l *segmentLog) append(typ recordType, payload []byte) error {
+	if l.size >= l.segmentSize {
+		err := l.rotate()
+		if err != nil {
+			return err
+		}
+	}
+
+	buf := make([]byte, headerSize+len(payload))
+	buf[0] = byte(typ)
+	binary.LittleEndian.PutUint32(buf[1:5], uint32(len(payload)))
+	copy(buf[headerSize:], payload)
+	binary.LittleEndian.PutUint32(buf[5:9], checksum(typ, payload))
+
+	n, err := l.file.Write(buf)
+	l.size += int64(n)
+	if err != nil {
+		return err
+	}
+	return l.file.Sync()
+}
+
+// rotate closes the current segment and starts a new one.
+func (l *segmentLog) rotate() error {
+	err := l.file.Close()
+	if err != nil {
+		return err
+	}
+	l.index++
+	l.size = 0
+	l.file, err = os.OpenFile(l.segmentPath(l.index), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	return err
+}
+
+// startSegment starts a new segment, unless the current segment is empty, and returns its index.
+func (l *segmentLog) startSegment() (int, error) {
+	if l.size > 0 {
+		err := l.rotate()
+		if err != nil {
+			return 0, err
+		}
+	}
+	return l.index, nil
+}
+
+// removeBefore deletes the segments whose index is lower than the given index.
+func (l *segmentLog) removeBefore(index int) error {
+	indices, err := listSegments(l.dir)
+	if err != nil {
+		return err
+	}
+	for _, i := range indices {
+		if i >= index {
+			break
+		}
+		err = os.Remove(l.segmentPath(i))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the current segment file.
+func (l *segmentLog) Close() error {
+	return l.file.Close()
+}
+
+func checksum(typ recordType, payload []byte) uint32 {
+	crc := crc32.Update(0, crc32.IEEETable, []byte{byte(typ)})
+	return crc32.Update(crc, crc32.IEEETable, payload)
+}
+
+// listSegments returns the indices of the segment files in dir in ascending order.
+func listSegments(dir string) ([]int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+	var indices []int
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+		index, err := strconv.Atoi(strings.TrimSuffix(name, segmentExt))
+		if err != nil {
+			continue
+		}
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+	return indices, nil
+}
+
+// replaySegment reads all records in the segment file and passes them to replay.
+// It returns the offset of the end of the last valid record.
+func replaySegment(path string, replay func(typ recordType, payload []byte) error) (valid int64, err error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	for int64(len(b)) > valid {
+		rest := b[valid:]
+		if len(rest) < headerSize {
+			return valid, fmt.Errorf("%w: short header", errCorruptRecord)
+		}
+		typ := recordType(rest[0])
+		length := int64(binary.LittleEndian.Uint32(rest[1:5]))
+		if int64(len(rest)-headerSize) < length {
+			return valid, fmt.Errorf("%w: %v", errCorruptRecord, io.ErrUnexpectedEOF)
+		}
+		payload := rest[headerSize : headerSize+length]
+		if binary.LittleEndian.Uint32(rest[5:9]) != checksum(typ, payload) {
+			return valid, fmt.Errorf("%w: checksum mismatch", errCorruptRecord)
+		}
+		err = replay(typ, payload)
+		if err != nil {
+			return valid, err
+		}
+		valid += headerSize + length
+	}
+	return valid, nil
+}
@@ -0,0 +1,492 @@
+// Package checkpoint implements periodic checkpoints of the application state.
+//
+// Every time the number of committed blocks reaches a multiple of the checkpoint interval,
+// each replica signs a checkpoint that contains the hash of the last committed block and the digest
+// of the application state after executing it, and sends its signature to all replicas.
+// A quorum of matching signatures is combined into a checkpoint certificate, which proves the state that was
+// produced by the blocks up to and including the block of the checkpoint.
+// Once a checkpoint is certified, the block chain discards the committed blocks that precede the previous checkpoint,
+// such that replicas that lag behind by less than an interval can still fetch the blocks they are missing,
+// and a new replica can bootstrap from the latest certificate instead of executing the blocks from the genesis block.
+// A replica that falls further behind catches up in the same way, by restoring the state of a later certificate.
+// The replicas keep a snapshot of the application state for each checkpoint that is not older than the latest
+// certificate, such that a bootstrapping replica can restore the state that the certificate describes.
+//
+// Checkpoints are enabled with the `--checkpoint-interval` flag:
+//
+//	./hotstuff run --checkpoint-interval 1000
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/internal/proto/checkpointpb"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+)
+
+// maxPending is the number of checkpoint intervals after the latest certificate for which votes are collected.
+// Votes for later checkpoints are dropped, such that a faulty replica cannot exhaust the memory of the other replicas.
+const maxPending = 4
+
+// catchUpTimeout is how long a replica that has fallen behind tries to fetch the block and snapshot of a checkpoint.
+const catchUpTimeout = 10 * time.Second
+
+// State is the part of the modules.StateMachine interface that is used to create and restore checkpoints.
+type State interface {
+	// Snapshot returns a serialized copy of the state, and a digest that identifies the state.
+	Snapshot() (snapshot []byte, digest hotstuff.Hash)
+	// Restore replaces the state with the state of a snapshot.
+	Restore(snapshot []byte) error
+}
+
+// Applier is implemented by states that defer part of Restore until the restored state has been checked against the
+// checkpoint, such as changes to the configuration of the replicas, which cannot be undone if the snapshot is invalid.
+type Applier interface {
+	// ApplyRestored applies the deferred part of the last restored snapshot.
+	ApplyRestored() error
+}
+
+// vote is a checkpoint signed by a single replica.
+type vote struct {
+	checkpoint hotstuff.Checkpoint
+	epoch      hotstuff.Epoch
+	sig        hotstuff.QuorumSignature
+}
+
+// certifiedEvent is added to the event loop when a checkpoint certificate has been stored.
+type certifiedEvent struct {
+	cert hotstuff.CheckpointCert
+}
+
+// catchUpEvent is added to the event loop when the block and snapshot of a checkpoint have been fetched.
+type catchUpEvent struct {
+	cert     hotstuff.CheckpointCert
+	block    *hotstuff.Block
+	snapshot []byte
+	err      error
+}
+
+// Checkpointer creates checkpoints of the application state and collects certificates for them.
+// It implements the ExecutorExt module by passing the committed blocks to the inner executor,
+// which must be added to the module system separately.
+type Checkpointer struct {
+	blockChain    modules.BlockChain
+	configuration *backend.Config
+	consensus     modules.Consensus
+	crypto        modules.Crypto
+	eventLoop     *eventloop.EventLoop
+	logger        logging.Logger
+	opts          *modules.Options
+	server        *backend.Server
+
+	interval uint64
+	executor modules.ExecutorExt
+	state    State
+
+	// height is the number of committed blocks. It is only accessed by Bootstrap and from the event loop.
+	height      uint64
+	knownHeight bool
+	catchingUp  bool
+
+	mut       sync.Mutex
+	cfg       *checkpointpb.Configuration
+	votes     map[uint64]map[hotstuff.ID]vote // the votes for each height
+	snapshots map[uint64][]byte               // the snapshots of the state for each height
+	latest    *hotstuff.CheckpointCert
+}
+
+// New returns a new checkpointer that creates a checkpoint every interval committed blocks.
+// The blocks are executed by the executor, which must apply them to the state.
+func New(interval uint64, executor modules.ExecutorExt, state State) *Checkpointer {
+	return &Checkpointer{
+		interval: interval,
+		executor: executor,
+		state:    state,
+		votes:    make(map[uint64]map[hotstuff.ID]vote),
+
+		snapshots: make(map[uint64][]byte),
+	}
+}
+
+// InitModule gives the module access to the other modules.
+func (c *Checkpointer) InitModule(mods *modules.Core) {
+	mods.Get(
+		&c.blockChain,
+		&c.configuration,
+		&c.consensus,
+		&c.crypto,
+		&c.eventLoop,
+		&c.logger,
+		&c.opts,
+		&c.server,
+	)
+
+	// a persistent block chain remembers the latest certificate across restarts.
+	if store, ok := c.blockChain.(modules.CheckpointStore); ok {
+		if cert, ok := store.LatestCheckpoint(); ok {
+			c.latest = &cert
+		}
+	}
+
+	checkpointpb.RegisterCheckpointServer(c.server.GetGorumsServer(), serviceImpl{c})
+
+	c.eventLoop.RegisterObserver(backend.ConnectedEvent{}, func(_ any) {
+		c.mut.Lock()
+		c.cfg = checkpointpb.ConfigurationFromRaw(c.configuration.GetRawConfiguration(), qspec{c})
+		c.mut.Unlock()
+	})
+	c.eventLoop.RegisterHandler(certifiedEvent{}, func(event any) {
+		c.onCertified(event.(certifiedEvent).cert)
+	})
+	c.eventLoop.RegisterHandler(catchUpEvent{}, func(event any) {
+		c.onCatchUp(event.(catchUpEvent))
+	})
+}
+
+// Latest returns the latest checkpoint certificate, if any.
+func (c *Checkpointer) Latest() (hotstuff.CheckpointCert, bool) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if c.latest == nil {
+		return hotstuff.CheckpointCert{}, false
+	}
+	return *c.latest, true
+}
+
+// Exec executes the block, and creates a checkpoint if the height of the block is a multiple of the interval.
+func (c *Checkpointer) Exec(block *hotstuff.Block) {
+	c.executor.Exec(block)
+
+	if !c.knownHeight {
+		height, err := c.heightOf(block.Parent())
+		if err != nil {
+			c.logger.Errorf("Failed to determine the height of block %.8s: %v", block.Hash(), err)
+			return
+		}
+		c.height = height
+		c.knownHeight = true
+	}
+	c.height++
+
+	if c.height%c.interval != 0 {
+		return
+	}
+	snapshot, digest := c.state.Snapshot()
+	c.mut.Lock()
+	c.snapshots[c.height] = snapshot
+	c.mut.Unlock()
+	c.vote(hotstuff.NewCheckpoint(c.height, block.View(), block.Hash(), digest))
+}
+
+// Snapshot returns the snapshot of the state at the given height, if it is still stored.
+func (c *Checkpointer) Snapshot(height uint64) ([]byte, bool) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	snapshot, ok := c.snapshots[height]
+	return snapshot, ok
+}
+
+// heightOf returns the height of a committed block, by counting the blocks back to the latest checkpoint,
+// or to the genesis block if there is no checkpoint. This is only needed once after the replica has started.
+func (c *Checkpointer) heightOf(hash hotstuff.Hash) (uint64, error) {
+	latest, hasLatest := c.Latest()
+	var height uint64
+	for {
+		if hasLatest && hash == latest.Checkpoint().BlockHash() {
+			return latest.Checkpoint().Height() + height, nil
+		}
+		if hash == hotstuff.GetGenesis().Hash() {
+			return height, nil
+		}
+		block, ok := c.blockChain.LocalGet(hash)
+		if !ok {
+			return 0, fmt.Errorf("block %.8s is missing", hash)
+		}
+		height++
+		hash = block.Parent()
+	}
+}
+
+// vote signs the checkpoint and sends the signature to all replicas.
+func (c *Checkpointer) vote(cp hotstuff.Checkpoint) {
+	// the private key belongs to the epoch of the current view, which may be later than the view of the block.
+	epoch := c.configuration.CurrentEpoch()
+	sig, err := c.crypto.Sign(checkpointBytes(cp, epoch))
+	if err != nil {
+		c.logger.Errorf("Failed to sign checkpoint: %v", err)
+		return
+	}
+	v := vote{checkpoint: cp, epoch: epoch, sig: sig}
+
+	c.mut.Lock()
+	cfg := c.cfg
+	c.mut.Unlock()
+
+	c.logger.Debugf("Vote: %v", cp)
+	if cfg != nil {
+		cfg.Vote(context.Background(), voteToProto(v))
+	}
+	c.onVote(c.opts.ID(), v)
+}
+
+// onVote collects the votes for a checkpoint, and creates a certificate once a quorum of replicas have signed it.
+func (c *Checkpointer) onVote(sender hotstuff.ID, v vote) {
+	if v.sig == nil || v.sig.Participants().Len() != 1 || !v.sig.Participants().Contains(sender) {
+		return
+	}
+	height := v.checkpoint.Height()
+	if height == 0 || height%c.interval != 0 {
+		return
+	}
+
+	c.mut.Lock()
+	latest := uint64(0)
+	if c.latest != nil {
+		latest = c.latest.Checkpoint().Height()
+	}
+	c.mut.Unlock()
+	if height <= latest || height > latest+maxPending*c.interval {
+		return
+	}
+
+	if !c.verifyEpoch(v.checkpoint, v.epoch) || !c.crypto.VerifyInEpoch(v.epoch, v.sig, checkpointBytes(v.checkpoint, v.epoch)) {
+		c.logger.Infof("Checkpoint vote from %d: invalid signature", sender)
+		return
+	}
+
+	c.mut.Lock()
+	votes, ok := c.votes[height]
+	if !ok {
+		votes = make(map[hotstuff.ID]vote)
+		c.votes[height] = votes
+	}
+	votes[sender] = v
+
+	var sigs []hotstuff.QuorumSignature
+	for _, other := range votes {
+		if other.checkpoint == v.checkpoint && other.epoch == v.epoch {
+			sigs = append(sigs, other.sig)
+		}
+	}
+	if len(sigs) < modules.QuorumSizeAt(c.configuration, v.checkpoint.View()) {
+		c.mut.Unlock()
+		return
+	}
+	delete(c.votes, height)
+	c.mut.Unlock()
+
+	sig, err := c.crypto.Combine(sigs...)
+	if err != nil {
+		c.logger.Errorf("Failed to combine checkpoint votes: %v", err)
+		return
+	}
+	c.store(hotstuff.NewCheckpointCert(v.checkpoint, sig, v.epoch))
+}
+
+// verifyEpoch returns true if the epoch can be used to sign the checkpoint.
+// Since replicas sign with the key of their current view, the epoch may be later than the epoch of the block.
+func (c *Checkpointer) verifyEpoch(cp hotstuff.Checkpoint, epoch hotstuff.Epoch) bool {
+	return epoch >= modules.EpochAt(c.configuration, cp.View())
+}
+
+// Verify returns true if the certificate is signed by a quorum of replicas.
+func (c *Checkpointer) Verify(cert hotstuff.CheckpointCert) bool {
+	cp := cert.Checkpoint()
+	if cp.Height() == 0 || cert.Signature() == nil || !c.verifyEpoch(cp, cert.Epoch()) {
+		return false
+	}
+	if cert.Signature().Participants().Len() < modules.QuorumSizeAt(c.configuration, cp.View()) {
+		return false
+	}
+	return c.crypto.VerifyInEpoch(cert.Epoch(), cert.Signature(), checkpointBytes(cp, cert.Epoch()))
+}
+
+// store makes the certificate the latest certificate, unless there already is a later one,
+// and lets the block chain discard the blocks that are no longer needed.
+func (c *Checkpointer) store(cert hotstuff.CheckpointCert) {
+	height := cert.Checkpoint().Height()
+
+	c.mut.Lock()
+	if c.latest != nil && c.latest.Checkpoint().Height() >= height {
+		c.mut.Unlock()
+		return
+	}
+	c.latest = &cert
+	for h := range c.votes {
+		if h <= height {
+			delete(c.votes, h)
+		}
+	}
+	// the snapshot of the certified checkpoint is kept for replicas that bootstrap from it.
+	for h := range c.snapshots {
+		if h < height {
+			delete(c.snapshots, h)
+		}
+	}
+	c.mut.Unlock()
+
+	c.logger.Infof("Certified checkpoint at height %d (view %d)", height, cert.Checkpoint().View())
+	if store, ok := c.blockChain.(modules.CheckpointStore); ok {
+		store.StoreCheckpoint(cert)
+	}
+	c.eventLoop.AddEvent(certifiedEvent{cert})
+}
+
+// onCertified starts to catch up with the certified checkpoint if the replica has fallen more than an interval behind.
+// The other replicas discard the blocks that precede their previous checkpoint, so the replica may not be able to
+// fetch the blocks that it is missing, and must instead restore the state of the checkpoint.
+func (c *Checkpointer) onCertified(cert hotstuff.CheckpointCert) {
+	if !c.knownHeight || c.catchingUp || cert.Checkpoint().Height() <= c.height+c.interval {
+		return
+	}
+	if _, ok := c.consensus.(modules.Bootstrapper); !ok {
+		return
+	}
+	c.mut.Lock()
+	cfg := c.cfg
+	c.mut.Unlock()
+	if cfg == nil {
+		return
+	}
+
+	c.logger.Infof("Catching up from height %d to checkpoint at height %d", c.height, cert.Checkpoint().Height())
+	c.catchingUp = true
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), catchUpTimeout)
+		defer cancel()
+		block, snapshot, err := c.fetch(ctx, cfg, cert.Checkpoint())
+		c.eventLoop.AddEvent(catchUpEvent{cert: cert, block: block, snapshot: snapshot, err: err})
+	}()
+}
+
+// onCatchUp restores the state of a fetched checkpoint, unless the replica has caught up by executing the blocks.
+func (c *Checkpointer) onCatchUp(e catchUpEvent) {
+	c.catchingUp = false
+	cp := e.cert.Checkpoint()
+	if e.err != nil {
+		c.logger.Infof("Failed to catch up to checkpoint at height %d: %v", cp.Height(), e.err)
+		return
+	}
+	if c.height >= cp.Height() {
+		return
+	}
+
+	// the current state is kept, such that it can be put back if the snapshot is invalid.
+	backup, _ := c.state.Snapshot()
+	if err := c.restore(cp, e.snapshot); err != nil {
+		if err := c.state.Restore(backup); err != nil {
+			c.logger.Errorf("Failed to restore the previous state: %v", err)
+		}
+		c.logger.Infof("Failed to catch up to checkpoint at height %d: %v", cp.Height(), err)
+		return
+	}
+	c.blockChain.Store(e.block)
+	c.height = cp.Height()
+	c.consensus.(modules.Bootstrapper).Bootstrap(e.block)
+
+	c.logger.Infof("Caught up to checkpoint at height %d (view %d)", cp.Height(), cp.View())
+}
+
+// Bootstrap fetches the latest checkpoint certificate from the other replicas, restores the state from a snapshot
+// that matches the certificate, and lets the consensus module continue from the block of the checkpoint
+// instead of executing the blocks that precede it.
+// Bootstrap must be called after connecting to the other replicas, and before the replica is started.
+func (c *Checkpointer) Bootstrap(ctx context.Context) (cert hotstuff.CheckpointCert, err error) {
+	bootstrapper, ok := c.consensus.(modules.Bootstrapper)
+	if !ok {
+		return cert, errors.New("the consensus module cannot bootstrap from a checkpoint")
+	}
+
+	// the configuration is created here, since the connected event is not handled until the replica is started.
+	cfg := checkpointpb.ConfigurationFromRaw(c.configuration.GetRawConfiguration(), qspec{c})
+	pb, err := cfg.Latest(ctx, &checkpointpb.LatestRequest{})
+	if err != nil {
+		return cert, fmt.Errorf("failed to fetch the latest checkpoint: %w", err)
+	}
+	cert = hotstuffpb.CheckpointCertFromProto(pb)
+
+	cp := cert.Checkpoint()
+	block, snapshot, err := c.fetch(ctx, cfg, cp)
+	if err != nil {
+		return cert, err
+	}
+	if err := c.restore(cp, snapshot); err != nil {
+		return cert, err
+	}
+	c.blockChain.Store(block)
+	c.store(cert)
+
+	c.height = cp.Height()
+	c.knownHeight = true
+	bootstrapper.Bootstrap(block)
+
+	c.logger.Infof("Bootstrapped from checkpoint at height %d (view %d)", cp.Height(), cp.View())
+	return cert, nil
+}
+
+// fetch fetches the block and the snapshot of the checkpoint from the other replicas.
+func (c *Checkpointer) fetch(ctx context.Context, cfg *checkpointpb.Configuration, cp hotstuff.Checkpoint) (*hotstuff.Block, []byte, error) {
+	block, ok := c.configuration.Fetch(ctx, cp.BlockHash())
+	if !ok || block.Hash() != cp.BlockHash() || block.View() != cp.View() {
+		return nil, nil, fmt.Errorf("failed to fetch the block of the checkpoint: %.8s", cp.BlockHash())
+	}
+	snapshot, err := cfg.Snapshot(ctx, &checkpointpb.SnapshotRequest{Height: cp.Height()})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch the snapshot of the checkpoint: %w", err)
+	}
+	return block, snapshot.GetState(), nil
+}
+
+// restore replaces the state with the snapshot, and checks that the restored state matches the checkpoint.
+// The snapshot is kept, such that other replicas can restore it too.
+func (c *Checkpointer) restore(cp hotstuff.Checkpoint, snapshot []byte) error {
+	// the snapshot can only be checked against the certificate after it has been restored.
+	if err := c.state.Restore(snapshot); err != nil {
+		return fmt.Errorf("failed to restore the snapshot of the checkpoint: %w", err)
+	}
+	if _, digest := c.state.Snapshot(); digest != cp.StateHash() {
+		return errors.New("the restored state does not match the checkpoint")
+	}
+	if applier, ok := c.state.(Applier); ok {
+		if err := applier.ApplyRestored(); err != nil {
+			return fmt.Errorf("failed to apply the snapshot of the checkpoint: %w", err)
+		}
+	}
+	c.mut.Lock()
+	c.snapshots[cp.Height()] = snapshot
+	c.mut.Unlock()
+	return nil
+}
+
+// checkpointBytes returns the message that is signed by a replica to vote for a checkpoint.
+func checkpointBytes(cp hotstuff.Checkpoint, epoch hotstuff.Epoch) []byte {
+	b := append([]byte("hotstuff-checkpoint"), cp.ToBytes()...)
+	return append(b, epoch.ToBytes()...)
+}
+
+func voteToProto(v vote) *checkpointpb.CheckpointVote {
+	return &checkpointpb.CheckpointVote{
+		Checkpoint: hotstuffpb.CheckpointToProto(v.checkpoint),
+		Epoch:      uint64(v.epoch),
+		Sig:        hotstuffpb.QuorumSignatureToProto(v.sig),
+	}
+}
+
+func voteFromProto(pb *checkpointpb.CheckpointVote) vote {
+	return vote{
+		checkpoint: hotstuffpb.CheckpointFromProto(pb.GetCheckpoint()),
+		epoch:      hotstuff.Epoch(pb.GetEpoch()),
+		sig:        hotstuffpb.QuorumSignatureFromProto(pb.GetSig()),
+	}
+}
+
+var _ modules.ExecutorExt = (*Checkpointer)(nil)
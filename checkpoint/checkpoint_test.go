@@ -0,0 +1,199 @@
+package checkpoint
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/gorums"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/blockchain"
+	"github.com/relab/hotstuff/crypto"
+	"github.com/relab/hotstuff/crypto/ecdsa"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/internal/testutil"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const testInterval = 5
+
+// createCheckpointers creates n checkpointers whose configurations contain all of them.
+// The event loops do not run, so the votes and certificates must be passed to the checkpointers directly.
+func createCheckpointers(t *testing.T, n int) []*Checkpointer {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	keys := testutil.GenerateKeys(t, n, testutil.GenerateECDSAKey)
+	infos := make([]backend.ReplicaInfo, 0, n)
+	configs := make([]*backend.Config, 0, n)
+	checkpointers := make([]*Checkpointer, 0, n)
+	for i, key := range keys {
+		id := hotstuff.ID(i + 1)
+		cfg := backend.NewConfig(insecure.NewCredentials(), gorums.WithDialTimeout(time.Second))
+		srv := backend.NewServer()
+		c := New(testInterval, nil, nil)
+		builder := modules.NewBuilder(id, key)
+		builder.Add(
+			eventloop.New(100),
+			logging.New(fmt.Sprintf("cp%d", id)),
+			blockchain.New(),
+			mocks.NewMockConsensus(ctrl),
+			mocks.NewMockSynchronizer(ctrl),
+			cfg,
+			srv,
+			crypto.New(ecdsa.New()),
+			c,
+		)
+		builder.Build()
+
+		lis := testutil.CreateTCPListener(t)
+		srv.StartOnListener(lis)
+		t.Cleanup(srv.Stop)
+		infos = append(infos, backend.ReplicaInfo{ID: id, Address: lis.Addr().String(), PubKey: key.Public()})
+		configs = append(configs, cfg)
+		checkpointers = append(checkpointers, c)
+	}
+	for _, cfg := range configs {
+		if err := cfg.Connect(infos); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(cfg.Close)
+	}
+	return checkpointers
+}
+
+// signVote returns the vote of the checkpointer for the checkpoint.
+func signVote(t *testing.T, c *Checkpointer, cp hotstuff.Checkpoint) vote {
+	t.Helper()
+	sig, err := c.crypto.Sign(checkpointBytes(cp, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return vote{checkpoint: cp, epoch: 0, sig: sig}
+}
+
+// createCert returns a certificate for the checkpoint that is signed by the given checkpointers.
+func createCert(t *testing.T, checkpointers []*Checkpointer, cp hotstuff.Checkpoint) hotstuff.CheckpointCert {
+	t.Helper()
+	sigs := make([]hotstuff.QuorumSignature, 0, len(checkpointers))
+	for _, c := range checkpointers {
+		sigs = append(sigs, signVote(t, c, cp).sig)
+	}
+	sig, err := checkpointers[0].crypto.Combine(sigs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hotstuff.NewCheckpointCert(cp, sig, 0)
+}
+
+func testCheckpoint(height uint64) hotstuff.Checkpoint {
+	return hotstuff.NewCheckpoint(height, hotstuff.View(height), hotstuff.Hash{1}, hotstuff.Hash{2})
+}
+
+func TestOnVote(t *testing.T) {
+	checkpointers := createCheckpointers(t, 4)
+	c := checkpointers[0]
+	cp := testCheckpoint(testInterval)
+
+	// votes that are not signed by the sender alone, or that are not for a multiple of the interval, are ignored.
+	c.onVote(2, signVote(t, checkpointers[2], cp))
+	c.onVote(3, signVote(t, checkpointers[2], testCheckpoint(testInterval+1)))
+	// a vote for a different state does not count towards the checkpoint.
+	c.onVote(4, signVote(t, checkpointers[3], hotstuff.NewCheckpoint(testInterval, testInterval, hotstuff.Hash{1}, hotstuff.Hash{3})))
+	// repeated votes from the same replica are only counted once.
+	c.onVote(1, signVote(t, checkpointers[0], cp))
+	c.onVote(1, signVote(t, checkpointers[0], cp))
+	c.onVote(2, signVote(t, checkpointers[1], cp))
+	if _, ok := c.Latest(); ok {
+		t.Fatal("expected no certificate before a quorum of replicas have voted")
+	}
+
+	c.onVote(3, signVote(t, checkpointers[2], cp))
+	cert, ok := c.Latest()
+	if !ok {
+		t.Fatal("expected a certificate once a quorum of replicas have voted")
+	}
+	if cert.Checkpoint() != cp {
+		t.Errorf("got a certificate for %v, want %v", cert.Checkpoint(), cp)
+	}
+	if !c.Verify(cert) {
+		t.Error("expected the certificate to be valid")
+	}
+
+	// votes for checkpoints that are certified already, or too far ahead, are dropped.
+	c.onVote(4, signVote(t, checkpointers[3], cp))
+	far := testCheckpoint(testInterval * (maxPending + 2))
+	c.onVote(4, signVote(t, checkpointers[3], far))
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if len(c.votes) != 0 {
+		t.Errorf("got votes for %d checkpoints, want 0", len(c.votes))
+	}
+}
+
+func TestVerify(t *testing.T) {
+	checkpointers := createCheckpointers(t, 4)
+	c := checkpointers[0]
+	cp := testCheckpoint(testInterval)
+	cert := createCert(t, checkpointers[:3], cp)
+
+	tests := []struct {
+		name string
+		cert hotstuff.CheckpointCert
+		want bool
+	}{
+		{"Quorum", cert, true},
+		{"NoQuorum", createCert(t, checkpointers[:2], cp), false},
+		{"WrongCheckpoint", hotstuff.NewCheckpointCert(testCheckpoint(2*testInterval), cert.Signature(), 0), false},
+		{"WrongEpoch", hotstuff.NewCheckpointCert(cp, cert.Signature(), 1), false},
+		{"ZeroHeight", createCert(t, checkpointers[:3], testCheckpoint(0)), false},
+		{"NoSignature", hotstuff.NewCheckpointCert(cp, nil, 0), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := c.Verify(test.cert); got != test.want {
+				t.Errorf("Verify() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestStore(t *testing.T) {
+	checkpointers := createCheckpointers(t, 4)
+	c := checkpointers[0]
+
+	for h := uint64(1); h <= 3; h++ {
+		c.snapshots[h*testInterval] = []byte{byte(h)}
+	}
+	c.onVote(1, signVote(t, c, testCheckpoint(testInterval)))
+	c.onVote(1, signVote(t, c, testCheckpoint(3*testInterval)))
+
+	cert := createCert(t, checkpointers[:3], testCheckpoint(2*testInterval))
+	c.store(cert)
+	if latest, ok := c.Latest(); !ok || latest.Checkpoint() != cert.Checkpoint() {
+		t.Fatalf("got latest certificate %v, want %v", latest.Checkpoint(), cert.Checkpoint())
+	}
+	// the snapshot of the certified checkpoint is kept, and the earlier snapshots and votes are discarded.
+	for h, want := range map[uint64]bool{testInterval: false, 2 * testInterval: true, 3 * testInterval: true} {
+		if _, ok := c.Snapshot(h); ok != want {
+			t.Errorf("snapshot at height %d stored: %v, want %v", h, ok, want)
+		}
+	}
+	c.mut.Lock()
+	_, early := c.votes[testInterval]
+	_, late := c.votes[3*testInterval]
+	c.mut.Unlock()
+	if early || !late {
+		t.Errorf("votes stored for heights %d and %d: %v and %v, want false and true", testInterval, 3*testInterval, early, late)
+	}
+
+	// an earlier certificate does not replace the latest one.
+	c.store(createCert(t, checkpointers[:3], testCheckpoint(testInterval)))
+	if latest, _ := c.Latest(); latest.Checkpoint() != cert.Checkpoint() {
+		t.Errorf("got latest certificate %v after storing an earlier one, want %v", latest.Checkpoint(), cert.Checkpoint())
+	}
+}
@@ -0,0 +1,74 @@
+package checkpoint
+
+import (
+	"github.com/relab/gorums"
+	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/internal/proto/checkpointpb"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type serviceImpl struct {
+	c *Checkpointer
+}
+
+func (impl serviceImpl) Vote(ctx gorums.ServerCtx, msg *checkpointpb.CheckpointVote) {
+	id, err := backend.GetPeerIDFromContext(ctx, impl.c.configuration)
+	if err != nil {
+		impl.c.logger.Error(err)
+		return
+	}
+	impl.c.onVote(id, voteFromProto(msg))
+}
+
+func (impl serviceImpl) Latest(_ gorums.ServerCtx, _ *checkpointpb.LatestRequest) (*hotstuffpb.CheckpointCert, error) {
+	cert, ok := impl.c.Latest()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no checkpoint has been certified")
+	}
+	return hotstuffpb.CheckpointCertToProto(cert), nil
+}
+
+func (impl serviceImpl) Snapshot(_ gorums.ServerCtx, req *checkpointpb.SnapshotRequest) (*checkpointpb.StateSnapshot, error) {
+	snapshot, ok := impl.c.Snapshot(req.GetHeight())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no snapshot at height %d", req.GetHeight())
+	}
+	return &checkpointpb.StateSnapshot{Height: req.GetHeight(), State: snapshot}, nil
+}
+
+type qspec struct {
+	c *Checkpointer
+}
+
+// LatestQF is the quorum function for the Latest quorum call method.
+// It waits for a quorum of replies, and returns the valid certificate with the greatest height.
+// Since a quorum of replicas have stored each certificate, the reply includes the latest certificate
+// of at least one correct replica.
+func (q qspec) LatestQF(_ *checkpointpb.LatestRequest, replies map[uint32]*hotstuffpb.CheckpointCert) (*hotstuffpb.CheckpointCert, bool) {
+	if len(replies) < q.c.configuration.QuorumSize()-1 {
+		return nil, false
+	}
+	var latest *hotstuffpb.CheckpointCert
+	for _, reply := range replies {
+		if latest != nil && reply.GetCheckpoint().GetHeight() <= latest.GetCheckpoint().GetHeight() {
+			continue
+		}
+		if q.c.Verify(hotstuffpb.CheckpointCertFromProto(reply)) {
+			latest = reply
+		}
+	}
+	return latest, latest != nil
+}
+
+// SnapshotQF is the quorum function for the Snapshot quorum call method.
+// It returns the first snapshot for the requested height, which is checked against the certificate after it is restored.
+func (q qspec) SnapshotQF(in *checkpointpb.SnapshotRequest, replies map[uint32]*checkpointpb.StateSnapshot) (*checkpointpb.StateSnapshot, bool) {
+	for _, reply := range replies {
+		if reply.GetHeight() == in.GetHeight() {
+			return reply, true
+		}
+	}
+	return nil, false
+}
@@ -1,6 +1,6 @@
 // Package client implements a simple client for testing HotStuff.
 // The client reads data from an input stream and sends the data in commands to a HotStuff replica.
-// The client waits for replies from f+1 replicas before it considers a command to be executed.
+// The client waits for matching results from f+1 replicas before it considers a command to be executed.
 package client
 
 import (
@@ -15,6 +15,7 @@ import (
 	"github.com/relab/gorums"
 	"github.com/relab/hotstuff"
 	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/crypto/keygen"
 	"github.com/relab/hotstuff/eventloop"
 	"github.com/relab/hotstuff/internal/proto/clientpb"
 	"github.com/relab/hotstuff/logging"
@@ -23,24 +24,35 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/proto"
 )
 
 type qspec struct {
 	faulty int
 }
 
-func (q *qspec) ExecCommandQF(_ *clientpb.Command, signatures map[uint32]*emptypb.Empty) (*emptypb.Empty, bool) {
-	if len(signatures) < q.faulty+1 {
-		return nil, false
+// ExecCommandQF is the quorum function for the ExecCommand quorum call method.
+// It waits for f+1 matching results, such that at least one of them is from a correct replica.
+// If the replicas have executed the command differently, the call fails once all of them have replied.
+func (q *qspec) ExecCommandQF(_ *clientpb.Command, replies map[uint32]*clientpb.CommandResult) (*clientpb.CommandResult, bool) {
+	for _, reply := range replies {
+		n := 0
+		for _, other := range replies {
+			if proto.Equal(reply, other) {
+				n++
+			}
+		}
+		if n > q.faulty {
+			return reply, true
+		}
 	}
-	return &emptypb.Empty{}, true
+	return nil, false
 }
 
 type pendingCmd struct {
 	sequenceNumber uint64
 	sendTime       time.Time
-	promise        *clientpb.AsyncEmpty
+	promise        *clientpb.AsyncCommandResult
 	cancelCtx      context.CancelFunc
 }
 
@@ -69,6 +81,7 @@ type Client struct {
 	gorumsConfig     *clientpb.Configuration
 	payloadSize      uint32
 	highestCommitted uint64 // highest sequence number acknowledged by the replicas
+	execNumber       uint64 // sequence number of the last command sent by Exec
 	pendingCmds      chan pendingCmd
 	cancel           context.CancelFunc
 	done             chan struct{}
@@ -188,6 +201,102 @@ func (c *Client) Stop() {
 	<-c.done
 }
 
+// Reconfigure sends a command that adds the given replicas to the configuration of the replicas,
+// and removes the replicas with the given IDs. It waits until f+1 replicas have decided the change.
+// The new set of replicas participates from a fixed number of views after the view where the command was committed.
+//
+// The command is signed with the operator's private key, which must match the operator key of the replicas.
+// The epoch must be the epoch in which the command is committed, otherwise the replicas reject it.
+// The command is numbered by the clock, and the replicas reject it if they have decided a reconfiguration with the
+// same or a higher number, such that it cannot be committed again.
+//
+// Like Exec, Reconfigure is used by clients that are connected, but not running.
+func (c *Client) Reconfigure(ctx context.Context, operatorKey hotstuff.PrivateKey, epoch hotstuff.Epoch, add []backend.ReplicaInfo, remove []hotstuff.ID) error {
+	r := &clientpb.Reconfiguration{Epoch: uint64(epoch), Sequence: uint64(time.Now().UnixNano())}
+	for _, replica := range add {
+		pubKey, err := keygen.PublicKeyToPEM(replica.PubKey)
+		if err != nil {
+			return err
+		}
+		r.Add = append(r.Add, &clientpb.Replica{
+			ID:        uint32(replica.ID),
+			Address:   replica.Address,
+			PublicKey: pubKey,
+		})
+	}
+	for _, id := range remove {
+		r.Remove = append(r.Remove, uint32(id))
+	}
+	if err := r.Sign(operatorKey); err != nil {
+		return err
+	}
+	cmd := c.execCommand(nil)
+	cmd.Reconfiguration = r
+	_, err := c.execFilled(ctx, cmd)
+	return err
+}
+
+// Exec sends a command with the given data to the replicas, and waits until f+1 replicas have executed it
+// with matching results.
+//
+// Exec is used by clients that are connected, but not running, and the sequence numbers of the
+// commands are taken from the clock. The commands should be sent one at a time, since the replicas drop commands
+// whose sequence numbers are lower than those of the client's proposed commands.
+func (c *Client) Exec(ctx context.Context, data []byte) (*clientpb.CommandResult, error) {
+	return c.execFilled(ctx, c.execCommand(data))
+}
+
+// execFilled sends the command and waits for the result. The replicas only propose blocks when they have commands,
+// so while it waits, execFilled sends empty commands, like Run does at the end of its input,
+// such that the block of the command is extended and committed.
+func (c *Client) execFilled(ctx context.Context, cmd *clientpb.Command) (*clientpb.CommandResult, error) {
+	promise := c.gorumsConfig.ExecCommand(ctx, cmd)
+
+	fillCtx, cancel := context.WithCancel(ctx)
+	filled := make(chan struct{})
+	go func() {
+		defer close(filled)
+		ticker := time.NewTicker(fillInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				// the results of the empty commands are not needed.
+				c.gorumsConfig.ExecCommand(fillCtx, c.execCommand(nil))
+			case <-fillCtx.Done():
+				return
+			}
+		}
+	}()
+
+	res, err := promise.Get()
+	cancel()
+	<-filled
+	return res, err
+}
+
+// fillInterval is the interval between the empty commands that Exec and Reconfigure send while they wait.
+const fillInterval = 10 * time.Millisecond
+
+func (c *Client) execCommand(data []byte) *clientpb.Command {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.execNumber++
+	if now := uint64(time.Now().UnixNano()); now > c.execNumber {
+		c.execNumber = now
+	}
+	return &clientpb.Command{
+		ClientID:       uint32(c.opts.ID()),
+		SequenceNumber: c.execNumber,
+		Data:           data,
+	}
+}
+
+// Close closes the connections of a client that is not running.
+func (c *Client) Close() {
+	c.mgr.Close()
+}
+
 func (c *Client) close() {
 	c.mgr.Close()
 	err := c.reader.Close()
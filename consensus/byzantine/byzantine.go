@@ -2,14 +2,19 @@
 package byzantine
 
 import (
+	"sort"
+
 	"github.com/relab/hotstuff"
 	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/logging"
 	"github.com/relab/hotstuff/modules"
 )
 
 func init() {
 	modules.RegisterModule("silence", func() Byzantine { return &silence{} })
 	modules.RegisterModule("fork", func() Byzantine { return &fork{} })
+	modules.RegisterModule("equivocate", func() Byzantine { return &equivocate{} })
 }
 
 // Byzantine wraps a consensus rules implementation and alters its behavior.
@@ -43,15 +48,17 @@ func NewSilence(c consensus.Rules) consensus.Rules {
 }
 
 type fork struct {
-	blockChain   modules.BlockChain
-	synchronizer modules.Synchronizer
-	opts         *modules.Options
+	blockChain    modules.BlockChain
+	configuration modules.Configuration
+	synchronizer  modules.Synchronizer
+	opts          *modules.Options
 	consensus.Rules
 }
 
 func (f *fork) InitModule(mods *modules.Core) {
 	mods.Get(
 		&f.blockChain,
+		&f.configuration,
 		&f.synchronizer,
 		&f.opts,
 	)
@@ -73,17 +80,21 @@ func (f *fork) ProposeRule(cert hotstuff.SyncInfo, cmd hotstuff.Command) (propos
 
 	proposal = hotstuff.ProposeMsg{
 		ID: f.opts.ID(),
-		Block: hotstuff.NewBlock(
+		Block: hotstuff.NewBlockWithEpoch(
 			grandparent.Hash(),
 			grandparent.QuorumCert(),
 			cmd,
 			f.synchronizer.View(),
 			f.opts.ID(),
+			modules.EpochAt(f.configuration, f.synchronizer.View()),
 		),
 	}
 	if aggQC, ok := cert.AggQC(); f.opts.ShouldUseAggQC() && ok {
 		proposal.AggregateQC = &aggQC
 	}
+	if tc, ok := cert.TC(); f.opts.ShouldUseQCViewTC() && ok {
+		proposal.TimeoutCert = &tc
+	}
 	return proposal, true
 }
 
@@ -96,3 +107,143 @@ func (f *fork) Wrap(rules consensus.Rules) consensus.Rules {
 	f.Rules = rules
 	return f
 }
+
+type equivocate struct {
+	blockChain     modules.BlockChain
+	configuration  modules.Configuration
+	eventLoop      *eventloop.EventLoop
+	leaderRotation modules.LeaderRotation
+	logger         logging.Logger
+	synchronizer   modules.Synchronizer
+	opts           *modules.Options
+	consensus.Rules
+
+	halves      [2]modules.Configuration
+	halvesEpoch hotstuff.Epoch // the epoch whose members the halves were created from
+}
+
+func (e *equivocate) InitModule(mods *modules.Core) {
+	mods.Get(
+		&e.blockChain,
+		&e.configuration,
+		&e.eventLoop,
+		&e.leaderRotation,
+		&e.logger,
+		&e.synchronizer,
+		&e.opts,
+	)
+
+	if mod, ok := e.Rules.(modules.Module); ok {
+		mod.InitModule(mods)
+	}
+}
+
+// subConfigs splits the other replicas that participate in the view into two subconfigurations.
+// The subconfigurations are created again when the view belongs to a new epoch, since the members may have changed.
+func (e *equivocate) subConfigs(view hotstuff.View) bool {
+	epoch := modules.EpochAt(e.configuration, view)
+	if e.halves[0] != nil && e.halvesEpoch == epoch {
+		return true
+	}
+	var members []hotstuff.ID
+	if m, ok := e.configuration.(modules.Membership); ok {
+		members = m.Members(view)
+	} else {
+		for id := range e.configuration.Replicas() {
+			members = append(members, id)
+		}
+	}
+	var ids []hotstuff.ID
+	for _, id := range members {
+		if id != e.opts.ID() {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	var halves [2][]hotstuff.ID
+	for i, id := range ids {
+		halves[i%2] = append(halves[i%2], id)
+	}
+	for i, half := range halves {
+		cfg, err := e.configuration.SubConfig(half)
+		if err != nil {
+			e.logger.Errorf("Failed to create subconfiguration: %v", err)
+			return false
+		}
+		e.halves[i] = cfg
+	}
+	e.halvesEpoch = epoch
+	return true
+}
+
+// ProposeRule creates the proposal of the wrapped rules, and a conflicting proposal without the command.
+// The two proposals are sent to different halves of the configuration, and the replica itself votes for the first one.
+func (e *equivocate) ProposeRule(cert hotstuff.SyncInfo, cmd hotstuff.Command) (proposal hotstuff.ProposeMsg, ok bool) {
+	if proposer, isProposer := e.Rules.(consensus.ProposeRuler); isProposer {
+		proposal, ok = proposer.ProposeRule(cert, cmd)
+		if !ok {
+			return proposal, false
+		}
+	} else {
+		qc, _ := cert.QC()
+		proposal = hotstuff.ProposeMsg{
+			ID: e.opts.ID(),
+			Block: hotstuff.NewBlockWithEpoch(
+				e.synchronizer.LeafBlock().Hash(),
+				qc,
+				cmd,
+				e.synchronizer.View(),
+				e.opts.ID(),
+				modules.EpochAt(e.configuration, e.synchronizer.View()),
+			),
+		}
+		if aggQC, ok := cert.AggQC(); ok && e.opts.ShouldUseAggQC() {
+			proposal.AggregateQC = &aggQC
+		}
+		if tc, ok := cert.TC(); ok && e.opts.ShouldUseQCViewTC() {
+			proposal.TimeoutCert = &tc
+		}
+	}
+
+	// both proposals carry the proof, since the other replicas reject proposals from replicas that cannot prove
+	// that they are leaders.
+	if leaderElection, ok := e.leaderRotation.(modules.LeaderElection); ok {
+		proof, isLeader := leaderElection.ProveLeader(proposal.Block.View())
+		if !isLeader {
+			return proposal, false
+		}
+		proposal.LeaderProof = proof
+	}
+
+	if !e.subConfigs(proposal.Block.View()) {
+		return proposal, true
+	}
+
+	conflicting := proposal
+	conflicting.Block = hotstuff.NewBlockWithEpoch(
+		proposal.Block.Parent(),
+		proposal.Block.QuorumCert(),
+		"",
+		proposal.Block.View(),
+		proposal.Block.Proposer(),
+		proposal.Block.Epoch(),
+	)
+
+	e.blockChain.Store(proposal.Block)
+	e.blockChain.Store(conflicting.Block)
+	e.halves[0].Propose(proposal)
+	e.halves[1].Propose(conflicting)
+	// the proposal is handled locally through the event loop, since the consensus implementation will not send it.
+	e.eventLoop.AddEvent(proposal)
+	return proposal, false
+}
+
+// NewEquivocate returns a byzantine replica that will send conflicting proposals to different replicas.
+func NewEquivocate(rules consensus.Rules) consensus.Rules {
+	return &equivocate{Rules: rules}
+}
+
+func (e *equivocate) Wrap(rules consensus.Rules) consensus.Rules {
+	e.Rules = rules
+	return e
+}
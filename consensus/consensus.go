@@ -48,22 +48,25 @@ type consensusBase struct {
 	leaderRotation modules.LeaderRotation
 	logger         logging.Logger
 	opts           *modules.Options
+	safetyRules    modules.SafetyRules
 	synchronizer   modules.Synchronizer
 
-	handel modules.Handel
-
-	lastVote hotstuff.View
+	handel         modules.Handel
+	verifier       modules.Verifier
+	safetyState    modules.SafetyStateStore
+	leaderElection modules.LeaderElection // set if the leader rotation does not reveal the leaders in advance
 
 	mut   sync.Mutex
 	bExec *hotstuff.Block
+	// proof is the chain of blocks from bExec to the block whose QCs satisfied the commit rule for bExec.
+	proof []*hotstuff.Block
 }
 
 // New returns a new Consensus instance based on the given Rules implementation.
 func New(impl Rules) modules.Consensus {
 	return &consensusBase{
-		impl:     impl,
-		lastVote: 0,
-		bExec:    hotstuff.GetGenesis(),
+		impl:  impl,
+		bExec: hotstuff.GetGenesis(),
 	}
 }
 
@@ -81,10 +84,22 @@ func (cs *consensusBase) InitModule(mods *modules.Core) {
 		&cs.leaderRotation,
 		&cs.logger,
 		&cs.opts,
+		&cs.safetyRules,
 		&cs.synchronizer,
 	)
 
 	mods.TryGet(&cs.handel)
+	cs.leaderElection, _ = cs.leaderRotation.(modules.LeaderElection)
+
+	if cs.opts.VerificationWorkers() > 0 {
+		mods.Get(&cs.verifier)
+	}
+
+	if mods.TryGet(&cs.safetyState) {
+		if state, ok := cs.safetyState.LoadConsensusState(); ok {
+			cs.bExec = state.BExec
+		}
+	}
 
 	if mod, ok := cs.impl.(modules.Module); ok {
 		mod.InitModule(mods)
@@ -101,10 +116,33 @@ func (cs *consensusBase) CommittedBlock() *hotstuff.Block {
 	return cs.bExec
 }
 
+// Bootstrap makes the given block the most recently committed block, without executing it or its ancestors.
+// It is used to continue from a checkpoint, when the executor's state has been restored from elsewhere.
+func (cs *consensusBase) Bootstrap(block *hotstuff.Block) {
+	cs.mut.Lock()
+	if cs.bExec.View() >= block.View() {
+		cs.mut.Unlock()
+		return
+	}
+	cs.bExec = block
+	cs.mut.Unlock()
+
+	if err := cs.storeState(); err != nil {
+		cs.logger.Warnf("failed to store safety state: %v", err)
+	}
+	for _, forked := range cs.blockChain.PruneToHeight(block.View()) {
+		cs.forkHandler.Fork(forked)
+	}
+}
+
 // StopVoting ensures that no voting happens in a view earlier than `view`.
 func (cs *consensusBase) StopVoting(view hotstuff.View) {
-	if cs.lastVote < view {
-		cs.lastVote = view
+	if err := cs.safetyRules.StopVoting(view); err != nil {
+		cs.logger.Error("StopVoting: ", err)
+		return
+	}
+	if err := cs.storeState(); err != nil {
+		cs.logger.Error("StopVoting: failed to store safety state: ", err)
 	}
 }
 
@@ -138,18 +176,32 @@ func (cs *consensusBase) Propose(cert hotstuff.SyncInfo) {
 	} else {
 		proposal = hotstuff.ProposeMsg{
 			ID: cs.opts.ID(),
-			Block: hotstuff.NewBlock(
+			Block: hotstuff.NewBlockWithEpoch(
 				cs.synchronizer.LeafBlock().Hash(),
 				qc,
 				cmd,
 				cs.synchronizer.View(),
 				cs.opts.ID(),
+				modules.EpochAt(cs.configuration, cs.synchronizer.View()),
 			),
 		}
 
 		if aggQC, ok := cert.AggQC(); ok && cs.opts.ShouldUseAggQC() {
 			proposal.AggregateQC = &aggQC
 		}
+
+		if tc, ok := cert.TC(); ok && cs.opts.ShouldUseQCViewTC() {
+			proposal.TimeoutCert = &tc
+		}
+	}
+
+	if cs.leaderElection != nil {
+		proof, ok := cs.leaderElection.ProveLeader(proposal.Block.View())
+		if !ok {
+			cs.logger.Debug("Propose: not a leader")
+			return
+		}
+		proposal.LeaderProof = proof
 	}
 
 	cs.blockChain.Store(proposal.Block)
@@ -165,26 +217,25 @@ func (cs *consensusBase) OnPropose(proposal hotstuff.ProposeMsg) { //nolint:gocy
 
 	block := proposal.Block
 
-	if cs.opts.ShouldUseAggQC() && proposal.AggregateQC != nil {
-		highQC, ok := cs.crypto.VerifyAggregateQC(*proposal.AggregateQC)
-		if !ok {
-			cs.logger.Warn("OnPropose: failed to verify aggregate QC")
+	if !proposal.Verified {
+		if cs.verifier != nil {
+			// the proposal is handled again once the verifier has verified it.
+			proposal.Verified = true
+			cs.verifier.Verify(proposal, func() bool { return cs.verifyProposal(proposal) })
 			return
 		}
-		// NOTE: for simplicity, we require that the highQC found in the AggregateQC equals the QC embedded in the block.
-		if !block.QuorumCert().Equals(highQC) {
-			cs.logger.Warn("OnPropose: block QC does not equal highQC")
+		if !cs.verifyProposal(proposal) {
 			return
 		}
 	}
 
-	if !cs.crypto.VerifyQuorumCert(block.QuorumCert()) {
-		cs.logger.Info("OnPropose: invalid QC")
+	if block.Epoch() != modules.EpochAt(cs.configuration, block.View()) {
+		cs.logger.Info("OnPropose: block does not belong to the epoch of its view")
 		return
 	}
 
-	// ensure the block came from the leader.
-	if proposal.ID != cs.leaderRotation.GetLeader(block.View()) {
+	// ensure the block came from the leader. A proof of leadership is checked by verifyProposal instead.
+	if cs.leaderElection == nil && proposal.ID != cs.leaderRotation.GetLeader(block.View()) {
 		cs.logger.Info("OnPropose: block was not proposed by the expected leader")
 		return
 	}
@@ -194,7 +245,8 @@ func (cs *consensusBase) OnPropose(proposal hotstuff.ProposeMsg) { //nolint:gocy
 		return
 	}
 
-	if qcBlock, ok := cs.blockChain.Get(block.QuorumCert().BlockHash()); ok {
+	qcBlock, ok := cs.blockChain.Get(block.QuorumCert().BlockHash())
+	if ok {
 		cs.acceptor.Proposed(qcBlock.Command())
 	} else {
 		cs.logger.Info("OnPropose: Failed to fetch qcBlock")
@@ -212,25 +264,29 @@ func (cs *consensusBase) OnPropose(proposal hotstuff.ProposeMsg) { //nolint:gocy
 	// we defer the following in order to speed up voting
 	defer func() {
 		if b := cs.impl.CommitRule(block); b != nil {
-			cs.commit(b)
+			cs.commit(b, block)
 		}
 		if !didAdvanceView {
 			cs.synchronizer.AdvanceView(hotstuff.NewSyncInfo().WithQC(block.QuorumCert()))
 		}
 	}()
 
-	if block.View() <= cs.lastVote {
+	if block.View() <= cs.safetyRules.LastVote() {
 		cs.logger.Info("OnPropose: block view too old")
 		return
 	}
 
-	pc, err := cs.crypto.CreatePartialCert(block)
+	pc, err := cs.safetyRules.SignVote(block, qcBlock)
 	if err != nil {
 		cs.logger.Error("OnPropose: failed to sign block: ", err)
 		return
 	}
 
-	cs.lastVote = block.View()
+	// the vote must be stored before it is sent, such that we do not vote again in this view after a restart.
+	if err := cs.storeState(); err != nil {
+		cs.logger.Error("OnPropose: failed to store safety state: ", err)
+		return
+	}
 
 	if cs.handel != nil {
 		// Need to call advanceview such that the view context will be fresh.
@@ -240,7 +296,13 @@ func (cs *consensusBase) OnPropose(proposal hotstuff.ProposeMsg) { //nolint:gocy
 		return
 	}
 
-	leaderID := cs.leaderRotation.GetLeader(cs.lastVote + 1)
+	if cs.opts.ShouldVoteToAll() {
+		cs.configuration.Vote(pc)
+		cs.eventLoop.AddEvent(hotstuff.VoteMsg{ID: cs.opts.ID(), PartialCert: pc})
+		return
+	}
+
+	leaderID := cs.leaderRotation.GetLeader(block.View() + 1)
 	if leaderID == cs.opts.ID() {
 		cs.eventLoop.AddEvent(hotstuff.VoteMsg{ID: cs.opts.ID(), PartialCert: pc})
 		return
@@ -255,10 +317,51 @@ func (cs *consensusBase) OnPropose(proposal hotstuff.ProposeMsg) { //nolint:gocy
 	leader.Vote(pc)
 }
 
-func (cs *consensusBase) commit(block *hotstuff.Block) {
+// verifyProposal verifies the certificates included in the proposal.
+// It does not access the state of the consensus module, such that it can be called by the verifier.
+func (cs *consensusBase) verifyProposal(proposal hotstuff.ProposeMsg) bool {
+	block := proposal.Block
+
+	if cs.leaderElection != nil && !cs.leaderElection.VerifyLeader(proposal.ID, block.View(), proposal.LeaderProof) {
+		cs.logger.Info("OnPropose: invalid proof of leadership")
+		return false
+	}
+
+	if cs.opts.ShouldUseAggQC() && proposal.AggregateQC != nil {
+		highQC, ok := cs.crypto.VerifyAggregateQC(*proposal.AggregateQC)
+		if !ok {
+			cs.logger.Warn("OnPropose: failed to verify aggregate QC")
+			return false
+		}
+		// NOTE: for simplicity, we require that the highQC found in the AggregateQC equals the QC embedded in the block.
+		if !block.QuorumCert().Equals(highQC) {
+			cs.logger.Warn("OnPropose: block QC does not equal highQC")
+			return false
+		}
+	}
+
+	if cs.opts.ShouldUseQCViewTC() && proposal.TimeoutCert != nil {
+		if !cs.crypto.VerifyTimeoutCert(*proposal.TimeoutCert) {
+			cs.logger.Warn("OnPropose: failed to verify timeout certificate")
+			return false
+		}
+	}
+
+	if !cs.crypto.VerifyQuorumCert(block.QuorumCert()) {
+		cs.logger.Info("OnPropose: invalid QC")
+		return false
+	}
+	return true
+}
+
+// commit commits the block and its ancestors. The proposal is the block that satisfied the commit rule.
+func (cs *consensusBase) commit(block, proposal *hotstuff.Block) {
 	cs.mut.Lock()
 	// can't recurse due to requiring the mutex, so we use a helper instead.
 	err := cs.commitInner(block)
+	if err == nil && cs.bExec == block {
+		cs.proof = cs.chain(block, proposal)
+	}
 	cs.mut.Unlock()
 
 	if err != nil {
@@ -266,6 +369,10 @@ func (cs *consensusBase) commit(block *hotstuff.Block) {
 		return
 	}
 
+	if err := cs.storeState(); err != nil {
+		cs.logger.Warnf("failed to store safety state: %v", err)
+	}
+
 	// prune the blockchain and handle forked blocks
 	forkedBlocks := cs.blockChain.PruneToHeight(block.View())
 	for _, block := range forkedBlocks {
@@ -292,7 +399,75 @@ func (cs *consensusBase) commitInner(block *hotstuff.Block) error {
 	return nil
 }
 
+// chain returns the chain of blocks from the ancestor to the block, or nil if part of the chain is missing.
+func (cs *consensusBase) chain(ancestor, block *hotstuff.Block) []*hotstuff.Block {
+	blocks := []*hotstuff.Block{block}
+	for block.Hash() != ancestor.Hash() {
+		if block.View() <= ancestor.View() {
+			return nil
+		}
+		var ok bool
+		if block, ok = cs.blockChain.LocalGet(block.Parent()); !ok {
+			return nil
+		}
+		blocks = append(blocks, block)
+	}
+	for i, j := 0, len(blocks)-1; i < j; i, j = i+1, j-1 {
+		blocks[i], blocks[j] = blocks[j], blocks[i]
+	}
+	return blocks
+}
+
+// CommitProof returns a proof that the block with the given hash was committed.
+// The proof extends the chain of blocks that satisfied the commit rule for the most recently committed block
+// with the committed blocks between it and the requested block.
+func (cs *consensusBase) CommitProof(hash hotstuff.Hash) (hotstuff.CommitProof, error) {
+	cs.mut.Lock()
+	proof := cs.proof
+	cs.mut.Unlock()
+
+	if len(proof) == 0 {
+		return hotstuff.CommitProof{}, fmt.Errorf("no commit proof is available")
+	}
+	bExec := proof[0]
+	if bExec.Hash() == hash {
+		return hotstuff.NewCommitProof(proof), nil
+	}
+	block, ok := cs.blockChain.LocalGet(hash)
+	if !ok {
+		return hotstuff.CommitProof{}, fmt.Errorf("block %.8s was not found", hash)
+	}
+	if block.View() >= bExec.View() {
+		return hotstuff.CommitProof{}, fmt.Errorf("block %.8s is not committed", hash)
+	}
+	blocks := cs.chain(block, bExec)
+	if blocks == nil {
+		return hotstuff.CommitProof{}, fmt.Errorf("block %.8s is not an ancestor of the committed block", hash)
+	}
+	return hotstuff.NewCommitProof(append(blocks, proof[1:]...)), nil
+}
+
+// storeState writes the last vote and bExec to the safety state store, if there is one.
+// The lock is stored by the safety rules, so the stored lock is kept.
+func (cs *consensusBase) storeState() error {
+	if cs.safetyState == nil {
+		return nil
+	}
+	cs.mut.Lock()
+	bExec := cs.bExec
+	cs.mut.Unlock()
+	state, _ := cs.safetyState.LoadConsensusState()
+	state.LastVote = cs.safetyRules.LastVote()
+	state.BExec = bExec
+	return cs.safetyState.StoreConsensusState(state)
+}
+
 // ChainLength returns the number of blocks that need to be chained together in order to commit.
 func (cs *consensusBase) ChainLength() int {
 	return cs.impl.ChainLength()
 }
+
+var (
+	_ modules.Bootstrapper = (*consensusBase)(nil)
+	_ modules.CommitProver = (*consensusBase)(nil)
+)
@@ -11,6 +11,7 @@ import (
 	"github.com/relab/hotstuff/internal/testutil"
 	"github.com/relab/hotstuff/modules"
 	"github.com/relab/hotstuff/synchronizer"
+	"github.com/relab/hotstuff/verifier"
 )
 
 // TestVote checks that a leader can collect votes on a proposal to form a QC
@@ -60,3 +61,55 @@ func TestVote(t *testing.T) {
 		t.Error("No new view event happened")
 	}
 }
+
+// TestVoteVerifier checks that a leader can form a QC when the votes are verified by the verifier module.
+func TestVoteVerifier(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	cs := mocks.NewMockConsensus(ctrl)
+	bl[0].Options().SetVerificationWorkers(2)
+	bl[0].Add(synchronizer.New(testutil.FixedTimeout(1000)), cs, verifier.New(10))
+	hl := bl.Build()
+	hs := hl[0]
+
+	var (
+		eventLoop  *eventloop.EventLoop
+		blockChain modules.BlockChain
+		v          modules.Verifier
+	)
+
+	hs.Get(&eventLoop, &blockChain, &v)
+
+	cs.EXPECT().Propose(gomock.AssignableToTypeOf(hotstuff.NewSyncInfo()))
+
+	ok := false
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventLoop.RegisterObserver(hotstuff.NewViewMsg{}, func(event any) {
+		ok = true
+		cancel()
+	})
+
+	b := testutil.NewProposeMsg(
+		hotstuff.GetGenesis().Hash(),
+		hotstuff.NewQuorumCert(nil, 1, hotstuff.GetGenesis().Hash()),
+		"test", 1, 1,
+	)
+	blockChain.Store(b.Block)
+
+	for i, signer := range hl.Signers() {
+		pc, err := signer.CreatePartialCert(b.Block)
+		if err != nil {
+			t.Fatalf("Failed to create partial certificate: %v", err)
+		}
+		eventLoop.AddEvent(hotstuff.VoteMsg{ID: hotstuff.ID(i + 1), PartialCert: pc})
+	}
+
+	v.Start(ctx)
+	eventLoop.Run(ctx)
+
+	if !ok {
+		t.Error("No new view event happened")
+	}
+}
@@ -0,0 +1,107 @@
+// Package hotstuff2 implements the two-phase HotStuff-2 protocol.
+package hotstuff2
+
+import (
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+)
+
+func init() {
+	modules.RegisterModule("hotstuff2", New)
+}
+
+// HotStuff2 implements the chained version of the HotStuff-2 protocol.
+//
+// Based on the paper "Extended Abstract: HotStuff-2: Optimal Two-Phase Responsive BFT"
+// by Dahlia Malkhi and Kartik Nayak.
+//
+// Unlike the three-phase HotStuff protocol, a replica locks on the block certified by the highest QC it has seen,
+// and a block is committed as soon as it is certified by a QC that is itself certified in the following view.
+type HotStuff2 struct {
+	blockChain   modules.BlockChain
+	logger       logging.Logger
+	synchronizer modules.Synchronizer
+
+	locked *hotstuff.Block // the block certified by the highest known QC
+}
+
+// New returns a new HotStuff2 instance.
+func New() consensus.Rules {
+	return &HotStuff2{
+		locked: hotstuff.GetGenesis(),
+	}
+}
+
+// InitModule initializes the module.
+func (hs *HotStuff2) InitModule(mods *modules.Core) {
+	mods.Get(&hs.blockChain, &hs.logger, &hs.synchronizer)
+}
+
+func (hs *HotStuff2) qcRef(qc hotstuff.QuorumCert) (*hotstuff.Block, bool) {
+	if (hotstuff.Hash{}) == qc.BlockHash() {
+		return nil, false
+	}
+	return hs.blockChain.Get(qc.BlockHash())
+}
+
+// VoteRule decides whether to vote for the proposal or not.
+func (hs *HotStuff2) VoteRule(proposal hotstuff.ProposeMsg) bool {
+	block := proposal.Block
+
+	// Rule 1: can only vote in increasing views
+	if block.View() < hs.synchronizer.View() {
+		hs.logger.Info("VoteRule: block view too low")
+		return false
+	}
+
+	qcBlock, ok := hs.qcRef(block.QuorumCert())
+	if !ok {
+		hs.logger.Info("VoteRule: missing QC block: ", block.QuorumCert().BlockHash())
+		return false
+	}
+
+	// Rule 2: the proposal must extend the block certified by its QC.
+	if block.Parent() != qcBlock.Hash() {
+		hs.logger.Info("VoteRule: block does not extend its QC block")
+		return false
+	}
+
+	// Rule 3: the QC must be at least as high as the one we are locked on.
+	if qcBlock.View() < hs.locked.View() {
+		hs.logger.Info("VoteRule: QC is older than the locked block")
+		return false
+	}
+
+	return true
+}
+
+// CommitRule decides whether an ancestor of the block can be committed.
+func (hs *HotStuff2) CommitRule(block *hotstuff.Block) *hotstuff.Block {
+	// the QC in the block certifies the parent, which becomes the new locked block.
+	parent, ok := hs.qcRef(block.QuorumCert())
+	if !ok {
+		return nil
+	}
+	if parent.View() > hs.locked.View() {
+		hs.locked = parent
+		hs.logger.Debug("Locked: ", parent)
+	}
+
+	grandparent, ok := hs.qcRef(parent.QuorumCert())
+	if !ok {
+		return nil
+	}
+	// the grandparent is committed if it was certified directly by its child in the next view.
+	if parent.Parent() == grandparent.Hash() && parent.View() == grandparent.View()+1 {
+		hs.logger.Debug("COMMIT: ", grandparent)
+		return grandparent
+	}
+	return nil
+}
+
+// ChainLength returns the number of blocks that need to be chained together in order to commit.
+func (hs *HotStuff2) ChainLength() int {
+	return 2
+}
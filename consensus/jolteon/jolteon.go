@@ -0,0 +1,110 @@
+// Package jolteon implements the two-chain Jolteon protocol.
+package jolteon
+
+import (
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+)
+
+func init() {
+	modules.RegisterModule("jolteon", New)
+}
+
+// Jolteon implements the Jolteon protocol, which is also used by DiemBFT v4.
+//
+// Based on the paper "Jolteon and Ditto: Network-Adaptive Efficient Consensus with Asynchronous Fallback"
+// by Rati Gelashvili, Lefteris Kokoris-Kogias, Alberto Sonnino, Alexander Spiegelman, and Zhuolun Xiang.
+//
+// In the happy path, a block is committed as soon as it has a direct child that is certified in the next view.
+// After a view change, the timeout certificate records the highQC view of each signer,
+// and replicas only vote for a block if its QC is at least as high as all of those views.
+// This makes the view change quadratic in the number of replicas, in contrast with Fast-HotStuff,
+// which instead sends the highQC of each signer in an AggregateQC.
+type Jolteon struct {
+	blockChain   modules.BlockChain
+	logger       logging.Logger
+	synchronizer modules.Synchronizer
+}
+
+// New returns a new Jolteon instance.
+func New() consensus.Rules {
+	return &Jolteon{}
+}
+
+// InitModule initializes the module.
+func (j *Jolteon) InitModule(mods *modules.Core) {
+	var opts *modules.Options
+
+	mods.Get(&opts, &j.blockChain, &j.logger, &j.synchronizer)
+
+	opts.SetShouldUseQCViewTC()
+}
+
+func (j *Jolteon) qcRef(qc hotstuff.QuorumCert) (*hotstuff.Block, bool) {
+	if (hotstuff.Hash{}) == qc.BlockHash() {
+		return nil, false
+	}
+	return j.blockChain.Get(qc.BlockHash())
+}
+
+// VoteRule decides whether to vote for the proposal or not.
+// The base implementation has already verified the QC and the TC in the proposal.
+func (j *Jolteon) VoteRule(proposal hotstuff.ProposeMsg) bool {
+	block := proposal.Block
+	qc := block.QuorumCert()
+
+	if block.View() < j.synchronizer.View() {
+		j.logger.Info("VoteRule: block view too low")
+		return false
+	}
+
+	// the proposal must extend the block certified by its QC.
+	if block.Parent() != qc.BlockHash() {
+		j.logger.Info("VoteRule: block does not extend its QC block")
+		return false
+	}
+
+	// happy path: the QC is from the previous view.
+	if block.View() == qc.View()+1 {
+		return true
+	}
+
+	// after a view change, the TC must be from the previous view,
+	// and the QC must be at least as high as the highQC of every replica that timed out.
+	tc := proposal.TimeoutCert
+	if tc == nil || block.View() != tc.View()+1 {
+		j.logger.Info("VoteRule: missing timeout certificate from the previous view")
+		return false
+	}
+	if qc.View() < tc.HighQCView() {
+		j.logger.Info("VoteRule: QC is older than the highQC in the timeout certificate")
+		return false
+	}
+	return true
+}
+
+// CommitRule decides whether an ancestor of the block can be committed.
+func (j *Jolteon) CommitRule(block *hotstuff.Block) *hotstuff.Block {
+	parent, ok := j.qcRef(block.QuorumCert())
+	if !ok {
+		return nil
+	}
+	j.logger.Debug("PRECOMMIT: ", parent)
+	grandparent, ok := j.qcRef(parent.QuorumCert())
+	if !ok {
+		return nil
+	}
+	// the grandparent is committed if it was certified directly by its child in the next view.
+	if parent.Parent() == grandparent.Hash() && parent.View() == grandparent.View()+1 {
+		j.logger.Debug("COMMIT: ", grandparent)
+		return grandparent
+	}
+	return nil
+}
+
+// ChainLength returns the number of blocks that need to be chained together in order to commit.
+func (j *Jolteon) ChainLength() int {
+	return 2
+}
@@ -0,0 +1,223 @@
+package pbft
+
+import (
+	"github.com/relab/gorums"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+)
+
+type phase byte
+
+const (
+	preparePhase phase = iota + 1
+	commitPhase
+)
+
+// prePrepareMsg is sent by the primary to assign a block to the next sequence number.
+type prePrepareMsg struct {
+	id    hotstuff.ID
+	view  hotstuff.View
+	block *hotstuff.Block
+}
+
+// voteMsg is a prepare or commit message for the block with the given hash and sequence number.
+type voteMsg struct {
+	phase phase
+	view  hotstuff.View
+	seq   hotstuff.View
+	hash  hotstuff.Hash
+	sig   hotstuff.QuorumSignature
+}
+
+// preparedCert proves that a quorum of replicas sent prepare messages for the block in the given view.
+type preparedCert struct {
+	view  hotstuff.View
+	block *hotstuff.Block
+	sig   hotstuff.QuorumSignature
+}
+
+// viewChangeMsg is sent by a replica that wants to move to the given view.
+// It includes the highest prepared certificate known to the replica, if any.
+type viewChangeMsg struct {
+	view     hotstuff.View
+	prepared *preparedCert
+	sig      hotstuff.QuorumSignature
+}
+
+// newViewMsg is sent by the primary of a new view.
+// It proves that a quorum of replicas wanted to change the view,
+// and carries the prepared block that must be proposed again in the new view, if any.
+type newViewMsg struct {
+	id          hotstuff.ID
+	view        hotstuff.View
+	viewChanges []viewChangeMsg
+	block       *hotstuff.Block
+}
+
+// proposeEvent is sent when the command queue has returned a command for the primary to propose.
+type proposeEvent struct {
+	view hotstuff.View
+	cmd  hotstuff.Command
+	ok   bool
+}
+
+// timeoutEvent is sent when the timer expires.
+type timeoutEvent struct{}
+
+// voteBytes returns the message that is signed by prepare and commit messages.
+func voteBytes(p phase, view, seq hotstuff.View, hash hotstuff.Hash) []byte {
+	b := []byte{byte(p)}
+	b = append(b, view.ToBytes()...)
+	b = append(b, seq.ToBytes()...)
+	return append(b, hash[:]...)
+}
+
+// viewChangeBytes returns the message that is signed by view change messages.
+func viewChangeBytes(view hotstuff.View, prepared *preparedCert) []byte {
+	b := view.ToBytes()
+	if prepared != nil {
+		hash := prepared.block.Hash()
+		b = append(b, prepared.view.ToBytes()...)
+		b = append(b, hash[:]...)
+	}
+	return b
+}
+
+// signer returns the ID of the replica that created the signature.
+func signer(sig hotstuff.QuorumSignature) (id hotstuff.ID) {
+	sig.Participants().RangeWhile(func(i hotstuff.ID) bool {
+		id = i
+		return false
+	})
+	return id
+}
+
+func prePrepareToProto(m prePrepareMsg) *hotstuffpb.PBFTPrePrepare {
+	return &hotstuffpb.PBFTPrePrepare{
+		View:  uint64(m.view),
+		Block: hotstuffpb.BlockToProto(m.block),
+	}
+}
+
+func voteToProto(m voteMsg) *hotstuffpb.PBFTVote {
+	return &hotstuffpb.PBFTVote{
+		View:     uint64(m.view),
+		Sequence: uint64(m.seq),
+		Hash:     m.hash[:],
+		Sig:      hotstuffpb.QuorumSignatureToProto(m.sig),
+	}
+}
+
+func voteFromProto(p phase, m *hotstuffpb.PBFTVote) voteMsg {
+	var hash hotstuff.Hash
+	copy(hash[:], m.GetHash())
+	return voteMsg{
+		phase: p,
+		view:  hotstuff.View(m.GetView()),
+		seq:   hotstuff.View(m.GetSequence()),
+		hash:  hash,
+		sig:   hotstuffpb.QuorumSignatureFromProto(m.GetSig()),
+	}
+}
+
+func viewChangeToProto(m viewChangeMsg) *hotstuffpb.PBFTViewChange {
+	vc := &hotstuffpb.PBFTViewChange{
+		View: uint64(m.view),
+		Sig:  hotstuffpb.QuorumSignatureToProto(m.sig),
+	}
+	if m.prepared != nil {
+		vc.Prepared = &hotstuffpb.PBFTPreparedCert{
+			View:  uint64(m.prepared.view),
+			Block: hotstuffpb.BlockToProto(m.prepared.block),
+			Sig:   hotstuffpb.QuorumSignatureToProto(m.prepared.sig),
+		}
+	}
+	return vc
+}
+
+func viewChangeFromProto(m *hotstuffpb.PBFTViewChange) viewChangeMsg {
+	vc := viewChangeMsg{
+		view: hotstuff.View(m.GetView()),
+		sig:  hotstuffpb.QuorumSignatureFromProto(m.GetSig()),
+	}
+	if p := m.GetPrepared(); p != nil && p.GetBlock() != nil {
+		vc.prepared = &preparedCert{
+			view:  hotstuff.View(p.GetView()),
+			block: hotstuffpb.BlockFromProto(p.GetBlock()),
+			sig:   hotstuffpb.QuorumSignatureFromProto(p.GetSig()),
+		}
+	}
+	return vc
+}
+
+func newViewToProto(m newViewMsg) *hotstuffpb.PBFTNewView {
+	nv := &hotstuffpb.PBFTNewView{
+		View:        uint64(m.view),
+		ViewChanges: make([]*hotstuffpb.PBFTViewChange, 0, len(m.viewChanges)),
+	}
+	for _, vc := range m.viewChanges {
+		nv.ViewChanges = append(nv.ViewChanges, viewChangeToProto(vc))
+	}
+	if m.block != nil {
+		nv.Block = hotstuffpb.BlockToProto(m.block)
+	}
+	return nv
+}
+
+func newViewFromProto(id hotstuff.ID, m *hotstuffpb.PBFTNewView) newViewMsg {
+	nv := newViewMsg{
+		id:          id,
+		view:        hotstuff.View(m.GetView()),
+		viewChanges: make([]viewChangeMsg, 0, len(m.GetViewChanges())),
+	}
+	for _, vc := range m.GetViewChanges() {
+		nv.viewChanges = append(nv.viewChanges, viewChangeFromProto(vc))
+	}
+	if m.GetBlock() != nil {
+		nv.block = hotstuffpb.BlockFromProto(m.GetBlock())
+	}
+	return nv
+}
+
+// serviceImpl implements the PBFT gorums service by adding the received messages to the event loop.
+type serviceImpl struct {
+	p *PBFT
+}
+
+func (impl serviceImpl) PrePrepare(ctx gorums.ServerCtx, msg *hotstuffpb.PBFTPrePrepare) {
+	if msg.GetBlock() == nil {
+		return
+	}
+	id, err := backend.GetPeerIDFromContext(ctx, impl.p.configuration)
+	if err != nil {
+		impl.p.logger.Infof("Failed to get client ID: %v", err)
+		return
+	}
+	impl.p.eventLoop.AddEvent(prePrepareMsg{
+		id:    id,
+		view:  hotstuff.View(msg.GetView()),
+		block: hotstuffpb.BlockFromProto(msg.GetBlock()),
+	})
+}
+
+func (impl serviceImpl) Prepare(_ gorums.ServerCtx, msg *hotstuffpb.PBFTVote) {
+	impl.p.eventLoop.AddEvent(voteFromProto(preparePhase, msg))
+}
+
+func (impl serviceImpl) Commit(_ gorums.ServerCtx, msg *hotstuffpb.PBFTVote) {
+	impl.p.eventLoop.AddEvent(voteFromProto(commitPhase, msg))
+}
+
+func (impl serviceImpl) ViewChange(_ gorums.ServerCtx, msg *hotstuffpb.PBFTViewChange) {
+	impl.p.eventLoop.AddEvent(viewChangeFromProto(msg))
+}
+
+func (impl serviceImpl) NewView(ctx gorums.ServerCtx, msg *hotstuffpb.PBFTNewView) {
+	id, err := backend.GetPeerIDFromContext(ctx, impl.p.configuration)
+	if err != nil {
+		impl.p.logger.Infof("Failed to get client ID: %v", err)
+		return
+	}
+	impl.p.eventLoop.AddEvent(newViewFromProto(id, msg))
+}
@@ -0,0 +1,524 @@
+// Package pbft implements the Practical Byzantine Fault Tolerance protocol as a baseline for comparison with HotStuff.
+//
+// Unlike the other consensus implementations, PBFT does not use the consensus.Rules interface,
+// because it has a different message pattern and a different view change protocol.
+// Instead, the PBFT module implements both the Consensus and the Synchronizer interfaces,
+// and it communicates with the other replicas through its own gorums service.
+package pbft
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/internal/proto/pbftpb"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+	"github.com/relab/hotstuff/synchronizer"
+)
+
+func init() {
+	modules.RegisterModule("pbft", New)
+}
+
+// maxPending is how far beyond the committed block the pre-prepares and votes that arrive early are kept,
+// and how far beyond the current view the messages for later views are kept.
+// Later messages are dropped, such that faulty replicas cannot exhaust the memory of the other replicas.
+const maxPending = 10
+
+// PBFT implements the normal case and the view change protocol of PBFT.
+//
+// Based on the paper "Practical Byzantine Fault Tolerance" by Miguel Castro and Barbara Liskov.
+//
+// The primary of a view is chosen by the leader rotation module, and it remains the primary until the view changes.
+// The primary assigns one block at a time to the next sequence number, which is stored as the view of the block.
+// A block is prepared when a quorum of replicas has sent prepare messages for it,
+// and it is committed when a quorum of replicas has sent commit messages for it.
+// The primary proposes the next block once it has committed the previous one.
+//
+// If a replica does not commit a block before its timer expires, it sends a view change message with its
+// highest prepared certificate to all replicas. The primary of the new view collects a quorum of view change
+// messages and sends a new view message that re-proposes the prepared block with the highest sequence number, if any.
+type PBFT struct {
+	acceptor       modules.Acceptor
+	blockChain     modules.BlockChain
+	commandQueue   modules.CommandQueue
+	configuration  *backend.Config
+	crypto         modules.Crypto
+	duration       synchronizer.ViewDuration
+	eventLoop      *eventloop.EventLoop
+	executor       modules.ExecutorExt
+	forkHandler    modules.ForkHandlerExt
+	leaderRotation modules.LeaderRotation
+	logger         logging.Logger
+	opts           *modules.Options
+	server         *backend.Server
+
+	pbCfg *pbftpb.Configuration
+
+	mut   sync.Mutex
+	bExec *hotstuff.Block
+
+	view         hotstuff.View
+	viewChanging bool // true while waiting for the new view message of the current view
+	viewCtx      context.Context
+	cancelCtx    context.CancelFunc
+	timer        *time.Timer
+	timerStopped bool
+	deadline     time.Time
+
+	accepted   *hotstuff.Block // the block that is currently being agreed upon
+	sentCommit bool
+	prepared   *preparedCert // the highest prepared certificate
+	prepares   map[hotstuff.View]map[hotstuff.ID]voteMsg
+	commits    map[hotstuff.View]map[hotstuff.ID]voteMsg
+	pending    map[hotstuff.View]prePrepareMsg // pre-prepares that arrived before the previous block was committed
+	proposing  bool
+
+	viewChanges map[hotstuff.View]map[hotstuff.ID]viewChangeMsg
+	sentNewView hotstuff.View
+}
+
+// New returns a new PBFT instance.
+func New() modules.Consensus {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &PBFT{
+		bExec:       hotstuff.GetGenesis(),
+		view:        1,
+		viewCtx:     ctx,
+		cancelCtx:   cancel,
+		prepares:    make(map[hotstuff.View]map[hotstuff.ID]voteMsg),
+		commits:     make(map[hotstuff.View]map[hotstuff.ID]voteMsg),
+		pending:     make(map[hotstuff.View]prePrepareMsg),
+		viewChanges: make(map[hotstuff.View]map[hotstuff.ID]viewChangeMsg),
+	}
+}
+
+// InitModule initializes the module.
+func (p *PBFT) InitModule(mods *modules.Core) {
+	mods.Get(
+		&p.configuration,
+		&p.server,
+
+		&p.acceptor,
+		&p.blockChain,
+		&p.commandQueue,
+		&p.crypto,
+		&p.duration,
+		&p.eventLoop,
+		&p.executor,
+		&p.forkHandler,
+		&p.leaderRotation,
+		&p.logger,
+		&p.opts,
+	)
+
+	pbftpb.RegisterPBFTServer(p.server.GetGorumsServer(), serviceImpl{p})
+
+	p.eventLoop.RegisterObserver(backend.ConnectedEvent{}, func(_ any) {
+		p.pbCfg = pbftpb.ConfigurationFromRaw(p.configuration.GetRawConfiguration(), nil)
+	})
+
+	p.eventLoop.RegisterHandler(prePrepareMsg{}, func(event any) {
+		p.onPrePrepare(event.(prePrepareMsg))
+	})
+	p.eventLoop.RegisterHandler(voteMsg{}, func(event any) {
+		p.onVote(event.(voteMsg))
+	})
+	p.eventLoop.RegisterHandler(viewChangeMsg{}, func(event any) {
+		p.onViewChange(event.(viewChangeMsg))
+	})
+	p.eventLoop.RegisterHandler(newViewMsg{}, func(event any) {
+		p.onNewView(event.(newViewMsg))
+	})
+	p.eventLoop.RegisterHandler(proposeEvent{}, func(event any) {
+		p.onProposeEvent(event.(proposeEvent))
+	})
+	p.eventLoop.RegisterHandler(timeoutEvent{}, func(_ any) {
+		p.onTimeout()
+	})
+}
+
+// Start starts the view timer and lets the first primary propose.
+func (p *PBFT) Start(ctx context.Context) {
+	p.duration.ViewStarted()
+	d := p.duration.Duration()
+	p.deadline = time.Now().Add(d)
+	p.timer = time.AfterFunc(d, func() {
+		p.eventLoop.AddEvent(timeoutEvent{})
+	})
+
+	go func() {
+		<-ctx.Done()
+		p.timer.Stop()
+		p.cancelCtx()
+	}()
+
+	p.propose()
+}
+
+// AdvanceView does nothing, as PBFT changes views through its own view change protocol.
+func (p *PBFT) AdvanceView(_ hotstuff.SyncInfo) {}
+
+// View returns the current view.
+func (p *PBFT) View() hotstuff.View {
+	return p.view
+}
+
+// ViewContext returns a context that is cancelled at the end of the view.
+func (p *PBFT) ViewContext() context.Context {
+	return p.viewCtx
+}
+
+// HighQC returns a reference to the last committed block.
+// PBFT does not create quorum certificates for blocks, so the returned certificate has no signature.
+func (p *PBFT) HighQC() hotstuff.QuorumCert {
+	bExec := p.CommittedBlock()
+	return hotstuff.NewQuorumCert(nil, bExec.View(), bExec.Hash())
+}
+
+// LeafBlock returns the last committed block.
+func (p *PBFT) LeafBlock() *hotstuff.Block {
+	return p.CommittedBlock()
+}
+
+// StopVoting does nothing, as PBFT replicas only vote for one block per sequence number and view.
+func (p *PBFT) StopVoting(_ hotstuff.View) {}
+
+// Propose starts a new proposal if the local replica is the primary.
+func (p *PBFT) Propose(_ hotstuff.SyncInfo) {
+	p.propose()
+}
+
+// CommittedBlock returns the most recently committed block.
+func (p *PBFT) CommittedBlock() *hotstuff.Block {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	return p.bExec
+}
+
+// ChainLength returns the number of blocks that need to be chained together in order to commit.
+func (p *PBFT) ChainLength() int {
+	return 1
+}
+
+// Bootstrap makes the given block the most recently committed block, without executing it or its ancestors.
+// It is used to continue from a checkpoint, when the executor's state has been restored from elsewhere.
+// After the replica has started, it must be called from the event loop.
+func (p *PBFT) Bootstrap(block *hotstuff.Block) {
+	p.mut.Lock()
+	if p.bExec.View() >= block.View() {
+		p.mut.Unlock()
+		return
+	}
+	p.bExec = block
+	p.mut.Unlock()
+
+	for _, forked := range p.blockChain.PruneToHeight(block.View()) {
+		p.forkHandler.Fork(forked)
+	}
+
+	if p.accepted != nil && p.accepted.View() <= block.View() {
+		p.accepted = nil
+		p.sentCommit = false
+	}
+	p.prune(block.View())
+	if msg, ok := p.pending[block.View()+1]; ok {
+		delete(p.pending, block.View()+1)
+		p.onPrePrepare(msg)
+	}
+
+	p.propose()
+}
+
+func (p *PBFT) isPrimary() bool {
+	return p.leaderRotation.GetLeader(p.view) == p.opts.ID()
+}
+
+// propose fetches a command for the next block in the background, if the local replica is the primary.
+func (p *PBFT) propose() {
+	if p.viewChanging || p.proposing || p.accepted != nil || !p.isPrimary() {
+		return
+	}
+	p.proposing = true
+	view, ctx := p.view, p.viewCtx
+	go func() {
+		cmd, ok := p.commandQueue.Get(ctx)
+		p.eventLoop.AddEvent(proposeEvent{view: view, cmd: cmd, ok: ok})
+	}()
+}
+
+func (p *PBFT) onProposeEvent(e proposeEvent) {
+	if e.view != p.view || p.viewChanging {
+		return
+	}
+	p.proposing = false
+	if !e.ok || p.accepted != nil {
+		return
+	}
+
+	bExec := p.CommittedBlock()
+	block := hotstuff.NewBlockWithEpoch(
+		bExec.Hash(),
+		hotstuff.NewQuorumCert(nil, bExec.View(), bExec.Hash()),
+		e.cmd,
+		bExec.View()+1,
+		p.opts.ID(),
+		modules.EpochAt(p.configuration, bExec.View()+1),
+	)
+	msg := prePrepareMsg{id: p.opts.ID(), view: p.view, block: block}
+
+	p.logger.Debugf("PrePrepare: %v", block)
+	if p.pbCfg != nil {
+		p.pbCfg.PrePrepare(context.Background(), prePrepareToProto(msg))
+	}
+	p.onPrePrepare(msg)
+}
+
+func (p *PBFT) onPrePrepare(msg prePrepareMsg) {
+	if msg.view < p.view || msg.view > p.view+maxPending || msg.block == nil {
+		return
+	}
+	if msg.id != p.leaderRotation.GetLeader(msg.view) || msg.block.Proposer() != msg.id {
+		p.logger.Infof("PrePrepare from %d: sender is not the primary", msg.id)
+		return
+	}
+	if msg.view > p.view || p.viewChanging {
+		// the pre-prepare is handled once the new view has been installed.
+		p.eventLoop.DelayUntil(synchronizer.ViewChangeEvent{}, msg)
+		return
+	}
+
+	bExec := p.CommittedBlock()
+	if msg.block.View() > bExec.View()+maxPending {
+		p.logger.Infof("PrePrepare from %d: block %d is too far ahead of the committed block", msg.id, msg.block.View())
+		return
+	}
+	if msg.block.View() > bExec.View()+1 {
+		// the previous block is not committed yet.
+		p.pending[msg.block.View()] = msg
+		return
+	}
+	if p.accepted != nil || msg.block.View() != bExec.View()+1 || msg.block.Parent() != bExec.Hash() {
+		p.logger.Infof("PrePrepare from %d: unexpected block %v", msg.id, msg.block)
+		return
+	}
+	if !p.acceptor.Accept(msg.block.Command()) {
+		p.logger.Infof("PrePrepare from %d: command not accepted", msg.id)
+		return
+	}
+	p.accept(msg.block)
+}
+
+// accept makes the block the one that is currently being agreed upon, and sends a prepare message for it.
+func (p *PBFT) accept(block *hotstuff.Block) {
+	p.blockChain.Store(block)
+	p.accepted = block
+	p.sendVote(preparePhase, block)
+	p.checkProgress()
+}
+
+func (p *PBFT) sendVote(ph phase, block *hotstuff.Block) {
+	hash := block.Hash()
+	sig, err := p.crypto.Sign(voteBytes(ph, p.view, block.View(), hash))
+	if err != nil {
+		p.logger.Errorf("Failed to sign vote: %v", err)
+		return
+	}
+	vote := voteMsg{phase: ph, view: p.view, seq: block.View(), hash: hash, sig: sig}
+	if p.pbCfg != nil {
+		if ph == preparePhase {
+			p.pbCfg.Prepare(context.Background(), voteToProto(vote))
+		} else {
+			p.pbCfg.Commit(context.Background(), voteToProto(vote))
+		}
+	}
+	p.addVote(vote)
+}
+
+func (p *PBFT) onVote(vote voteMsg) {
+	if vote.view < p.view || vote.view > p.view+maxPending || vote.sig == nil || vote.sig.Participants().Len() != 1 {
+		return
+	}
+	if !p.crypto.Verify(vote.sig, voteBytes(vote.phase, vote.view, vote.seq, vote.hash)) {
+		p.logger.Infof("Vote from %d: invalid signature", signer(vote.sig))
+		return
+	}
+	if vote.view > p.view || p.viewChanging {
+		// the vote is handled once the new view has been installed.
+		p.eventLoop.DelayUntil(synchronizer.ViewChangeEvent{}, vote)
+		return
+	}
+	p.addVote(vote)
+	p.checkProgress()
+}
+
+// addVote stores the vote if it is for the accepted block or one of the next maxPending sequence numbers.
+// The accepted block may be further ahead if it was carried over by a view change.
+// Only the first vote from each replica is kept for each sequence number and phase,
+// since a correct replica does not vote for more than one block per sequence number in a view.
+func (p *PBFT) addVote(vote voteMsg) {
+	bExec := p.CommittedBlock()
+	accepted := p.accepted != nil && vote.seq == p.accepted.View()
+	if vote.seq <= bExec.View() || (vote.seq > bExec.View()+maxPending && !accepted) {
+		return
+	}
+	votes := p.prepares
+	if vote.phase == commitPhase {
+		votes = p.commits
+	}
+	seqVotes, ok := votes[vote.seq]
+	if !ok {
+		seqVotes = make(map[hotstuff.ID]voteMsg)
+		votes[vote.seq] = seqVotes
+	}
+	id := signer(vote.sig)
+	if _, ok := seqVotes[id]; ok {
+		return
+	}
+	seqVotes[id] = vote
+}
+
+// checkProgress prepares and commits the accepted block once the required votes have been received.
+func (p *PBFT) checkProgress() {
+	block := p.accepted
+	if block == nil {
+		return
+	}
+	quorum := p.configuration.QuorumSize()
+
+	if prepares := signatures(p.prepares[block.View()], block.Hash()); !p.sentCommit && len(prepares) >= quorum {
+		sig, err := p.crypto.Combine(prepares...)
+		if err != nil {
+			p.logger.Errorf("Failed to create prepared certificate: %v", err)
+			return
+		}
+		p.prepared = &preparedCert{view: p.view, block: block, sig: sig}
+		p.sentCommit = true
+		p.acceptor.Proposed(block.Command())
+		p.sendVote(commitPhase, block)
+	}
+
+	if commits := signatures(p.commits[block.View()], block.Hash()); p.sentCommit && len(commits) >= quorum {
+		p.commit(block)
+	}
+}
+
+// signatures returns the signatures of the votes for the block with the given hash.
+func signatures(votes map[hotstuff.ID]voteMsg, hash hotstuff.Hash) []hotstuff.QuorumSignature {
+	sigs := make([]hotstuff.QuorumSignature, 0, len(votes))
+	for _, vote := range votes {
+		if vote.hash == hash {
+			sigs = append(sigs, vote.sig)
+		}
+	}
+	return sigs
+}
+
+func (p *PBFT) commit(block *hotstuff.Block) {
+	p.mut.Lock()
+	// can't recurse due to requiring the mutex, so we use a helper instead.
+	err := p.commitInner(block)
+	bExec := p.bExec
+	p.mut.Unlock()
+
+	if err != nil {
+		p.logger.Warnf("failed to commit: %v", err)
+		return
+	}
+
+	// prune the blockchain and handle forked blocks
+	forkedBlocks := p.blockChain.PruneToHeight(bExec.View())
+	for _, block := range forkedBlocks {
+		p.forkHandler.Fork(block)
+	}
+
+	p.accepted = nil
+	p.sentCommit = false
+
+	p.duration.ViewSucceeded()
+	p.duration.ViewStarted()
+	p.resetTimer()
+
+	p.prune(bExec.View())
+	if msg, ok := p.pending[bExec.View()+1]; ok {
+		delete(p.pending, bExec.View()+1)
+		p.onPrePrepare(msg)
+	}
+
+	p.propose()
+}
+
+// recursive helper for commit
+func (p *PBFT) commitInner(block *hotstuff.Block) error {
+	if p.bExec.View() >= block.View() {
+		return nil
+	}
+	if parent, ok := p.blockChain.Get(block.Parent()); ok {
+		err := p.commitInner(parent)
+		if err != nil {
+			return err
+		}
+	} else {
+		return fmt.Errorf("failed to locate block: %s", block.Parent())
+	}
+	p.logger.Debug("EXEC: ", block)
+	p.executor.Exec(block)
+	p.bExec = block
+	return nil
+}
+
+func (p *PBFT) resetTimer() {
+	if p.timer == nil {
+		return
+	}
+	d := p.duration.Duration()
+	p.deadline = time.Now().Add(d)
+	p.timerStopped = false
+	p.timer.Reset(d)
+}
+
+func (p *PBFT) stopTimer() {
+	if p.timer == nil {
+		return
+	}
+	p.timerStopped = true
+	p.timer.Stop()
+}
+
+// resetState clears the state of the normal case protocol and cancels the view context.
+func (p *PBFT) resetState() {
+	p.cancelCtx()
+	p.viewCtx, p.cancelCtx = context.WithCancel(context.Background())
+	p.accepted = nil
+	p.sentCommit = false
+	p.proposing = false
+	p.prepares = make(map[hotstuff.View]map[hotstuff.ID]voteMsg)
+	p.commits = make(map[hotstuff.View]map[hotstuff.ID]voteMsg)
+	p.pending = make(map[hotstuff.View]prePrepareMsg)
+}
+
+// prune removes the pre-prepares and votes for the sequence numbers up to and including the given one.
+func (p *PBFT) prune(seq hotstuff.View) {
+	for s := range p.pending {
+		if s <= seq {
+			delete(p.pending, s)
+		}
+	}
+	for s := range p.prepares {
+		if s <= seq {
+			delete(p.prepares, s)
+		}
+	}
+	for s := range p.commits {
+		if s <= seq {
+			delete(p.commits, s)
+		}
+	}
+}
+
+var _ modules.Bootstrapper = (*PBFT)(nil)
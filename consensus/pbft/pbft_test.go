@@ -0,0 +1,300 @@
+package pbft
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/gorums"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/blockchain"
+	"github.com/relab/hotstuff/crypto"
+	"github.com/relab/hotstuff/crypto/ecdsa"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/internal/testutil"
+	"github.com/relab/hotstuff/leaderrotation"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// createReplicas creates n PBFT replicas whose configurations contain all of them.
+// The event loops do not run and the replicas do not send any messages,
+// so the messages must be passed between the replicas directly.
+// With the round-robin leader rotation, the primary of view v is replica v%n+1.
+func createReplicas(t *testing.T, n int) []*PBFT {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	keys := testutil.GenerateKeys(t, n, testutil.GenerateECDSAKey)
+	infos := make([]backend.ReplicaInfo, 0, n)
+	configs := make([]*backend.Config, 0, n)
+	replicas := make([]*PBFT, 0, n)
+	for i, key := range keys {
+		id := hotstuff.ID(i + 1)
+
+		acceptor := mocks.NewMockAcceptor(ctrl)
+		acceptor.EXPECT().Accept(gomock.Any()).AnyTimes().Return(true)
+		acceptor.EXPECT().Proposed(gomock.Any()).AnyTimes()
+		executor := mocks.NewMockExecutor(ctrl)
+		executor.EXPECT().Exec(gomock.Any()).AnyTimes()
+		commandQ := mocks.NewMockCommandQueue(ctrl)
+		commandQ.EXPECT().Get(gomock.Any()).AnyTimes().Return(hotstuff.Command("foo"), true)
+
+		cfg := backend.NewConfig(insecure.NewCredentials(), gorums.WithDialTimeout(time.Second))
+		srv := backend.NewServer()
+		p := New().(*PBFT)
+		builder := modules.NewBuilder(id, key)
+		builder.Add(
+			eventloop.New(100),
+			logging.New(fmt.Sprintf("pbft%d", id)),
+			blockchain.New(),
+			cfg,
+			srv,
+			crypto.New(ecdsa.New()),
+			leaderrotation.NewRoundRobin(),
+			testutil.FixedTimeout(time.Second),
+			acceptor,
+			modules.ExtendedExecutor(executor),
+			commandQ,
+			modules.ExtendedForkHandler(mocks.NewMockForkHandler(ctrl)),
+			p,
+		)
+		builder.Build()
+
+		lis := testutil.CreateTCPListener(t)
+		srv.StartOnListener(lis)
+		t.Cleanup(srv.Stop)
+		infos = append(infos, backend.ReplicaInfo{ID: id, Address: lis.Addr().String(), PubKey: key.Public()})
+		configs = append(configs, cfg)
+		replicas = append(replicas, p)
+	}
+	for _, cfg := range configs {
+		if err := cfg.Connect(infos); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(cfg.Close)
+	}
+	return replicas
+}
+
+// propose lets the primary of the current view propose a block, and delivers the pre-prepare to the other replicas.
+func propose(t *testing.T, replicas []*PBFT, primary hotstuff.ID) *hotstuff.Block {
+	t.Helper()
+	p := replicas[primary-1]
+	p.onProposeEvent(proposeEvent{view: p.view, cmd: "foo", ok: true})
+	if p.accepted == nil {
+		t.Fatalf("replica %d did not propose a block", primary)
+	}
+	msg := prePrepareMsg{id: primary, view: p.view, block: p.accepted}
+	for _, r := range replicas {
+		if r != p {
+			r.onPrePrepare(msg)
+		}
+	}
+	return p.accepted
+}
+
+// deliverVotes delivers the votes that the replicas have sent for the sequence number to the other replicas.
+func deliverVotes(replicas []*PBFT, ph phase, seq hotstuff.View) {
+	votes := make([]voteMsg, 0, len(replicas))
+	for _, r := range replicas {
+		sent := r.prepares
+		if ph == commitPhase {
+			sent = r.commits
+		}
+		if vote, ok := sent[seq][r.opts.ID()]; ok {
+			votes = append(votes, vote)
+		}
+	}
+	for _, vote := range votes {
+		for _, r := range replicas {
+			if r.opts.ID() != signer(vote.sig) {
+				r.onVote(vote)
+			}
+		}
+	}
+}
+
+// changeView lets the replicas start a view change to the given view, and delivers the view change messages.
+// It returns the new view message of the primary of the view.
+func changeView(t *testing.T, replicas []*PBFT, view hotstuff.View) newViewMsg {
+	t.Helper()
+	for _, r := range replicas {
+		r.startViewChange(view)
+	}
+	vcs := make([]viewChangeMsg, 0, len(replicas))
+	for _, r := range replicas {
+		vcs = append(vcs, r.viewChanges[view][r.opts.ID()])
+	}
+	for _, vc := range vcs {
+		for _, r := range replicas {
+			if r.opts.ID() != signer(vc.sig) {
+				r.onViewChange(vc)
+			}
+		}
+	}
+	primary := replicas[0].leaderRotation.GetLeader(view)
+	if replicas[primary-1].sentNewView != view {
+		t.Fatalf("the primary of view %d did not send a new view message", view)
+	}
+	return newViewMsg{id: primary, view: view, viewChanges: vcs, block: selectBlock(vcs)}
+}
+
+func TestNormalCase(t *testing.T) {
+	replicas := createReplicas(t, 4)
+
+	block := propose(t, replicas, 2)
+	deliverVotes(replicas, preparePhase, block.View())
+	deliverVotes(replicas, commitPhase, block.View())
+	for _, r := range replicas {
+		if committed := r.CommittedBlock(); committed.Hash() != block.Hash() {
+			t.Errorf("replica %d committed %v, want %v", r.opts.ID(), committed, block)
+		}
+	}
+}
+
+func TestViewChangeSilentPrimary(t *testing.T) {
+	replicas := createReplicas(t, 4)
+
+	// the primary of view 1 does not send anything, so the replicas time out and move to view 2.
+	nv := changeView(t, replicas, 2)
+	if nv.block != nil {
+		t.Errorf("the new view message carries block %v, want none", nv.block)
+	}
+	for _, r := range replicas {
+		if r.opts.ID() != nv.id {
+			r.onNewView(nv)
+		}
+	}
+	for _, r := range replicas {
+		if r.view != 2 || r.viewChanging {
+			t.Errorf("replica %d is in view %d (changing: %v), want view 2 installed", r.opts.ID(), r.view, r.viewChanging)
+		}
+	}
+
+	// the new primary continues with the next sequence number.
+	block := propose(t, replicas, nv.id)
+	if block.View() != 1 {
+		t.Errorf("the new primary proposed sequence number %d, want 1", block.View())
+	}
+	deliverVotes(replicas, preparePhase, block.View())
+	deliverVotes(replicas, commitPhase, block.View())
+	for _, r := range replicas {
+		if committed := r.CommittedBlock(); committed.Hash() != block.Hash() {
+			t.Errorf("replica %d committed %v, want %v", r.opts.ID(), committed, block)
+		}
+	}
+}
+
+func TestViewChangeCarriesPreparedBlock(t *testing.T) {
+	replicas := createReplicas(t, 4)
+
+	// the block is prepared in view 1, but the commit messages are lost.
+	block := propose(t, replicas, 2)
+	deliverVotes(replicas, preparePhase, block.View())
+	for _, r := range replicas {
+		if r.prepared == nil || r.prepared.block.Hash() != block.Hash() {
+			t.Fatalf("replica %d did not prepare %v", r.opts.ID(), block)
+		}
+	}
+
+	nv := changeView(t, replicas, 2)
+	if nv.block == nil || nv.block.Hash() != block.Hash() {
+		t.Fatalf("the new view message carries block %v, want %v", nv.block, block)
+	}
+	for _, r := range replicas {
+		if r.opts.ID() != nv.id {
+			r.onNewView(nv)
+		}
+	}
+	for _, r := range replicas {
+		if r.view != 2 || r.accepted == nil || r.accepted.Hash() != block.Hash() {
+			t.Fatalf("replica %d did not accept %v again in view 2", r.opts.ID(), block)
+		}
+	}
+
+	deliverVotes(replicas, preparePhase, block.View())
+	deliverVotes(replicas, commitPhase, block.View())
+	for _, r := range replicas {
+		if committed := r.CommittedBlock(); committed.Hash() != block.Hash() {
+			t.Errorf("replica %d committed %v, want %v", r.opts.ID(), committed, block)
+		}
+	}
+}
+
+func TestInvalidNewView(t *testing.T) {
+	replicas := createReplicas(t, 4)
+
+	block := propose(t, replicas, 2)
+	deliverVotes(replicas, preparePhase, block.View())
+	nv := changeView(t, replicas, 2)
+
+	otherBlock := hotstuff.NewBlock(hotstuff.GetGenesis().Hash(), hotstuff.NewQuorumCert(nil, 0, hotstuff.GetGenesis().Hash()), "bar", 1, 3)
+	tests := []struct {
+		name string
+		nv   newViewMsg
+	}{
+		{"NotPrimary", newViewMsg{id: 2, view: nv.view, viewChanges: nv.viewChanges, block: nv.block}},
+		{"NoQuorum", newViewMsg{id: nv.id, view: nv.view, viewChanges: nv.viewChanges[:2], block: nv.block}},
+		{"DuplicateViewChanges", newViewMsg{id: nv.id, view: nv.view, viewChanges: []viewChangeMsg{nv.viewChanges[0], nv.viewChanges[0], nv.viewChanges[1]}, block: nv.block}},
+		{"WrongView", newViewMsg{id: 4, view: 3, viewChanges: nv.viewChanges, block: nv.block}},
+		{"WrongBlock", newViewMsg{id: nv.id, view: nv.view, viewChanges: nv.viewChanges, block: otherBlock}},
+		{"MissingBlock", newViewMsg{id: nv.id, view: nv.view, viewChanges: nv.viewChanges}},
+	}
+	r := replicas[0]
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r.onNewView(test.nv)
+			if !r.viewChanging || r.accepted != nil {
+				t.Errorf("installed view %d from an invalid new view message", r.view)
+			}
+		})
+	}
+
+	r.onNewView(nv)
+	if r.view != 2 || r.viewChanging {
+		t.Errorf("replica is in view %d (changing: %v), want view 2 installed", r.view, r.viewChanging)
+	}
+}
+
+func TestAddVoteBounds(t *testing.T) {
+	replicas := createReplicas(t, 4)
+	r := replicas[0]
+	sign := func(id hotstuff.ID, seq hotstuff.View, hash hotstuff.Hash) voteMsg {
+		sig, err := replicas[id-1].crypto.Sign(voteBytes(preparePhase, 1, seq, hash))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return voteMsg{phase: preparePhase, view: 1, seq: seq, hash: hash, sig: sig}
+	}
+
+	// only the first vote from a replica for a sequence number is kept.
+	r.onVote(sign(2, 1, hotstuff.Hash{1}))
+	r.onVote(sign(2, 1, hotstuff.Hash{2}))
+	if vote := r.prepares[1][2]; vote.hash != (hotstuff.Hash{1}) {
+		t.Errorf("got a vote for %v, want the first vote", vote.hash)
+	}
+
+	// votes for committed or far ahead sequence numbers, and votes with invalid signatures, are dropped.
+	r.onVote(sign(3, 0, hotstuff.Hash{1}))
+	r.onVote(sign(3, maxPending+1, hotstuff.Hash{1}))
+	forged := sign(3, 2, hotstuff.Hash{1})
+	forged.hash = hotstuff.Hash{3}
+	r.onVote(forged)
+	for seq, votes := range r.prepares {
+		if _, ok := votes[3]; ok {
+			t.Errorf("kept a vote from replica 3 for sequence number %d", seq)
+		}
+	}
+
+	// a block that was carried over by a view change may be further ahead.
+	far := hotstuff.NewBlock(hotstuff.Hash{1}, hotstuff.NewQuorumCert(nil, 0, hotstuff.Hash{1}), "foo", maxPending+5, 2)
+	r.accepted = far
+	r.onVote(sign(3, far.View(), far.Hash()))
+	if _, ok := r.prepares[far.View()][3]; !ok {
+		t.Error("dropped a vote for the accepted block")
+	}
+}
@@ -0,0 +1,212 @@
+package pbft
+
+import (
+	"context"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/synchronizer"
+)
+
+func (p *PBFT) onTimeout() {
+	// the timer may have been reset or stopped after this event was added.
+	if p.timerStopped || time.Now().Before(p.deadline) {
+		return
+	}
+	p.logger.Debugf("Local timeout in view %d", p.view)
+	p.startViewChange(p.view + 1)
+}
+
+// startViewChange moves the replica to the given view and sends a view change message to all replicas.
+func (p *PBFT) startViewChange(view hotstuff.View) {
+	if view <= p.view {
+		return
+	}
+	p.view = view
+	p.viewChanging = true
+	p.resetState()
+
+	// the timer is restarted once a quorum of replicas wants to change the view,
+	// such that a replica that falls behind does not move through the views on its own.
+	p.duration.ViewTimeout()
+	p.stopTimer()
+
+	sig, err := p.crypto.Sign(viewChangeBytes(view, p.prepared))
+	if err != nil {
+		p.logger.Errorf("Failed to sign view change: %v", err)
+		return
+	}
+	vc := viewChangeMsg{view: view, prepared: p.prepared, sig: sig}
+	if p.pbCfg != nil {
+		p.pbCfg.ViewChange(context.Background(), viewChangeToProto(vc))
+	}
+	p.onViewChange(vc)
+}
+
+func (p *PBFT) onViewChange(vc viewChangeMsg) {
+	if vc.view < p.view || (vc.view == p.view && !p.viewChanging) {
+		return
+	}
+	if vc.sig == nil || vc.sig.Participants().Len() != 1 {
+		return
+	}
+	if !p.verifyViewChange(vc) {
+		p.logger.Infof("ViewChange from %d: invalid message", signer(vc.sig))
+		return
+	}
+
+	vcs, ok := p.viewChanges[vc.view]
+	if !ok {
+		vcs = make(map[hotstuff.ID]viewChangeMsg)
+		p.viewChanges[vc.view] = vcs
+	}
+	vcs[signer(vc.sig)] = vc
+
+	// if f+1 replicas want to move to a higher view, at least one of them is correct,
+	// so we join the smallest of those views instead of waiting for our own timer to expire.
+	senders := make(map[hotstuff.ID]struct{})
+	minView := hotstuff.View(0)
+	for view, vcs := range p.viewChanges {
+		if view <= p.view {
+			continue
+		}
+		if minView == 0 || view < minView {
+			minView = view
+		}
+		for id := range vcs {
+			senders[id] = struct{}{}
+		}
+	}
+	if len(senders) > p.configuration.Len()-p.configuration.QuorumSize() {
+		p.startViewChange(minView)
+		return
+	}
+
+	if p.viewChanging && p.timerStopped && len(p.viewChanges[p.view]) >= p.configuration.QuorumSize() {
+		p.resetTimer()
+	}
+
+	if p.viewChanging && p.sentNewView < p.view && p.isPrimary() &&
+		len(p.viewChanges[p.view]) >= p.configuration.QuorumSize() {
+		p.sendNewView()
+	}
+}
+
+// verifyViewChange verifies the signature of the view change message and its prepared certificate.
+func (p *PBFT) verifyViewChange(vc viewChangeMsg) bool {
+	if cert := vc.prepared; cert != nil {
+		if cert.view >= vc.view || cert.sig == nil || cert.block == nil ||
+			cert.sig.Participants().Len() < p.configuration.QuorumSize() {
+			return false
+		}
+		if !p.crypto.Verify(cert.sig, voteBytes(preparePhase, cert.view, cert.block.View(), cert.block.Hash())) {
+			return false
+		}
+	}
+	return p.crypto.Verify(vc.sig, viewChangeBytes(vc.view, vc.prepared))
+}
+
+// sendNewView sends a new view message with the view change messages that were collected for the current view.
+func (p *PBFT) sendNewView() {
+	p.sentNewView = p.view
+	vcs := make([]viewChangeMsg, 0, len(p.viewChanges[p.view]))
+	for _, vc := range p.viewChanges[p.view] {
+		vcs = append(vcs, vc)
+	}
+	nv := newViewMsg{
+		id:          p.opts.ID(),
+		view:        p.view,
+		viewChanges: vcs,
+		block:       selectBlock(vcs),
+	}
+	p.logger.Debugf("NewView: view %d", p.view)
+	if p.pbCfg != nil {
+		p.pbCfg.NewView(context.Background(), newViewToProto(nv))
+	}
+	p.onNewView(nv)
+}
+
+// selectBlock returns the block with the highest sequence number among the prepared certificates,
+// preferring the certificate from the highest view, or nil if there are no prepared certificates.
+func selectBlock(vcs []viewChangeMsg) *hotstuff.Block {
+	var highest *preparedCert
+	for _, vc := range vcs {
+		cert := vc.prepared
+		if cert == nil {
+			continue
+		}
+		if highest == nil || cert.block.View() > highest.block.View() ||
+			(cert.block.View() == highest.block.View() && cert.view > highest.view) {
+			highest = cert
+		}
+	}
+	if highest == nil {
+		return nil
+	}
+	return highest.block
+}
+
+func (p *PBFT) onNewView(nv newViewMsg) {
+	if nv.view < p.view || (nv.view == p.view && !p.viewChanging) {
+		return
+	}
+	if nv.id != p.leaderRotation.GetLeader(nv.view) {
+		p.logger.Infof("NewView from %d: sender is not the primary", nv.id)
+		return
+	}
+
+	senders := make(map[hotstuff.ID]struct{})
+	for _, vc := range nv.viewChanges {
+		if vc.view != nv.view || vc.sig == nil || vc.sig.Participants().Len() != 1 || !p.verifyViewChange(vc) {
+			p.logger.Infof("NewView from %d: invalid view change message", nv.id)
+			return
+		}
+		senders[signer(vc.sig)] = struct{}{}
+	}
+	if len(senders) < p.configuration.QuorumSize() {
+		p.logger.Infof("NewView from %d: not enough view change messages", nv.id)
+		return
+	}
+
+	block := selectBlock(nv.viewChanges)
+	if (block == nil) != (nv.block == nil) || (block != nil && block.Hash() != nv.block.Hash()) {
+		p.logger.Infof("NewView from %d: wrong block", nv.id)
+		return
+	}
+
+	p.installView(nv.view, block)
+}
+
+// installView starts the normal case protocol in the given view.
+// If a block was prepared in an earlier view, it must be agreed upon again before new blocks can be proposed.
+func (p *PBFT) installView(view hotstuff.View, block *hotstuff.Block) {
+	p.logger.Debugf("Installing view %d", view)
+	p.view = view
+	p.viewChanging = false
+	p.resetState()
+	for v := range p.viewChanges {
+		if v <= view {
+			delete(p.viewChanges, v)
+		}
+	}
+
+	p.duration.ViewStarted()
+	p.resetTimer()
+	p.eventLoop.AddEvent(synchronizer.ViewChangeEvent{View: view, Timeout: true})
+
+	if block != nil && block.View() <= p.CommittedBlock().View() {
+		// the prepared certificate is sent in view changes until a later block is prepared,
+		// since a primary that has not committed the block must propose it again.
+		// This replica has already committed it, so it only votes for it again,
+		// such that the replicas that have not committed it yet can reach a quorum,
+		// and continues with the next block.
+		p.sendVote(preparePhase, block)
+		p.sendVote(commitPhase, block)
+		block = nil
+	}
+	if block != nil {
+		p.accept(block)
+		return
+	}
+	p.propose()
+}
@@ -0,0 +1,174 @@
+// Package streamlet implements the Streamlet protocol.
+package streamlet
+
+import (
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+)
+
+func init() {
+	modules.RegisterModule("streamlet", New)
+}
+
+// Streamlet implements the Streamlet protocol.
+//
+// Based on the paper "Streamlet: Textbook Streamlined Blockchains" by Benjamin Y. Chan and Elaine Shi.
+//
+// Each view is an epoch with a single leader. The leader proposes a block that extends one of the longest
+// notarized chains that it has seen, and the replicas vote for the first such proposal in the epoch.
+// Votes are sent to all replicas, such that every replica notarizes the block once it has seen a quorum of votes.
+// When three adjacent blocks of a chain are notarized in consecutive epochs,
+// the second of the three blocks is finalized together with its ancestors.
+type Streamlet struct {
+	blockChain    modules.BlockChain
+	configuration modules.Configuration
+	logger        logging.Logger
+	opts          *modules.Options
+	synchronizer  modules.Synchronizer
+
+	longest       hotstuff.QuorumCert // the notarization of the tip of the longest notarized chain
+	longestHeight uint64
+	heights       map[hotstuff.Hash]blockHeight
+}
+
+// blockHeight is the length of the chain that ends with a block.
+type blockHeight struct {
+	view   hotstuff.View
+	height uint64
+}
+
+// New returns a new Streamlet instance.
+func New() consensus.Rules {
+	genesis := hotstuff.GetGenesis()
+	return &Streamlet{
+		longest: hotstuff.NewQuorumCert(nil, 0, genesis.Hash()),
+		heights: map[hotstuff.Hash]blockHeight{genesis.Hash(): {}},
+	}
+}
+
+// InitModule initializes the module.
+func (s *Streamlet) InitModule(mods *modules.Core) {
+	mods.Get(&s.blockChain, &s.configuration, &s.logger, &s.opts, &s.synchronizer)
+
+	s.opts.SetShouldVoteToAll()
+}
+
+func (s *Streamlet) qcRef(qc hotstuff.QuorumCert) (*hotstuff.Block, bool) {
+	if (hotstuff.Hash{}) == qc.BlockHash() {
+		return nil, false
+	}
+	return s.blockChain.Get(qc.BlockHash())
+}
+
+// height returns the length of the chain that ends with the block with the given hash.
+func (s *Streamlet) height(hash hotstuff.Hash) (uint64, bool) {
+	var chain []*hotstuff.Block
+	known, ok := s.heights[hash]
+	for !ok {
+		block, found := s.blockChain.Get(hash)
+		if !found {
+			return 0, false
+		}
+		chain = append(chain, block)
+		hash = block.Parent()
+		known, ok = s.heights[hash]
+	}
+	// record the heights of the blocks that were traversed, starting with the oldest.
+	for i := len(chain) - 1; i >= 0; i-- {
+		known = blockHeight{view: chain[i].View(), height: known.height + 1}
+		s.heights[chain[i].Hash()] = known
+	}
+	return known.height, true
+}
+
+// updateLongest updates the longest notarized chain if the QC notarizes a block on a longer chain.
+func (s *Streamlet) updateLongest(qc hotstuff.QuorumCert) {
+	if h, ok := s.height(qc.BlockHash()); ok && h > s.longestHeight {
+		s.longest = qc
+		s.longestHeight = h
+	}
+}
+
+// ProposeRule creates a new proposal that extends the longest notarized chain.
+func (s *Streamlet) ProposeRule(cert hotstuff.SyncInfo, cmd hotstuff.Command) (proposal hotstuff.ProposeMsg, ok bool) {
+	if qc, ok := cert.QC(); ok {
+		s.updateLongest(qc)
+	}
+	s.updateLongest(s.synchronizer.HighQC())
+
+	proposal = hotstuff.ProposeMsg{
+		ID: s.opts.ID(),
+		Block: hotstuff.NewBlockWithEpoch(
+			s.longest.BlockHash(),
+			s.longest,
+			cmd,
+			s.synchronizer.View(),
+			s.opts.ID(),
+			modules.EpochAt(s.configuration, s.synchronizer.View()),
+		),
+	}
+	return proposal, true
+}
+
+// VoteRule decides whether to vote for the proposal or not.
+// The base implementation has already verified the QC and ensures that we only vote once per epoch.
+func (s *Streamlet) VoteRule(proposal hotstuff.ProposeMsg) bool {
+	block := proposal.Block
+	qc := block.QuorumCert()
+
+	if block.View() < s.synchronizer.View() {
+		s.logger.Info("VoteRule: block epoch too low")
+		return false
+	}
+
+	// the QC notarizes the parent block.
+	if block.Parent() != qc.BlockHash() {
+		s.logger.Info("VoteRule: block does not extend its QC block")
+		return false
+	}
+
+	s.updateLongest(s.synchronizer.HighQC())
+	h, ok := s.height(qc.BlockHash())
+	if !ok || h < s.longestHeight {
+		s.logger.Info("VoteRule: block does not extend a longest notarized chain")
+		return false
+	}
+	s.updateLongest(qc)
+	return true
+}
+
+// CommitRule decides whether an ancestor of the block can be committed.
+// The QC of a block notarizes its parent, so the three notarized blocks are the ancestors of the block.
+func (s *Streamlet) CommitRule(block *hotstuff.Block) *hotstuff.Block {
+	third, ok := s.qcRef(block.QuorumCert())
+	if !ok {
+		return nil
+	}
+	second, ok := s.qcRef(third.QuorumCert())
+	if !ok {
+		return nil
+	}
+	first, ok := s.qcRef(second.QuorumCert())
+	if !ok {
+		return nil
+	}
+	if third.Parent() == second.Hash() && third.View() == second.View()+1 &&
+		second.Parent() == first.Hash() && second.View() == first.View()+1 {
+		s.logger.Debug("FINALIZE: ", second)
+		// heights of blocks older than the finalized block are no longer needed.
+		for hash, h := range s.heights {
+			if h.view < second.View() {
+				delete(s.heights, hash)
+			}
+		}
+		return second
+	}
+	return nil
+}
+
+// ChainLength returns the number of blocks that need to be chained together in order to commit.
+func (s *Streamlet) ChainLength() int {
+	return 3
+}
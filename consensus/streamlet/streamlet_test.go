@@ -0,0 +1,95 @@
+package streamlet_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/blockchain"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/consensus/streamlet"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+)
+
+type testChain struct {
+	rules      consensus.Rules
+	blockChain modules.BlockChain
+	sync       *mocks.MockSynchronizer
+}
+
+func newTestChain(t *testing.T) testChain {
+	ctrl := gomock.NewController(t)
+	cfg := mocks.NewMockConfiguration(ctrl)
+	cfg.EXPECT().Fetch(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, false)
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().ViewContext().AnyTimes().Return(context.Background())
+
+	builder := modules.NewBuilder(1, nil)
+	rules := streamlet.New()
+	builder.Add(
+		blockchain.New(),
+		cfg,
+		mocks.NewMockConsensus(ctrl),
+		logging.New("test"),
+		rules,
+		sync,
+	)
+	mods := builder.Build()
+
+	var blockChain modules.BlockChain
+	mods.Get(&blockChain)
+	return testChain{rules: rules, blockChain: blockChain, sync: sync}
+}
+
+// extend creates a block in the given epoch whose QC notarizes the parent.
+func (tc testChain) extend(parent *hotstuff.Block, epoch hotstuff.View) *hotstuff.Block {
+	qc := hotstuff.NewQuorumCert(nil, parent.View(), parent.Hash())
+	block := hotstuff.NewBlock(parent.Hash(), qc, "", epoch, 1)
+	tc.blockChain.Store(block)
+	return block
+}
+
+func TestFinalizeConsecutiveEpochs(t *testing.T) {
+	tc := newTestChain(t)
+	genesis := hotstuff.GetGenesis()
+
+	b1 := tc.extend(genesis, 1)
+	b2 := tc.extend(b1, 2)
+	b4 := tc.extend(b2, 4)
+	b5 := tc.extend(b4, 5)
+	b6 := tc.extend(b5, 6)
+	b7 := tc.extend(b6, 7)
+
+	// b1, b2 and b4 are notarized, but the epochs are not consecutive.
+	if b := tc.rules.CommitRule(b5); b != nil {
+		t.Errorf("expected no block to be finalized, got %v", b)
+	}
+	// b4, b5 and b6 are notarized in consecutive epochs.
+	if b := tc.rules.CommitRule(b7); b != b5 {
+		t.Errorf("expected %v to be finalized, got %v", b5, b)
+	}
+}
+
+func TestVoteLongestNotarizedChain(t *testing.T) {
+	tc := newTestChain(t)
+	genesis := hotstuff.GetGenesis()
+
+	b1 := tc.extend(genesis, 1)
+	b2 := tc.extend(b1, 2)
+	b3 := tc.extend(b2, 3)
+	fork := tc.extend(genesis, 4)
+
+	tc.sync.EXPECT().View().AnyTimes().Return(hotstuff.View(4))
+	tc.sync.EXPECT().HighQC().AnyTimes().Return(b3.QuorumCert())
+
+	// b2 is notarized by the QC of b3, so the chain ending with b2 is the longest notarized chain.
+	if tc.rules.VoteRule(hotstuff.ProposeMsg{ID: 1, Block: tc.extend(fork, 5)}) {
+		t.Error("expected no vote for a block that extends a shorter notarized chain")
+	}
+	if !tc.rules.VoteRule(hotstuff.ProposeMsg{ID: 1, Block: tc.extend(b2, 5)}) {
+		t.Error("expected a vote for a block that extends the longest notarized chain")
+	}
+}
@@ -18,6 +18,7 @@ type VotingMachine struct {
 	logger        logging.Logger
 	synchronizer  modules.Synchronizer
 	opts          *modules.Options
+	verifier      modules.Verifier
 
 	mut           sync.Mutex
 	verifiedVotes map[hotstuff.Hash][]hotstuff.PartialCert // verified votes that could become a QC
@@ -42,6 +43,10 @@ func (vm *VotingMachine) InitModule(mods *modules.Core) {
 		&vm.opts,
 	)
 
+	if vm.opts.VerificationWorkers() > 0 {
+		mods.Get(&vm.verifier)
+	}
+
 	vm.eventLoop.RegisterHandler(hotstuff.VoteMsg{}, func(event any) { vm.OnVote(event.(hotstuff.VoteMsg)) })
 }
 
@@ -80,19 +85,35 @@ func (vm *VotingMachine) OnVote(vote hotstuff.VoteMsg) {
 		return
 	}
 
-	if vm.opts.ShouldVerifyVotesSync() {
+	switch {
+	case vote.Verified:
+		vm.addVote(cert, block)
+	case vm.verifier != nil:
+		vote.Verified = true
+		vm.verifier.Verify(vote, func() bool { return vm.verifyPartialCert(cert) })
+	case vm.opts.ShouldVerifyVotesSync():
 		vm.verifyCert(cert, block)
-	} else {
+	default:
 		go vm.verifyCert(cert, block)
 	}
 }
 
-func (vm *VotingMachine) verifyCert(cert hotstuff.PartialCert, block *hotstuff.Block) {
+func (vm *VotingMachine) verifyPartialCert(cert hotstuff.PartialCert) bool {
 	if !vm.crypto.VerifyPartialCert(cert) {
 		vm.logger.Info("OnVote: Vote could not be verified!")
-		return
+		return false
 	}
+	return true
+}
+
+func (vm *VotingMachine) verifyCert(cert hotstuff.PartialCert, block *hotstuff.Block) {
+	if vm.verifyPartialCert(cert) {
+		vm.addVote(cert, block)
+	}
+}
 
+// addVote adds a verified vote, and creates a QC once a quorum of votes for the block have been verified.
+func (vm *VotingMachine) addVote(cert hotstuff.PartialCert, block *hotstuff.Block) {
 	vm.mut.Lock()
 	defer vm.mut.Unlock()
 
@@ -114,7 +135,7 @@ func (vm *VotingMachine) verifyCert(cert hotstuff.PartialCert, block *hotstuff.B
 	votes = append(votes, cert)
 	vm.verifiedVotes[cert.BlockHash()] = votes
 
-	if len(votes) < vm.configuration.QuorumSize() {
+	if len(votes) < modules.QuorumSizeAt(vm.configuration, block.View()) {
 		return
 	}
 
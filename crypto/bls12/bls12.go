@@ -44,7 +44,9 @@ type PublicKey struct {
 
 // ToBytes marshals the public key to a byte slice.
 func (pub PublicKey) ToBytes() []byte {
-	return bls12.NewG1().ToCompressed(pub.p)
+	// ToCompressed converts the point to affine coordinates in place,
+	// so a copy is used, since the key may be serialized by several goroutines at once.
+	return bls12.NewG1().ToCompressed(new(bls12.PointG1).Set(pub.p))
 }
 
 // FromBytes unmarshals the public key from a byte slice.
@@ -116,7 +118,8 @@ func (agg *AggregateSignature) ToBytes() []byte {
 	if agg == nil {
 		return nil
 	}
-	b := bls12.NewG2().ToCompressed(&agg.sig)
+	// the signature may be serialized by several goroutines at once, see PublicKey.ToBytes.
+	b := bls12.NewG2().ToCompressed(new(bls12.PointG2).Set(&agg.sig))
 	return b
 }
 
@@ -147,12 +150,15 @@ type bls12Base struct {
 	mut sync.RWMutex
 	// popCache caches the proof-of-possession results of popVerify for each public key.
 	popCache map[string]bool
+	// provenKeys contains the announced public keys whose proofs of possession were verified by VerifyKeyProof.
+	provenKeys map[string]struct{}
 }
 
 // New returns a new instance of the BLS12 CryptoBase implementation.
 func New() modules.CryptoBase {
 	return &bls12Base{
-		popCache: make(map[string]bool),
+		popCache:   make(map[string]bool),
+		provenKeys: make(map[string]struct{}),
 	}
 }
 
@@ -165,7 +171,11 @@ func (bls *bls12Base) InitModule(mods *modules.Core) {
 		&bls.opts,
 	)
 
-	pop := bls.popProve()
+	// if the key is held by another process, that process must provide the proof of possession.
+	if _, ok := bls.opts.PrivateKey().(*PrivateKey); !ok {
+		return
+	}
+	pop := bls.popProve(bls.privateKey())
 	b := bls12.NewG2().ToCompressed(pop)
 	bls.opts.SetConnectionMetadata(popMetadataKey, string(b))
 }
@@ -174,18 +184,39 @@ func (bls *bls12Base) privateKey() *PrivateKey {
 	return bls.opts.PrivateKey().(*PrivateKey)
 }
 
-func (bls *bls12Base) publicKey(id hotstuff.ID) (pubKey *PublicKey, ok bool) {
-	if replica, ok := bls.configuration.Replica(id); ok {
-		if replica.ID() != bls.opts.ID() && !bls.checkPop(replica) {
-			bls.logger.Warnf("Invalid POP for replica %d", id)
-			return nil, false
-		}
-		if pubKey, ok = replica.PublicKey().(*PublicKey); ok {
-			return pubKey, true
-		}
-		bls.logger.Errorf("Unsupported public key type: %T", replica.PublicKey())
+// keyFunc returns the public key of a replica.
+type keyFunc func(id hotstuff.ID) (hotstuff.PublicKey, bool)
+
+// currentKey returns the public key that the replica uses in the current epoch.
+func (bls *bls12Base) currentKey(id hotstuff.ID) (hotstuff.PublicKey, bool) {
+	replica, ok := bls.configuration.Replica(id)
+	if !ok {
+		return nil, false
+	}
+	return replica.PublicKey(), true
+}
+
+// epochKey returns a keyFunc that returns the public key that a replica uses in the given epoch.
+func (bls *bls12Base) epochKey(epoch hotstuff.Epoch) keyFunc {
+	return func(id hotstuff.ID) (hotstuff.PublicKey, bool) {
+		return modules.PublicKeyAt(bls.configuration, id, epoch)
+	}
+}
+
+func (bls *bls12Base) publicKey(id hotstuff.ID, lookup keyFunc) (pubKey *PublicKey, ok bool) {
+	key, ok := lookup(id)
+	if !ok {
+		return nil, false
 	}
-	return nil, false
+	if pubKey, ok = key.(*PublicKey); !ok {
+		bls.logger.Errorf("Unsupported public key type: %T", key)
+		return nil, false
+	}
+	if id != bls.opts.ID() && !bls.checkPop(id, pubKey) {
+		bls.logger.Warnf("Invalid POP for replica %d", id)
+		return nil, false
+	}
+	return pubKey, true
 }
 
 func (bls *bls12Base) subgroupCheck(point *bls12.PointG2) bool {
@@ -195,8 +226,7 @@ func (bls *bls12Base) subgroupCheck(point *bls12.PointG2) bool {
 	return g2.IsZero(&p)
 }
 
-func (bls *bls12Base) coreSign(message []byte, domainTag []byte) (*bls12.PointG2, error) {
-	pk := bls.privateKey()
+func (bls *bls12Base) coreSign(pk *PrivateKey, message []byte, domainTag []byte) (*bls12.PointG2, error) {
 	g2 := bls12.NewG2()
 	point, err := g2.HashToCurve(message, domainTag)
 	if err != nil {
@@ -217,14 +247,16 @@ func (bls *bls12Base) coreVerify(pubKey *PublicKey, message []byte, signature *b
 		return false
 	}
 	engine := bls12.NewEngine()
-	engine.AddPairInv(&bls12.G1One, signature)
-	engine.AddPair(pubKey.p, messagePoint)
+	// AddPair converts the points to affine coordinates in place, so copies are used,
+	// since the keys and signatures may be verified by several goroutines at once.
+	engine.AddPairInv(&bls12.G1One, new(bls12.PointG2).Set(signature))
+	engine.AddPair(new(bls12.PointG1).Set(pubKey.p), messagePoint)
 	return engine.Result().IsOne()
 }
 
-func (bls *bls12Base) popProve() *bls12.PointG2 {
-	pubKey := bls.privateKey().Public().(*PublicKey)
-	proof, err := bls.coreSign(pubKey.ToBytes(), domainPOP)
+func (bls *bls12Base) popProve(pk *PrivateKey) *bls12.PointG2 {
+	pubKey := pk.Public().(*PublicKey)
+	proof, err := bls.coreSign(pk, pubKey.ToBytes(), domainPOP)
 	if err != nil {
 		bls.logger.Panicf("Failed to generate proof-of-possession: %v", err)
 	}
@@ -235,22 +267,35 @@ func (bls *bls12Base) popVerify(pubKey *PublicKey, proof *bls12.PointG2) bool {
 	return bls.coreVerify(pubKey, pubKey.ToBytes(), proof, domainPOP)
 }
 
-func (bls *bls12Base) checkPop(replica modules.Replica) (valid bool) {
+// checkPop checks that the replica has proven that it knows the private key of the public key,
+// either with the proof in its connection metadata, or with a proof that was given to VerifyKeyProof.
+func (bls *bls12Base) checkPop(id hotstuff.ID, pubKey *PublicKey) (valid bool) {
 	defer func() {
 		if !valid {
-			bls.logger.Warnf("Invalid proof-of-possession for replica %d", replica.ID())
+			bls.logger.Warnf("Invalid proof-of-possession for replica %d", id)
 		}
 	}()
 
+	bls.mut.RLock()
+	_, valid = bls.provenKeys[string(pubKey.ToBytes())]
+	bls.mut.RUnlock()
+	if valid {
+		return true
+	}
+
+	replica, ok := bls.configuration.Replica(id)
+	if !ok {
+		return false
+	}
 	popBytes, ok := replica.Metadata()[popMetadataKey]
 	if !ok {
-		bls.logger.Warnf("Missing proof-of-possession for replica: %d", replica.ID())
+		bls.logger.Warnf("Missing proof-of-possession for replica: %d", id)
 		return false
 	}
 
 	var key strings.Builder
 	key.WriteString(popBytes)
-	_, _ = key.Write(replica.PublicKey().(*PublicKey).ToBytes())
+	_, _ = key.Write(pubKey.ToBytes())
 
 	bls.mut.RLock()
 	valid, ok = bls.popCache[key.String()]
@@ -264,7 +309,7 @@ func (bls *bls12Base) checkPop(replica modules.Replica) (valid bool) {
 		return false
 	}
 
-	valid = bls.popVerify(replica.PublicKey().(*PublicKey), proof)
+	valid = bls.popVerify(pubKey, proof)
 
 	bls.mut.Lock()
 	bls.popCache[key.String()] = valid
@@ -296,10 +341,10 @@ func (bls *bls12Base) coreAggregateVerify(publicKeys []*PublicKey, messages [][]
 		if err != nil {
 			return false
 		}
-		engine.AddPair(publicKeys[i].p, q)
+		engine.AddPair(new(bls12.PointG1).Set(publicKeys[i].p), q)
 	}
 
-	engine.AddPairInv(&bls12.G1One, signature)
+	engine.AddPairInv(&bls12.G1One, new(bls12.PointG2).Set(signature))
 	return engine.Result().IsOne()
 }
 
@@ -322,7 +367,7 @@ func (bls *bls12Base) fastAggregateVerify(publicKeys []*PublicKey, message []byt
 
 // Sign creates a cryptographic signature of the given messsage.
 func (bls *bls12Base) Sign(message []byte) (signature hotstuff.QuorumSignature, err error) {
-	p, err := bls.coreSign(message, domain)
+	p, err := bls.coreSign(bls.privateKey(), message, domain)
 	if err != nil {
 		return nil, fmt.Errorf("bls12: coreSign failed: %w", err)
 	}
@@ -363,6 +408,15 @@ func (bls *bls12Base) Combine(signatures ...hotstuff.QuorumSignature) (combined
 
 // Verify verifies the given quorum signature against the message.
 func (bls *bls12Base) Verify(signature hotstuff.QuorumSignature, message []byte) bool {
+	return bls.verify(signature, message, bls.currentKey)
+}
+
+// VerifyInEpoch verifies the given quorum signature against the message, using the keys of the given epoch.
+func (bls *bls12Base) VerifyInEpoch(epoch hotstuff.Epoch, signature hotstuff.QuorumSignature, message []byte) bool {
+	return bls.verify(signature, message, bls.epochKey(epoch))
+}
+
+func (bls *bls12Base) verify(signature hotstuff.QuorumSignature, message []byte, lookup keyFunc) bool {
 	s, ok := signature.(*AggregateSignature)
 	if !ok {
 		bls.logger.Panicf("cannot verify signature of incompatible type %T (expected %T)", signature, s)
@@ -372,7 +426,7 @@ func (bls *bls12Base) Verify(signature hotstuff.QuorumSignature, message []byte)
 
 	if n == 1 {
 		id := firstParticipant(s.Participants())
-		pk, ok := bls.publicKey(id)
+		pk, ok := bls.publicKey(id, lookup)
 		if !ok {
 			bls.logger.Warnf("Missing public key for ID %d", id)
 			return false
@@ -383,7 +437,7 @@ func (bls *bls12Base) Verify(signature hotstuff.QuorumSignature, message []byte)
 	// else if l > 1:
 	pks := make([]*PublicKey, 0, n)
 	s.Participants().RangeWhile(func(id hotstuff.ID) bool {
-		pk, ok := bls.publicKey(id)
+		pk, ok := bls.publicKey(id, lookup)
 		if ok {
 			pks = append(pks, pk)
 			return true
@@ -399,6 +453,16 @@ func (bls *bls12Base) Verify(signature hotstuff.QuorumSignature, message []byte)
 
 // BatchVerify verifies the given quorum signature against the batch of messages.
 func (bls *bls12Base) BatchVerify(signature hotstuff.QuorumSignature, batch map[hotstuff.ID][]byte) bool {
+	return bls.batchVerify(signature, batch, bls.currentKey)
+}
+
+// BatchVerifyInEpoch verifies the given quorum signature against the batch of messages,
+// using the keys of the given epoch.
+func (bls *bls12Base) BatchVerifyInEpoch(epoch hotstuff.Epoch, signature hotstuff.QuorumSignature, batch map[hotstuff.ID][]byte) bool {
+	return bls.batchVerify(signature, batch, bls.epochKey(epoch))
+}
+
+func (bls *bls12Base) batchVerify(signature hotstuff.QuorumSignature, batch map[hotstuff.ID][]byte, lookup keyFunc) bool {
 	s, ok := signature.(*AggregateSignature)
 	if !ok {
 		bls.logger.Panicf("cannot verify incompatible signature type %T (expected %T)", signature, s)
@@ -413,7 +477,7 @@ func (bls *bls12Base) BatchVerify(signature hotstuff.QuorumSignature, batch map[
 
 	for id, msg := range batch {
 		msgs = append(msgs, msg)
-		pk, ok := bls.publicKey(id)
+		pk, ok := bls.publicKey(id, lookup)
 		if !ok {
 			bls.logger.Warnf("Missing public key for ID %d", id)
 			return false
@@ -427,3 +491,40 @@ func (bls *bls12Base) BatchVerify(signature hotstuff.QuorumSignature, batch map[
 
 	return bls.aggregateVerify(pks, msgs, &s.sig)
 }
+
+// ProveKey returns a proof-of-possession of the private key,
+// which must be given to VerifyKeyProof before the public key can be used.
+func (bls *bls12Base) ProveKey(key hotstuff.PrivateKey) ([]byte, error) {
+	pk, ok := key.(*PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("bls12: unsupported private key type: %T", key)
+	}
+	return bls12.NewG2().ToCompressed(bls.popProve(pk)), nil
+}
+
+// VerifyKeyProof verifies the proof-of-possession of the public key.
+// If the proof is valid, the key can be used to verify signatures without a proof in the connection metadata.
+func (bls *bls12Base) VerifyKeyProof(key hotstuff.PublicKey, proof []byte) bool {
+	pubKey, ok := key.(*PublicKey)
+	if !ok {
+		return false
+	}
+	p, err := bls12.NewG2().FromCompressed(proof)
+	if err != nil || !bls.popVerify(pubKey, p) {
+		return false
+	}
+	bls.mut.Lock()
+	bls.provenKeys[string(pubKey.ToBytes())] = struct{}{}
+	bls.mut.Unlock()
+	return true
+}
+
+// UniqueSignatures returns true, as a BLS signature is determined by the private key and the message.
+func (bls *bls12Base) UniqueSignatures() bool {
+	return true
+}
+
+var (
+	_ modules.KeyProver    = (*bls12Base)(nil)
+	_ modules.UniqueSigner = (*bls12Base)(nil)
+)
@@ -76,35 +76,60 @@ func (cache *cache) Sign(message []byte) (sig hotstuff.QuorumSignature, err erro
 	if err != nil {
 		return nil, err
 	}
-	var key strings.Builder
-	hash := sha256.Sum256(message)
-	_, _ = key.Write(hash[:])
-	_, _ = key.Write(sig.ToBytes())
-	cache.insert(key.String())
+	cache.insert(messageKey(nil, sig, message))
 	return sig, nil
 }
 
 // Verify verifies the given quorum signature against the message.
 func (cache *cache) Verify(signature hotstuff.QuorumSignature, message []byte) bool {
-	var key strings.Builder
-	hash := sha256.Sum256(message)
-	_, _ = key.Write(hash[:])
-	_, _ = key.Write(signature.ToBytes())
+	key := messageKey(nil, signature, message)
+	return cache.verify(key, func() bool { return cache.impl.Verify(signature, message) })
+}
+
+// BatchVerify verifies the given quorum signature against the batch of messages.
+func (cache *cache) BatchVerify(signature hotstuff.QuorumSignature, batch map[hotstuff.ID][]byte) bool {
+	key := batchKey(nil, signature, batch)
+	return cache.verify(key, func() bool { return cache.impl.BatchVerify(signature, batch) })
+}
+
+// VerifyInEpoch verifies the given quorum signature against the message, using the keys of the given epoch.
+func (cache *cache) VerifyInEpoch(epoch hotstuff.Epoch, signature hotstuff.QuorumSignature, message []byte) bool {
+	// the same signature may be valid in one epoch and invalid in another, so the epoch is part of the key.
+	key := messageKey(epoch.ToBytes(), signature, message)
+	return cache.verify(key, func() bool { return cache.impl.VerifyInEpoch(epoch, signature, message) })
+}
 
-	if cache.check(key.String()) {
+// BatchVerifyInEpoch verifies the given quorum signature against the batch of messages,
+// using the keys of the given epoch.
+func (cache *cache) BatchVerifyInEpoch(epoch hotstuff.Epoch, signature hotstuff.QuorumSignature, batch map[hotstuff.ID][]byte) bool {
+	key := batchKey(epoch.ToBytes(), signature, batch)
+	return cache.verify(key, func() bool { return cache.impl.BatchVerifyInEpoch(epoch, signature, batch) })
+}
+
+// verify returns true if the key is in the cache, or if verify returns true, in which case the key is added.
+func (cache *cache) verify(key string, verify func() bool) bool {
+	if cache.check(key) {
 		return true
 	}
-
-	if cache.impl.Verify(signature, message) {
-		cache.insert(key.String())
+	if verify() {
+		cache.insert(key)
 		return true
 	}
-
 	return false
 }
 
-// BatchVerify verifies the given quorum signature against the batch of messages.
-func (cache *cache) BatchVerify(signature hotstuff.QuorumSignature, batch map[hotstuff.ID][]byte) bool {
+// messageKey returns the cache key of a signature of a single message.
+func messageKey(prefix []byte, signature hotstuff.QuorumSignature, message []byte) string {
+	var key strings.Builder
+	_, _ = key.Write(prefix)
+	hash := sha256.Sum256(message)
+	_, _ = key.Write(hash[:])
+	_, _ = key.Write(signature.ToBytes())
+	return key.String()
+}
+
+// batchKey returns the cache key of a signature of a batch of messages.
+func batchKey(prefix []byte, signature hotstuff.QuorumSignature, batch map[hotstuff.ID][]byte) string {
 	// sort the list of ids from the batch map
 	ids := maps.Keys(batch)
 	slices.Sort(ids)
@@ -114,22 +139,33 @@ func (cache *cache) BatchVerify(signature hotstuff.QuorumSignature, batch map[ho
 	for _, id := range ids {
 		_, _ = hasher.Write(batch[id])
 	}
-	hasher.Sum(hash[:])
+	hasher.Sum(hash[:0])
 
 	var key strings.Builder
+	_, _ = key.Write(prefix)
 	_, _ = key.Write(hash[:])
 	_, _ = key.Write(signature.ToBytes())
+	return key.String()
+}
 
-	if cache.check(key.String()) {
-		return true
+// SignVote signs the block as a vote.
+func (cache *cache) SignVote(block *hotstuff.Block) (sig hotstuff.QuorumSignature, err error) {
+	sig, err = signVote(cache.impl, block)
+	if err != nil {
+		return nil, err
 	}
+	cache.insert(messageKey(nil, sig, block.ToBytes()))
+	return sig, nil
+}
 
-	if cache.impl.BatchVerify(signature, batch) {
-		cache.insert(key.String())
-		return true
+// SignTimeout signs a message that belongs to the timeout.
+func (cache *cache) SignTimeout(timeout hotstuff.TimeoutMsg, message []byte) (sig hotstuff.QuorumSignature, err error) {
+	sig, err = signTimeout(cache.impl, timeout, message)
+	if err != nil {
+		return nil, err
 	}
-
-	return false
+	cache.insert(messageKey(nil, sig, message))
+	return sig, nil
 }
 
 // Combine combines multiple signatures together into a single signature.
@@ -137,3 +173,18 @@ func (cache *cache) Combine(signatures ...hotstuff.QuorumSignature) (hotstuff.Qu
 	// we don't cache the result of this operation, because it is not guaranteed to be valid.
 	return cache.impl.Combine(signatures...)
 }
+
+// ProveKey returns a proof of possession of the key, if the CryptoBase implementation needs one.
+func (cache *cache) ProveKey(key hotstuff.PrivateKey) ([]byte, error) {
+	return proveKey(cache.impl, key)
+}
+
+// VerifyKeyProof verifies a proof of possession of the key, if the CryptoBase implementation needs one.
+func (cache *cache) VerifyKeyProof(key hotstuff.PublicKey, proof []byte) bool {
+	return verifyKeyProof(cache.impl, key, proof)
+}
+
+// UniqueSignatures returns true if the CryptoBase implementation creates unique signatures.
+func (cache *cache) UniqueSignatures() bool {
+	return uniqueSignatures(cache.impl)
+}
@@ -9,6 +9,7 @@ import (
 type crypto struct {
 	blockChain    modules.BlockChain
 	configuration modules.Configuration
+	opts          *modules.Options
 
 	modules.CryptoBase
 }
@@ -25,6 +26,7 @@ func (c *crypto) InitModule(mods *modules.Core) {
 	mods.Get(
 		&c.blockChain,
 		&c.configuration,
+		&c.opts,
 	)
 
 	if mod, ok := c.CryptoBase.(modules.Module); ok {
@@ -34,7 +36,7 @@ func (c *crypto) InitModule(mods *modules.Core) {
 
 // CreatePartialCert signs a single block and returns the partial certificate.
 func (c crypto) CreatePartialCert(block *hotstuff.Block) (cert hotstuff.PartialCert, err error) {
-	sig, err := c.Sign(block.ToBytes())
+	sig, err := c.SignVote(block)
 	if err != nil {
 		return hotstuff.PartialCert{}, err
 	}
@@ -55,7 +57,7 @@ func (c crypto) CreateQuorumCert(block *hotstuff.Block, signatures []hotstuff.Pa
 	if err != nil {
 		return hotstuff.QuorumCert{}, err
 	}
-	return hotstuff.NewQuorumCert(sig, block.View(), block.Hash()), nil
+	return hotstuff.NewQuorumCertWithEpoch(sig, block.View(), block.Hash(), block.Epoch()), nil
 }
 
 // CreateTimeoutCert creates a timeout certificate from a list of timeout messages.
@@ -72,6 +74,15 @@ func (c crypto) CreateTimeoutCert(view hotstuff.View, timeouts []hotstuff.Timeou
 	if err != nil {
 		return hotstuff.TimeoutCert{}, err
 	}
+	if c.opts.ShouldUseQCViewTC() {
+		// each timeout signature also covers the view of the sender's highQC,
+		// so the views must be included for the TC to be verifiable.
+		qcViews := make(map[hotstuff.ID]hotstuff.View, len(timeouts))
+		for _, timeout := range timeouts {
+			qcViews[timeout.ID] = timeout.HighQCView()
+		}
+		return hotstuff.NewTimeoutCertWithQCViews(sig, view, qcViews), nil
+	}
 	return hotstuff.NewTimeoutCert(sig, view), nil
 }
 
@@ -100,7 +111,13 @@ func (c crypto) VerifyPartialCert(cert hotstuff.PartialCert) bool {
 	if !ok {
 		return false
 	}
-	return c.Verify(cert.Signature(), block.ToBytes())
+	if cert.Signature() == nil || !c.checkSigners(cert.Signature().Participants(), block.View(), 1) {
+		return false
+	}
+	if block.Epoch() != modules.EpochAt(c.configuration, block.View()) {
+		return false
+	}
+	return c.VerifyInEpoch(block.Epoch(), cert.Signature(), block.ToBytes())
 }
 
 // VerifyQuorumCert verifies a quorum certificate.
@@ -109,14 +126,18 @@ func (c crypto) VerifyQuorumCert(qc hotstuff.QuorumCert) bool {
 	if qc.BlockHash() == hotstuff.GetGenesis().Hash() {
 		return true
 	}
-	if qc.Signature().Participants().Len() < c.configuration.QuorumSize() {
+	if !c.checkSigners(qc.Signature().Participants(), qc.View(), modules.QuorumSizeAt(c.configuration, qc.View())) {
+		return false
+	}
+	// the QC must be signed with the keys of the epoch that its view belongs to.
+	if qc.Epoch() != modules.EpochAt(c.configuration, qc.View()) {
 		return false
 	}
 	block, ok := c.blockChain.Get(qc.BlockHash())
-	if !ok {
+	if !ok || block.Epoch() != qc.Epoch() {
 		return false
 	}
-	return c.Verify(qc.Signature(), block.ToBytes())
+	return c.VerifyInEpoch(qc.Epoch(), qc.Signature(), block.ToBytes())
 }
 
 // VerifyTimeoutCert verifies a timeout certificate.
@@ -125,10 +146,30 @@ func (c crypto) VerifyTimeoutCert(tc hotstuff.TimeoutCert) bool {
 	if tc.View() == 0 {
 		return true
 	}
-	if tc.Signature().Participants().Len() < c.configuration.QuorumSize() {
+	if !c.checkSigners(tc.Signature().Participants(), tc.View(), modules.QuorumSizeAt(c.configuration, tc.View())) {
+		return false
+	}
+	if c.opts.ShouldUseQCViewTC() {
+		return c.verifyQCViewTC(tc)
+	}
+	return c.VerifyInEpoch(modules.EpochAt(c.configuration, tc.View()), tc.Signature(), tc.View().ToBytes())
+}
+
+// verifyQCViewTC verifies a timeout certificate where each signer has signed both the view and the view of its highQC.
+func (c crypto) verifyQCViewTC(tc hotstuff.TimeoutCert) bool {
+	qcViews := tc.QCViews()
+	if len(qcViews) != tc.Signature().Participants().Len() {
 		return false
 	}
-	return c.Verify(tc.Signature(), tc.View().ToBytes())
+	messages := make(map[hotstuff.ID][]byte, len(qcViews))
+	for id, qcView := range qcViews {
+		// a replica cannot have a QC from the view that it timed out in, or later.
+		if qcView >= tc.View() {
+			return false
+		}
+		messages[id] = hotstuff.TimeoutQCViewBytes(id, tc.View(), qcView)
+	}
+	return c.BatchVerifyInEpoch(modules.EpochAt(c.configuration, tc.View()), tc.Signature(), messages)
 }
 
 // VerifyAggregateQC verifies the AggregateQC and returns the highQC, if valid.
@@ -145,12 +186,94 @@ func (c crypto) VerifyAggregateQC(aggQC hotstuff.AggregateQC) (highQC hotstuff.Q
 			SyncInfo: hotstuff.NewSyncInfo().WithQC(qc),
 		}.ToBytes()
 	}
-	if aggQC.Sig().Participants().Len() < c.configuration.QuorumSize() {
+	if !c.checkSigners(aggQC.Sig().Participants(), aggQC.View(), modules.QuorumSizeAt(c.configuration, aggQC.View())) {
 		return hotstuff.QuorumCert{}, false
 	}
 	// both the batched aggQC signatures and the highQC must be verified
-	if c.BatchVerify(aggQC.Sig(), messages) && c.VerifyQuorumCert(highQC) {
+	epoch := modules.EpochAt(c.configuration, aggQC.View())
+	if c.BatchVerifyInEpoch(epoch, aggQC.Sig(), messages) && c.VerifyQuorumCert(highQC) {
 		return highQC, true
 	}
 	return hotstuff.QuorumCert{}, false
 }
+
+// checkSigners returns true if there are at least min signers, and all of them participate in the given view.
+func (c crypto) checkSigners(signers hotstuff.IDSet, view hotstuff.View, min int) bool {
+	if signers.Len() < min {
+		return false
+	}
+	members := true
+	signers.RangeWhile(func(id hotstuff.ID) bool {
+		members = modules.IsMember(c.configuration, id, view)
+		return members
+	})
+	return members
+}
+
+// ProveKey returns a proof of possession of the key, if the CryptoBase implementation needs one.
+// Otherwise, the proof is nil.
+func (c crypto) ProveKey(key hotstuff.PrivateKey) ([]byte, error) {
+	return proveKey(c.CryptoBase, key)
+}
+
+// VerifyKeyProof verifies a proof of possession of the key, if the CryptoBase implementation needs one.
+// Otherwise, it returns true.
+func (c crypto) VerifyKeyProof(key hotstuff.PublicKey, proof []byte) bool {
+	return verifyKeyProof(c.CryptoBase, key, proof)
+}
+
+// UniqueSignatures returns true if the CryptoBase implementation creates unique signatures.
+func (c crypto) UniqueSignatures() bool {
+	return uniqueSignatures(c.CryptoBase)
+}
+
+// SignVote signs the block as a vote.
+func (c crypto) SignVote(block *hotstuff.Block) (hotstuff.QuorumSignature, error) {
+	return signVote(c.CryptoBase, block)
+}
+
+// SignTimeout signs a message that belongs to the timeout.
+func (c crypto) SignTimeout(timeout hotstuff.TimeoutMsg, message []byte) (hotstuff.QuorumSignature, error) {
+	return signTimeout(c.CryptoBase, timeout, message)
+}
+
+func signVote(impl modules.CryptoBase, block *hotstuff.Block) (hotstuff.QuorumSignature, error) {
+	if signer, ok := impl.(modules.SafetySigner); ok {
+		return signer.SignVote(block)
+	}
+	return impl.Sign(block.ToBytes())
+}
+
+func signTimeout(impl modules.CryptoBase, timeout hotstuff.TimeoutMsg, message []byte) (hotstuff.QuorumSignature, error) {
+	if signer, ok := impl.(modules.SafetySigner); ok {
+		return signer.SignTimeout(timeout, message)
+	}
+	return impl.Sign(message)
+}
+
+func proveKey(impl modules.CryptoBase, key hotstuff.PrivateKey) ([]byte, error) {
+	if prover, ok := impl.(modules.KeyProver); ok {
+		return prover.ProveKey(key)
+	}
+	return nil, nil
+}
+
+func verifyKeyProof(impl modules.CryptoBase, key hotstuff.PublicKey, proof []byte) bool {
+	if prover, ok := impl.(modules.KeyProver); ok {
+		return prover.VerifyKeyProof(key, proof)
+	}
+	return true
+}
+
+func uniqueSignatures(impl modules.CryptoBase) bool {
+	if signer, ok := impl.(modules.UniqueSigner); ok {
+		return signer.UniqueSignatures()
+	}
+	return false
+}
+
+var (
+	_ modules.KeyProver    = (*crypto)(nil)
+	_ modules.SafetySigner = (*crypto)(nil)
+	_ modules.UniqueSigner = (*crypto)(nil)
+)
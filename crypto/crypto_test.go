@@ -1,6 +1,8 @@
 package crypto_test
 
 import (
+	"bytes"
+	"fmt"
 	"testing"
 
 	"github.com/relab/hotstuff/modules"
@@ -10,7 +12,11 @@ import (
 	"github.com/relab/hotstuff/crypto"
 	"github.com/relab/hotstuff/crypto/bls12"
 	"github.com/relab/hotstuff/crypto/ecdsa"
+	"github.com/relab/hotstuff/crypto/ed25519"
+	"github.com/relab/hotstuff/crypto/thresholdbls"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
 	"github.com/relab/hotstuff/internal/testutil"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestCreatePartialCert(t *testing.T) {
@@ -123,6 +129,51 @@ func TestVerifyQuorumCert(t *testing.T) {
 	runAll(t, run)
 }
 
+func TestVerifyCombinedWithInvalidSignature(t *testing.T) {
+	run := func(t *testing.T, setup setupFunc) {
+		ctrl := gomock.NewController(t)
+
+		td := setup(t, ctrl, 4)
+
+		// the first signer signs a different message than the others.
+		message := td.block.ToBytes()
+		sigs := append(
+			[]hotstuff.QuorumSignature{testutil.Sign(t, []byte("bar"), td.signers[0])},
+			testutil.CreateSignatures(t, message, td.signers[1:])...,
+		)
+		combined, err := td.signers[0].Combine(sigs...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, verifier := range td.verifiers {
+			if verifier.Verify(combined, message) {
+				t.Errorf("verifier %d verified a signature that contains an invalid signature", i+1)
+			}
+		}
+	}
+	runAll(t, run)
+}
+
+func TestVerifyQuorumCertWrongEpoch(t *testing.T) {
+	run := func(t *testing.T, setup setupFunc) {
+		ctrl := gomock.NewController(t)
+
+		td := setup(t, ctrl, 4)
+
+		qc := testutil.CreateQC(t, td.block, td.signers)
+		// every view belongs to epoch 0, so a QC that claims to be signed with the keys of epoch 1 is invalid.
+		qc = hotstuff.NewQuorumCertWithEpoch(qc.Signature(), qc.View(), qc.BlockHash(), 1)
+
+		for i, verifier := range td.verifiers {
+			if verifier.VerifyQuorumCert(qc) {
+				t.Errorf("verifier %d verified a QC from the wrong epoch!", i+1)
+			}
+		}
+	}
+	runAll(t, run)
+}
+
 func TestVerifyTimeoutCert(t *testing.T) {
 	run := func(t *testing.T, setup setupFunc) {
 		ctrl := gomock.NewController(t)
@@ -140,6 +191,54 @@ func TestVerifyTimeoutCert(t *testing.T) {
 	runAll(t, run)
 }
 
+func TestVerifyQCViewTimeoutCert(t *testing.T) {
+	run := func(t *testing.T, setup setupFunc) {
+		ctrl := gomock.NewController(t)
+		td := setup(t, ctrl, 4)
+		for _, opts := range td.opts {
+			opts.SetShouldUseQCViewTC()
+		}
+
+		const view = 5
+		timeouts := make([]hotstuff.TimeoutMsg, 0, len(td.signers))
+		for i, signer := range td.signers {
+			id := hotstuff.ID(i + 1)
+			qcView := hotstuff.View(i + 1)
+			timeouts = append(timeouts, hotstuff.TimeoutMsg{
+				ID:            id,
+				View:          view,
+				ViewSignature: testutil.Sign(t, hotstuff.TimeoutQCViewBytes(id, view, qcView), signer),
+				SyncInfo:      hotstuff.NewSyncInfo().WithQC(hotstuff.NewQuorumCert(nil, qcView, hotstuff.Hash{})),
+			})
+		}
+
+		tc, err := td.signers[0].CreateTimeoutCert(view, timeouts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tc.HighQCView() != 4 {
+			t.Errorf("got highQC view %d, want 4", tc.HighQCView())
+		}
+		for i, verifier := range td.verifiers {
+			if !verifier.VerifyTimeoutCert(tc) {
+				t.Errorf("verifier %d failed to verify TC!", i+1)
+			}
+		}
+
+		// claiming a higher QC view than the one that was signed must invalidate the TC.
+		qcViews := make(map[hotstuff.ID]hotstuff.View)
+		for id, qcView := range tc.QCViews() {
+			qcViews[id] = qcView
+		}
+		qcViews[1] = 4
+		forged := hotstuff.NewTimeoutCertWithQCViews(tc.Signature(), tc.View(), qcViews)
+		if td.verifiers[0].VerifyTimeoutCert(forged) {
+			t.Error("TC with forged QC view was verified")
+		}
+	}
+	runMultiMessage(t, run)
+}
+
 func TestVerifyAggregateQC(t *testing.T) {
 	run := func(t *testing.T, setup setupFunc) {
 		ctrl := gomock.NewController(t)
@@ -160,18 +259,30 @@ func TestVerifyAggregateQC(t *testing.T) {
 			t.Fatal("Wrong hash for highQC")
 		}
 	}
-	runAll(t, run)
+	runMultiMessage(t, run)
 }
 
 func runAll(t *testing.T, run func(*testing.T, setupFunc)) {
+	t.Helper()
+	runMultiMessage(t, run)
+	t.Run("ThresholdBLS", func(t *testing.T) { run(t, setupDealt(NewBase(thresholdbls.New), testutil.GenerateThresholdBLSKeys)) })
+	t.Run("Cache+ThresholdBLS", func(t *testing.T) {
+		run(t, setupDealt(NewCache(thresholdbls.New), testutil.GenerateThresholdBLSKeys))
+	})
+}
+
+// runMultiMessage runs the tests with the implementations that can combine signatures of different messages.
+func runMultiMessage(t *testing.T, run func(*testing.T, setupFunc)) {
 	t.Helper()
 	t.Run("Ecdsa", func(t *testing.T) { run(t, setup(NewBase(ecdsa.New), testutil.GenerateECDSAKey)) })
 	t.Run("Cache+Ecdsa", func(t *testing.T) { run(t, setup(NewCache(ecdsa.New), testutil.GenerateECDSAKey)) })
+	t.Run("Ed25519", func(t *testing.T) { run(t, setup(NewBase(ed25519.New), testutil.GenerateED25519Key)) })
+	t.Run("Cache+Ed25519", func(t *testing.T) { run(t, setup(NewCache(ed25519.New), testutil.GenerateED25519Key)) })
 	t.Run("BLS12-381", func(t *testing.T) { run(t, setup(NewBase(bls12.New), testutil.GenerateBLS12Key)) })
 	t.Run("Cache+BLS12-381", func(t *testing.T) { run(t, setup(NewCache(bls12.New), testutil.GenerateBLS12Key)) })
 }
 
-func createBlock(t *testing.T, signer modules.Crypto) *hotstuff.Block {
+func createBlock(t testing.TB, signer modules.Crypto) *hotstuff.Block {
 	t.Helper()
 
 	qc, err := signer.CreateQuorumCert(hotstuff.GetGenesis(), []hotstuff.PartialCert{})
@@ -183,12 +294,20 @@ func createBlock(t *testing.T, signer modules.Crypto) *hotstuff.Block {
 	return b
 }
 
-type keyFunc func(t *testing.T) hotstuff.PrivateKey
-type setupFunc func(*testing.T, *gomock.Controller, int) testData
+type keyFunc func(t testing.TB) hotstuff.PrivateKey
+type keysFunc func(t testing.TB, n int) []hotstuff.PrivateKey
+type setupFunc func(testing.TB, *gomock.Controller, int) testData
 
 func setup(newFunc func() modules.Crypto, keyFunc keyFunc) setupFunc {
-	return func(t *testing.T, ctrl *gomock.Controller, n int) testData {
-		return newTestData(t, ctrl, n, newFunc, keyFunc)
+	return setupDealt(newFunc, func(t testing.TB, n int) []hotstuff.PrivateKey {
+		return testutil.GenerateKeys(t, n, keyFunc)
+	})
+}
+
+// setupDealt returns a setupFunc for crypto implementations whose keys must be generated together.
+func setupDealt(newFunc func() modules.Crypto, keysFunc keysFunc) setupFunc {
+	return func(t testing.TB, ctrl *gomock.Controller, n int) testData {
+		return newTestData(t, ctrl, n, newFunc, keysFunc(t, n))
 	}
 }
 
@@ -207,13 +326,14 @@ func NewBase(impl func() modules.CryptoBase) func() modules.Crypto {
 type testData struct {
 	signers   []modules.Crypto
 	verifiers []modules.Crypto
+	opts      []*modules.Options
 	block     *hotstuff.Block
 }
 
-func newTestData(t *testing.T, ctrl *gomock.Controller, n int, newFunc func() modules.Crypto, keyFunc keyFunc) testData {
+func newTestData(t testing.TB, ctrl *gomock.Controller, n int, newFunc func() modules.Crypto, keys []hotstuff.PrivateKey) testData {
 	t.Helper()
 
-	bl := testutil.CreateBuilders(t, ctrl, n, testutil.GenerateKeys(t, n, keyFunc)...)
+	bl := testutil.CreateBuilders(t, ctrl, n, keys...)
 	for _, builder := range bl {
 		signer := newFunc()
 		builder.Add(signer)
@@ -225,16 +345,98 @@ func newTestData(t *testing.T, ctrl *gomock.Controller, n int, newFunc func() mo
 
 	block := createBlock(t, signer)
 
+	opts := make([]*modules.Options, 0, len(hl))
 	for _, mods := range hl {
-		var blockChain modules.BlockChain
-		mods.Get(&blockChain)
+		var (
+			blockChain modules.BlockChain
+			o          *modules.Options
+		)
+		mods.Get(&blockChain, &o)
 
 		blockChain.Store(block)
+		opts = append(opts, o)
 	}
 
 	return testData{
 		signers:   hl.Signers(),
 		verifiers: hl.Verifiers(),
+		opts:      opts,
 		block:     block,
 	}
 }
+
+func TestThresholdBLSGroupSignature(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	const n = 7
+	td := setupDealt(NewBase(thresholdbls.New), testutil.GenerateThresholdBLSKeys)(t, ctrl, n)
+	quorum := hotstuff.QuorumSize(n)
+
+	// any quorum of shares must be interpolated to the same group signature.
+	first := testutil.CreateQC(t, td.block, td.signers[:quorum])
+	last := testutil.CreateQC(t, td.block, td.signers[n-quorum:])
+	for _, qc := range []hotstuff.QuorumCert{first, last} {
+		if _, ok := qc.Signature().(*thresholdbls.GroupSignature); !ok {
+			t.Fatalf("got signature of type %T, want a group signature", qc.Signature())
+		}
+		if !td.verifiers[n-1].VerifyQuorumCert(qc) {
+			t.Error("failed to verify QC")
+		}
+	}
+	if !bytes.Equal(first.Signature().ToBytes(), last.Signature().ToBytes()) {
+		t.Error("different quorums produced different group signatures")
+	}
+
+	// fewer shares than the threshold are not a QC.
+	qc, err := td.signers[0].CreateQuorumCert(td.block, testutil.CreatePCs(t, td.block, td.signers[:quorum-1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := qc.Signature().(thresholdbls.Shares); !ok {
+		t.Fatalf("got signature of type %T, want shares", qc.Signature())
+	}
+	if td.verifiers[0].VerifyQuorumCert(qc) {
+		t.Error("QC with too few shares was verified")
+	}
+
+	if td.verifiers[0].Verify(first.Signature(), []byte("bar")) {
+		t.Error("group signature was verified against the wrong message")
+	}
+	forged, err := thresholdbls.RestoreGroupSignature(first.Signature().ToBytes(), quorum+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if td.verifiers[0].Verify(forged, td.block.ToBytes()) {
+		t.Error("group signature with the wrong threshold was verified")
+	}
+}
+
+// BenchmarkVerifyQuorumCert compares the cost of verifying a QC, and the size of the QC, as the number of replicas
+// grows. The implementations are not cached, such that every iteration verifies the signature.
+func BenchmarkVerifyQuorumCert(b *testing.B) {
+	implementations := []struct {
+		name  string
+		setup setupFunc
+	}{
+		{"Ecdsa", setup(NewBase(ecdsa.New), testutil.GenerateECDSAKey)},
+		{"Ed25519", setup(NewBase(ed25519.New), testutil.GenerateED25519Key)},
+		{"BLS12-381", setup(NewBase(bls12.New), testutil.GenerateBLS12Key)},
+		{"ThresholdBLS", setupDealt(NewBase(thresholdbls.New), testutil.GenerateThresholdBLSKeys)},
+	}
+	for _, n := range []int{4, 16, 64} {
+		for _, impl := range implementations {
+			b.Run(fmt.Sprintf("%s/n=%d", impl.name, n), func(b *testing.B) {
+				ctrl := gomock.NewController(b)
+				td := impl.setup(b, ctrl, n)
+				qc := testutil.CreateQC(b, td.block, td.signers[:hotstuff.QuorumSize(n)])
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					if !td.verifiers[n-1].VerifyQuorumCert(qc) {
+						b.Fatal("failed to verify QC")
+					}
+				}
+				b.ReportMetric(float64(proto.Size(hotstuffpb.QuorumCertToProto(qc))), "qc-bytes")
+			})
+		}
+	}
+}
@@ -197,6 +197,15 @@ func (ec *ecdsaBase) Combine(signatures ...hotstuff.QuorumSignature) (hotstuff.Q
 
 // Verify verifies the given quorum signature against the message.
 func (ec *ecdsaBase) Verify(signature hotstuff.QuorumSignature, message []byte) bool {
+	return ec.verify(signature, message, ec.currentKey)
+}
+
+// VerifyInEpoch verifies the given quorum signature against the message, using the keys of the given epoch.
+func (ec *ecdsaBase) VerifyInEpoch(epoch hotstuff.Epoch, signature hotstuff.QuorumSignature, message []byte) bool {
+	return ec.verify(signature, message, ec.epochKey(epoch))
+}
+
+func (ec *ecdsaBase) verify(signature hotstuff.QuorumSignature, message []byte, publicKey keyFunc) bool {
 	s, ok := signature.(MultiSignature)
 	if !ok {
 		ec.logger.Panicf("cannot verify signature of incompatible type %T (expected %T)", signature, s)
@@ -212,7 +221,7 @@ func (ec *ecdsaBase) Verify(signature hotstuff.QuorumSignature, message []byte)
 
 	for _, sig := range s {
 		go func(sig *Signature, hash hotstuff.Hash) {
-			results <- ec.verifySingle(sig, hash)
+			results <- ec.verifySingle(sig, hash, publicKey)
 		}(sig, hash)
 	}
 
@@ -228,6 +237,16 @@ func (ec *ecdsaBase) Verify(signature hotstuff.QuorumSignature, message []byte)
 
 // BatchVerify verifies the given quorum signature against the batch of messages.
 func (ec *ecdsaBase) BatchVerify(signature hotstuff.QuorumSignature, batch map[hotstuff.ID][]byte) bool {
+	return ec.batchVerify(signature, batch, ec.currentKey)
+}
+
+// BatchVerifyInEpoch verifies the given quorum signature against the batch of messages,
+// using the keys of the given epoch.
+func (ec *ecdsaBase) BatchVerifyInEpoch(epoch hotstuff.Epoch, signature hotstuff.QuorumSignature, batch map[hotstuff.ID][]byte) bool {
+	return ec.batchVerify(signature, batch, ec.epochKey(epoch))
+}
+
+func (ec *ecdsaBase) batchVerify(signature hotstuff.QuorumSignature, batch map[hotstuff.ID][]byte, publicKey keyFunc) bool {
 	s, ok := signature.(MultiSignature)
 	if !ok {
 		ec.logger.Panicf("cannot verify signature of incompatible type %T (expected %T)", signature, s)
@@ -248,7 +267,7 @@ func (ec *ecdsaBase) BatchVerify(signature hotstuff.QuorumSignature, batch map[h
 		hash := sha256.Sum256(message)
 		set[hash] = struct{}{}
 		go func(sig *Signature, hash hotstuff.Hash) {
-			results <- ec.verifySingle(sig, hash)
+			results <- ec.verifySingle(sig, hash, publicKey)
 		}(sig, hash)
 	}
 
@@ -263,12 +282,35 @@ func (ec *ecdsaBase) BatchVerify(signature hotstuff.QuorumSignature, batch map[h
 	return valid && len(set) == len(batch)
 }
 
-func (ec *ecdsaBase) verifySingle(sig *Signature, hash hotstuff.Hash) bool {
-	replica, ok := ec.configuration.Replica(sig.Signer())
+func (ec *ecdsaBase) verifySingle(sig *Signature, hash hotstuff.Hash, publicKey keyFunc) bool {
+	key, ok := publicKey(sig.Signer())
 	if !ok {
 		ec.logger.Warnf("ecdsaBase: got signature from replica whose ID (%d) was not in the config.", sig.Signer())
 		return false
 	}
-	pk := replica.PublicKey().(*ecdsa.PublicKey)
+	pk, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		ec.logger.Warnf("ecdsaBase: unsupported public key type %T for replica %d", key, sig.Signer())
+		return false
+	}
 	return ecdsa.Verify(pk, hash[:], sig.R(), sig.S())
 }
+
+// keyFunc returns the public key of a replica.
+type keyFunc func(id hotstuff.ID) (hotstuff.PublicKey, bool)
+
+// currentKey returns the public key that the replica uses in the current epoch.
+func (ec *ecdsaBase) currentKey(id hotstuff.ID) (hotstuff.PublicKey, bool) {
+	replica, ok := ec.configuration.Replica(id)
+	if !ok {
+		return nil, false
+	}
+	return replica.PublicKey(), true
+}
+
+// epochKey returns a keyFunc that returns the public key that a replica uses in the given epoch.
+func (ec *ecdsaBase) epochKey(epoch hotstuff.Epoch) keyFunc {
+	return func(id hotstuff.ID) (hotstuff.PublicKey, bool) {
+		return modules.PublicKeyAt(ec.configuration, id, epoch)
+	}
+}
@@ -0,0 +1,294 @@
+// Package ed25519 provides a crypto implementation for HotStuff using Ed25519 signatures.
+//
+// Like the ecdsa implementation, a quorum certificate holds the signature of each signer.
+// The signatures of a certificate are verified together with a batch verifier, which is considerably faster than
+// verifying them one at a time. Signatures are verified with the ZIP-215 rules, such that a batch is only valid if
+// each of its signatures is valid on its own, and all replicas agree on the validity of a signature.
+package ed25519
+
+import (
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/hdevalence/ed25519consensus"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/crypto"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+	"golang.org/x/exp/slices"
+)
+
+func init() {
+	modules.RegisterModule("ed25519", New)
+}
+
+const (
+	// PrivateKeyFileType is the PEM type for a private key.
+	PrivateKeyFileType = "ED25519 PRIVATE KEY"
+
+	// PublicKeyFileType is the PEM type for a public key.
+	PublicKeyFileType = "ED25519 PUBLIC KEY"
+)
+
+// Signature is an Ed25519 signature.
+type Signature struct {
+	sig    []byte
+	signer hotstuff.ID
+}
+
+// RestoreSignature restores an existing signature. It should not be used to create new signatures, use Sign instead.
+func RestoreSignature(sig []byte, signer hotstuff.ID) *Signature {
+	return &Signature{sig, signer}
+}
+
+// Signer returns the ID of the replica that generated the signature.
+func (sig Signature) Signer() hotstuff.ID {
+	return sig.signer
+}
+
+// ToBytes returns a raw byte string representation of the signature.
+func (sig Signature) ToBytes() []byte {
+	return sig.sig
+}
+
+// MultiSignature is a set of (partial) signatures.
+type MultiSignature map[hotstuff.ID]*Signature
+
+// RestoreMultiSignature should only be used to restore an existing threshold signature from a set of signatures.
+func RestoreMultiSignature(signatures []*Signature) MultiSignature {
+	sig := make(MultiSignature, len(signatures))
+	for _, s := range signatures {
+		sig[s.signer] = s
+	}
+	return sig
+}
+
+// ToBytes returns the object as bytes.
+func (sig MultiSignature) ToBytes() []byte {
+	var b []byte
+	// sort by ID to make it deterministic
+	order := make([]hotstuff.ID, 0, len(sig))
+	for _, signature := range sig {
+		order = append(order, signature.signer)
+	}
+	slices.Sort(order)
+	for _, id := range order {
+		b = append(b, sig[id].ToBytes()...)
+	}
+	return b
+}
+
+// Participants returns the IDs of replicas who participated in the threshold signature.
+func (sig MultiSignature) Participants() hotstuff.IDSet {
+	return sig
+}
+
+// Add adds an ID to the set.
+func (sig MultiSignature) Add(id hotstuff.ID) {
+	panic("not implemented")
+}
+
+// Contains returns true if the set contains the ID.
+func (sig MultiSignature) Contains(id hotstuff.ID) bool {
+	_, ok := sig[id]
+	return ok
+}
+
+// ForEach calls f for each ID in the set.
+func (sig MultiSignature) ForEach(f func(hotstuff.ID)) {
+	for id := range sig {
+		f(id)
+	}
+}
+
+// RangeWhile calls f for each ID in the set until f returns false.
+func (sig MultiSignature) RangeWhile(f func(hotstuff.ID) bool) {
+	for id := range sig {
+		if !f(id) {
+			break
+		}
+	}
+}
+
+// Len returns the number of entries in the set.
+func (sig MultiSignature) Len() int {
+	return len(sig)
+}
+
+func (sig MultiSignature) String() string {
+	return hotstuff.IDSetToString(sig)
+}
+
+var _ hotstuff.QuorumSignature = (*MultiSignature)(nil)
+var _ hotstuff.IDSet = (*MultiSignature)(nil)
+
+type ed25519Base struct {
+	configuration modules.Configuration
+	logger        logging.Logger
+	opts          *modules.Options
+}
+
+// New returns a new instance of the Ed25519 CryptoBase implementation.
+func New() modules.CryptoBase {
+	return &ed25519Base{}
+}
+
+func (ed *ed25519Base) getPrivateKey() ed25519.PrivateKey {
+	return ed.opts.PrivateKey().(ed25519.PrivateKey)
+}
+
+// InitModule gives the module a reference to the Core object.
+// It also allows the module to set module options using the OptionsBuilder.
+func (ed *ed25519Base) InitModule(mods *modules.Core) {
+	mods.Get(
+		&ed.configuration,
+		&ed.logger,
+		&ed.opts,
+	)
+}
+
+// Sign creates a cryptographic signature of the given message.
+func (ed *ed25519Base) Sign(message []byte) (signature hotstuff.QuorumSignature, err error) {
+	return MultiSignature{ed.opts.ID(): &Signature{
+		sig:    ed25519.Sign(ed.getPrivateKey(), message),
+		signer: ed.opts.ID(),
+	}}, nil
+}
+
+// Combine combines multiple signatures into a single signature.
+func (ed *ed25519Base) Combine(signatures ...hotstuff.QuorumSignature) (hotstuff.QuorumSignature, error) {
+	if len(signatures) < 2 {
+		return nil, crypto.ErrCombineMultiple
+	}
+
+	ts := make(MultiSignature)
+
+	for _, sig1 := range signatures {
+		if sig2, ok := sig1.(MultiSignature); ok {
+			for id, s := range sig2 {
+				if _, ok := ts[id]; ok {
+					return nil, crypto.ErrCombineOverlap
+				}
+				ts[id] = s
+			}
+		} else {
+			ed.logger.Panicf("cannot combine signature of incompatible type %T (expected %T)", sig1, sig2)
+		}
+	}
+
+	return ts, nil
+}
+
+// Verify verifies the given quorum signature against the message.
+func (ed *ed25519Base) Verify(signature hotstuff.QuorumSignature, message []byte) bool {
+	return ed.verify(signature, message, ed.currentKey)
+}
+
+// VerifyInEpoch verifies the given quorum signature against the message, using the keys of the given epoch.
+func (ed *ed25519Base) VerifyInEpoch(epoch hotstuff.Epoch, signature hotstuff.QuorumSignature, message []byte) bool {
+	return ed.verify(signature, message, ed.epochKey(epoch))
+}
+
+func (ed *ed25519Base) verify(signature hotstuff.QuorumSignature, message []byte, publicKey keyFunc) bool {
+	s, ok := signature.(MultiSignature)
+	if !ok {
+		ed.logger.Panicf("cannot verify signature of incompatible type %T (expected %T)", signature, s)
+	}
+
+	batch := make(map[hotstuff.ID][]byte, len(s))
+	for id := range s {
+		batch[id] = message
+	}
+	return ed.verifyBatch(s, batch, publicKey)
+}
+
+// BatchVerify verifies the given quorum signature against the batch of messages.
+func (ed *ed25519Base) BatchVerify(signature hotstuff.QuorumSignature, batch map[hotstuff.ID][]byte) bool {
+	return ed.batchVerify(signature, batch, ed.currentKey)
+}
+
+// BatchVerifyInEpoch verifies the given quorum signature against the batch of messages,
+// using the keys of the given epoch.
+func (ed *ed25519Base) BatchVerifyInEpoch(epoch hotstuff.Epoch, signature hotstuff.QuorumSignature, batch map[hotstuff.ID][]byte) bool {
+	return ed.batchVerify(signature, batch, ed.epochKey(epoch))
+}
+
+func (ed *ed25519Base) batchVerify(signature hotstuff.QuorumSignature, batch map[hotstuff.ID][]byte, publicKey keyFunc) bool {
+	s, ok := signature.(MultiSignature)
+	if !ok {
+		ed.logger.Panicf("cannot verify signature of incompatible type %T (expected %T)", signature, s)
+	}
+
+	// valid if all partial signatures are valid and there are no duplicate messages
+	set := make(map[string]struct{}, len(batch))
+	for _, message := range batch {
+		set[string(message)] = struct{}{}
+	}
+	return len(set) == len(batch) && ed.verifyBatch(s, batch, publicKey)
+}
+
+// verifyBatch verifies each signature against the signer's message in the batch.
+func (ed *ed25519Base) verifyBatch(s MultiSignature, batch map[hotstuff.ID][]byte, publicKey keyFunc) bool {
+	if len(s) == 0 {
+		return false
+	}
+
+	verifier := ed25519consensus.NewBatchVerifier()
+	for id, sig := range s {
+		message, ok := batch[id]
+		if !ok {
+			return false
+		}
+		pk, ok := ed.publicKey(sig.Signer(), publicKey)
+		if !ok {
+			return false
+		}
+		if len(s) == 1 {
+			return ed25519consensus.Verify(pk, message, sig.ToBytes())
+		}
+		verifier.Add(pk, message, sig.ToBytes())
+	}
+	return verifier.Verify()
+}
+
+func (ed *ed25519Base) publicKey(id hotstuff.ID, publicKey keyFunc) (ed25519.PublicKey, bool) {
+	key, ok := publicKey(id)
+	if !ok {
+		ed.logger.Warnf("ed25519Base: got signature from replica whose ID (%d) was not in the config.", id)
+		return nil, false
+	}
+	pk, ok := key.(ed25519.PublicKey)
+	if !ok {
+		ed.logger.Warnf("ed25519Base: unsupported public key type %T for replica %d", key, id)
+		return nil, false
+	}
+	return pk, true
+}
+
+// keyFunc returns the public key of a replica.
+type keyFunc func(id hotstuff.ID) (hotstuff.PublicKey, bool)
+
+// currentKey returns the public key that the replica uses in the current epoch.
+func (ed *ed25519Base) currentKey(id hotstuff.ID) (hotstuff.PublicKey, bool) {
+	replica, ok := ed.configuration.Replica(id)
+	if !ok {
+		return nil, false
+	}
+	return replica.PublicKey(), true
+}
+
+// epochKey returns a keyFunc that returns the public key that a replica uses in the given epoch.
+func (ed *ed25519Base) epochKey(epoch hotstuff.Epoch) keyFunc {
+	return func(id hotstuff.ID) (hotstuff.PublicKey, bool) {
+		return modules.PublicKeyAt(ed.configuration, id, epoch)
+	}
+}
+
+// GeneratePrivateKey returns a new Ed25519 private key.
+func GeneratePrivateKey() (ed25519.PrivateKey, error) {
+	_, pk, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		return nil, fmt.Errorf("ed25519: failed to generate private key: %w", err)
+	}
+	return pk, nil
+}
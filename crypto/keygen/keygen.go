@@ -4,6 +4,7 @@ package keygen
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/x509"
@@ -18,6 +19,8 @@ import (
 	"github.com/relab/hotstuff"
 	"github.com/relab/hotstuff/crypto/bls12"
 	ecdsacrypto "github.com/relab/hotstuff/crypto/ecdsa"
+	ed25519crypto "github.com/relab/hotstuff/crypto/ed25519"
+	"github.com/relab/hotstuff/crypto/thresholdbls"
 )
 
 // GenerateECDSAPrivateKey returns a new ECDSA private key.
@@ -54,7 +57,8 @@ func GenerateRootCert(privateKey *ecdsa.PrivateKey) (cert *x509.Certificate, err
 }
 
 // GenerateTLSCert generates a TLS certificate for the server that is valid for the given hosts.
-func GenerateTLSCert(id hotstuff.ID, hosts []string, parent *x509.Certificate, signeeKey *ecdsa.PublicKey, signerKey *ecdsa.PrivateKey) (cert *x509.Certificate, err error) {
+// The signee key is either an ECDSA or an Ed25519 public key.
+func GenerateTLSCert(id hotstuff.ID, hosts []string, parent *x509.Certificate, signeeKey hotstuff.PublicKey, signerKey *ecdsa.PrivateKey) (cert *x509.Certificate, err error) {
 	sn, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
 	if err != nil {
 		return nil, err
@@ -101,9 +105,18 @@ func PrivateKeyToPEM(key hotstuff.PrivateKey) ([]byte, error) {
 			return nil, err
 		}
 		keyType = ecdsacrypto.PrivateKeyFileType
+	case ed25519.PrivateKey:
+		marshalled, err = x509.MarshalPKCS8PrivateKey(k)
+		if err != nil {
+			return nil, err
+		}
+		keyType = ed25519crypto.PrivateKeyFileType
 	case *bls12.PrivateKey:
 		marshalled = k.ToBytes()
 		keyType = bls12.PrivateKeyFileType
+	case *thresholdbls.PrivateKey:
+		marshalled = k.ToBytes()
+		keyType = thresholdbls.PrivateKeyFileType
 	}
 	b := &pem.Block{
 		Type:  keyType,
@@ -147,9 +160,18 @@ func PublicKeyToPEM(key hotstuff.PublicKey) ([]byte, error) {
 			return nil, err
 		}
 		keyType = ecdsacrypto.PublicKeyFileType
+	case ed25519.PublicKey:
+		marshalled, err = x509.MarshalPKIXPublicKey(k)
+		if err != nil {
+			return nil, err
+		}
+		keyType = ed25519crypto.PublicKeyFileType
 	case *bls12.PublicKey:
 		marshalled = k.ToBytes()
 		keyType = bls12.PublicKeyFileType
+	case *thresholdbls.PublicKey:
+		marshalled = k.ToBytes()
+		keyType = thresholdbls.PublicKeyFileType
 	}
 
 	b := &pem.Block{
@@ -209,10 +231,16 @@ func ParsePrivateKey(buf []byte) (key hotstuff.PrivateKey, err error) {
 	switch b.Type {
 	case ecdsacrypto.PrivateKeyFileType:
 		key, err = x509.ParseECPrivateKey(b.Bytes)
+	case ed25519crypto.PrivateKeyFileType:
+		key, err = parseED25519PrivateKey(b.Bytes)
 	case bls12.PrivateKeyFileType:
 		k := &bls12.PrivateKey{}
 		k.FromBytes(b.Bytes)
 		key = k
+	case thresholdbls.PrivateKeyFileType:
+		k := &thresholdbls.PrivateKey{}
+		err = k.FromBytes(b.Bytes)
+		key = k
 	default:
 		return nil, fmt.Errorf("file type did not match any known types")
 	}
@@ -222,6 +250,18 @@ func ParsePrivateKey(buf []byte) (key hotstuff.PrivateKey, err error) {
 	return
 }
 
+func parseED25519PrivateKey(b []byte) (hotstuff.PrivateKey, error) {
+	key, err := x509.ParsePKCS8PrivateKey(b)
+	if err != nil {
+		return nil, err
+	}
+	k, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an ed25519 key, got %T", key)
+	}
+	return k, nil
+}
+
 // ReadPrivateKeyFile reads a private key from the specified file.
 func ReadPrivateKeyFile(keyFile string) (key hotstuff.PrivateKey, err error) {
 	b, err := os.ReadFile(keyFile)
@@ -240,6 +280,8 @@ func ParsePublicKey(buf []byte) (key hotstuff.PublicKey, err error) {
 	switch b.Type {
 	case ecdsacrypto.PublicKeyFileType:
 		key, err = x509.ParsePKIXPublicKey(b.Bytes)
+	case ed25519crypto.PublicKeyFileType:
+		key, err = parseED25519PublicKey(b.Bytes)
 	case bls12.PublicKeyFileType:
 		k := &bls12.PublicKey{}
 		err = k.FromBytes(b.Bytes)
@@ -247,6 +289,10 @@ func ParsePublicKey(buf []byte) (key hotstuff.PublicKey, err error) {
 			return nil, err
 		}
 		key = k
+	case thresholdbls.PublicKeyFileType:
+		k := &thresholdbls.PublicKey{}
+		err = k.FromBytes(b.Bytes)
+		key = k
 	default:
 		return nil, fmt.Errorf("file type did not match any known types")
 	}
@@ -256,6 +302,18 @@ func ParsePublicKey(buf []byte) (key hotstuff.PublicKey, err error) {
 	return
 }
 
+func parseED25519PublicKey(b []byte) (hotstuff.PublicKey, error) {
+	key, err := x509.ParsePKIXPublicKey(b)
+	if err != nil {
+		return nil, err
+	}
+	k, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an ed25519 key, got %T", key)
+	}
+	return k, nil
+}
+
 // ReadPublicKeyFile reads a public key from the specified file.
 func ReadPublicKeyFile(keyFile string) (key hotstuff.PublicKey, err error) {
 	b, err := os.ReadFile(keyFile)
@@ -298,36 +356,61 @@ type KeyChain struct {
 }
 
 // GenerateKeyChain generates keys and certificates for a replica.
+// The keys of the threshold-bls implementation must be generated for all replicas at once with GeneratePrivateKeys.
 func GenerateKeyChain(id hotstuff.ID, validFor []string, crypto string, ca *x509.Certificate, caKey *ecdsa.PrivateKey) (KeyChain, error) {
 	ecdsaKey, err := GenerateECDSAPrivateKey()
 	if err != nil {
 		return KeyChain{}, err
 	}
-	certKeyPEM, err := PrivateKeyToPEM(ecdsaKey)
-	if err != nil {
-		return KeyChain{}, err
-	}
-
-	cert, err := GenerateTLSCert(id, validFor, ca, &ecdsaKey.PublicKey, caKey)
-	if err != nil {
-		return KeyChain{}, err
-	}
-
-	certPEM := CertToPEM(cert)
 
 	var privateKey hotstuff.PrivateKey
 	switch crypto {
 	case "ecdsa":
 		privateKey = ecdsaKey
+	case "ed25519":
+		// the certificate uses the same key, as for ecdsa.
+		edKey, err := ed25519crypto.GeneratePrivateKey()
+		if err != nil {
+			return KeyChain{}, err
+		}
+		return newKeyChain(id, validFor, edKey, edKey, ca, caKey)
 	case "bls12":
 		privateKey, err = bls12.GeneratePrivateKey()
 		if err != nil {
 			return KeyChain{}, fmt.Errorf("failed to generate bls12-381 private key: %w", err)
 		}
+	case "threshold-bls":
+		return KeyChain{}, fmt.Errorf("threshold-bls keys must be dealt to all replicas at once")
 	default:
 		return KeyChain{}, fmt.Errorf("unknown crypto implementation: %s", crypto)
 	}
 
+	return newKeyChain(id, validFor, privateKey, ecdsaKey, ca, caKey)
+}
+
+// NewKeyChain generates a certificate for a replica with the given private key.
+func NewKeyChain(id hotstuff.ID, validFor []string, privateKey hotstuff.PrivateKey, ca *x509.Certificate, caKey *ecdsa.PrivateKey) (KeyChain, error) {
+	certKey, err := GenerateECDSAPrivateKey()
+	if err != nil {
+		return KeyChain{}, err
+	}
+	return newKeyChain(id, validFor, privateKey, certKey, ca, caKey)
+}
+
+// newKeyChain creates a key chain with a certificate for the certificate key, which is an ECDSA or an Ed25519 key.
+func newKeyChain(id hotstuff.ID, validFor []string, privateKey, certKey hotstuff.PrivateKey, ca *x509.Certificate, caKey *ecdsa.PrivateKey) (KeyChain, error) {
+	certKeyPEM, err := PrivateKeyToPEM(certKey)
+	if err != nil {
+		return KeyChain{}, err
+	}
+
+	cert, err := GenerateTLSCert(id, validFor, ca, certKey.Public(), caKey)
+	if err != nil {
+		return KeyChain{}, err
+	}
+
+	certPEM := CertToPEM(cert)
+
 	privateKeyPEM, err := PrivateKeyToPEM(privateKey)
 	if err != nil {
 		return KeyChain{}, err
@@ -346,6 +429,50 @@ func GenerateKeyChain(id hotstuff.ID, validFor []string, crypto string, ca *x509
 	}, nil
 }
 
+// GeneratePrivateKeys generates private keys for the replicas with the given IDs.
+// For the threshold-bls implementation, a dealer shares a new group key among the replicas,
+// such that the signature shares of a quorum of the replicas can be combined into a signature of the group key.
+func GeneratePrivateKeys(crypto string, ids []hotstuff.ID) (map[hotstuff.ID]hotstuff.PrivateKey, error) {
+	keys := make(map[hotstuff.ID]hotstuff.PrivateKey, len(ids))
+	switch crypto {
+	case "ecdsa":
+		for _, id := range ids {
+			key, err := GenerateECDSAPrivateKey()
+			if err != nil {
+				return nil, err
+			}
+			keys[id] = key
+		}
+	case "ed25519":
+		for _, id := range ids {
+			key, err := ed25519crypto.GeneratePrivateKey()
+			if err != nil {
+				return nil, err
+			}
+			keys[id] = key
+		}
+	case "bls12":
+		for _, id := range ids {
+			key, err := bls12.GeneratePrivateKey()
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate bls12-381 private key: %w", err)
+			}
+			keys[id] = key
+		}
+	case "threshold-bls":
+		shares, err := thresholdbls.Deal(ids, hotstuff.QuorumSize(len(ids)))
+		if err != nil {
+			return nil, err
+		}
+		for id, share := range shares {
+			keys[id] = share
+		}
+	default:
+		return nil, fmt.Errorf("unknown crypto implementation: %s", crypto)
+	}
+	return keys, nil
+}
+
 // GenerateCA returns a certificate authority for generating new certificates.
 func GenerateCA() (pk *ecdsa.PrivateKey, ca *x509.Certificate, err error) {
 	pk, err = GenerateECDSAPrivateKey()
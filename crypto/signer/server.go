@@ -0,0 +1,178 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/crypto"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	"github.com/relab/hotstuff/internal/proto/signerpb"
+	"github.com/relab/hotstuff/modules"
+	"github.com/relab/hotstuff/safetystate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// domains are the prefixes of the messages, other than votes and timeouts, that the server signs.
+// Votes start with the hash of the parent block, and timeouts with the ID of the replica,
+// so they cannot be mistaken for these messages.
+var domains = []string{
+	"hotstuff-checkpoint",
+	"hotstuff-key-announcement",
+	"hotstuff-vrf",
+	"mempool-ack",
+}
+
+// Server holds the private key of a single replica and signs messages on its behalf.
+type Server struct {
+	impl      modules.CryptoBase
+	opts      *modules.Options
+	server    *grpc.Server
+	stateFile string
+
+	mut   sync.Mutex
+	state *signerpb.State // the newest vote or timeout that has been signed
+}
+
+// NewServer returns a server that signs messages for the replica with the given ID,
+// using the given private key and crypto implementation.
+// The newest vote or timeout that the server has signed is stored in the state file before the signature is returned,
+// and is loaded from it if the file exists.
+func NewServer(id hotstuff.ID, privateKey hotstuff.PrivateKey, impl modules.CryptoBase, stateFile string) (*Server, error) {
+	if stateFile == "" {
+		return nil, errors.New("signer: a state file is required")
+	}
+	srv := &Server{
+		impl:      impl,
+		opts:      crypto.NewStandalone(id, privateKey, impl),
+		server:    grpc.NewServer(),
+		stateFile: stateFile,
+		state:     &signerpb.State{},
+	}
+	b, err := os.ReadFile(stateFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("signer: failed to read state: %w", err)
+	}
+	if err == nil {
+		if err := proto.Unmarshal(b, srv.state); err != nil {
+			return nil, fmt.Errorf("signer: invalid state file %s: %w", stateFile, err)
+		}
+	}
+	signerpb.RegisterSignerServer(srv.server, serviceImpl{srv: srv})
+	return srv, nil
+}
+
+// Serve accepts connections on the listener and serves requests until Stop is called.
+func (srv *Server) Serve(lis net.Listener) error {
+	return srv.server.Serve(lis)
+}
+
+// Stop stops the server.
+func (srv *Server) Stop() {
+	srv.server.Stop()
+}
+
+// signVote signs the block, unless a different block, or a timeout, has been signed in the same or a newer view.
+func (srv *Server) signVote(block *hotstuff.Block) (hotstuff.QuorumSignature, error) {
+	return srv.sign(block.View(), signerpb.Kind_VOTE, block.Hash(), block.ToBytes())
+}
+
+// signTimeout signs the message for the timeout,
+// unless a different timeout has been signed in the same view, or anything has been signed in a newer view.
+func (srv *Server) signTimeout(timeout hotstuff.TimeoutMsg, message []byte) (hotstuff.QuorumSignature, error) {
+	if timeout.ID != srv.opts.ID() {
+		return nil, fmt.Errorf("signer: timeout is from replica %d", timeout.ID)
+	}
+	qcViewBytes := hotstuff.TimeoutQCViewBytes(timeout.ID, timeout.View, timeout.HighQCView())
+	if !bytes.Equal(message, timeout.View.ToBytes()) && !bytes.Equal(message, qcViewBytes) &&
+		!bytes.Equal(message, timeout.ToBytes()) {
+		return nil, errors.New("signer: message does not belong to the timeout")
+	}
+	return srv.sign(timeout.View, signerpb.Kind_TIMEOUT, sha256.Sum256(qcViewBytes), message)
+}
+
+// signOther signs a message that is neither a vote nor a timeout, if it starts with one of the known domains.
+func (srv *Server) signOther(message []byte) (hotstuff.QuorumSignature, error) {
+	for _, domain := range domains {
+		if bytes.HasPrefix(message, []byte(domain)) {
+			return srv.impl.Sign(message)
+		}
+	}
+	return nil, errors.New("signer: unknown message")
+}
+
+// sign signs the message for a vote or timeout in the given view, if it does not conflict with the stored state.
+func (srv *Server) sign(view hotstuff.View, kind signerpb.Kind, hash hotstuff.Hash, message []byte) (hotstuff.QuorumSignature, error) {
+	srv.mut.Lock()
+	defer srv.mut.Unlock()
+
+	state := srv.state
+	stateView := hotstuff.View(state.GetView())
+	if view < stateView {
+		return nil, fmt.Errorf("signer: already signed a message for view %d", stateView)
+	}
+	if view == stateView {
+		if kind == state.GetKind() && bytes.Equal(hash[:], state.GetHash()) {
+			// the same vote or timeout can be signed again.
+			return srv.impl.Sign(message)
+		}
+		// a replica may time out in a view after voting in it, but not the other way around.
+		if kind != signerpb.Kind_TIMEOUT || state.GetKind() != signerpb.Kind_VOTE {
+			return nil, fmt.Errorf("signer: already signed a conflicting %v in view %d", state.GetKind(), view)
+		}
+	}
+	// the state must be stored before the signature is released.
+	newState := &signerpb.State{View: uint64(view), Kind: kind, Hash: hash[:]}
+	b, err := proto.Marshal(newState)
+	if err != nil {
+		return nil, fmt.Errorf("signer: failed to marshal state: %w", err)
+	}
+	if err := safetystate.WriteFile(srv.stateFile, b); err != nil {
+		return nil, fmt.Errorf("signer: failed to store state: %w", err)
+	}
+	srv.state = newState
+	return srv.impl.Sign(message)
+}
+
+type serviceImpl struct {
+	signerpb.UnimplementedSignerServer
+
+	srv *Server
+}
+
+func (impl serviceImpl) Sign(_ context.Context, req *signerpb.SignRequest) (*hotstuffpb.QuorumSignature, error) {
+	var (
+		sig hotstuff.QuorumSignature
+		err error
+	)
+	switch r := req.GetRequest().(type) {
+	case *signerpb.SignRequest_Vote:
+		sig, err = impl.srv.signVote(hotstuffpb.BlockFromProto(r.Vote))
+	case *signerpb.SignRequest_Timeout:
+		timeout := hotstuffpb.TimeoutMsgFromProto(r.Timeout.GetTimeout())
+		sig, err = impl.srv.signTimeout(timeout, r.Timeout.GetMessage())
+	case *signerpb.SignRequest_Message:
+		sig, err = impl.srv.signOther(r.Message)
+	default:
+		err = errors.New("signer: empty request")
+	}
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return hotstuffpb.QuorumSignatureToProto(sig), nil
+}
+
+// ConnectionMetadata returns the metadata that was set by the crypto implementation, such as proofs of possession.
+func (impl serviceImpl) ConnectionMetadata(_ context.Context, _ *emptypb.Empty) (*signerpb.Metadata, error) {
+	return &signerpb.Metadata{Entries: impl.srv.opts.ConnectionMetadata()}, nil
+}
@@ -0,0 +1,144 @@
+// Package signer provides a CryptoBase implementation that keeps the replica's private key in a separate process.
+//
+// The replica forwards each Sign request to a Server, which typically runs as the `hotstuff signer` command,
+// through a local gRPC connection such as a Unix socket. Signatures are still combined and verified by the replica,
+// using the crypto implementation that the Server uses to sign.
+//
+// Votes and timeouts are sent to the Server as blocks and timeout messages, rather than as bytes,
+// such that the Server can take the view from the message itself. The Server stores the view, kind, and hash
+// of the newest vote or timeout that it has signed in a state file, and refuses to sign votes or timeouts for older views,
+// or a different vote or timeout in the same view. Thus, a compromised or rolled-back replica cannot obtain
+// conflicting signatures, even across restarts of the Server. Other messages are only signed if they start with
+// one of the domain tags that the Server knows.
+//
+// The Server only listens on Unix sockets, which can only be reached by local users that have access to the socket file.
+package signer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/internal/localrpc"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	"github.com/relab/hotstuff/internal/proto/signerpb"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// callTimeout is the maximum time to wait for the signer to answer a request.
+const callTimeout = 5 * time.Second
+
+type remoteSigner struct {
+	// verifies signatures locally
+	modules.CryptoBase
+
+	logger logging.Logger
+	opts   *modules.Options
+
+	client signerpb.SignerClient
+}
+
+// New returns a CryptoBase that forwards Sign requests to a signer at the given address,
+// and uses impl to combine and verify signatures.
+// The address is the path to a Unix socket prefixed by "unix://".
+// The signer must use the same crypto implementation as impl.
+func New(address string, impl modules.CryptoBase) (modules.CryptoBase, error) {
+	conn, err := localrpc.Dial(address)
+	if err != nil {
+		return nil, fmt.Errorf("signer: failed to connect to %s: %w", address, err)
+	}
+	return &remoteSigner{
+		CryptoBase: impl,
+		client:     signerpb.NewSignerClient(conn),
+	}, nil
+}
+
+// InitModule gives the module a reference to the Core object.
+// It also allows the module to set module options using the OptionsBuilder.
+func (rs *remoteSigner) InitModule(mods *modules.Core) {
+	mods.Get(
+		&rs.logger,
+		&rs.opts,
+	)
+
+	if mod, ok := rs.CryptoBase.(modules.Module); ok {
+		mod.InitModule(mods)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+	md, err := rs.client.ConnectionMetadata(ctx, &emptypb.Empty{}, grpc.WaitForReady(true))
+	if err != nil {
+		rs.logger.Errorf("Failed to get connection metadata from signer: %v", err)
+		return
+	}
+	for key, value := range md.GetEntries() {
+		rs.opts.SetConnectionMetadata(key, value)
+	}
+}
+
+// Sign creates a cryptographic signature of the given message.
+// The signer only signs messages that start with one of the domain tags that it knows.
+func (rs *remoteSigner) Sign(message []byte) (signature hotstuff.QuorumSignature, err error) {
+	return rs.sign(&signerpb.SignRequest{Request: &signerpb.SignRequest_Message{Message: message}})
+}
+
+// SignVote signs the block as a vote.
+func (rs *remoteSigner) SignVote(block *hotstuff.Block) (signature hotstuff.QuorumSignature, err error) {
+	return rs.sign(&signerpb.SignRequest{Request: &signerpb.SignRequest_Vote{Vote: hotstuffpb.BlockToProto(block)}})
+}
+
+// SignTimeout signs a message that belongs to the timeout.
+func (rs *remoteSigner) SignTimeout(timeout hotstuff.TimeoutMsg, message []byte) (signature hotstuff.QuorumSignature, err error) {
+	return rs.sign(&signerpb.SignRequest{Request: &signerpb.SignRequest_Timeout{Timeout: &signerpb.TimeoutMessage{
+		Timeout: hotstuffpb.TimeoutMsgToProto(timeout),
+		Message: message,
+	}}})
+}
+
+func (rs *remoteSigner) sign(req *signerpb.SignRequest) (signature hotstuff.QuorumSignature, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+
+	sig, err := rs.client.Sign(ctx, req, grpc.WaitForReady(true))
+	if err != nil {
+		return nil, fmt.Errorf("signer: sign failed: %w", err)
+	}
+	signature = hotstuffpb.QuorumSignatureFromProto(sig)
+	if signature == nil {
+		return nil, fmt.Errorf("signer: got signature of unknown type")
+	}
+	return signature, nil
+}
+
+// ProveKey returns an error, because the private keys are held by the signer,
+// and it does not support announcing new keys.
+func (rs *remoteSigner) ProveKey(_ hotstuff.PrivateKey) ([]byte, error) {
+	return nil, fmt.Errorf("signer: key rotation is not supported by the remote signer")
+}
+
+// VerifyKeyProof verifies a proof of possession of a key announced by another replica,
+// if the local crypto implementation needs one.
+func (rs *remoteSigner) VerifyKeyProof(key hotstuff.PublicKey, proof []byte) bool {
+	if prover, ok := rs.CryptoBase.(modules.KeyProver); ok {
+		return prover.VerifyKeyProof(key, proof)
+	}
+	return true
+}
+
+// UniqueSignatures returns true if the local crypto implementation, which the signer also uses, creates unique signatures.
+func (rs *remoteSigner) UniqueSignatures() bool {
+	if signer, ok := rs.CryptoBase.(modules.UniqueSigner); ok {
+		return signer.UniqueSignatures()
+	}
+	return false
+}
+
+var (
+	_ modules.SafetySigner = (*remoteSigner)(nil)
+	_ modules.UniqueSigner = (*remoteSigner)(nil)
+)
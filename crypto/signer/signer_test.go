@@ -0,0 +1,115 @@
+package signer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/crypto"
+	"github.com/relab/hotstuff/crypto/ecdsa"
+	"github.com/relab/hotstuff/internal/localrpc"
+	"github.com/relab/hotstuff/internal/proto/signerpb"
+	"github.com/relab/hotstuff/internal/testutil"
+)
+
+func TestRemoteSign(t *testing.T) {
+	key := testutil.GenerateECDSAKey(t)
+
+	lis, err := localrpc.Listen("unix://" + filepath.Join(t.TempDir(), "signer.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv, err := NewServer(1, key, ecdsa.New(), filepath.Join(t.TempDir(), "signer.state"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	impl, err := New(localrpc.Address(lis), ecdsa.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, 2, key)
+	builders[0].Add(crypto.New(impl))
+	hl := builders.Build()
+
+	block := newBlock(1)
+	pc, err := hl.Signers()[0].CreatePartialCert(block)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	if !hl.Verifiers()[1].Verify(pc.Signature(), block.ToBytes()) {
+		t.Error("failed to verify signature from signer")
+	}
+	if _, err := hl.Signers()[0].Sign([]byte("foo")); err == nil {
+		t.Error("expected a message without a known domain to be refused")
+	}
+}
+
+func newBlock(view hotstuff.View) *hotstuff.Block {
+	genesis := hotstuff.GetGenesis()
+	return hotstuff.NewBlock(genesis.Hash(), hotstuff.NewQuorumCert(nil, 0, genesis.Hash()), "foo", view, 1)
+}
+
+func TestRefuseConflicts(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "signer.state")
+	key := testutil.GenerateECDSAKey(t)
+
+	srv, err := NewServer(1, key, ecdsa.New(), stateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := newBlock(2)
+	if _, err := srv.signVote(block); err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	if _, err := srv.signVote(block); err != nil {
+		t.Errorf("failed to sign the same vote again: %v", err)
+	}
+	conflicting := hotstuff.NewBlock(block.Parent(), block.QuorumCert(), "bar", 2, 1)
+	if _, err := srv.signVote(conflicting); err == nil {
+		t.Error("expected a different vote in the same view to be refused")
+	}
+	if _, err := srv.signVote(newBlock(1)); err == nil {
+		t.Error("expected a vote for an older view to be refused")
+	}
+
+	timeout := hotstuff.TimeoutMsg{ID: 1, View: 2, SyncInfo: hotstuff.NewSyncInfo().WithQC(block.QuorumCert())}
+	if _, err := srv.signTimeout(timeout, timeout.View.ToBytes()); err != nil {
+		t.Errorf("failed to sign a timeout after voting in the same view: %v", err)
+	}
+	if _, err := srv.signTimeout(timeout, []byte("foo")); err == nil {
+		t.Error("expected a message that does not belong to the timeout to be refused")
+	}
+	if _, err := srv.signVote(block); err == nil {
+		t.Error("expected a vote after a timeout in the same view to be refused")
+	}
+	if _, err := srv.signOther([]byte("foo")); err == nil {
+		t.Error("expected a message without a known domain to be refused")
+	}
+
+	// the state must be remembered after a restart.
+	srv, err = NewServer(1, key, ecdsa.New(), stateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if srv.state.GetView() != 2 || srv.state.GetKind() != signerpb.Kind_TIMEOUT {
+		t.Errorf("got state %v after restart, want a timeout in view 2", srv.state)
+	}
+	if _, err := srv.signVote(block); err == nil {
+		t.Error("expected a vote after a timeout in the same view to be refused after a restart")
+	}
+	if _, err := srv.signVote(newBlock(3)); err != nil {
+		t.Errorf("failed to sign a vote in a newer view: %v", err)
+	}
+}
+
+func TestRequireStateFile(t *testing.T) {
+	if _, err := NewServer(1, testutil.GenerateECDSAKey(t), ecdsa.New(), ""); err == nil {
+		t.Error("expected the server to require a state file")
+	}
+}
@@ -0,0 +1,39 @@
+package crypto
+
+import (
+	"fmt"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+)
+
+// NewStandalone initializes the given CryptoBase for a process that signs messages on behalf of the replica
+// with the given ID, but that does not connect to the other replicas, such as a process that holds the replica's key.
+// Such a process can only verify its own signatures.
+// The returned options contain the connection metadata that was set by the CryptoBase.
+func NewStandalone(id hotstuff.ID, privateKey hotstuff.PrivateKey, impl modules.CryptoBase) *modules.Options {
+	builder := modules.NewBuilder(id, privateKey)
+	builder.Add(
+		logging.New(fmt.Sprintf("hs%d", id)),
+		noConfiguration{},
+		impl,
+	)
+	builder.Build()
+	return builder.Options()
+}
+
+// noConfiguration stands in for the configuration of a process that does not connect to other replicas.
+type noConfiguration struct {
+	modules.Configuration
+}
+
+// Replica returns false, as there are no known replicas.
+func (noConfiguration) Replica(hotstuff.ID) (modules.Replica, bool) {
+	return nil, false
+}
+
+// Len returns 0, as there are no known replicas.
+func (noConfiguration) Len() int {
+	return 0
+}
@@ -0,0 +1,634 @@
+// Package thresholdbls implements the crypto primitives used by HotStuff using a threshold BLS signature scheme on
+// curve BLS12-381.
+//
+// A trusted dealer shares a group private key among the replicas with a random polynomial, such that any threshold
+// of the replicas' signature shares can be interpolated into a signature of the group key, see Deal.
+// A quorum certificate is thus a single group signature that is verified against the group public key,
+// regardless of the number of replicas. Unlike the bls12 implementation, the signature does not record which
+// replicas signed, and the verifier does not need the public keys of the signers.
+//
+// Since the group signature does not reveal its signers, the participants of a group signature contain no IDs,
+// and only report the threshold as their length. The implementation can therefore not be used with modules that
+// need to know the signers of a quorum certificate, such as the carousel and reputation leader rotations, and Handel.
+// The shares of a group signature must sign the same message, so the timeout certificates of Jolteon
+// and the aggregate QCs of Fast-HotStuff, where each replica signs a different message, cannot be verified.
+// A replica's key is a share of a dealing for a fixed set of replicas, and cannot be rotated.
+package thresholdbls
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	bls12 "github.com/kilic/bls12-381"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/crypto"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+	"golang.org/x/exp/slices"
+)
+
+func init() {
+	modules.RegisterModule("threshold-bls", New)
+}
+
+const (
+	// PrivateKeyFileType is the PEM type for a private key.
+	PrivateKeyFileType = "THRESHOLD BLS12-381 PRIVATE KEY"
+
+	// PublicKeyFileType is the PEM type for a public key.
+	PublicKeyFileType = "THRESHOLD BLS12-381 PUBLIC KEY"
+
+	thresholdSize = 4
+	g1Size        = 48
+	scalarSize    = 32
+)
+
+var (
+	domain = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_")
+
+	// the order r of G1
+	curveOrder, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+	errCombineGroup = errors.New("cannot combine group signatures")
+)
+
+// PublicKey is the public part of a replica's share of the group key.
+// It also holds the group public key and the threshold of the dealing that the share belongs to.
+type PublicKey struct {
+	share     *bls12.PointG1
+	group     *bls12.PointG1
+	threshold int
+}
+
+// Threshold returns the number of signature shares that are needed to create a group signature.
+func (pub PublicKey) Threshold() int {
+	return pub.threshold
+}
+
+// GroupKey returns the group public key, which verifies the group signatures.
+func (pub PublicKey) GroupKey() []byte {
+	return compressG1(pub.group)
+}
+
+// ToBytes marshals the public key to a byte slice.
+func (pub PublicKey) ToBytes() []byte {
+	b := make([]byte, thresholdSize, thresholdSize+2*g1Size)
+	binary.BigEndian.PutUint32(b, uint32(pub.threshold))
+	b = append(b, compressG1(pub.group)...)
+	return append(b, compressG1(pub.share)...)
+}
+
+// FromBytes unmarshals the public key from a byte slice.
+func (pub *PublicKey) FromBytes(b []byte) (err error) {
+	if len(b) != thresholdSize+2*g1Size {
+		return errors.New("thresholdbls: invalid public key length")
+	}
+	g1 := bls12.NewG1()
+	pub.threshold = int(binary.BigEndian.Uint32(b))
+	pub.group, err = g1.FromCompressed(b[thresholdSize : thresholdSize+g1Size])
+	if err != nil {
+		return fmt.Errorf("thresholdbls: failed to decompress group key: %w", err)
+	}
+	pub.share, err = g1.FromCompressed(b[thresholdSize+g1Size:])
+	if err != nil {
+		return fmt.Errorf("thresholdbls: failed to decompress public key: %w", err)
+	}
+	return nil
+}
+
+// compressG1 returns the compressed form of the point.
+// ToCompressed converts the point to affine coordinates in place, so it is given a copy,
+// since keys and signatures may be serialized by several goroutines at once.
+func compressG1(p *bls12.PointG1) []byte {
+	return bls12.NewG1().ToCompressed(new(bls12.PointG1).Set(p))
+}
+
+// compressG2 returns the compressed form of the point, see compressG1.
+func compressG2(p *bls12.PointG2) []byte {
+	return bls12.NewG2().ToCompressed(new(bls12.PointG2).Set(p))
+}
+
+// PrivateKey is a replica's share of the group private key.
+type PrivateKey struct {
+	share     *big.Int
+	group     *bls12.PointG1
+	threshold int
+}
+
+// ToBytes marshals the private key to a byte slice.
+func (priv PrivateKey) ToBytes() []byte {
+	b := make([]byte, thresholdSize, thresholdSize+g1Size+scalarSize)
+	binary.BigEndian.PutUint32(b, uint32(priv.threshold))
+	b = append(b, compressG1(priv.group)...)
+	return append(b, priv.share.FillBytes(make([]byte, scalarSize))...)
+}
+
+// FromBytes unmarshals the private key from a byte slice.
+func (priv *PrivateKey) FromBytes(b []byte) (err error) {
+	if len(b) != thresholdSize+g1Size+scalarSize {
+		return errors.New("thresholdbls: invalid private key length")
+	}
+	priv.threshold = int(binary.BigEndian.Uint32(b))
+	priv.group, err = bls12.NewG1().FromCompressed(b[thresholdSize : thresholdSize+g1Size])
+	if err != nil {
+		return fmt.Errorf("thresholdbls: failed to decompress group key: %w", err)
+	}
+	priv.share = new(big.Int).SetBytes(b[thresholdSize+g1Size:])
+	return nil
+}
+
+// Public returns the public key associated with this private key.
+func (priv *PrivateKey) Public() hotstuff.PublicKey {
+	p := &bls12.PointG1{}
+	return &PublicKey{
+		share:     bls12.NewG1().MulScalarBig(p, &bls12.G1One, priv.share),
+		group:     priv.group,
+		threshold: priv.threshold,
+	}
+}
+
+// Deal generates a group key and shares it among the replicas with the given IDs,
+// such that the signature shares of any threshold of the replicas can be combined into a group signature.
+// The dealer learns the group private key, and must be trusted to forget it.
+func Deal(ids []hotstuff.ID, threshold int) (keys map[hotstuff.ID]*PrivateKey, err error) {
+	if threshold < 1 || threshold > len(ids) {
+		return nil, fmt.Errorf("thresholdbls: invalid threshold %d for %d replicas", threshold, len(ids))
+	}
+
+	// the shares are the points of a random polynomial of degree threshold-1 at the replicas' IDs,
+	// and the group private key is the point at 0.
+	coefficients := make([]*big.Int, threshold)
+	for i := range coefficients {
+		coefficients[i], err = rand.Int(rand.Reader, curveOrder)
+		if err != nil {
+			return nil, fmt.Errorf("thresholdbls: failed to generate polynomial: %w", err)
+		}
+	}
+	group := bls12.NewG1().MulScalarBig(&bls12.PointG1{}, &bls12.G1One, coefficients[0])
+
+	keys = make(map[hotstuff.ID]*PrivateKey, len(ids))
+	for _, id := range ids {
+		if id == 0 {
+			return nil, errors.New("thresholdbls: cannot deal a share to replica 0")
+		}
+		if _, ok := keys[id]; ok {
+			return nil, fmt.Errorf("thresholdbls: duplicate replica %d", id)
+		}
+		x := big.NewInt(int64(id))
+		share := new(big.Int)
+		for i := len(coefficients) - 1; i >= 0; i-- {
+			share.Mul(share, x)
+			share.Add(share, coefficients[i])
+			share.Mod(share, curveOrder)
+		}
+		keys[id] = &PrivateKey{share: share, group: group, threshold: threshold}
+	}
+	return keys, nil
+}
+
+// Share is a replica's signature share.
+type Share struct {
+	sig    bls12.PointG2
+	signer hotstuff.ID
+}
+
+// RestoreShare restores an existing signature share. It should not be used to create new shares, use Sign instead.
+func RestoreShare(sig []byte, signer hotstuff.ID) (*Share, error) {
+	p, err := bls12.NewG2().FromCompressed(sig)
+	if err != nil {
+		return nil, fmt.Errorf("thresholdbls: failed to restore signature share: %w", err)
+	}
+	return &Share{sig: *p, signer: signer}, nil
+}
+
+// Signer returns the ID of the replica that created the share.
+func (s Share) Signer() hotstuff.ID {
+	return s.signer
+}
+
+// ToBytes returns a byte representation of the share.
+func (s Share) ToBytes() []byte {
+	return bls12.NewG2().ToCompressed(&s.sig)
+}
+
+// Shares is a set of signature shares that are fewer than the threshold.
+type Shares map[hotstuff.ID]*Share
+
+// RestoreShares restores an existing set of signature shares.
+func RestoreShares(shares []*Share) Shares {
+	s := make(Shares, len(shares))
+	for _, share := range shares {
+		s[share.signer] = share
+	}
+	return s
+}
+
+// ToBytes returns the shares, sorted by the IDs of their signers, as bytes.
+func (s Shares) ToBytes() []byte {
+	order := make([]hotstuff.ID, 0, len(s))
+	for id := range s {
+		order = append(order, id)
+	}
+	slices.Sort(order)
+	var b []byte
+	for _, id := range order {
+		b = append(b, s[id].ToBytes()...)
+	}
+	return b
+}
+
+// Participants returns the IDs of replicas who created the shares.
+func (s Shares) Participants() hotstuff.IDSet {
+	return s
+}
+
+// Add adds an ID to the set.
+func (s Shares) Add(id hotstuff.ID) {
+	panic("not implemented")
+}
+
+// Contains returns true if the set contains the ID.
+func (s Shares) Contains(id hotstuff.ID) bool {
+	_, ok := s[id]
+	return ok
+}
+
+// ForEach calls f for each ID in the set.
+func (s Shares) ForEach(f func(hotstuff.ID)) {
+	for id := range s {
+		f(id)
+	}
+}
+
+// RangeWhile calls f for each ID in the set until f returns false.
+func (s Shares) RangeWhile(f func(hotstuff.ID) bool) {
+	for id := range s {
+		if !f(id) {
+			break
+		}
+	}
+}
+
+// Len returns the number of entries in the set.
+func (s Shares) Len() int {
+	return len(s)
+}
+
+func (s Shares) String() string {
+	return hotstuff.IDSetToString(s)
+}
+
+// GroupSignature is a signature of the group key that was interpolated from a threshold of signature shares.
+type GroupSignature struct {
+	sig       bls12.PointG2
+	threshold int
+}
+
+// RestoreGroupSignature restores an existing group signature. It should not be used to create new group signatures,
+// use Combine instead.
+func RestoreGroupSignature(sig []byte, threshold int) (*GroupSignature, error) {
+	p, err := bls12.NewG2().FromCompressed(sig)
+	if err != nil {
+		return nil, fmt.Errorf("thresholdbls: failed to restore group signature: %w", err)
+	}
+	return &GroupSignature{sig: *p, threshold: threshold}, nil
+}
+
+// ToBytes returns a byte representation of the group signature.
+func (gs *GroupSignature) ToBytes() []byte {
+	if gs == nil {
+		return nil
+	}
+	return compressG2(&gs.sig)
+}
+
+// Threshold returns the number of shares that the group signature was created from.
+func (gs GroupSignature) Threshold() int {
+	return gs.threshold
+}
+
+// Participants returns a set whose length is the threshold. The set contains no IDs, since the group signature does
+// not reveal which replicas created the shares.
+func (gs GroupSignature) Participants() hotstuff.IDSet {
+	return quorum(gs.threshold)
+}
+
+// quorum is the set of an unknown threshold of replicas.
+type quorum int
+
+// Add adds an ID to the set.
+func (q quorum) Add(id hotstuff.ID) {
+	panic("not implemented")
+}
+
+// Contains returns false, since the IDs of the replicas are unknown.
+func (q quorum) Contains(id hotstuff.ID) bool {
+	return false
+}
+
+// ForEach does nothing, since the IDs of the replicas are unknown.
+func (q quorum) ForEach(f func(hotstuff.ID)) {}
+
+// RangeWhile does nothing, since the IDs of the replicas are unknown.
+func (q quorum) RangeWhile(f func(hotstuff.ID) bool) {}
+
+// Len returns the number of replicas in the set.
+func (q quorum) Len() int {
+	return int(q)
+}
+
+func (q quorum) String() string {
+	return fmt.Sprintf("[ %d replicas ]", int(q))
+}
+
+var (
+	_ hotstuff.QuorumSignature = (*Shares)(nil)
+	_ hotstuff.IDSet           = (*Shares)(nil)
+	_ hotstuff.QuorumSignature = (*GroupSignature)(nil)
+	_ hotstuff.IDSet           = (*quorum)(nil)
+)
+
+type thresholdBase struct {
+	configuration modules.Configuration
+	logger        logging.Logger
+	opts          *modules.Options
+}
+
+// New returns a new instance of the threshold BLS CryptoBase implementation.
+func New() modules.CryptoBase {
+	return &thresholdBase{}
+}
+
+// InitModule gives the module a reference to the Core object.
+// It also allows the module to set module options using the OptionsBuilder.
+func (tb *thresholdBase) InitModule(mods *modules.Core) {
+	mods.Get(
+		&tb.configuration,
+		&tb.logger,
+		&tb.opts,
+	)
+}
+
+// keyFunc returns the public key of a replica.
+type keyFunc func(id hotstuff.ID) (hotstuff.PublicKey, bool)
+
+// currentKey returns the public key that the replica uses in the current epoch.
+func (tb *thresholdBase) currentKey(id hotstuff.ID) (hotstuff.PublicKey, bool) {
+	replica, ok := tb.configuration.Replica(id)
+	if !ok {
+		return nil, false
+	}
+	return replica.PublicKey(), true
+}
+
+// epochKey returns a keyFunc that returns the public key that a replica uses in the given epoch.
+func (tb *thresholdBase) epochKey(epoch hotstuff.Epoch) keyFunc {
+	return func(id hotstuff.ID) (hotstuff.PublicKey, bool) {
+		return modules.PublicKeyAt(tb.configuration, id, epoch)
+	}
+}
+
+func (tb *thresholdBase) publicKey(id hotstuff.ID, lookup keyFunc) (*PublicKey, bool) {
+	key, ok := lookup(id)
+	if !ok {
+		return nil, false
+	}
+	pubKey, ok := key.(*PublicKey)
+	if !ok {
+		tb.logger.Errorf("Unsupported public key type: %T", key)
+		return nil, false
+	}
+	return pubKey, true
+}
+
+// groupKey returns a public key that holds the group key. The replica's own key is trusted to hold the right group
+// key. Verifiers that are not replicas, such as light clients, only trust a group key that all replicas agree on.
+func (tb *thresholdBase) groupKey(lookup keyFunc) (*PublicKey, bool) {
+	if own, ok := tb.publicKey(tb.opts.ID(), lookup); ok {
+		return own, true
+	}
+	var group *PublicKey
+	g1 := bls12.NewG1()
+	for id := range tb.configuration.Replicas() {
+		key, ok := tb.publicKey(id, lookup)
+		if !ok {
+			continue
+		}
+		if group == nil {
+			group = key
+		} else if !g1.Equal(group.group, key.group) || group.threshold != key.threshold {
+			tb.logger.Warn("The replicas do not agree on the group key")
+			return nil, false
+		}
+	}
+	return group, group != nil
+}
+
+// shareKey returns the public key of a replica's share, if it belongs to the same dealing as the group key.
+func (tb *thresholdBase) shareKey(id hotstuff.ID, group *PublicKey, lookup keyFunc) (*PublicKey, bool) {
+	key, ok := tb.publicKey(id, lookup)
+	if !ok {
+		tb.logger.Warnf("Missing public key for ID %d", id)
+		return nil, false
+	}
+	if !bls12.NewG1().Equal(key.group, group.group) {
+		tb.logger.Warnf("The key of replica %d belongs to another group key", id)
+		return nil, false
+	}
+	return key, true
+}
+
+func subgroupCheck(point *bls12.PointG2) bool {
+	var p bls12.PointG2
+	g2 := bls12.NewG2()
+	g2.MulScalarBig(&p, point, curveOrder)
+	return g2.IsZero(&p)
+}
+
+func coreVerify(pubKey *bls12.PointG1, message []byte, signature *bls12.PointG2) bool {
+	if !subgroupCheck(signature) {
+		return false
+	}
+	messagePoint, err := bls12.NewG2().HashToCurve(message, domain)
+	if err != nil {
+		return false
+	}
+	engine := bls12.NewEngine()
+	// AddPair converts the points to affine coordinates in place, so copies are used, see compressG1.
+	engine.AddPairInv(&bls12.G1One, new(bls12.PointG2).Set(signature))
+	engine.AddPair(new(bls12.PointG1).Set(pubKey), messagePoint)
+	return engine.Result().IsOne()
+}
+
+// interpolate combines the shares of the threshold replicas with the lowest IDs into a group signature,
+// by evaluating the Lagrange interpolation of the shares at 0.
+func interpolate(shares Shares, threshold int) *GroupSignature {
+	ids := make([]hotstuff.ID, 0, len(shares))
+	for id := range shares {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	ids = ids[:threshold]
+
+	g2 := bls12.NewG2()
+	gs := &GroupSignature{threshold: threshold}
+	for _, i := range ids {
+		// the Lagrange coefficient of the share of replica i is the product of x_j / (x_j - x_i) for all j != i.
+		num, den := big.NewInt(1), big.NewInt(1)
+		for _, j := range ids {
+			if i == j {
+				continue
+			}
+			num.Mul(num, big.NewInt(int64(j)))
+			num.Mod(num, curveOrder)
+			den.Mul(den, big.NewInt(int64(j)-int64(i)))
+			den.Mod(den, curveOrder)
+		}
+		coefficient := num.Mul(num, den.ModInverse(den, curveOrder))
+		coefficient.Mod(coefficient, curveOrder)
+
+		var p bls12.PointG2
+		g2.MulScalarBig(&p, &shares[i].sig, coefficient)
+		g2.Add(&gs.sig, &gs.sig, &p)
+	}
+	return gs
+}
+
+// Sign creates a signature share of the given message.
+func (tb *thresholdBase) Sign(message []byte) (signature hotstuff.QuorumSignature, err error) {
+	pk, ok := tb.opts.PrivateKey().(*PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("thresholdbls: unsupported private key type: %T", tb.opts.PrivateKey())
+	}
+	g2 := bls12.NewG2()
+	p, err := g2.HashToCurve(message, domain)
+	if err != nil {
+		return nil, fmt.Errorf("thresholdbls: failed to hash message: %w", err)
+	}
+	g2.MulScalarBig(p, p, pk.share)
+	return Shares{tb.opts.ID(): &Share{sig: *p, signer: tb.opts.ID()}}, nil
+}
+
+// Combine combines multiple sets of signature shares.
+// If the combined set has at least the threshold of shares, it is interpolated into a group signature.
+func (tb *thresholdBase) Combine(signatures ...hotstuff.QuorumSignature) (combined hotstuff.QuorumSignature, err error) {
+	if len(signatures) < 2 {
+		return nil, crypto.ErrCombineMultiple
+	}
+
+	shares := make(Shares)
+	for _, sig1 := range signatures {
+		switch sig2 := sig1.(type) {
+		case Shares:
+			for id, share := range sig2 {
+				if _, ok := shares[id]; ok {
+					return nil, crypto.ErrCombineOverlap
+				}
+				shares[id] = share
+			}
+		case *GroupSignature:
+			return nil, errCombineGroup
+		default:
+			tb.logger.Panicf("cannot combine incompatible signature type %T (expected %T)", sig1, shares)
+		}
+	}
+
+	group, ok := tb.groupKey(tb.currentKey)
+	if !ok || len(shares) < group.threshold {
+		return shares, nil
+	}
+	return interpolate(shares, group.threshold), nil
+}
+
+// Verify verifies the given quorum signature against the message.
+func (tb *thresholdBase) Verify(signature hotstuff.QuorumSignature, message []byte) bool {
+	return tb.verify(signature, message, tb.currentKey)
+}
+
+// VerifyInEpoch verifies the given quorum signature against the message, using the keys of the given epoch.
+func (tb *thresholdBase) VerifyInEpoch(epoch hotstuff.Epoch, signature hotstuff.QuorumSignature, message []byte) bool {
+	return tb.verify(signature, message, tb.epochKey(epoch))
+}
+
+func (tb *thresholdBase) verify(signature hotstuff.QuorumSignature, message []byte, lookup keyFunc) bool {
+	group, ok := tb.groupKey(lookup)
+	if !ok {
+		tb.logger.Warn("Missing group key")
+		return false
+	}
+
+	switch s := signature.(type) {
+	case *GroupSignature:
+		return s.threshold == group.threshold && coreVerify(group.group, message, &s.sig)
+	case Shares:
+		if len(s) == 0 {
+			return false
+		}
+		// the shares are verified one by one, since the share keys are not proven to be shares of the group key.
+		for id, share := range s {
+			key, ok := tb.shareKey(id, group, lookup)
+			if !ok || !coreVerify(key.share, message, &share.sig) {
+				return false
+			}
+		}
+		return true
+	default:
+		tb.logger.Panicf("cannot verify signature of incompatible type %T (expected %T)", signature, s)
+		return false
+	}
+}
+
+// BatchVerify verifies the given quorum signature against the batch of messages.
+// A group signature can only be verified if every replica signed the same message.
+func (tb *thresholdBase) BatchVerify(signature hotstuff.QuorumSignature, batch map[hotstuff.ID][]byte) bool {
+	return tb.batchVerify(signature, batch, tb.currentKey)
+}
+
+// BatchVerifyInEpoch verifies the given quorum signature against the batch of messages,
+// using the keys of the given epoch.
+func (tb *thresholdBase) BatchVerifyInEpoch(epoch hotstuff.Epoch, signature hotstuff.QuorumSignature, batch map[hotstuff.ID][]byte) bool {
+	return tb.batchVerify(signature, batch, tb.epochKey(epoch))
+}
+
+func (tb *thresholdBase) batchVerify(signature hotstuff.QuorumSignature, batch map[hotstuff.ID][]byte, lookup keyFunc) bool {
+	switch s := signature.(type) {
+	case *GroupSignature:
+		if len(batch) != s.threshold {
+			return false
+		}
+		var message []byte
+		for _, msg := range batch {
+			if message != nil && string(message) != string(msg) {
+				return false
+			}
+			message = msg
+		}
+		return tb.verify(s, message, lookup)
+	case Shares:
+		if len(s) != len(batch) {
+			return false
+		}
+		group, ok := tb.groupKey(lookup)
+		if !ok {
+			tb.logger.Warn("Missing group key")
+			return false
+		}
+		for id, msg := range batch {
+			share, ok := s[id]
+			if !ok {
+				return false
+			}
+			key, ok := tb.shareKey(id, group, lookup)
+			if !ok || !coreVerify(key.share, msg, &share.sig) {
+				return false
+			}
+		}
+		return true
+	default:
+		tb.logger.Panicf("cannot verify incompatible signature type %T (expected %T)", signature, s)
+		return false
+	}
+}
@@ -7,13 +7,16 @@ import (
 
 // ProposeMsg is broadcast when a leader makes a proposal.
 type ProposeMsg struct {
-	ID          ID           // The ID of the replica who sent the message.
-	Block       *Block       // The block that is proposed.
-	AggregateQC *AggregateQC // Optional AggregateQC
+	ID          ID              // The ID of the replica who sent the message.
+	Block       *Block          // The block that is proposed.
+	AggregateQC *AggregateQC    // Optional AggregateQC
+	TimeoutCert *TimeoutCert    // Optional TimeoutCert that justifies the block's QC after a view change.
+	LeaderProof QuorumSignature // Optional proof that the sender is a leader of the block's view.
+	Verified    bool            // Set when the signatures have been verified by the verifier module.
 }
 
 func (p ProposeMsg) String() string {
-	return fmt.Sprintf("ID %d, %s, AggQC: %v", p.ID, p.Block, p.AggregateQC != nil)
+	return fmt.Sprintf("ID %d, %s, AggQC: %v, TC: %v", p.ID, p.Block, p.AggregateQC != nil, p.TimeoutCert != nil)
 }
 
 // VoteMsg is sent to the leader by replicas voting on a proposal.
@@ -21,6 +24,7 @@ type VoteMsg struct {
 	ID          ID          // the ID of the replica who sent the message.
 	PartialCert PartialCert // The partial certificate.
 	Deferred    bool
+	Verified    bool // Set when the partial certificate has been verified by the verifier module.
 }
 
 func (v VoteMsg) String() string {
@@ -34,6 +38,7 @@ type TimeoutMsg struct {
 	ViewSignature QuorumSignature // A signature of the view
 	MsgSignature  QuorumSignature // A signature of the view, QC.BlockHash, and the replica ID
 	SyncInfo      SyncInfo        // The highest QC/TC known to the sender.
+	Verified      bool            // Set when the signatures have been verified by the verifier module.
 }
 
 // ToBytes returns a byte form of the timeout message.
@@ -47,6 +52,23 @@ func (timeout TimeoutMsg) ToBytes() []byte {
 	return b.Bytes()
 }
 
+// HighQCView returns the view of the highQC included in the timeout message.
+func (timeout TimeoutMsg) HighQCView() View {
+	if qc, ok := timeout.SyncInfo.QC(); ok {
+		return qc.View()
+	}
+	return 0
+}
+
+// TimeoutQCViewBytes returns the message that is signed by the ViewSignature of a timeout message
+// when the timeout must also commit to the view of the sender's highQC.
+// The ID is included so that the messages signed by different replicas are distinct.
+func TimeoutQCViewBytes(id ID, view, qcView View) []byte {
+	b := id.ToBytes()
+	b = append(b, view.ToBytes()...)
+	return append(b, qcView.ToBytes()...)
+}
+
 func (timeout TimeoutMsg) String() string {
 	return fmt.Sprintf("ID: %d, View: %d, SyncInfo: %v", timeout.ID, timeout.View, timeout.SyncInfo)
 }
@@ -62,4 +84,9 @@ type NewViewMsg struct {
 // and includes the number of client commands that were executed.
 type CommitEvent struct {
 	Commands int
+	// Block is the block that was executed. Its command may have been replaced by the executor,
+	// such as by the mempool, but its hash is the hash of the committed block.
+	Block *Block
+	// Height is the number of committed blocks up to and including the block.
+	Height uint64
 }
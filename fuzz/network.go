@@ -17,6 +17,7 @@ import (
 	"github.com/relab/hotstuff/eventloop"
 	"github.com/relab/hotstuff/logging"
 	"github.com/relab/hotstuff/modules"
+	"github.com/relab/hotstuff/safetyrules"
 	"github.com/relab/hotstuff/synchronizer"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
@@ -150,6 +151,7 @@ func (n *Network) createTwinsNodes(nodes []NodeID, scenario Scenario, consensusN
 			consensus.New(consensusModule),
 			consensus.NewVotingMachine(),
 			crypto.NewCache(ecdsa.New(), 100),
+			safetyrules.New(),
 			synchronizer.New(FixedTimeout(0)),
 			logging.NewWithDest(&node.log, fmt.Sprintf("r%dn%d", nodeID.ReplicaID, nodeID.NetworkID)),
 			// twins-specific:
@@ -364,6 +366,14 @@ func (c *configuration) Timeout(msg hotstuff.TimeoutMsg) {
 	c.broadcastMessage(msg)
 }
 
+// Vote sends the partial certificate to all replicas.
+func (c *configuration) Vote(cert hotstuff.PartialCert) {
+	c.broadcastMessage(hotstuff.VoteMsg{
+		ID:          c.node.opts.ID(),
+		PartialCert: cert,
+	})
+}
+
 // Fetch requests a block from all the replicas in the configuration.
 func (c *configuration) Fetch(_ context.Context, hash hotstuff.Hash) (block *hotstuff.Block, ok bool) {
 	for _, replica := range c.network.replicas {
@@ -380,6 +390,22 @@ func (c *configuration) Fetch(_ context.Context, hash hotstuff.Hash) (block *hot
 	return nil, false
 }
 
+// FetchRange requests a chain of blocks from all the replicas in the configuration.
+func (c *configuration) FetchRange(_ context.Context, ancestor, target hotstuff.Hash) (blocks []*hotstuff.Block, ok bool) {
+	for _, replica := range c.network.replicas {
+		for _, node := range replica {
+			if c.shouldDrop(node.id, target) {
+				continue
+			}
+			blocks = modules.LocalRange(node.blockChain, ancestor, target, 0)
+			if len(blocks) > 0 && blocks[0].Parent() == ancestor {
+				return blocks, true
+			}
+		}
+	}
+	return nil, false
+}
+
 type replica struct {
 	// pointer to the node that wants to contact this replica.
 	config *configuration
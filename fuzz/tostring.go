@@ -85,9 +85,11 @@ func ProposalToString(proposal *hotstuffpb.Proposal, depth int) string {
 		"hotstuffpb.Proposal{\n"+
 			"%s\tBlock: %v\n"+
 			"%s\tAggQC: %v\n"+
+			"%s\tTC: %v\n"+
 			"%s}",
 		tabs, BlockToString(proposal.Block, depth+1),
 		tabs, AggQCToString(proposal.AggQC, depth+1),
+		tabs, TimeoutCertToString(proposal.TC, depth+1),
 		tabs)
 }
 
@@ -0,0 +1,302 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/relab/gorums"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/client"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/internal/config"
+	"github.com/relab/hotstuff/internal/proto/clientpb"
+	"github.com/relab/hotstuff/kvstore"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	clientID          uint32
+	clientGenerate    int
+	clientPayloadSize int
+	clientTimeout     time.Duration
+)
+
+var clientCmd = &cobra.Command{
+	Use:   "client [file...]",
+	Short: "Send commands to the replicas of a cluster and print the results.",
+	Long: `Connects to the replicas of the cluster configuration file given by the config flag, and sends commands to them
+one at a time. Each command is considered executed once f+1 replicas have returned matching results,
+and each command is printed with its result, the committed block, and the latency.
+
+The commands are read line by line from the given files, or from stdin if no files are given.
+Empty lines and lines starting with '#' are skipped. If the workload of the cluster is "kv", each line is
+a key-value operation, such as 'get <key>', 'put <key> <value>', 'delete <key>', or 'cas <key> <expected> <value>',
+and otherwise the line is sent as the data of the command. With the generate flag, the client instead sends
+the given number of generated commands, which are either random payloads or random key-value operations.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runClient(args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(clientCmd)
+
+	clientCmd.Flags().Uint32Var(&clientID, "id", 0, "The ID of the client. A random ID is chosen if it is zero.")
+	clientCmd.Flags().IntVar(&clientGenerate, "generate", 0, "The number of commands to generate instead of reading them.")
+	clientCmd.Flags().IntVar(&clientPayloadSize, "payload-size", 16, "The size in bytes of the generated commands.")
+	clientCmd.Flags().DurationVar(&clientTimeout, "timeout", 10*time.Second, "How long to wait for the result of a command.")
+}
+
+func runClient(files []string) {
+	if cfgFile == "" {
+		log.Fatal("the config flag must give the cluster configuration file")
+	}
+	cluster, err := config.Read(cfgFile)
+	checkf("failed to read cluster configuration: %v", err)
+	if cluster.Workload != "" && cluster.Workload != "kv" {
+		log.Fatalf("invalid workload: '%s'", cluster.Workload)
+	}
+
+	if clientID == 0 {
+		// avoid the low IDs that are used by the benchmark clients.
+		var b [4]byte
+		_, err := rand.Read(b[:])
+		checkf("failed to generate client ID: %v", err)
+		clientID = binary.LittleEndian.Uint32(b[:]) | 1<<31
+	}
+
+	rootCAs, err := cluster.CertPool()
+	checkf("failed to load certificate authority: %v", err)
+	replicas, err := cluster.Configuration(true)
+	checkf("failed to read the public keys of the replicas: %v", err)
+
+	builder := modules.NewBuilder(hotstuff.ID(clientID), nil)
+	builder.Add(
+		eventloop.New(100),
+		logging.New("cli"+strconv.Itoa(int(clientID))),
+	)
+	cli := client.New(client.Config{
+		TLS:     cluster.TLS,
+		RootCAs: rootCAs,
+		ManagerOptions: []gorums.ManagerOption{
+			gorums.WithDialTimeout(cluster.ConnectTimeout.Duration),
+			gorums.WithGrpcDialOptions(grpc.WithReturnConnectionError()),
+		},
+	}, builder)
+	err = cli.Connect(replicas)
+	checkf("failed to connect to the replicas: %v", err)
+	defer cli.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var commands <-chan string
+	if clientGenerate > 0 {
+		commands = generateCommands(ctx, cluster.Workload == "kv")
+	} else {
+		commands = readCommands(ctx, files)
+	}
+
+	var stats clientStats
+	for n := 1; ; n++ {
+		var line string
+		select {
+		case l, ok := <-commands:
+			if !ok {
+				stats.print()
+				return
+			}
+			line = l
+		case <-ctx.Done():
+			stats.print()
+			return
+		}
+
+		data := []byte(line)
+		var op *clientpb.KVOperation
+		if cluster.Workload == "kv" {
+			if op, err = kvstore.ParseOperation(line); err == nil {
+				data, err = proto.Marshal(op)
+			}
+			if err != nil {
+				fmt.Printf("%d: %s -> %v\n", n, line, err)
+				continue
+			}
+		}
+
+		cmdCtx, cancel := context.WithTimeout(ctx, clientTimeout)
+		start := time.Now()
+		res, err := cli.Exec(cmdCtx, data)
+		latency := time.Since(start)
+		cancel()
+		if err != nil {
+			fmt.Printf("%d: %s -> failed after %v: %v\n", n, line, latency, err)
+			stats.failed++
+			continue
+		}
+		stats.add(latency)
+		// the hash is printed like in the logs of the replicas.
+		var hash hotstuff.Hash
+		copy(hash[:], res.GetBlockHash())
+		fmt.Printf("%d: %s -> %s (height: %d, block: %.8s, latency: %v)\n",
+			n, line, formatResult(op, res.GetResult()), res.GetHeight(), hash, latency)
+	}
+}
+
+// readCommands sends the lines of the files, or stdin if there are no files, on the returned channel.
+func readCommands(ctx context.Context, files []string) <-chan string {
+	var readers []io.Reader
+	for _, name := range files {
+		f, err := os.Open(name)
+		checkf("failed to open input file: %v", err)
+		readers = append(readers, f)
+	}
+	if len(readers) == 0 {
+		readers = append(readers, os.Stdin)
+	}
+
+	c := make(chan string)
+	go func() {
+		defer close(c)
+		scanner := bufio.NewScanner(io.MultiReader(readers...))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			select {
+			case c <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			log.Printf("failed to read commands: %v", err)
+		}
+	}()
+	return c
+}
+
+// generateCommands sends random payloads, or random key-value operations in text form, on the returned channel.
+func generateCommands(ctx context.Context, kv bool) <-chan string {
+	c := make(chan string)
+	go func() {
+		defer close(c)
+		var seed [8]byte
+		_, _ = rand.Read(seed[:])
+		workload := kvstore.NewWorkload(100, 0.5, int64(binary.LittleEndian.Uint64(seed[:])))
+		for i := 0; i < clientGenerate; i++ {
+			var line string
+			if kv {
+				line = formatOperation(workload, clientPayloadSize)
+			} else {
+				b := make([]byte, (clientPayloadSize+1)/2)
+				_, _ = rand.Read(b)
+				line = fmt.Sprintf("%x", b)[:clientPayloadSize]
+			}
+			select {
+			case c <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return c
+}
+
+// formatOperation returns an operation from the workload in the form that is parsed by kvstore.ParseOperation.
+// The values are printable, such that the results can be read.
+func formatOperation(workload *kvstore.Workload, size int) string {
+	if size < kvstore.MinPayloadSize {
+		size = kvstore.MinPayloadSize
+	}
+	b := make([]byte, size)
+	n, err := workload.Read(b)
+	checkf("failed to generate operation: %v", err)
+	op := new(clientpb.KVOperation)
+	err = proto.Unmarshal(b[:n], op)
+	checkf("failed to generate operation: %v", err)
+
+	value := fmt.Sprintf("%x", op.GetValue())
+	if len(value) > len(op.GetValue()) {
+		value = value[:len(op.GetValue())]
+	}
+	switch op.GetType() {
+	case clientpb.KVOperation_PUT:
+		return fmt.Sprintf("put %s %q", op.GetKey(), value)
+	case clientpb.KVOperation_DELETE:
+		return "delete " + op.GetKey()
+	case clientpb.KVOperation_CAS:
+		return fmt.Sprintf("cas %s %q %q", op.GetKey(), op.GetExpected(), value)
+	default:
+		return "get " + op.GetKey()
+	}
+}
+
+// formatResult returns the result of a command in readable form.
+// If op is not nil, the result is that of a key-value operation.
+func formatResult(op *clientpb.KVOperation, result []byte) string {
+	if op == nil {
+		return strconv.Quote(string(result))
+	}
+	r := new(clientpb.KVResult)
+	if err := proto.Unmarshal(result, r); err != nil {
+		return fmt.Sprintf("invalid result: %v", err)
+	}
+	switch {
+	case !r.GetOK() && op.GetType() == clientpb.KVOperation_CAS:
+		return "not swapped"
+	case !r.GetOK():
+		return "failed"
+	case op.GetType() == clientpb.KVOperation_GET && !r.GetFound():
+		return "not found"
+	case op.GetType() == clientpb.KVOperation_GET:
+		return strconv.Quote(string(r.GetValue()))
+	case op.GetType() == clientpb.KVOperation_CAS:
+		return "swapped"
+	default:
+		return "ok"
+	}
+}
+
+type clientStats struct {
+	executed int
+	failed   int
+	total    time.Duration
+	min      time.Duration
+	max      time.Duration
+}
+
+func (s *clientStats) add(latency time.Duration) {
+	if s.executed == 0 || latency < s.min {
+		s.min = latency
+	}
+	if latency > s.max {
+		s.max = latency
+	}
+	s.executed++
+	s.total += latency
+}
+
+func (s *clientStats) print() {
+	fmt.Printf("executed: %d, failed: %d", s.executed, s.failed)
+	if s.executed > 0 {
+		fmt.Printf(", latency min/avg/max: %v/%v/%v", s.min, s.total/time.Duration(s.executed), s.max)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+
+	"github.com/relab/hotstuff/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	keygenReplicas       int
+	keygenHosts          []string
+	keygenReplicaPort    int
+	keygenClientPort     int
+	keygenCrypto         string
+	keygenConsensus      string
+	keygenLeaderRotation string
+	keygenOutput         string
+)
+
+var keygenCmd = &cobra.Command{
+	Use:   "keygen",
+	Short: "Generate the configuration file, keys, and certificates of a cluster of replicas.",
+	Long: `Generates keys and certificates for a cluster of replicas, and a cluster configuration file that lists them.
+The files are written to the output directory, and the configuration file is named cluster.toml.
+The replicas are assigned to the hosts in turn, and replica i listens on the replica port + i - 1 for other replicas,
+and on the client port + i - 1 for clients. The remaining settings can be changed by editing the configuration file.
+Each replica is started with 'hotstuff replica --config cluster.toml --id N'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runKeygen()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(keygenCmd)
+
+	keygenCmd.Flags().IntVar(&keygenReplicas, "replicas", 4, "The number of replicas.")
+	keygenCmd.Flags().StringSliceVar(&keygenHosts, "hosts", []string{"localhost"}, "The hosts that run the replicas.")
+	keygenCmd.Flags().IntVar(&keygenReplicaPort, "replica-port", 10000, "The port of the first replica for connections from other replicas.")
+	keygenCmd.Flags().IntVar(&keygenClientPort, "client-port", 20000, "The port of the first replica for connections from clients.")
+	keygenCmd.Flags().StringVar(&keygenCrypto, "crypto", "ecdsa", "The name of the crypto implementation to use.")
+	keygenCmd.Flags().StringVar(&keygenConsensus, "consensus", "chainedhotstuff", "The name of the consensus implementation to use.")
+	keygenCmd.Flags().StringVar(&keygenLeaderRotation, "leader-rotation", "round-robin", "The name of the leader rotation algorithm to use.")
+	keygenCmd.Flags().StringVar(&keygenOutput, "output", "cluster", "The directory to write the files to.")
+}
+
+func runKeygen() {
+	cluster := config.Default()
+	cluster.Crypto = keygenCrypto
+	cluster.Consensus = keygenConsensus
+	cluster.LeaderRotation = keygenLeaderRotation
+
+	var seed [8]byte
+	_, err := rand.Read(seed[:])
+	checkf("failed to generate shared seed: %v", err)
+	cluster.SharedSeed = int64(binary.LittleEndian.Uint64(seed[:]) >> 1)
+
+	err = cluster.Generate(keygenOutput, keygenReplicas, keygenHosts, keygenReplicaPort, keygenClientPort)
+	checkf("failed to generate keys: %v", err)
+
+	path := filepath.Join(keygenOutput, "cluster.toml")
+	err = cluster.Write(path)
+	checkf("failed to write cluster configuration: %v", err)
+	fmt.Println("Wrote", path)
+}
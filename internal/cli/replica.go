@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/internal/config"
+	"github.com/relab/hotstuff/internal/orchestration"
+	"github.com/spf13/cobra"
+)
+
+var replicaID uint32
+
+var replicaCmd = &cobra.Command{
+	Use:   "replica",
+	Short: "Run a single replica as a service.",
+	Long: `Runs a replica with the settings, addresses, keys, and certificates of the cluster configuration file
+given by the config flag, until it receives an interrupt or terminate signal.
+The configuration file is created by the 'hotstuff keygen' command.
+The replicas connect to each other when they start, so they must be started within the connect timeout of each other.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runReplica()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(replicaCmd)
+
+	replicaCmd.Flags().Uint32Var(&replicaID, "id", 0, "The ID of the replica.")
+	_ = replicaCmd.MarkFlagRequired("id")
+}
+
+func runReplica() {
+	if cfgFile == "" {
+		log.Fatal("the config flag must give the cluster configuration file")
+	}
+	cluster, err := config.Read(cfgFile)
+	checkf("failed to read cluster configuration: %v", err)
+
+	id := hotstuff.ID(replicaID)
+	info, ok := cluster.Replica(id)
+	if !ok {
+		log.Fatalf("replica %d is not in the cluster configuration", id)
+	}
+
+	opts, err := cluster.ReplicaOpts(id)
+	checkf("failed to load replica options: %v", err)
+
+	r, err := orchestration.NewReplica(opts, cluster.DataDir)
+	checkf("failed to create replica: %v", err)
+
+	replicaAddr, err := config.ListenAddress(info.Address)
+	checkf("invalid replica address: %v", err)
+	replicaListener, err := net.Listen("tcp", replicaAddr)
+	checkf("failed to listen: %v", err)
+
+	clientAddr, err := config.ListenAddress(info.ClientAddress)
+	checkf("invalid client address: %v", err)
+	clientListener, err := net.Listen("tcp", clientAddr)
+	checkf("failed to listen: %v", err)
+
+	r.StartServers(replicaListener, clientListener)
+
+	replicas, err := cluster.Configuration(false)
+	checkf("failed to read the public keys of the replicas: %v", err)
+	err = r.Connect(replicas)
+	checkf("failed to connect to the replicas: %v", err)
+
+	r.Start()
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	<-c
+	r.Stop()
+}
@@ -62,6 +62,10 @@ func init() {
 	runCmd.Flags().String("leader-rotation", "round-robin", "name of the leader rotation algorithm")
 	runCmd.Flags().Int64("shared-seed", 0, "Shared random number generator seed")
 	runCmd.Flags().StringSlice("modules", nil, "Name additional modules to be loaded.")
+	runCmd.Flags().Bool("mempool", false, "disseminate batches through the DAG mempool instead of proposing them directly")
+	runCmd.Flags().Int("verification-workers", 0, "number of workers that verify incoming messages off the event loop (0 verifies them on the event loop)")
+	runCmd.Flags().Uint64("checkpoint-interval", 0, "number of committed blocks between checkpoints (0 disables checkpoints)")
+	runCmd.Flags().String("workload", "", "the application workload: random payloads (default) or \"kv\" for key-value store operations")
 
 	runCmd.Flags().Bool("worker", false, "run a local worker")
 	runCmd.Flags().StringSlice("hosts", nil, "the remote hosts to run the experiment on via ssh")
@@ -69,6 +73,7 @@ func init() {
 	runCmd.Flags().String("ssh-config", "", "path to ssh_config file to resolve host aliases (defaults to ~/.ssh/config)")
 
 	runCmd.Flags().String("output", "", "the directory to save data and profiles to (disabled by default)")
+	runCmd.Flags().String("data-dir", "", "the directory where replicas store blocks and safety state (disabled by default)")
 	runCmd.Flags().Bool("cpu-profile", false, "enable cpu profiling")
 	runCmd.Flags().Bool("mem-profile", false, "enable memory profiling")
 	runCmd.Flags().Bool("trace", false, "enable trace")
@@ -104,18 +109,22 @@ func runController() {
 		Duration:    viper.GetDuration("duration"),
 		Output:      outputDir,
 		ReplicaOpts: &orchestrationpb.ReplicaOpts{
-			UseTLS:            true,
-			BatchSize:         viper.GetUint32("batch-size"),
-			TimeoutMultiplier: float32(viper.GetFloat64("timeout-multiplier")),
-			Consensus:         viper.GetString("consensus"),
-			Crypto:            viper.GetString("crypto"),
-			LeaderRotation:    viper.GetString("leader-rotation"),
-			ConnectTimeout:    durationpb.New(viper.GetDuration("connect-timeout")),
-			InitialTimeout:    durationpb.New(viper.GetDuration("view-timeout")),
-			TimeoutSamples:    viper.GetUint32("duration-samples"),
-			MaxTimeout:        durationpb.New(viper.GetDuration("max-timeout")),
-			SharedSeed:        viper.GetInt64("shared-seed"),
-			Modules:           viper.GetStringSlice("modules"),
+			UseTLS:              true,
+			BatchSize:           viper.GetUint32("batch-size"),
+			TimeoutMultiplier:   float32(viper.GetFloat64("timeout-multiplier")),
+			Consensus:           viper.GetString("consensus"),
+			Crypto:              viper.GetString("crypto"),
+			LeaderRotation:      viper.GetString("leader-rotation"),
+			ConnectTimeout:      durationpb.New(viper.GetDuration("connect-timeout")),
+			InitialTimeout:      durationpb.New(viper.GetDuration("view-timeout")),
+			TimeoutSamples:      viper.GetUint32("duration-samples"),
+			MaxTimeout:          durationpb.New(viper.GetDuration("max-timeout")),
+			SharedSeed:          viper.GetInt64("shared-seed"),
+			Modules:             viper.GetStringSlice("modules"),
+			Mempool:             viper.GetBool("mempool"),
+			CheckpointInterval:  viper.GetUint64("checkpoint-interval"),
+			VerificationWorkers: viper.GetUint32("verification-workers"),
+			Workload:            viper.GetString("workload"),
 		},
 		ClientOpts: &orchestrationpb.ClientOpts{
 			UseTLS:           true,
@@ -126,6 +135,7 @@ func runController() {
 			RateStep:         viper.GetFloat64("rate-step"),
 			RateStepInterval: durationpb.New(viper.GetDuration("rate-step-interval")),
 			Timeout:          durationpb.New(viper.GetDuration("client-timeout")),
+			Workload:         viper.GetString("workload"),
 		},
 	}
 
@@ -153,6 +163,7 @@ func runController() {
 		Fgprof:              viper.GetBool("fgprof-profile"),
 		Metrics:             viper.GetStringSlice("metrics"),
 		MeasurementInterval: viper.GetDuration("measurement-interval"),
+		DataDir:             viper.GetString("data-dir"),
 	})
 	checkf("failed to deploy workers: %v", err)
 
@@ -169,7 +180,7 @@ func runController() {
 
 	if worker || len(hosts) == 0 {
 
-		worker, wait := localWorker(outputDir, viper.GetStringSlice("metrics"), viper.GetDuration("measurement-interval"))
+		worker, wait := localWorker(outputDir, viper.GetStringSlice("metrics"), viper.GetDuration("measurement-interval"), viper.GetString("data-dir"))
 		defer wait()
 		experiment.Hosts["localhost"] = worker
 	}
@@ -230,7 +241,7 @@ func parseByzantine() (map[string]int, error) {
 	return strategies, nil
 }
 
-func localWorker(globalOutput string, enableMetrics []string, interval time.Duration) (worker orchestration.RemoteWorker, wait func()) {
+func localWorker(globalOutput string, enableMetrics []string, interval time.Duration, dataDir string) (worker orchestration.RemoteWorker, wait func()) {
 	// set up an output dir
 	output := ""
 	if globalOutput != "" {
@@ -269,6 +280,7 @@ func localWorker(globalOutput string, enableMetrics []string, interval time.Dura
 			logger,
 			enableMetrics,
 			interval,
+			dataDir,
 		)
 
 		err := worker.Run()
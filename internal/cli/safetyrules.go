@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/crypto/keygen"
+	"github.com/relab/hotstuff/internal/localrpc"
+	"github.com/relab/hotstuff/modules"
+	"github.com/relab/hotstuff/safetyrules"
+	"github.com/spf13/cobra"
+
+	// imported modules
+	_ "github.com/relab/hotstuff/crypto/bls12"
+	_ "github.com/relab/hotstuff/crypto/ecdsa"
+)
+
+var (
+	safetyRulesID     uint32
+	safetyRulesKey    string
+	safetyRulesCrypto string
+	safetyRulesListen string
+	safetyRulesState  string
+)
+
+var safetyRulesCmd = &cobra.Command{
+	Use:   "safetyrules",
+	Short: "Run a safety rules server for a single replica.",
+	Long: `Starts a server that holds the private key of a replica and signs votes and timeouts for it.
+The replica connects to the server through the address given by the listen flag,
+which is the path to a Unix socket prefixed by "unix://".
+The last vote is stored in the state file before any signature is returned,
+such that the server does not sign conflicting messages after a restart.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runSafetyRules()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(safetyRulesCmd)
+
+	safetyRulesCmd.Flags().Uint32Var(&safetyRulesID, "id", 0, "The ID of the replica.")
+	safetyRulesCmd.Flags().StringVar(&safetyRulesKey, "key", "", "The private key file of the replica.")
+	safetyRulesCmd.Flags().StringVar(&safetyRulesCrypto, "crypto", "ecdsa", "The name of the crypto implementation to use.")
+	safetyRulesCmd.Flags().StringVar(&safetyRulesListen, "listen", "", "The address to listen on.")
+	safetyRulesCmd.Flags().StringVar(&safetyRulesState, "state", "", "The file to store the state of the server in.")
+	_ = safetyRulesCmd.MarkFlagRequired("id")
+	_ = safetyRulesCmd.MarkFlagRequired("key")
+	_ = safetyRulesCmd.MarkFlagRequired("listen")
+	_ = safetyRulesCmd.MarkFlagRequired("state")
+}
+
+func runSafetyRules() {
+	privKey, err := keygen.ReadPrivateKeyFile(safetyRulesKey)
+	checkf("failed to read private key: %v", err)
+
+	cryptoImpl, ok := modules.GetModule[modules.CryptoBase](safetyRulesCrypto)
+	if !ok {
+		log.Fatalf("invalid crypto name: '%s'", safetyRulesCrypto)
+	}
+
+	lis, err := localrpc.Listen(safetyRulesListen)
+	checkf("failed to listen: %v", err)
+
+	srv, err := safetyrules.NewServer(hotstuff.ID(safetyRulesID), privKey, cryptoImpl, safetyRulesState)
+	checkf("failed to create server: %v", err)
+
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt)
+		<-c
+		srv.Stop()
+	}()
+
+	err = srv.Serve(lis)
+	if err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/crypto/keygen"
+	"github.com/relab/hotstuff/crypto/signer"
+	"github.com/relab/hotstuff/internal/localrpc"
+	"github.com/relab/hotstuff/modules"
+	"github.com/spf13/cobra"
+)
+
+var (
+	signerID     uint32
+	signerKey    string
+	signerCrypto string
+	signerListen string
+	signerState  string
+)
+
+var signerCmd = &cobra.Command{
+	Use:   "signer",
+	Short: "Run a signer that holds the private key of a single replica.",
+	Long: `Starts a server that holds the private key of a replica and signs messages for it.
+The signer refuses to sign votes and timeouts for a view that is older than the newest view it has signed for,
+or a different vote or timeout in the same view. The newest vote or timeout is stored in the state file.
+The replica connects to the signer through the address given by the listen flag,
+which is the path to a Unix socket prefixed by "unix://".`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runSigner()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(signerCmd)
+
+	signerCmd.Flags().Uint32Var(&signerID, "id", 0, "The ID of the replica.")
+	signerCmd.Flags().StringVar(&signerKey, "key", "", "The private key file of the replica.")
+	signerCmd.Flags().StringVar(&signerCrypto, "crypto", "ecdsa", "The name of the crypto implementation to use.")
+	signerCmd.Flags().StringVar(&signerListen, "listen", "", "The address to listen on.")
+	signerCmd.Flags().StringVar(&signerState, "state", "", "The file that stores the newest vote or timeout that has been signed.")
+	_ = signerCmd.MarkFlagRequired("id")
+	_ = signerCmd.MarkFlagRequired("key")
+	_ = signerCmd.MarkFlagRequired("listen")
+	_ = signerCmd.MarkFlagRequired("state")
+}
+
+func runSigner() {
+	privKey, err := keygen.ReadPrivateKeyFile(signerKey)
+	checkf("failed to read private key: %v", err)
+
+	cryptoImpl, ok := modules.GetModule[modules.CryptoBase](signerCrypto)
+	if !ok {
+		log.Fatalf("invalid crypto name: '%s'", signerCrypto)
+	}
+
+	srv, err := signer.NewServer(hotstuff.ID(signerID), privKey, cryptoImpl, signerState)
+	checkf("failed to create signer: %v", err)
+
+	lis, err := localrpc.Listen(signerListen)
+	checkf("failed to listen: %v", err)
+
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt)
+		<-c
+		srv.Stop()
+	}()
+
+	err = srv.Serve(lis)
+	if err != nil {
+		log.Println(err)
+	}
+}
@@ -15,6 +15,7 @@ import (
 
 var (
 	dataPath      string
+	dataDir       string
 	cpuProfile    string
 	memProfile    string
 	trace         string
@@ -49,6 +50,7 @@ func init() {
 	// is called directly, e.g.:
 	// workerCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 	workerCmd.Flags().StringVar(&dataPath, "data-path", "", "Path to store experiment data.")
+	workerCmd.Flags().StringVar(&dataDir, "data-dir", "", "Directory to store replica blocks and safety state in, allowing replicas to be restarted (disabled by default).")
 	workerCmd.Flags().StringVar(&cpuProfile, "cpu-profile", "", "Path to store a CPU profile")
 	workerCmd.Flags().StringVar(&memProfile, "mem-profile", "", "Path to store a memory profile")
 	workerCmd.Flags().StringVar(&trace, "trace", "", "Path to store a trace")
@@ -82,7 +84,7 @@ func runWorker() {
 		}()
 	}
 
-	worker := orchestration.NewWorker(protostream.NewWriter(os.Stdout), protostream.NewReader(os.Stdin), metricsLogger, enableMetrics, measurementInterval)
+	worker := orchestration.NewWorker(protostream.NewWriter(os.Stdout), protostream.NewReader(os.Stdin), metricsLogger, enableMetrics, measurementInterval, dataDir)
 	err = worker.Run()
 	if err != nil {
 		log.Println(err)
@@ -0,0 +1,272 @@
+// Package config reads and writes the configuration file of a cluster of replicas that run as services.
+//
+// The configuration file is a TOML file that lists the address, keys, and certificate of each replica,
+// together with the protocol and modules that all replicas use. The keys and certificates are stored in PEM files,
+// whose paths are relative to the directory of the configuration file. Each replica only needs to read its own
+// private key, so the private key files of the other replicas do not need to be present on its host.
+// The configuration file and keys are generated by the 'hotstuff keygen' command.
+package config
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/crypto/keygen"
+	"github.com/relab/hotstuff/internal/proto/orchestrationpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Duration is a time.Duration that is written as a string, such as "100ms", in the configuration file.
+type Duration struct {
+	time.Duration
+}
+
+// MarshalText returns the duration as a string.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses a duration string.
+func (d *Duration) UnmarshalText(text []byte) (err error) {
+	d.Duration, err = time.ParseDuration(string(text))
+	return err
+}
+
+// Replica is the entry of a replica in the configuration file.
+type Replica struct {
+	ID uint32 `toml:"id"`
+	// The address that the other replicas connect to, as host:port.
+	Address string `toml:"address"`
+	// The address that the clients connect to, as host:port.
+	ClientAddress string `toml:"client-address"`
+	// The files that store the keys and the TLS certificate of the replica.
+	PublicKey      string `toml:"public-key"`
+	PrivateKey     string `toml:"private-key"`
+	Certificate    string `toml:"certificate"`
+	CertificateKey string `toml:"certificate-key"`
+}
+
+// Cluster is the configuration of a cluster of replicas.
+// The names of the settings match the flags of the 'hotstuff run' command.
+type Cluster struct {
+	Consensus           string   `toml:"consensus"`
+	Crypto              string   `toml:"crypto"`
+	LeaderRotation      string   `toml:"leader-rotation"`
+	Modules             []string `toml:"modules"`
+	BatchSize           uint32   `toml:"batch-size"`
+	ConnectTimeout      Duration `toml:"connect-timeout"`
+	ViewTimeout         Duration `toml:"view-timeout"`
+	MaxTimeout          Duration `toml:"max-timeout"`
+	DurationSamples     uint32   `toml:"duration-samples"`
+	TimeoutMultiplier   float32  `toml:"timeout-multiplier"`
+	SharedSeed          int64    `toml:"shared-seed"`
+	Mempool             bool     `toml:"mempool"`
+	VerificationWorkers uint32   `toml:"verification-workers"`
+	CheckpointInterval  uint64   `toml:"checkpoint-interval"`
+	Workload            string   `toml:"workload"`
+	// The directory where the replicas store their blocks and safety state. If empty, the state is kept in memory.
+	// Like the other paths, it is relative to the directory of the configuration file.
+	DataDir string `toml:"data-dir"`
+	// Determines whether the replicas and clients connect with TLS.
+	TLS bool `toml:"tls"`
+	// The file that stores the certificate authority that created the TLS certificates.
+	CertificateAuthority string `toml:"certificate-authority"`
+
+	Replicas []Replica `toml:"replicas"`
+
+	dir string // the directory of the configuration file
+}
+
+// Default returns a configuration with the same default settings as the 'hotstuff run' command, and no replicas.
+func Default() *Cluster {
+	return &Cluster{
+		Consensus:         "chainedhotstuff",
+		Crypto:            "ecdsa",
+		LeaderRotation:    "round-robin",
+		Modules:           []string{},
+		BatchSize:         1,
+		ConnectTimeout:    Duration{time.Minute},
+		ViewTimeout:       Duration{100 * time.Millisecond},
+		DurationSamples:   1000,
+		TimeoutMultiplier: 1.2,
+		TLS:               true,
+	}
+}
+
+// Read reads the configuration file. The settings that are missing from the file keep their default values.
+func Read(path string) (*Cluster, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := Default()
+	if err := toml.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	c.dir = filepath.Dir(path)
+	if c.DataDir != "" {
+		c.DataDir = c.path(c.DataDir)
+	}
+	return c, c.validate()
+}
+
+// Write writes the configuration file.
+func (c *Cluster) Write(path string) error {
+	b, err := toml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+func (c *Cluster) validate() error {
+	if len(c.Replicas) == 0 {
+		return errors.New("the configuration does not contain any replicas")
+	}
+	seen := make(map[uint32]bool, len(c.Replicas))
+	for _, r := range c.Replicas {
+		if r.ID == 0 {
+			return errors.New("replica IDs must be greater than zero")
+		}
+		if seen[r.ID] {
+			return fmt.Errorf("duplicate replica ID: %d", r.ID)
+		}
+		seen[r.ID] = true
+	}
+	return nil
+}
+
+// Replica returns the entry of the replica with the given ID.
+func (c *Cluster) Replica(id hotstuff.ID) (Replica, bool) {
+	for _, r := range c.Replicas {
+		if hotstuff.ID(r.ID) == id {
+			return r, true
+		}
+	}
+	return Replica{}, false
+}
+
+// path returns the path relative to the directory of the configuration file, unless the path is absolute.
+func (c *Cluster) path(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(c.dir, path)
+}
+
+func (c *Cluster) readFile(path string) ([]byte, error) {
+	if path == "" {
+		return nil, errors.New("missing file name")
+	}
+	return os.ReadFile(c.path(path))
+}
+
+// CertPool returns a pool with the certificate authority, or nil if TLS is disabled.
+func (c *Cluster) CertPool() (*x509.CertPool, error) {
+	if !c.TLS {
+		return nil, nil
+	}
+	ca, err := c.readFile(c.CertificateAuthority)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate authority: %w", err)
+	}
+	cp := x509.NewCertPool()
+	if !cp.AppendCertsFromPEM(ca) {
+		return nil, errors.New("invalid certificate authority")
+	}
+	return cp, nil
+}
+
+// ReplicaOpts returns the options of the replica with the given ID, including its private key and certificate.
+func (c *Cluster) ReplicaOpts(id hotstuff.ID) (*orchestrationpb.ReplicaOpts, error) {
+	r, ok := c.Replica(id)
+	if !ok {
+		return nil, fmt.Errorf("replica %d is not in the configuration", id)
+	}
+	opts := &orchestrationpb.ReplicaOpts{
+		ID:                  r.ID,
+		UseTLS:              c.TLS,
+		Crypto:              c.Crypto,
+		Consensus:           c.Consensus,
+		LeaderRotation:      c.LeaderRotation,
+		BatchSize:           c.BatchSize,
+		ConnectTimeout:      durationpb.New(c.ConnectTimeout.Duration),
+		InitialTimeout:      durationpb.New(c.ViewTimeout.Duration),
+		MaxTimeout:          durationpb.New(c.MaxTimeout.Duration),
+		TimeoutSamples:      c.DurationSamples,
+		TimeoutMultiplier:   c.TimeoutMultiplier,
+		SharedSeed:          c.SharedSeed,
+		Modules:             c.Modules,
+		Mempool:             c.Mempool,
+		VerificationWorkers: c.VerificationWorkers,
+		CheckpointInterval:  c.CheckpointInterval,
+		Workload:            c.Workload,
+	}
+	var err error
+	if opts.PrivateKey, err = c.readFile(r.PrivateKey); err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+	if opts.PublicKey, err = c.readFile(r.PublicKey); err != nil {
+		return nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+	if c.TLS {
+		if opts.Certificate, err = c.readFile(r.Certificate); err != nil {
+			return nil, fmt.Errorf("failed to read certificate: %w", err)
+		}
+		if opts.CertificateKey, err = c.readFile(r.CertificateKey); err != nil {
+			return nil, fmt.Errorf("failed to read certificate key: %w", err)
+		}
+		if opts.CertificateAuthority, err = c.readFile(c.CertificateAuthority); err != nil {
+			return nil, fmt.Errorf("failed to read certificate authority: %w", err)
+		}
+	}
+	return opts, nil
+}
+
+// Configuration returns the addresses and public keys of the replicas.
+// If client is true, the addresses are those that the clients connect to.
+func (c *Cluster) Configuration(client bool) ([]backend.ReplicaInfo, error) {
+	replicas := make([]backend.ReplicaInfo, 0, len(c.Replicas))
+	for _, r := range c.Replicas {
+		b, err := c.readFile(r.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read public key of replica %d: %w", r.ID, err)
+		}
+		pubKey, err := keygen.ParsePublicKey(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key of replica %d: %w", r.ID, err)
+		}
+		addr := r.Address
+		if client {
+			addr = r.ClientAddress
+		}
+		replicas = append(replicas, backend.ReplicaInfo{
+			ID:      hotstuff.ID(r.ID),
+			Address: addr,
+			PubKey:  pubKey,
+		})
+	}
+	return replicas, nil
+}
+
+// ListenAddress returns the address to listen on for connections to the given address,
+// which is the port of the address on all interfaces.
+func ListenAddress(addr string) (string, error) {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", err
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return "", fmt.Errorf("invalid port in address %s", addr)
+	}
+	return net.JoinHostPort("", port), nil
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/crypto/keygen"
+	"github.com/relab/hotstuff/crypto/thresholdbls"
+)
+
+func generate(t *testing.T, crypto string, n int) (dir string, cluster *Cluster) {
+	t.Helper()
+	dir = t.TempDir()
+	c := Default()
+	c.Crypto = crypto
+	c.ViewTimeout = Duration{250 * time.Millisecond}
+	c.DataDir = "data"
+	if err := c.Generate(dir, n, []string{"localhost", "127.0.0.1"}, 10000, 20000); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "cluster.toml")
+	if err := c.Write(path); err != nil {
+		t.Fatal(err)
+	}
+	cluster, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, cluster
+}
+
+func TestGenerateAndRead(t *testing.T) {
+	dir, cluster := generate(t, "ecdsa", 4)
+
+	if cluster.Crypto != "ecdsa" || cluster.ViewTimeout.Duration != 250*time.Millisecond || !cluster.TLS {
+		t.Errorf("settings were not read back: %+v", cluster)
+	}
+	if want := filepath.Join(dir, "data"); cluster.DataDir != want {
+		t.Errorf("DataDir = %s, want %s", cluster.DataDir, want)
+	}
+	if len(cluster.Replicas) != 4 {
+		t.Fatalf("got %d replicas, want 4", len(cluster.Replicas))
+	}
+	if r := cluster.Replicas[1]; r.ID != 2 || r.Address != "127.0.0.1:10001" || r.ClientAddress != "127.0.0.1:20001" {
+		t.Errorf("unexpected replica entry: %+v", r)
+	}
+
+	opts, err := cluster.ReplicaOpts(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := keygen.ParsePrivateKey(opts.GetPrivateKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.GetInitialTimeout().AsDuration() != 250*time.Millisecond || len(opts.GetCertificate()) == 0 {
+		t.Errorf("unexpected replica options: %v", opts)
+	}
+
+	replicas, err := cluster.Configuration(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replicas) != 4 || replicas[2].Address != "localhost:20002" {
+		t.Fatalf("unexpected configuration: %v", replicas)
+	}
+	pub, err := keygen.PublicKeyToPEM(replicas[2].PubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := keygen.PublicKeyToPEM(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(pub) != string(want) {
+		t.Error("the public key does not match the private key of the replica")
+	}
+}
+
+func TestGenerateThresholdBLS(t *testing.T) {
+	_, cluster := generate(t, "threshold-bls", 4)
+
+	opts, err := cluster.ReplicaOpts(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := keygen.ParsePrivateKey(opts.GetPrivateKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, ok := key.Public().(*thresholdbls.PublicKey)
+	if !ok {
+		t.Fatalf("got key of type %T, want a threshold-bls key", key.Public())
+	}
+	if pub.Threshold() != hotstuff.QuorumSize(4) {
+		t.Errorf("threshold = %d, want %d", pub.Threshold(), hotstuff.QuorumSize(4))
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cluster.toml")
+	files := map[string]string{
+		"no replicas": "crypto = 'ecdsa'\n",
+		"duplicate":   "[[replicas]]\nid = 1\n[[replicas]]\nid = 1\n",
+		"zero ID":     "[[replicas]]\nid = 0\n",
+		"duration":    "view-timeout = 'soon'\n[[replicas]]\nid = 1\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Read(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
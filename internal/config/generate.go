@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/crypto/keygen"
+)
+
+// Generate adds n replicas to the configuration, and writes their keys and certificates to dir,
+// together with a new certificate authority. The replicas are assigned to the hosts in turn,
+// and the replica with ID i listens on port replicaPort+i-1 for replicas, and on port clientPort+i-1 for clients.
+// The paths in the configuration are relative to dir, so the configuration file should be written to dir.
+func (c *Cluster) Generate(dir string, n int, hosts []string, replicaPort, clientPort int) error {
+	if n < 1 {
+		return fmt.Errorf("invalid number of replicas: %d", n)
+	}
+	if len(hosts) == 0 {
+		hosts = []string{"localhost"}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	caKey, ca, err := keygen.GenerateCA()
+	if err != nil {
+		return err
+	}
+	c.CertificateAuthority = "ca.crt"
+	if err := os.WriteFile(filepath.Join(dir, c.CertificateAuthority), keygen.CertToPEM(ca), 0644); err != nil {
+		return err
+	}
+
+	ids := make([]hotstuff.ID, n)
+	for i := range ids {
+		ids[i] = hotstuff.ID(i + 1)
+	}
+	// the keys of some crypto implementations must be dealt to all replicas at once.
+	var dealtKeys map[hotstuff.ID]hotstuff.PrivateKey
+	if c.Crypto == "threshold-bls" {
+		dealtKeys, err = keygen.GeneratePrivateKeys(c.Crypto, ids)
+		if err != nil {
+			return fmt.Errorf("failed to deal keys: %w", err)
+		}
+	}
+
+	c.Replicas = make([]Replica, 0, n)
+	for i, id := range ids {
+		host := hosts[i%len(hosts)]
+
+		// the certificate should be valid for the host and its ip addresses.
+		validFor := []string{"localhost", "127.0.0.1", host}
+		if ips, err := net.LookupIP(host); err == nil {
+			for _, ip := range ips {
+				if ipStr := ip.String(); ipStr != host {
+					validFor = append(validFor, ipStr)
+				}
+			}
+		}
+
+		var keyChain keygen.KeyChain
+		if key, ok := dealtKeys[id]; ok {
+			keyChain, err = keygen.NewKeyChain(id, validFor, key, ca, caKey)
+		} else {
+			keyChain, err = keygen.GenerateKeyChain(id, validFor, c.Crypto, ca, caKey)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to generate keychain: %w", err)
+		}
+
+		name := "r" + strconv.Itoa(int(id))
+		r := Replica{
+			ID:             uint32(id),
+			Address:        net.JoinHostPort(host, strconv.Itoa(replicaPort+i)),
+			ClientAddress:  net.JoinHostPort(host, strconv.Itoa(clientPort+i)),
+			PublicKey:      name + ".key.pub",
+			PrivateKey:     name + ".key",
+			Certificate:    name + ".crt",
+			CertificateKey: name + ".crt.key",
+		}
+		files := []struct {
+			name string
+			data []byte
+			perm os.FileMode
+		}{
+			{r.PublicKey, keyChain.PublicKey, 0644},
+			{r.PrivateKey, keyChain.PrivateKey, 0600},
+			{r.Certificate, keyChain.Certificate, 0644},
+			{r.CertificateKey, keyChain.CertificateKey, 0600},
+		}
+		for _, f := range files {
+			if err := os.WriteFile(filepath.Join(dir, f.name), f.data, f.perm); err != nil {
+				return err
+			}
+		}
+		c.Replicas = append(c.Replicas, r)
+	}
+	c.dir = dir
+	return nil
+}
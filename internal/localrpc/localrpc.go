@@ -0,0 +1,60 @@
+// Package localrpc provides helpers for gRPC connections between processes on the same host,
+// such as a replica and the process that holds its private key.
+//
+// Addresses are paths to Unix sockets prefixed by "unix://". The connections are not encrypted or authenticated,
+// so only Unix sockets are supported, and the socket file is only accessible to the user that created it.
+package localrpc
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const unixPrefix = "unix://"
+
+// socketPath returns the path of the Unix socket in the address.
+func socketPath(address string) (string, error) {
+	path := strings.TrimPrefix(address, unixPrefix)
+	if path == address || path == "" {
+		return "", fmt.Errorf("localrpc: address must be a Unix socket prefixed by %q: %s", unixPrefix, address)
+	}
+	return path, nil
+}
+
+// Listen creates a listener for the given address.
+// The socket file is made accessible only to the current user.
+func Listen(address string) (net.Listener, error) {
+	path, err := socketPath(address)
+	if err != nil {
+		return nil, err
+	}
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		_ = lis.Close()
+		return nil, fmt.Errorf("localrpc: failed to restrict access to socket: %w", err)
+	}
+	return lis, nil
+}
+
+// Address returns the address of the listener in the format accepted by Listen and Dial.
+func Address(lis net.Listener) string {
+	return unixPrefix + lis.Addr().String()
+}
+
+// Dial creates a client connection to the given address.
+// The connection is established lazily, so the server does not need to be running yet.
+func Dial(address string) (*grpc.ClientConn, error) {
+	path, err := socketPath(address)
+	if err != nil {
+		return nil, err
+	}
+	return grpc.Dial("unix:"+path, grpc.WithTransportCredentials(insecure.NewCredentials()))
+}
@@ -51,6 +51,21 @@ func (mr *MockConfigurationMockRecorder) Fetch(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fetch", reflect.TypeOf((*MockConfiguration)(nil).Fetch), arg0, arg1)
 }
 
+// FetchRange mocks base method.
+func (m *MockConfiguration) FetchRange(arg0 context.Context, arg1, arg2 hotstuff.Hash) ([]*hotstuff.Block, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchRange", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*hotstuff.Block)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// FetchRange indicates an expected call of FetchRange.
+func (mr *MockConfigurationMockRecorder) FetchRange(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRange", reflect.TypeOf((*MockConfiguration)(nil).FetchRange), arg0, arg1, arg2)
+}
+
 // Len mocks base method.
 func (m *MockConfiguration) Len() int {
 	m.ctrl.T.Helper()
@@ -146,3 +161,15 @@ func (mr *MockConfigurationMockRecorder) Timeout(arg0 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Timeout", reflect.TypeOf((*MockConfiguration)(nil).Timeout), arg0)
 }
+
+// Vote mocks base method.
+func (m *MockConfiguration) Vote(arg0 hotstuff.PartialCert) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Vote", arg0)
+}
+
+// Vote indicates an expected call of Vote.
+func (mr *MockConfigurationMockRecorder) Vote(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vote", reflect.TypeOf((*MockConfiguration)(nil).Vote), arg0)
+}
@@ -121,6 +121,19 @@ func (e *Experiment) createReplicas() (cfg *orchestrationpb.ReplicaConfiguration
 
 	cfg = &orchestrationpb.ReplicaConfiguration{Replicas: make(map[uint32]*orchestrationpb.ReplicaInfo)}
 
+	// the keys of a threshold scheme are shares of a group key, so they must be dealt to all replicas at once.
+	var dealtKeys map[hotstuff.ID]hotstuff.PrivateKey
+	if e.Crypto == "threshold-bls" {
+		ids := make([]hotstuff.ID, 0, len(e.replicaOpts))
+		for id := range e.replicaOpts {
+			ids = append(ids, id)
+		}
+		dealtKeys, err = keygen.GeneratePrivateKeys(e.Crypto, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deal keys: %w", err)
+		}
+	}
+
 	for host, worker := range e.Hosts {
 		internalAddr := e.HostConfigs[host].InternalAddress
 
@@ -145,7 +158,12 @@ func (e *Experiment) createReplicas() (cfg *orchestrationpb.ReplicaConfiguration
 				validFor = append(validFor, internalAddr)
 			}
 
-			keyChain, err := keygen.GenerateKeyChain(id, validFor, e.Crypto, e.ca, e.caKey)
+			var keyChain keygen.KeyChain
+			if key, ok := dealtKeys[id]; ok {
+				keyChain, err = keygen.NewKeyChain(id, validFor, key, e.ca, e.caKey)
+			} else {
+				keyChain, err = keygen.GenerateKeyChain(id, validFor, e.Crypto, e.ca, e.caKey)
+			}
 			if err != nil {
 				return nil, fmt.Errorf("failed to generate keychain: %w", err)
 			}
@@ -333,6 +351,7 @@ func (e *Experiment) startReplicas(cfg *orchestrationpb.ReplicaConfiguration) (e
 
 func (e *Experiment) stopReplicas() error {
 	hashes := make(map[uint32][]byte)
+	committed := make(map[uint64][]byte) // the hash of the block that was committed at each height
 	for host, worker := range e.Hosts {
 		req := &orchestrationpb.StopReplicaRequest{IDs: getIDs(host, e.hostsToReplicas)}
 		res, err := worker.StopReplica(req)
@@ -342,6 +361,14 @@ func (e *Experiment) stopReplicas() error {
 		for id, hash := range res.GetHashes() {
 			hashes[id] = hash
 		}
+		for id, blocks := range res.GetCommitted() {
+			for height, hash := range blocks.GetHashes() {
+				if cmp, ok := committed[height]; ok && !bytes.Equal(cmp, hash) {
+					return fmt.Errorf("replica %d committed a conflicting block at height %d", id, height)
+				}
+				committed[height] = hash
+			}
+		}
 	}
 	var cmp []byte
 	for _, hash := range hashes {
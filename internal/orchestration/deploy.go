@@ -32,6 +32,7 @@ type DeployConfig struct {
 	Fgprof              bool
 	Metrics             []string
 	MeasurementInterval time.Duration
+	DataDir             string
 }
 
 // Deploy deploys the hotstuff binary to a group of servers and starts a worker on the given port.
@@ -220,6 +221,12 @@ func (w *workerSetup) Apply(ctx context.Context, host iago.Host) (err error) {
 	}
 	sb.WriteString("\" ")
 
+	if w.cfg.DataDir != "" {
+		sb.WriteString("--data-dir ")
+		sb.WriteString(w.cfg.DataDir)
+		sb.WriteString(" ")
+	}
+
 	if w.cfg.CPUProfiling {
 		sb.WriteString("--cpu-profile ")
 		sb.WriteString(path.Join(dir, "cpuprofile"))
@@ -23,11 +23,11 @@ import (
 )
 
 func TestOrchestration(t *testing.T) {
-	run := func(consensusImpl string, crypto string, mods []string) {
+	runExperiment := func(consensusImpl string, crypto string, mods []string, configure func(*orchestration.Experiment)) {
 		controllerStream, workerStream := net.Pipe()
 
 		workerProxy := orchestration.NewRemoteWorker(protostream.NewWriter(controllerStream), protostream.NewReader(controllerStream))
-		worker := orchestration.NewWorker(protostream.NewWriter(workerStream), protostream.NewReader(workerStream), metrics.NopLogger(), nil, 0)
+		worker := orchestration.NewWorker(protostream.NewWriter(workerStream), protostream.NewReader(workerStream), metrics.NopLogger(), nil, 0, "")
 
 		experiment := &orchestration.Experiment{
 			Logger:      logging.New("ctrl"),
@@ -55,6 +55,10 @@ func TestOrchestration(t *testing.T) {
 			Hosts:    map[string]orchestration.RemoteWorker{"127.0.0.1": workerProxy},
 		}
 
+		if configure != nil {
+			configure(experiment)
+		}
+
 		c := make(chan error)
 		go func() {
 			c <- worker.Run()
@@ -70,13 +74,29 @@ func TestOrchestration(t *testing.T) {
 			t.Fatal(err)
 		}
 	}
+	run := func(consensusImpl string, crypto string, mods []string) {
+		runExperiment(consensusImpl, crypto, mods, nil)
+	}
 
 	t.Run("ChainedHotStuff+ECDSA", func(t *testing.T) { run("chainedhotstuff", "ecdsa", nil) })
 	t.Run("ChainedHotStuff+BLS12", func(t *testing.T) { run("chainedhotstuff", "bls12", nil) })
+	// the Ed25519 keys are also used for the TLS certificates.
+	tls := func(e *orchestration.Experiment) { e.ReplicaOpts.UseTLS = true; e.ClientOpts.UseTLS = true }
+	t.Run("ChainedHotStuff+Ed25519+TLS", func(t *testing.T) { runExperiment("chainedhotstuff", "ed25519", nil, tls) })
 	t.Run("Fast-HotStuff+ECDSA", func(t *testing.T) { run("fasthotstuff", "ecdsa", nil) })
 	t.Run("Fast-HotStuff+BLS12", func(t *testing.T) { run("fasthotstuff", "bls12", nil) })
 	t.Run("Simple-HotStuff+ECDSA", func(t *testing.T) { run("simplehotstuff", "ecdsa", nil) })
 	t.Run("Simple-HotStuff+BLS12", func(t *testing.T) { run("simplehotstuff", "bls12", nil) })
+	t.Run("HotStuff-2+ECDSA", func(t *testing.T) { run("hotstuff2", "ecdsa", nil) })
+	t.Run("HotStuff-2+BLS12", func(t *testing.T) { run("hotstuff2", "bls12", nil) })
+	t.Run("Jolteon+ECDSA", func(t *testing.T) { run("jolteon", "ecdsa", nil) })
+	t.Run("Jolteon+BLS12", func(t *testing.T) { run("jolteon", "bls12", nil) })
+	t.Run("PBFT+ECDSA", func(t *testing.T) { run("pbft", "ecdsa", nil) })
+	t.Run("PBFT+BLS12", func(t *testing.T) { run("pbft", "bls12", nil) })
+	t.Run("Streamlet+ECDSA", func(t *testing.T) { run("streamlet", "ecdsa", nil) })
+	t.Run("Streamlet+BLS12", func(t *testing.T) { run("streamlet", "bls12", nil) })
+	t.Run("ChainedHotStuff+ThresholdBLS", func(t *testing.T) { run("chainedhotstuff", "threshold-bls", nil) })
+	t.Run("HotStuff-2+ThresholdBLS", func(t *testing.T) { run("hotstuff2", "threshold-bls", nil) })
 
 	// handel
 	mods := []string{"handel"}
@@ -86,6 +106,50 @@ func TestOrchestration(t *testing.T) {
 	t.Run("Fast-HotStuff+BLS12+Handel", func(t *testing.T) { run("fasthotstuff", "bls12", mods) })
 	t.Run("Simple-HotStuff+ECDSA+Handel", func(t *testing.T) { run("simplehotstuff", "ecdsa", mods) })
 	t.Run("Simple-HotStuff+BLS12+Handel", func(t *testing.T) { run("simplehotstuff", "bls12", mods) })
+
+	// mempool
+	mempool := func(e *orchestration.Experiment) { e.ReplicaOpts.Mempool = true }
+	t.Run("ChainedHotStuff+ECDSA+Mempool", func(t *testing.T) { runExperiment("chainedhotstuff", "ecdsa", nil, mempool) })
+	t.Run("ChainedHotStuff+BLS12+Mempool", func(t *testing.T) { runExperiment("chainedhotstuff", "bls12", nil, mempool) })
+	t.Run("PBFT+ECDSA+Mempool", func(t *testing.T) { runExperiment("pbft", "ecdsa", nil, mempool) })
+
+	// verification workers
+	verification := func(e *orchestration.Experiment) { e.ReplicaOpts.VerificationWorkers = 4 }
+	t.Run("ChainedHotStuff+ECDSA+Verification", func(t *testing.T) { runExperiment("chainedhotstuff", "ecdsa", nil, verification) })
+	t.Run("Jolteon+BLS12+Verification", func(t *testing.T) { runExperiment("jolteon", "bls12", nil, verification) })
+	t.Run("Fast-HotStuff+ECDSA+Verification", func(t *testing.T) { runExperiment("fasthotstuff", "ecdsa", nil, verification) })
+
+	// unpredictable leaders
+	vrf := func(e *orchestration.Experiment) { e.ReplicaOpts.LeaderRotation = "vrf" }
+	t.Run("ChainedHotStuff+BLS12+VRF", func(t *testing.T) { runExperiment("chainedhotstuff", "bls12", nil, vrf) })
+	t.Run("HotStuff-2+BLS12+VRF", func(t *testing.T) { runExperiment("hotstuff2", "bls12", nil, vrf) })
+
+	// checkpoints
+	checkpoints := func(e *orchestration.Experiment) { e.ReplicaOpts.CheckpointInterval = 10 }
+	t.Run("ChainedHotStuff+ECDSA+Checkpoints", func(t *testing.T) { runExperiment("chainedhotstuff", "ecdsa", nil, checkpoints) })
+	t.Run("ChainedHotStuff+BLS12+Checkpoints", func(t *testing.T) { runExperiment("chainedhotstuff", "bls12", nil, checkpoints) })
+	t.Run("PBFT+ECDSA+Mempool+Checkpoints", func(t *testing.T) {
+		runExperiment("pbft", "ecdsa", nil, func(e *orchestration.Experiment) { mempool(e); checkpoints(e) })
+	})
+
+	// key-value workload
+	kv := func(e *orchestration.Experiment) { e.ReplicaOpts.Workload = "kv"; e.ClientOpts.Workload = "kv" }
+	t.Run("ChainedHotStuff+ECDSA+KV", func(t *testing.T) { runExperiment("chainedhotstuff", "ecdsa", nil, kv) })
+	t.Run("ChainedHotStuff+ECDSA+KV+Checkpoints", func(t *testing.T) {
+		runExperiment("chainedhotstuff", "ecdsa", nil, func(e *orchestration.Experiment) { kv(e); checkpoints(e) })
+	})
+
+	// equivocating leader: the experiment fails if two replicas commit different blocks at the same height.
+	equivocate := func(e *orchestration.Experiment) { e.Byzantine = map[string]int{"equivocate": 1} }
+	t.Run("ChainedHotStuff+Equivocate", func(t *testing.T) { runExperiment("chainedhotstuff", "ecdsa", nil, equivocate) })
+	t.Run("Fast-HotStuff+Equivocate", func(t *testing.T) { runExperiment("fasthotstuff", "ecdsa", nil, equivocate) })
+	t.Run("Simple-HotStuff+Equivocate", func(t *testing.T) { runExperiment("simplehotstuff", "ecdsa", nil, equivocate) })
+	t.Run("HotStuff-2+Equivocate", func(t *testing.T) { runExperiment("hotstuff2", "ecdsa", nil, equivocate) })
+	t.Run("Jolteon+Equivocate", func(t *testing.T) { runExperiment("jolteon", "ecdsa", nil, equivocate) })
+	t.Run("Streamlet+Equivocate", func(t *testing.T) { runExperiment("streamlet", "ecdsa", nil, equivocate) })
+	t.Run("ChainedHotStuff+BLS12+VRF+Equivocate", func(t *testing.T) {
+		runExperiment("chainedhotstuff", "bls12", nil, func(e *orchestration.Experiment) { vrf(e); equivocate(e) })
+	})
 }
 
 func TestDeployment(t *testing.T) {
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"path/filepath"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/relab/gorums"
@@ -19,14 +21,19 @@ import (
 	"github.com/relab/hotstuff/consensus/byzantine"
 	"github.com/relab/hotstuff/crypto"
 	"github.com/relab/hotstuff/crypto/keygen"
+	"github.com/relab/hotstuff/crypto/signer"
 	"github.com/relab/hotstuff/eventloop"
 	"github.com/relab/hotstuff/internal/proto/orchestrationpb"
 	"github.com/relab/hotstuff/internal/protostream"
+	"github.com/relab/hotstuff/kvstore"
+	"github.com/relab/hotstuff/lightclient"
 	"github.com/relab/hotstuff/logging"
 	"github.com/relab/hotstuff/metrics"
 	"github.com/relab/hotstuff/metrics/types"
 	"github.com/relab/hotstuff/modules"
 	"github.com/relab/hotstuff/replica"
+	"github.com/relab/hotstuff/safetyrules"
+	"github.com/relab/hotstuff/safetystate"
 	"github.com/relab/hotstuff/synchronizer"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -36,13 +43,25 @@ import (
 	// imported modules
 	_ "github.com/relab/hotstuff/consensus/chainedhotstuff"
 	_ "github.com/relab/hotstuff/consensus/fasthotstuff"
+	_ "github.com/relab/hotstuff/consensus/hotstuff2"
+	_ "github.com/relab/hotstuff/consensus/jolteon"
+	_ "github.com/relab/hotstuff/consensus/pbft"
 	_ "github.com/relab/hotstuff/consensus/simplehotstuff"
+	_ "github.com/relab/hotstuff/consensus/streamlet"
 	_ "github.com/relab/hotstuff/crypto/bls12"
 	_ "github.com/relab/hotstuff/crypto/ecdsa"
+	_ "github.com/relab/hotstuff/crypto/ed25519"
+	_ "github.com/relab/hotstuff/crypto/thresholdbls"
 	_ "github.com/relab/hotstuff/handel"
 	_ "github.com/relab/hotstuff/leaderrotation"
 )
 
+// The parameters of the key-value workload.
+const (
+	kvKeys      = 1000
+	kvReadRatio = 0.5
+)
+
 // Worker starts and runs clients and replicas based on commands from the controller.
 type Worker struct {
 	send *protostream.Writer
@@ -51,9 +70,38 @@ type Worker struct {
 	metricsLogger       metrics.Logger
 	metrics             []string
 	measurementInterval time.Duration
+	dataDir             string // if not empty, replicas store their blocks and safety state here
+
+	replicas  map[hotstuff.ID]*replica.Replica
+	committed map[hotstuff.ID]*committedBlocks
+	clients   map[hotstuff.ID]*client.Client
+}
+
+// committedBlocks records the hashes of the blocks that a replica commits, by height,
+// such that the controller can check that the replicas commit the same blocks.
+type committedBlocks struct {
+	mut    sync.Mutex
+	hashes map[uint64][]byte
+}
+
+func (c *committedBlocks) onCommit(event hotstuff.CommitEvent) {
+	if event.Block == nil {
+		return
+	}
+	hash := event.Block.Hash()
+	c.mut.Lock()
+	c.hashes[event.Height] = hash[:]
+	c.mut.Unlock()
+}
 
-	replicas map[hotstuff.ID]*replica.Replica
-	clients  map[hotstuff.ID]*client.Client
+func (c *committedBlocks) toProto() *orchestrationpb.CommittedBlocks {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	hashes := make(map[uint64][]byte, len(c.hashes))
+	for height, hash := range c.hashes {
+		hashes[height] = hash
+	}
+	return &orchestrationpb.CommittedBlocks{Hashes: hashes}
 }
 
 // Run runs the worker until it receives a command to quit.
@@ -93,18 +141,29 @@ func (w *Worker) Run() error {
 }
 
 // NewWorker returns a new worker.
-func NewWorker(send *protostream.Writer, recv *protostream.Reader, dl metrics.Logger, metrics []string, measurementInterval time.Duration) Worker {
+// If dataDir is not empty, the replicas started by the worker will persist their state in subdirectories of dataDir,
+// and will reload that state if they are started again.
+func NewWorker(send *protostream.Writer, recv *protostream.Reader, dl metrics.Logger, metrics []string, measurementInterval time.Duration, dataDir string) Worker {
 	return Worker{
 		send:                send,
 		recv:                recv,
 		metricsLogger:       dl,
 		metrics:             metrics,
 		measurementInterval: measurementInterval,
+		dataDir:             dataDir,
 		replicas:            make(map[hotstuff.ID]*replica.Replica),
+		committed:           make(map[hotstuff.ID]*committedBlocks),
 		clients:             make(map[hotstuff.ID]*client.Client),
 	}
 }
 
+// NewReplica creates a replica with the given options without a controller, such that it can run as a service.
+// If dataDir is not empty, the replica persists its state in a subdirectory of dataDir.
+func NewReplica(opts *orchestrationpb.ReplicaOpts, dataDir string) (*replica.Replica, error) {
+	w := NewWorker(nil, nil, metrics.NopLogger(), nil, 0, dataDir)
+	return w.createReplica(opts)
+}
+
 func (w *Worker) createReplicas(req *orchestrationpb.CreateReplicaRequest) (*orchestrationpb.CreateReplicaResponse, error) {
 	resp := &orchestrationpb.CreateReplicaResponse{Replicas: make(map[uint32]*orchestrationpb.ReplicaInfo)}
 	for _, cfg := range req.GetReplicas() {
@@ -131,8 +190,16 @@ func (w *Worker) createReplicas(req *orchestrationpb.CreateReplicaRequest) (*orc
 			return nil, err
 		}
 
+		committed := &committedBlocks{hashes: make(map[uint64][]byte)}
+		var eventLoop *eventloop.EventLoop
+		r.Modules().Get(&eventLoop)
+		eventLoop.RegisterObserver(hotstuff.CommitEvent{}, func(event any) {
+			committed.onCommit(event.(hotstuff.CommitEvent))
+		})
+
 		r.StartServers(replicaListener, clientListener)
 		w.replicas[hotstuff.ID(cfg.GetID())] = r
+		w.committed[hotstuff.ID(cfg.GetID())] = committed
 
 		resp.Replicas[cfg.GetID()] = &orchestrationpb.ReplicaInfo{
 			ID:          cfg.GetID(),
@@ -144,13 +211,35 @@ func (w *Worker) createReplicas(req *orchestrationpb.CreateReplicaRequest) (*orc
 	return resp, nil
 }
 
+// checkRemoteSafetyRules returns an error if the replica needs to sign anything other than votes and timeouts,
+// as only those are signed by the safety rules server when the replica does not have its private key.
+func checkRemoteSafetyRules(opts *orchestrationpb.ReplicaOpts) error {
+	if _, ok := modules.GetModule[consensus.Rules](opts.GetConsensus()); !ok {
+		return fmt.Errorf("consensus '%s' does not support remote safety rules", opts.GetConsensus())
+	}
+	if opts.GetMempool() || opts.GetCheckpointInterval() > 0 || opts.GetLeaderRotation() == "vrf" {
+		return fmt.Errorf("the mempool, checkpoints, and vrf leader rotation require a signer when using remote safety rules")
+	}
+	return nil
+}
+
 func (w *Worker) createReplica(opts *orchestrationpb.ReplicaOpts) (*replica.Replica, error) {
 	w.metricsLogger.Log(opts)
 
-	// get private key and certificates
-	privKey, err := keygen.ParsePrivateKey(opts.GetPrivateKey())
-	if err != nil {
-		return nil, err
+	// get private key and certificates.
+	// The key is not loaded if it is held by a signer or a safety rules server.
+	var privKey hotstuff.PrivateKey
+	var err error
+	if opts.GetSigner() == "" && opts.GetSafetyRules() == "" {
+		privKey, err = keygen.ParsePrivateKey(opts.GetPrivateKey())
+		if err != nil {
+			return nil, err
+		}
+	}
+	if privKey == nil && opts.GetSigner() == "" {
+		if err := checkRemoteSafetyRules(opts); err != nil {
+			return nil, err
+		}
 	}
 	var certificate tls.Certificate
 	var rootCAs *x509.CertPool
@@ -165,48 +254,97 @@ func (w *Worker) createReplica(opts *orchestrationpb.ReplicaOpts) (*replica.Repl
 	// prepare modules
 	builder := modules.NewBuilder(hotstuff.ID(opts.GetID()), privKey)
 
-	consensusRules, ok := modules.GetModule[consensus.Rules](opts.GetConsensus())
-	if !ok {
-		return nil, fmt.Errorf("invalid consensus name: '%s'", opts.GetConsensus())
-	}
-
-	if opts.GetByzantineStrategy() != "" {
-		if byz, ok := modules.GetModule[byzantine.Byzantine](opts.GetByzantineStrategy()); ok {
-			consensusRules = byz.Wrap(consensusRules)
-		} else {
-			return nil, fmt.Errorf("invalid byzantine strategy: '%s'", opts.GetByzantineStrategy())
-		}
-	}
-
 	cryptoImpl, ok := modules.GetModule[modules.CryptoBase](opts.GetCrypto())
 	if !ok {
 		return nil, fmt.Errorf("invalid crypto name: '%s'", opts.GetCrypto())
 	}
+	if addr := opts.GetSigner(); addr != "" {
+		// the signer only signs votes and timeouts that it can decode, which are signed by the safety rules module.
+		if _, ok := modules.GetModule[consensus.Rules](opts.GetConsensus()); !ok {
+			return nil, fmt.Errorf("consensus '%s' does not support a signer", opts.GetConsensus())
+		}
+		cryptoImpl, err = signer.New(addr, cryptoImpl)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	leaderRotation, ok := modules.GetModule[modules.LeaderRotation](opts.GetLeaderRotation())
 	if !ok {
 		return nil, fmt.Errorf("invalid leader-rotation algorithm: '%s'", opts.GetLeaderRotation())
 	}
+	_, isElection := leaderRotation.(modules.LeaderElection)
+	if signer, ok := cryptoImpl.(modules.UniqueSigner); isElection && (!ok || !signer.UniqueSignatures()) {
+		return nil, fmt.Errorf("leader-rotation '%s' requires crypto with unique signatures, such as bls12", opts.GetLeaderRotation())
+	}
 
-	sync := synchronizer.New(synchronizer.NewViewDuration(
+	viewDuration := synchronizer.NewViewDuration(
 		uint64(opts.GetTimeoutSamples()),
 		float64(opts.GetInitialTimeout().AsDuration().Nanoseconds())/float64(time.Millisecond),
 		float64(opts.GetMaxTimeout().AsDuration().Nanoseconds())/float64(time.Millisecond),
 		float64(opts.GetTimeoutMultiplier()),
-	))
+	)
+
+	if consensusRules, ok := modules.GetModule[consensus.Rules](opts.GetConsensus()); ok {
+		if opts.GetByzantineStrategy() != "" {
+			if byz, ok := modules.GetModule[byzantine.Byzantine](opts.GetByzantineStrategy()); ok {
+				consensusRules = byz.Wrap(consensusRules)
+			} else {
+				return nil, fmt.Errorf("invalid byzantine strategy: '%s'", opts.GetByzantineStrategy())
+			}
+		}
+		safetyRules := safetyrules.New()
+		if addr := opts.GetSafetyRules(); addr != "" {
+			safetyRules, err = safetyrules.NewRemote(addr)
+			if err != nil {
+				return nil, err
+			}
+		}
+		builder.Add(
+			consensus.New(consensusRules),
+			consensus.NewVotingMachine(),
+			safetyRules,
+			synchronizer.New(viewDuration),
+		)
+	} else if cs, ok := modules.GetModule[modules.Consensus](opts.GetConsensus()); ok {
+		// protocols such as PBFT implement their own synchronizer and message handling,
+		// so they are added as a single module that uses the view duration directly.
+		if opts.GetByzantineStrategy() != "" {
+			return nil, fmt.Errorf("byzantine strategies are not supported by consensus '%s'", opts.GetConsensus())
+		}
+		// the leaders must attach proofs to their proposals, which only the consensus.Rules protocols do.
+		if isElection {
+			return nil, fmt.Errorf("leader-rotation '%s' is not supported by consensus '%s'", opts.GetLeaderRotation(), opts.GetConsensus())
+		}
+		builder.Add(cs, viewDuration)
+	} else {
+		return nil, fmt.Errorf("invalid consensus name: '%s'", opts.GetConsensus())
+	}
 
 	builder.Add(
 		eventloop.New(1000),
-		consensus.New(consensusRules),
-		consensus.NewVotingMachine(),
 		crypto.NewCache(cryptoImpl, 100), // TODO: consider making this configurable
 		leaderRotation,
-		sync,
 		w.metricsLogger,
-		blockchain.New(),
 		logging.New("hs"+strconv.Itoa(int(opts.GetID()))),
 	)
 
+	if w.dataDir != "" {
+		dir := filepath.Join(w.dataDir, "replica"+strconv.Itoa(int(opts.GetID())))
+		chain, err := blockchain.NewPersistent(filepath.Join(dir, "blocks"))
+		if err != nil {
+			return nil, err
+		}
+		safetyState, err := safetystate.New(filepath.Join(dir, "state"), hotstuff.ID(opts.GetID()))
+		if err != nil {
+			_ = chain.(io.Closer).Close()
+			return nil, err
+		}
+		builder.Add(chain, safetyState)
+	} else {
+		builder.Add(blockchain.New())
+	}
+
 	builder.Options().SetSharedRandomSeed(opts.GetSharedSeed())
 
 	if w.measurementInterval > 0 {
@@ -223,20 +361,41 @@ func (w *Worker) createReplica(opts *orchestrationpb.ReplicaOpts) (*replica.Repl
 		builder.Add(m)
 	}
 
+	var store *kvstore.Store
+	switch opts.GetWorkload() {
+	case "":
+	case "kv":
+		store = kvstore.New()
+	default:
+		return nil, fmt.Errorf("invalid workload: '%s'", opts.GetWorkload())
+	}
+
 	c := replica.Config{
-		ID:          hotstuff.ID(opts.GetID()),
-		PrivateKey:  privKey,
-		TLS:         opts.GetUseTLS(),
-		Certificate: &certificate,
-		RootCAs:     rootCAs,
-		BatchSize:   opts.GetBatchSize(),
+		ID:                  hotstuff.ID(opts.GetID()),
+		PrivateKey:          privKey,
+		TLS:                 opts.GetUseTLS(),
+		Certificate:         &certificate,
+		RootCAs:             rootCAs,
+		BatchSize:           opts.GetBatchSize(),
+		Mempool:             opts.GetMempool(),
+		CheckpointInterval:  opts.GetCheckpointInterval(),
+		VerificationWorkers: int(opts.GetVerificationWorkers()),
 		ManagerOptions: []gorums.ManagerOption{
 			gorums.WithDialTimeout(opts.GetConnectTimeout().AsDuration()),
 			gorums.WithGrpcDialOptions(grpc.WithReturnConnectionError()),
 		},
 	}
 
-	return replica.New(c, builder), nil
+	if store != nil {
+		c.StateMachine = store
+	}
+
+	r := replica.New(c, builder)
+	lightclient.Register(r)
+	if store != nil {
+		kvstore.Register(r, store)
+	}
+	return r, nil
 }
 
 func (w *Worker) startReplicas(req *orchestrationpb.StartReplicaRequest) (*orchestrationpb.StartReplicaResponse, error) {
@@ -264,7 +423,8 @@ func (w *Worker) startReplicas(req *orchestrationpb.StartReplicaRequest) (*orche
 
 func (w *Worker) stopReplicas(req *orchestrationpb.StopReplicaRequest) (*orchestrationpb.StopReplicaResponse, error) {
 	res := &orchestrationpb.StopReplicaResponse{
-		Hashes: make(map[uint32][]byte),
+		Hashes:    make(map[uint32][]byte),
+		Committed: make(map[uint32]*orchestrationpb.CommittedBlocks),
 	}
 	for _, id := range req.GetIDs() {
 		r, ok := w.replicas[hotstuff.ID(id)]
@@ -273,7 +433,7 @@ func (w *Worker) stopReplicas(req *orchestrationpb.StopReplicaRequest) (*orchest
 		}
 		r.Stop()
 		res.Hashes[id] = r.GetHash()
-		// TODO: return test results
+		res.Committed[id] = w.committed[hotstuff.ID(id)].toProto()
 	}
 	return res, nil
 }
@@ -285,12 +445,25 @@ func (w *Worker) startClients(req *orchestrationpb.StartClientRequest) (*orchest
 	for _, opts := range req.GetClients() {
 		w.metricsLogger.Log(opts)
 
+		var input io.ReadCloser = io.NopCloser(rand.Reader)
+		payloadSize := opts.GetPayloadSize()
+		switch opts.GetWorkload() {
+		case "":
+		case "kv":
+			input = kvstore.NewWorkload(kvKeys, kvReadRatio, int64(opts.GetID()))
+			if payloadSize < kvstore.MinPayloadSize {
+				payloadSize = kvstore.MinPayloadSize
+			}
+		default:
+			return nil, fmt.Errorf("invalid workload: '%s'", opts.GetWorkload())
+		}
+
 		c := client.Config{
 			TLS:           opts.GetUseTLS(),
 			RootCAs:       cp,
 			MaxConcurrent: opts.GetMaxConcurrent(),
-			PayloadSize:   opts.GetPayloadSize(),
-			Input:         io.NopCloser(rand.Reader),
+			PayloadSize:   payloadSize,
+			Input:         input,
 			ManagerOptions: []gorums.ManagerOption{
 				gorums.WithDialTimeout(opts.GetConnectTimeout().AsDuration()),
 				gorums.WithGrpcDialOptions(grpc.WithReturnConnectionError()),
@@ -0,0 +1,259 @@
+// Code generated by protoc-gen-gorums. DO NOT EDIT.
+// versions:
+// 	protoc-gen-gorums v0.7.0-devel
+// 	protoc            v3.19.4
+// source: internal/proto/checkpointpb/checkpoint.proto
+
+package checkpointpb
+
+import (
+	context "context"
+	fmt "fmt"
+	gorums "github.com/relab/gorums"
+	hotstuffpb "github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	encoding "google.golang.org/grpc/encoding"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = gorums.EnforceVersion(7 - gorums.MinVersion)
+	// Verify that the gorums runtime is sufficiently up-to-date.
+	_ = gorums.EnforceVersion(gorums.MaxVersion - 7)
+)
+
+// A Configuration represents a static set of nodes on which quorum remote
+// procedure calls may be invoked.
+type Configuration struct {
+	gorums.RawConfiguration
+	nodes []*Node
+	qspec QuorumSpec
+}
+
+// ConfigurationFromRaw returns a new Configuration from the given raw configuration and QuorumSpec.
+//
+// This function may for example be used to "clone" a configuration but install a different QuorumSpec:
+//  cfg1, err := mgr.NewConfiguration(qspec1, opts...)
+//  cfg2 := ConfigurationFromRaw(cfg1.RawConfig, qspec2)
+func ConfigurationFromRaw(rawCfg gorums.RawConfiguration, qspec QuorumSpec) *Configuration {
+	// return an error if the QuorumSpec interface is not empty and no implementation was provided.
+	var test interface{} = struct{}{}
+	if _, empty := test.(QuorumSpec); !empty && qspec == nil {
+		panic("QuorumSpec may not be nil")
+	}
+	return &Configuration{
+		RawConfiguration: rawCfg,
+		qspec:            qspec,
+	}
+}
+
+// Nodes returns a slice of each available node. IDs are returned in the same
+// order as they were provided in the creation of the Manager.
+//
+// NOTE: mutating the returned slice is not supported.
+func (c *Configuration) Nodes() []*Node {
+	if c.nodes == nil {
+		c.nodes = make([]*Node, 0, c.Size())
+		for _, n := range c.RawConfiguration {
+			c.nodes = append(c.nodes, &Node{n})
+		}
+	}
+	return c.nodes
+}
+
+// And returns a NodeListOption that can be used to create a new configuration combining c and d.
+func (c Configuration) And(d *Configuration) gorums.NodeListOption {
+	return c.RawConfiguration.And(d.RawConfiguration)
+}
+
+// Except returns a NodeListOption that can be used to create a new configuration
+// from c without the nodes in rm.
+func (c Configuration) Except(rm *Configuration) gorums.NodeListOption {
+	return c.RawConfiguration.Except(rm.RawConfiguration)
+}
+
+func init() {
+	if encoding.GetCodec(gorums.ContentSubtype) == nil {
+		encoding.RegisterCodec(gorums.NewCodec())
+	}
+}
+
+// Manager maintains a connection pool of nodes on
+// which quorum calls can be performed.
+type Manager struct {
+	*gorums.RawManager
+}
+
+// NewManager returns a new Manager for managing connection to nodes added
+// to the manager. This function accepts manager options used to configure
+// various aspects of the manager.
+func NewManager(opts ...gorums.ManagerOption) (mgr *Manager) {
+	mgr = &Manager{}
+	mgr.RawManager = gorums.NewRawManager(opts...)
+	return mgr
+}
+
+// NewConfiguration returns a configuration based on the provided list of nodes (required)
+// and an optional quorum specification. The QuorumSpec is necessary for call types that
+// must process replies. For configurations only used for unicast or multicast call types,
+// a QuorumSpec is not needed. The QuorumSpec interface is also a ConfigOption.
+// Nodes can be supplied using WithNodeMap or WithNodeList, or WithNodeIDs.
+// A new configuration can also be created from an existing configuration,
+// using the And, WithNewNodes, Except, and WithoutNodes methods.
+func (m *Manager) NewConfiguration(opts ...gorums.ConfigOption) (c *Configuration, err error) {
+	if len(opts) < 1 || len(opts) > 2 {
+		return nil, fmt.Errorf("wrong number of options: %d", len(opts))
+	}
+	c = &Configuration{}
+	for _, opt := range opts {
+		switch v := opt.(type) {
+		case gorums.NodeListOption:
+			c.RawConfiguration, err = gorums.NewRawConfiguration(m.RawManager, v)
+			if err != nil {
+				return nil, err
+			}
+		case QuorumSpec:
+			// Must be last since v may match QuorumSpec if it is interface{}
+			c.qspec = v
+		default:
+			return nil, fmt.Errorf("unknown option type: %v", v)
+		}
+	}
+	// return an error if the QuorumSpec interface is not empty and no implementation was provided.
+	var test interface{} = struct{}{}
+	if _, empty := test.(QuorumSpec); !empty && c.qspec == nil {
+		return nil, fmt.Errorf("missing required QuorumSpec")
+	}
+	return c, nil
+}
+
+// Nodes returns a slice of available nodes on this manager.
+// IDs are returned in the order they were added at creation of the manager.
+func (m *Manager) Nodes() []*Node {
+	gorumsNodes := m.RawManager.Nodes()
+	nodes := make([]*Node, 0, len(gorumsNodes))
+	for _, n := range gorumsNodes {
+		nodes = append(nodes, &Node{n})
+	}
+	return nodes
+}
+
+// Node encapsulates the state of a node on which a remote procedure call
+// can be performed.
+type Node struct {
+	*gorums.RawNode
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ emptypb.Empty
+
+// Vote sends a signed checkpoint to all replicas.
+func (c *Configuration) Vote(ctx context.Context, in *CheckpointVote, opts ...gorums.CallOption) {
+	cd := gorums.QuorumCallData{
+		Message: in,
+		Method:  "checkpointpb.Checkpoint.Vote",
+	}
+
+	c.RawConfiguration.Multicast(ctx, cd, opts...)
+}
+
+// QuorumSpec is the interface of quorum functions for Checkpoint.
+type QuorumSpec interface {
+	gorums.ConfigOption
+
+	// LatestQF is the quorum function for the Latest
+	// quorum call method. The in parameter is the request object
+	// supplied to the Latest method at call time, and may or may not
+	// be used by the quorum function. If the in parameter is not needed
+	// you should implement your quorum function with '_ *LatestRequest'.
+	LatestQF(in *LatestRequest, replies map[uint32]*hotstuffpb.CheckpointCert) (*hotstuffpb.CheckpointCert, bool)
+
+	// SnapshotQF is the quorum function for the Snapshot
+	// quorum call method. The in parameter is the request object
+	// supplied to the Snapshot method at call time, and may or may not
+	// be used by the quorum function. If the in parameter is not needed
+	// you should implement your quorum function with '_ *SnapshotRequest'.
+	SnapshotQF(in *SnapshotRequest, replies map[uint32]*StateSnapshot) (*StateSnapshot, bool)
+}
+
+// Latest requests the latest checkpoint certificate of the replicas.
+func (c *Configuration) Latest(ctx context.Context, in *LatestRequest) (resp *hotstuffpb.CheckpointCert, err error) {
+	cd := gorums.QuorumCallData{
+		Message: in,
+		Method:  "checkpointpb.Checkpoint.Latest",
+	}
+	cd.QuorumFunction = func(req protoreflect.ProtoMessage, replies map[uint32]protoreflect.ProtoMessage) (protoreflect.ProtoMessage, bool) {
+		r := make(map[uint32]*hotstuffpb.CheckpointCert, len(replies))
+		for k, v := range replies {
+			r[k] = v.(*hotstuffpb.CheckpointCert)
+		}
+		return c.qspec.LatestQF(req.(*LatestRequest), r)
+	}
+
+	res, err := c.RawConfiguration.QuorumCall(ctx, cd)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*hotstuffpb.CheckpointCert), err
+}
+
+// Snapshot requests the snapshot of the application state at a checkpoint.
+func (c *Configuration) Snapshot(ctx context.Context, in *SnapshotRequest) (resp *StateSnapshot, err error) {
+	cd := gorums.QuorumCallData{
+		Message: in,
+		Method:  "checkpointpb.Checkpoint.Snapshot",
+	}
+	cd.QuorumFunction = func(req protoreflect.ProtoMessage, replies map[uint32]protoreflect.ProtoMessage) (protoreflect.ProtoMessage, bool) {
+		r := make(map[uint32]*StateSnapshot, len(replies))
+		for k, v := range replies {
+			r[k] = v.(*StateSnapshot)
+		}
+		return c.qspec.SnapshotQF(req.(*SnapshotRequest), r)
+	}
+
+	res, err := c.RawConfiguration.QuorumCall(ctx, cd)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*StateSnapshot), err
+}
+
+// Checkpoint is the server-side API for the Checkpoint Service
+type Checkpoint interface {
+	Vote(ctx gorums.ServerCtx, request *CheckpointVote)
+	Latest(ctx gorums.ServerCtx, request *LatestRequest) (response *hotstuffpb.CheckpointCert, err error)
+	Snapshot(ctx gorums.ServerCtx, request *SnapshotRequest) (response *StateSnapshot, err error)
+}
+
+func RegisterCheckpointServer(srv *gorums.Server, impl Checkpoint) {
+	srv.RegisterHandler("checkpointpb.Checkpoint.Vote", func(ctx gorums.ServerCtx, in *gorums.Message, _ chan<- *gorums.Message) {
+		req := in.Message.(*CheckpointVote)
+		defer ctx.Release()
+		impl.Vote(ctx, req)
+	})
+	srv.RegisterHandler("checkpointpb.Checkpoint.Latest", func(ctx gorums.ServerCtx, in *gorums.Message, finished chan<- *gorums.Message) {
+		req := in.Message.(*LatestRequest)
+		defer ctx.Release()
+		resp, err := impl.Latest(ctx, req)
+		gorums.SendMessage(ctx, finished, gorums.WrapMessage(in.Metadata, resp, err))
+	})
+	srv.RegisterHandler("checkpointpb.Checkpoint.Snapshot", func(ctx gorums.ServerCtx, in *gorums.Message, finished chan<- *gorums.Message) {
+		req := in.Message.(*SnapshotRequest)
+		defer ctx.Release()
+		resp, err := impl.Snapshot(ctx, req)
+		gorums.SendMessage(ctx, finished, gorums.WrapMessage(in.Metadata, resp, err))
+	})
+}
+
+type internalCheckpointCert struct {
+	nid   uint32
+	reply *hotstuffpb.CheckpointCert
+	err   error
+}
+
+type internalStateSnapshot struct {
+	nid   uint32
+	reply *StateSnapshot
+	err   error
+}
@@ -0,0 +1,72 @@
+package clientpb
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/relab/hotstuff"
+)
+
+// SignedBytes returns the part of the reconfiguration that is signed by the operator.
+func (r *Reconfiguration) SignedBytes() []byte {
+	b := []byte("hotstuff-reconfiguration")
+	b = appendUint64(b, r.GetEpoch())
+	b = appendUint64(b, r.GetSequence())
+	b = appendUint32(b, uint32(len(r.GetAdd())))
+	for _, replica := range r.GetAdd() {
+		b = appendUint32(b, replica.GetID())
+		b = appendUint32(b, uint32(len(replica.GetAddress())))
+		b = append(b, replica.GetAddress()...)
+		b = appendUint32(b, uint32(len(replica.GetPublicKey())))
+		b = append(b, replica.GetPublicKey()...)
+	}
+	b = appendUint32(b, uint32(len(r.GetRemove())))
+	for _, id := range r.GetRemove() {
+		b = appendUint32(b, id)
+	}
+	return b
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+// Sign signs the reconfiguration with the operator's private key, which must be an ECDSA or Ed25519 key.
+func (r *Reconfiguration) Sign(key hotstuff.PrivateKey) (err error) {
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		hash := sha256.Sum256(r.SignedBytes())
+		r.Signature, err = ecdsa.SignASN1(rand.Reader, k, hash[:])
+		return err
+	case ed25519.PrivateKey:
+		r.Signature = ed25519.Sign(k, r.SignedBytes())
+		return nil
+	default:
+		return fmt.Errorf("unsupported operator key type: %T", key)
+	}
+}
+
+// Verify returns true if the reconfiguration was signed with the private key of the operator's public key.
+func (r *Reconfiguration) Verify(key hotstuff.PublicKey) bool {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		hash := sha256.Sum256(r.SignedBytes())
+		return ecdsa.VerifyASN1(k, hash[:], r.GetSignature())
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, r.SignedBytes(), r.GetSignature())
+	default:
+		return false
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/relab/hotstuff/crypto"
 	"github.com/relab/hotstuff/crypto/bls12"
 	"github.com/relab/hotstuff/crypto/ecdsa"
+	"github.com/relab/hotstuff/crypto/ed25519"
+	"github.com/relab/hotstuff/crypto/thresholdbls"
 )
 
 // QuorumSignatureToProto converts a threshold signature to a protocol buffers message.
@@ -25,11 +27,38 @@ func QuorumSignatureToProto(sig hotstuff.QuorumSignature) *QuorumSignature {
 		signature.Sig = &QuorumSignature_ECDSASigs{ECDSASigs: &ECDSAMultiSignature{
 			Sigs: sigs,
 		}}
+	case ed25519.MultiSignature:
+		sigs := make([]*ED25519Signature, 0, len(s))
+		for _, p := range s {
+			sigs = append(sigs, &ED25519Signature{
+				Signer: uint32(p.Signer()),
+				Sig:    p.ToBytes(),
+			})
+		}
+		signature.Sig = &QuorumSignature_ED25519Sigs{ED25519Sigs: &ED25519MultiSignature{
+			Sigs: sigs,
+		}}
 	case *bls12.AggregateSignature:
 		signature.Sig = &QuorumSignature_BLS12Sig{BLS12Sig: &BLS12AggregateSignature{
 			Sig:          s.ToBytes(),
 			Participants: s.Bitfield().Bytes(),
 		}}
+	case thresholdbls.Shares:
+		shares := make([]*ThresholdBLSShare, 0, len(s))
+		for _, share := range s {
+			shares = append(shares, &ThresholdBLSShare{
+				Signer: uint32(share.Signer()),
+				Sig:    share.ToBytes(),
+			})
+		}
+		signature.Sig = &QuorumSignature_ThresholdBLSShares{ThresholdBLSShares: &ThresholdBLSShares{
+			Shares: shares,
+		}}
+	case *thresholdbls.GroupSignature:
+		signature.Sig = &QuorumSignature_ThresholdBLSGroupSig{ThresholdBLSGroupSig: &ThresholdBLSGroupSignature{
+			Sig:       s.ToBytes(),
+			Threshold: uint32(s.Threshold()),
+		}}
 	}
 	return signature
 }
@@ -47,6 +76,13 @@ func QuorumSignatureFromProto(sig *QuorumSignature) hotstuff.QuorumSignature {
 		}
 		return ecdsa.RestoreMultiSignature(sigs)
 	}
+	if signature := sig.GetED25519Sigs(); signature != nil {
+		sigs := make([]*ed25519.Signature, len(signature.GetSigs()))
+		for i, sig := range signature.GetSigs() {
+			sigs[i] = ed25519.RestoreSignature(sig.GetSig(), hotstuff.ID(sig.GetSigner()))
+		}
+		return ed25519.RestoreMultiSignature(sigs)
+	}
 	if signature := sig.GetBLS12Sig(); signature != nil {
 		aggSig, err := bls12.RestoreAggregateSignature(signature.GetSig(), crypto.BitfieldFromBytes(signature.GetParticipants()))
 		if err != nil {
@@ -54,6 +90,41 @@ func QuorumSignatureFromProto(sig *QuorumSignature) hotstuff.QuorumSignature {
 		}
 		return aggSig
 	}
+	if signature := sig.GetThresholdBLSShares(); signature != nil {
+		shares := make([]*thresholdbls.Share, len(signature.GetShares()))
+		for i, share := range signature.GetShares() {
+			var err error
+			shares[i], err = thresholdbls.RestoreShare(share.GetSig(), hotstuff.ID(share.GetSigner()))
+			if err != nil {
+				return nil
+			}
+		}
+		return thresholdbls.RestoreShares(shares)
+	}
+	if signature := sig.GetThresholdBLSGroupSig(); signature != nil {
+		groupSig, err := thresholdbls.RestoreGroupSignature(signature.GetSig(), int(signature.GetThreshold()))
+		if err != nil {
+			return nil
+		}
+		return groupSig
+	}
+	return nil
+}
+
+// ED25519SignatureToProto converts a single Ed25519 signature to a protocol buffers message.
+func ED25519SignatureToProto(sig *ed25519.Signature) *Signature {
+	return &Signature{Sig: &Signature_ED25519Sig{ED25519Sig: &ED25519Signature{
+		Signer: uint32(sig.Signer()),
+		Sig:    sig.ToBytes(),
+	}}}
+}
+
+// ED25519SignatureFromProto converts a protocol buffers message to a single Ed25519 signature.
+// It returns nil if the message does not hold an Ed25519 signature.
+func ED25519SignatureFromProto(sig *Signature) *ed25519.Signature {
+	if signature := sig.GetED25519Sig(); signature != nil {
+		return ed25519.RestoreSignature(signature.GetSig(), hotstuff.ID(signature.GetSigner()))
+	}
 	return nil
 }
 
@@ -77,9 +148,10 @@ func PartialCertFromProto(cert *PartialCert) hotstuff.PartialCert {
 func QuorumCertToProto(qc hotstuff.QuorumCert) *QuorumCert {
 	hash := qc.BlockHash()
 	return &QuorumCert{
-		Sig:  QuorumSignatureToProto(qc.Signature()),
-		Hash: hash[:],
-		View: uint64(qc.View()),
+		Sig:   QuorumSignatureToProto(qc.Signature()),
+		Hash:  hash[:],
+		View:  uint64(qc.View()),
+		Epoch: uint64(qc.Epoch()),
 	}
 }
 
@@ -87,7 +159,68 @@ func QuorumCertToProto(qc hotstuff.QuorumCert) *QuorumCert {
 func QuorumCertFromProto(qc *QuorumCert) hotstuff.QuorumCert {
 	var h hotstuff.Hash
 	copy(h[:], qc.GetHash())
-	return hotstuff.NewQuorumCert(QuorumSignatureFromProto(qc.GetSig()), hotstuff.View(qc.GetView()), h)
+	return hotstuff.NewQuorumCertWithEpoch(
+		QuorumSignatureFromProto(qc.GetSig()),
+		hotstuff.View(qc.GetView()),
+		h,
+		hotstuff.Epoch(qc.GetEpoch()),
+	)
+}
+
+// CheckpointToProto converts a hotstuff.Checkpoint to a hotstuffpb.Checkpoint.
+func CheckpointToProto(cp hotstuff.Checkpoint) *Checkpoint {
+	block := cp.BlockHash()
+	state := cp.StateHash()
+	return &Checkpoint{
+		Height:    cp.Height(),
+		View:      uint64(cp.View()),
+		BlockHash: block[:],
+		StateHash: state[:],
+	}
+}
+
+// CheckpointFromProto converts a hotstuffpb.Checkpoint to a hotstuff.Checkpoint.
+func CheckpointFromProto(cp *Checkpoint) hotstuff.Checkpoint {
+	var block, state hotstuff.Hash
+	copy(block[:], cp.GetBlockHash())
+	copy(state[:], cp.GetStateHash())
+	return hotstuff.NewCheckpoint(cp.GetHeight(), hotstuff.View(cp.GetView()), block, state)
+}
+
+// CheckpointCertToProto converts a hotstuff.CheckpointCert to a hotstuffpb.CheckpointCert.
+func CheckpointCertToProto(cert hotstuff.CheckpointCert) *CheckpointCert {
+	return &CheckpointCert{
+		Checkpoint: CheckpointToProto(cert.Checkpoint()),
+		Sig:        QuorumSignatureToProto(cert.Signature()),
+		Epoch:      uint64(cert.Epoch()),
+	}
+}
+
+// CheckpointCertFromProto converts a hotstuffpb.CheckpointCert to a hotstuff.CheckpointCert.
+func CheckpointCertFromProto(cert *CheckpointCert) hotstuff.CheckpointCert {
+	return hotstuff.NewCheckpointCert(
+		CheckpointFromProto(cert.GetCheckpoint()),
+		QuorumSignatureFromProto(cert.GetSig()),
+		hotstuff.Epoch(cert.GetEpoch()),
+	)
+}
+
+// CommitProofToProto converts a hotstuff.CommitProof to a hotstuffpb.CommitProof.
+func CommitProofToProto(proof hotstuff.CommitProof) *CommitProof {
+	blocks := make([]*Block, 0, len(proof.Blocks()))
+	for _, block := range proof.Blocks() {
+		blocks = append(blocks, BlockToProto(block))
+	}
+	return &CommitProof{Blocks: blocks}
+}
+
+// CommitProofFromProto converts a hotstuffpb.CommitProof to a hotstuff.CommitProof.
+func CommitProofFromProto(proof *CommitProof) hotstuff.CommitProof {
+	blocks := make([]*hotstuff.Block, 0, len(proof.GetBlocks()))
+	for _, block := range proof.GetBlocks() {
+		blocks = append(blocks, BlockFromProto(block))
+	}
+	return hotstuff.NewCommitProof(blocks)
 }
 
 // ProposalToProto converts a ProposeMsg to a protobuf message.
@@ -98,6 +231,12 @@ func ProposalToProto(proposal hotstuff.ProposeMsg) *Proposal {
 	if proposal.AggregateQC != nil {
 		p.AggQC = AggregateQCToProto(*proposal.AggregateQC)
 	}
+	if proposal.TimeoutCert != nil {
+		p.TC = TimeoutCertToProto(*proposal.TimeoutCert)
+	}
+	if proposal.LeaderProof != nil {
+		p.LeaderProof = QuorumSignatureToProto(proposal.LeaderProof)
+	}
 	return p
 }
 
@@ -108,6 +247,13 @@ func ProposalFromProto(p *Proposal) (proposal hotstuff.ProposeMsg) {
 		aggQC := AggregateQCFromProto(p.GetAggQC())
 		proposal.AggregateQC = &aggQC
 	}
+	if p.GetTC() != nil {
+		tc := TimeoutCertFromProto(p.GetTC())
+		proposal.TimeoutCert = &tc
+	}
+	if p.GetLeaderProof() != nil {
+		proposal.LeaderProof = QuorumSignatureFromProto(p.GetLeaderProof())
+	}
 	return
 }
 
@@ -120,6 +266,7 @@ func BlockToProto(block *hotstuff.Block) *Block {
 		QC:       QuorumCertToProto(block.QuorumCert()),
 		View:     uint64(block.View()),
 		Proposer: uint32(block.Proposer()),
+		Epoch:    uint64(block.Epoch()),
 	}
 }
 
@@ -127,12 +274,13 @@ func BlockToProto(block *hotstuff.Block) *Block {
 func BlockFromProto(block *Block) *hotstuff.Block {
 	var p hotstuff.Hash
 	copy(p[:], block.GetParent())
-	return hotstuff.NewBlock(
+	return hotstuff.NewBlockWithEpoch(
 		p,
 		QuorumCertFromProto(block.GetQC()),
 		hotstuff.Command(block.GetCommand()),
 		hotstuff.View(block.GetView()),
 		hotstuff.ID(block.GetProposer()),
+		hotstuff.Epoch(block.GetEpoch()),
 	)
 }
 
@@ -164,15 +312,29 @@ func TimeoutMsgToProto(timeoutMsg hotstuff.TimeoutMsg) *TimeoutMsg {
 
 // TimeoutCertFromProto converts a timeout certificate from the protobuf type to the hotstuff type.
 func TimeoutCertFromProto(m *TimeoutCert) hotstuff.TimeoutCert {
+	if len(m.GetQCViews()) > 0 {
+		qcViews := make(map[hotstuff.ID]hotstuff.View, len(m.GetQCViews()))
+		for id, view := range m.GetQCViews() {
+			qcViews[hotstuff.ID(id)] = hotstuff.View(view)
+		}
+		return hotstuff.NewTimeoutCertWithQCViews(QuorumSignatureFromProto(m.GetSig()), hotstuff.View(m.GetView()), qcViews)
+	}
 	return hotstuff.NewTimeoutCert(QuorumSignatureFromProto(m.GetSig()), hotstuff.View(m.GetView()))
 }
 
 // TimeoutCertToProto converts a timeout certificate from the hotstuff type to the protobuf type.
 func TimeoutCertToProto(timeoutCert hotstuff.TimeoutCert) *TimeoutCert {
-	return &TimeoutCert{
+	tc := &TimeoutCert{
 		View: uint64(timeoutCert.View()),
 		Sig:  QuorumSignatureToProto(timeoutCert.Signature()),
 	}
+	if qcViews := timeoutCert.QCViews(); qcViews != nil {
+		tc.QCViews = make(map[uint32]uint64, len(qcViews))
+		for id, view := range qcViews {
+			tc.QCViews[uint32(id)] = uint64(view)
+		}
+	}
+	return tc
 }
 
 // AggregateQCFromProto converts an AggregateQC from the protobuf type to the hotstuff type.
@@ -1,4 +1,4 @@
-package hotstuffpb
+package hotstuffpb_test
 
 import (
 	"bytes"
@@ -10,6 +10,9 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/relab/hotstuff/crypto"
 	"github.com/relab/hotstuff/crypto/bls12"
+	"github.com/relab/hotstuff/crypto/ed25519"
+	"github.com/relab/hotstuff/crypto/thresholdbls"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
 	"github.com/relab/hotstuff/internal/testutil"
 )
 
@@ -29,8 +32,8 @@ func TestConvertPartialCert(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	pb := PartialCertToProto(want)
-	got := PartialCertFromProto(pb)
+	pb := hotstuffpb.PartialCertToProto(want)
+	got := hotstuffpb.PartialCertFromProto(pb)
 
 	if !bytes.Equal(want.ToBytes(), got.ToBytes()) {
 		t.Error("Certificates don't match.")
@@ -55,8 +58,8 @@ func TestConvertQuorumCert(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	pb := QuorumCertToProto(want)
-	got := QuorumCertFromProto(pb)
+	pb := hotstuffpb.QuorumCertToProto(want)
+	got := hotstuffpb.QuorumCertFromProto(pb)
 
 	if !bytes.Equal(want.ToBytes(), got.ToBytes()) {
 		t.Error("Certificates don't match.")
@@ -66,8 +69,8 @@ func TestConvertQuorumCert(t *testing.T) {
 func TestConvertBlock(t *testing.T) {
 	qc := hotstuff.NewQuorumCert(nil, 0, hotstuff.Hash{})
 	want := hotstuff.NewBlock(hotstuff.GetGenesis().Hash(), qc, "", 1, 1)
-	pb := BlockToProto(want)
-	got := BlockFromProto(pb)
+	pb := hotstuffpb.BlockToProto(want)
+	got := hotstuffpb.BlockFromProto(pb)
 
 	if want.Hash() != got.Hash() {
 		t.Error("Hashes don't match.")
@@ -85,8 +88,8 @@ func TestConvertTimeoutCertBLS12(t *testing.T) {
 
 	tc1 := testutil.CreateTC(t, 1, hl.Signers())
 
-	pb := TimeoutCertToProto(tc1)
-	tc2 := TimeoutCertFromProto(pb)
+	pb := hotstuffpb.TimeoutCertToProto(tc1)
+	tc2 := hotstuffpb.TimeoutCertFromProto(pb)
 
 	var signer modules.Crypto
 	hl[0].Get(&signer)
@@ -95,3 +98,58 @@ func TestConvertTimeoutCertBLS12(t *testing.T) {
 		t.Fatal("Failed to verify timeout cert")
 	}
 }
+
+func TestConvertTimeoutCertThresholdBLS(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	builders := testutil.CreateBuilders(t, ctrl, 4, testutil.GenerateThresholdBLSKeys(t, 4)...)
+	for i := range builders {
+		builders[i].Add(crypto.New(thresholdbls.New()))
+	}
+	hl := builders.Build()
+
+	tc1 := testutil.CreateTC(t, 1, hl.Signers())
+
+	pb := hotstuffpb.TimeoutCertToProto(tc1)
+	tc2 := hotstuffpb.TimeoutCertFromProto(pb)
+
+	var signer modules.Crypto
+	hl[0].Get(&signer)
+
+	if !signer.VerifyTimeoutCert(tc2) {
+		t.Fatal("Failed to verify timeout cert")
+	}
+}
+
+func TestConvertQuorumCertED25519(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	builders := testutil.CreateBuilders(t, ctrl, 4, testutil.GenerateKeys(t, 4, testutil.GenerateED25519Key)...)
+	for i := range builders {
+		builders[i].Add(crypto.New(ed25519.New()))
+	}
+	hl := builders.Build()
+	signers := hl.Signers()
+
+	block := hotstuff.NewBlock(hotstuff.GetGenesis().Hash(), hotstuff.NewQuorumCert(nil, 0, hotstuff.GetGenesis().Hash()), "foo", 1, 1)
+	for _, mods := range hl {
+		var blockChain modules.BlockChain
+		mods.Get(&blockChain)
+		blockChain.Store(block)
+	}
+	qc1 := testutil.CreateQC(t, block, signers)
+
+	pb := hotstuffpb.QuorumCertToProto(qc1)
+	qc2 := hotstuffpb.QuorumCertFromProto(pb)
+
+	if !signers[0].VerifyQuorumCert(qc2) {
+		t.Fatal("Failed to verify quorum cert")
+	}
+
+	pc := testutil.CreatePC(t, block, signers[1])
+	sig := pc.Signature().(ed25519.MultiSignature)[2]
+	restored := hotstuffpb.ED25519SignatureFromProto(hotstuffpb.ED25519SignatureToProto(sig))
+	if restored.Signer() != sig.Signer() || !bytes.Equal(restored.ToBytes(), sig.ToBytes()) {
+		t.Error("Ed25519 signature was not restored")
+	}
+}
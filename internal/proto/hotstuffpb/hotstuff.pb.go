@@ -27,8 +27,12 @@ type Proposal struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Block *Block `protobuf:"bytes,1,opt,name=Block,proto3" json:"Block,omitempty"`
-	AggQC *AggQC `protobuf:"bytes,2,opt,name=AggQC,proto3,oneof" json:"AggQC,omitempty"`
+	Block *Block       `protobuf:"bytes,1,opt,name=Block,proto3" json:"Block,omitempty"`
+	AggQC *AggQC       `protobuf:"bytes,2,opt,name=AggQC,proto3,oneof" json:"AggQC,omitempty"`
+	TC    *TimeoutCert `protobuf:"bytes,3,opt,name=TC,proto3,oneof" json:"TC,omitempty"`
+	// LeaderProof proves that the proposer is a leader of the block's view, if
+	// the leader rotation does not let the replicas know the leader in advance.
+	LeaderProof *QuorumSignature `protobuf:"bytes,4,opt,name=LeaderProof,proto3,oneof" json:"LeaderProof,omitempty"`
 }
 
 func (x *Proposal) Reset() {
@@ -77,6 +81,20 @@ func (x *Proposal) GetAggQC() *AggQC {
 	return nil
 }
 
+func (x *Proposal) GetTC() *TimeoutCert {
+	if x != nil {
+		return x.TC
+	}
+	return nil
+}
+
+func (x *Proposal) GetLeaderProof() *QuorumSignature {
+	if x != nil {
+		return x.LeaderProof
+	}
+	return nil
+}
+
 type BlockHash struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -124,6 +142,121 @@ func (x *BlockHash) GetHash() []byte {
 	return nil
 }
 
+type BlockRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The hash of the newest block to fetch.
+	Target []byte `protobuf:"bytes,1,opt,name=Target,proto3" json:"Target,omitempty"`
+	// The hash of a block that the requester already has.
+	// The ancestor itself is not included in the reply.
+	Ancestor []byte `protobuf:"bytes,2,opt,name=Ancestor,proto3" json:"Ancestor,omitempty"`
+	// The maximum number of blocks to return.
+	MaxBlocks uint32 `protobuf:"varint,3,opt,name=MaxBlocks,proto3" json:"MaxBlocks,omitempty"`
+}
+
+func (x *BlockRange) Reset() {
+	*x = BlockRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockRange) ProtoMessage() {}
+
+func (x *BlockRange) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockRange.ProtoReflect.Descriptor instead.
+func (*BlockRange) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BlockRange) GetTarget() []byte {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *BlockRange) GetAncestor() []byte {
+	if x != nil {
+		return x.Ancestor
+	}
+	return nil
+}
+
+func (x *BlockRange) GetMaxBlocks() uint32 {
+	if x != nil {
+		return x.MaxBlocks
+	}
+	return 0
+}
+
+// Blocks is a chain of blocks ordered from the oldest to the newest block.
+type Blocks struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Blocks []*Block `protobuf:"bytes,1,rep,name=Blocks,proto3" json:"Blocks,omitempty"`
+}
+
+func (x *Blocks) Reset() {
+	*x = Blocks{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Blocks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Blocks) ProtoMessage() {}
+
+func (x *Blocks) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Blocks.ProtoReflect.Descriptor instead.
+func (*Blocks) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Blocks) GetBlocks() []*Block {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
 type Block struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -134,12 +267,13 @@ type Block struct {
 	View     uint64      `protobuf:"varint,3,opt,name=View,proto3" json:"View,omitempty"`
 	Command  []byte      `protobuf:"bytes,4,opt,name=Command,proto3" json:"Command,omitempty"`
 	Proposer uint32      `protobuf:"varint,5,opt,name=Proposer,proto3" json:"Proposer,omitempty"`
+	Epoch    uint64      `protobuf:"varint,6,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
 }
 
 func (x *Block) Reset() {
 	*x = Block{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[2]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -152,7 +286,7 @@ func (x *Block) String() string {
 func (*Block) ProtoMessage() {}
 
 func (x *Block) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[2]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -165,7 +299,7 @@ func (x *Block) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Block.ProtoReflect.Descriptor instead.
 func (*Block) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{2}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Block) GetParent() []byte {
@@ -203,6 +337,13 @@ func (x *Block) GetProposer() uint32 {
 	return 0
 }
 
+func (x *Block) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
 type ECDSASignature struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -216,7 +357,7 @@ type ECDSASignature struct {
 func (x *ECDSASignature) Reset() {
 	*x = ECDSASignature{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[3]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -229,7 +370,7 @@ func (x *ECDSASignature) String() string {
 func (*ECDSASignature) ProtoMessage() {}
 
 func (x *ECDSASignature) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[3]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -242,7 +383,7 @@ func (x *ECDSASignature) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ECDSASignature.ProtoReflect.Descriptor instead.
 func (*ECDSASignature) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{3}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ECDSASignature) GetSigner() uint32 {
@@ -277,7 +418,7 @@ type BLS12Signature struct {
 func (x *BLS12Signature) Reset() {
 	*x = BLS12Signature{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[4]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -290,7 +431,7 @@ func (x *BLS12Signature) String() string {
 func (*BLS12Signature) ProtoMessage() {}
 
 func (x *BLS12Signature) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[4]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -303,7 +444,7 @@ func (x *BLS12Signature) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BLS12Signature.ProtoReflect.Descriptor instead.
 func (*BLS12Signature) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{4}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *BLS12Signature) GetSig() []byte {
@@ -313,6 +454,61 @@ func (x *BLS12Signature) GetSig() []byte {
 	return nil
 }
 
+type ED25519Signature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Signer uint32 `protobuf:"varint,1,opt,name=Signer,proto3" json:"Signer,omitempty"`
+	Sig    []byte `protobuf:"bytes,2,opt,name=Sig,proto3" json:"Sig,omitempty"`
+}
+
+func (x *ED25519Signature) Reset() {
+	*x = ED25519Signature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ED25519Signature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ED25519Signature) ProtoMessage() {}
+
+func (x *ED25519Signature) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ED25519Signature.ProtoReflect.Descriptor instead.
+func (*ED25519Signature) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ED25519Signature) GetSigner() uint32 {
+	if x != nil {
+		return x.Signer
+	}
+	return 0
+}
+
+func (x *ED25519Signature) GetSig() []byte {
+	if x != nil {
+		return x.Sig
+	}
+	return nil
+}
+
 type Signature struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -321,13 +517,14 @@ type Signature struct {
 	// Types that are assignable to Sig:
 	//	*Signature_ECDSASig
 	//	*Signature_BLS12Sig
+	//	*Signature_ED25519Sig
 	Sig isSignature_Sig `protobuf_oneof:"Sig"`
 }
 
 func (x *Signature) Reset() {
 	*x = Signature{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[5]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -340,7 +537,7 @@ func (x *Signature) String() string {
 func (*Signature) ProtoMessage() {}
 
 func (x *Signature) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[5]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -353,7 +550,7 @@ func (x *Signature) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Signature.ProtoReflect.Descriptor instead.
 func (*Signature) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{5}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{8}
 }
 
 func (m *Signature) GetSig() isSignature_Sig {
@@ -377,6 +574,13 @@ func (x *Signature) GetBLS12Sig() *BLS12Signature {
 	return nil
 }
 
+func (x *Signature) GetED25519Sig() *ED25519Signature {
+	if x, ok := x.GetSig().(*Signature_ED25519Sig); ok {
+		return x.ED25519Sig
+	}
+	return nil
+}
+
 type isSignature_Sig interface {
 	isSignature_Sig()
 }
@@ -389,10 +593,16 @@ type Signature_BLS12Sig struct {
 	BLS12Sig *BLS12Signature `protobuf:"bytes,2,opt,name=BLS12Sig,proto3,oneof"`
 }
 
+type Signature_ED25519Sig struct {
+	ED25519Sig *ED25519Signature `protobuf:"bytes,3,opt,name=ED25519Sig,proto3,oneof"`
+}
+
 func (*Signature_ECDSASig) isSignature_Sig() {}
 
 func (*Signature_BLS12Sig) isSignature_Sig() {}
 
+func (*Signature_ED25519Sig) isSignature_Sig() {}
+
 type PartialCert struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -405,7 +615,7 @@ type PartialCert struct {
 func (x *PartialCert) Reset() {
 	*x = PartialCert{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[6]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -418,7 +628,7 @@ func (x *PartialCert) String() string {
 func (*PartialCert) ProtoMessage() {}
 
 func (x *PartialCert) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[6]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -431,7 +641,7 @@ func (x *PartialCert) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PartialCert.ProtoReflect.Descriptor instead.
 func (*PartialCert) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{6}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *PartialCert) GetSig() *QuorumSignature {
@@ -459,7 +669,7 @@ type ECDSAMultiSignature struct {
 func (x *ECDSAMultiSignature) Reset() {
 	*x = ECDSAMultiSignature{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[7]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -472,7 +682,7 @@ func (x *ECDSAMultiSignature) String() string {
 func (*ECDSAMultiSignature) ProtoMessage() {}
 
 func (x *ECDSAMultiSignature) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[7]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -485,7 +695,7 @@ func (x *ECDSAMultiSignature) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ECDSAMultiSignature.ProtoReflect.Descriptor instead.
 func (*ECDSAMultiSignature) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{7}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ECDSAMultiSignature) GetSigs() []*ECDSASignature {
@@ -495,6 +705,53 @@ func (x *ECDSAMultiSignature) GetSigs() []*ECDSASignature {
 	return nil
 }
 
+type ED25519MultiSignature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sigs []*ED25519Signature `protobuf:"bytes,1,rep,name=Sigs,proto3" json:"Sigs,omitempty"`
+}
+
+func (x *ED25519MultiSignature) Reset() {
+	*x = ED25519MultiSignature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ED25519MultiSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ED25519MultiSignature) ProtoMessage() {}
+
+func (x *ED25519MultiSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ED25519MultiSignature.ProtoReflect.Descriptor instead.
+func (*ED25519MultiSignature) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ED25519MultiSignature) GetSigs() []*ED25519Signature {
+	if x != nil {
+		return x.Sigs
+	}
+	return nil
+}
+
 type BLS12AggregateSignature struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -507,7 +764,7 @@ type BLS12AggregateSignature struct {
 func (x *BLS12AggregateSignature) Reset() {
 	*x = BLS12AggregateSignature{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[8]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -520,7 +777,7 @@ func (x *BLS12AggregateSignature) String() string {
 func (*BLS12AggregateSignature) ProtoMessage() {}
 
 func (x *BLS12AggregateSignature) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[8]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -533,7 +790,7 @@ func (x *BLS12AggregateSignature) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BLS12AggregateSignature.ProtoReflect.Descriptor instead.
 func (*BLS12AggregateSignature) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{8}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *BLS12AggregateSignature) GetSig() []byte {
@@ -550,34 +807,32 @@ func (x *BLS12AggregateSignature) GetParticipants() []byte {
 	return nil
 }
 
-type QuorumSignature struct {
+type ThresholdBLSShare struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Sig:
-	//	*QuorumSignature_ECDSASigs
-	//	*QuorumSignature_BLS12Sig
-	Sig isQuorumSignature_Sig `protobuf_oneof:"Sig"`
+	Signer uint32 `protobuf:"varint,1,opt,name=Signer,proto3" json:"Signer,omitempty"`
+	Sig    []byte `protobuf:"bytes,2,opt,name=Sig,proto3" json:"Sig,omitempty"`
 }
 
-func (x *QuorumSignature) Reset() {
-	*x = QuorumSignature{}
+func (x *ThresholdBLSShare) Reset() {
+	*x = ThresholdBLSShare{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[9]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *QuorumSignature) String() string {
+func (x *ThresholdBLSShare) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QuorumSignature) ProtoMessage() {}
+func (*ThresholdBLSShare) ProtoMessage() {}
 
-func (x *QuorumSignature) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[9]
+func (x *ThresholdBLSShare) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -588,20 +843,182 @@ func (x *QuorumSignature) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QuorumSignature.ProtoReflect.Descriptor instead.
-func (*QuorumSignature) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ThresholdBLSShare.ProtoReflect.Descriptor instead.
+func (*ThresholdBLSShare) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{13}
 }
 
-func (m *QuorumSignature) GetSig() isQuorumSignature_Sig {
-	if m != nil {
-		return m.Sig
+func (x *ThresholdBLSShare) GetSigner() uint32 {
+	if x != nil {
+		return x.Signer
 	}
-	return nil
+	return 0
 }
 
-func (x *QuorumSignature) GetECDSASigs() *ECDSAMultiSignature {
-	if x, ok := x.GetSig().(*QuorumSignature_ECDSASigs); ok {
+func (x *ThresholdBLSShare) GetSig() []byte {
+	if x != nil {
+		return x.Sig
+	}
+	return nil
+}
+
+type ThresholdBLSShares struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Shares []*ThresholdBLSShare `protobuf:"bytes,1,rep,name=Shares,proto3" json:"Shares,omitempty"`
+}
+
+func (x *ThresholdBLSShares) Reset() {
+	*x = ThresholdBLSShares{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ThresholdBLSShares) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThresholdBLSShares) ProtoMessage() {}
+
+func (x *ThresholdBLSShares) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThresholdBLSShares.ProtoReflect.Descriptor instead.
+func (*ThresholdBLSShares) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ThresholdBLSShares) GetShares() []*ThresholdBLSShare {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+type ThresholdBLSGroupSignature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sig       []byte `protobuf:"bytes,1,opt,name=Sig,proto3" json:"Sig,omitempty"`
+	Threshold uint32 `protobuf:"varint,2,opt,name=Threshold,proto3" json:"Threshold,omitempty"`
+}
+
+func (x *ThresholdBLSGroupSignature) Reset() {
+	*x = ThresholdBLSGroupSignature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ThresholdBLSGroupSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThresholdBLSGroupSignature) ProtoMessage() {}
+
+func (x *ThresholdBLSGroupSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThresholdBLSGroupSignature.ProtoReflect.Descriptor instead.
+func (*ThresholdBLSGroupSignature) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ThresholdBLSGroupSignature) GetSig() []byte {
+	if x != nil {
+		return x.Sig
+	}
+	return nil
+}
+
+func (x *ThresholdBLSGroupSignature) GetThreshold() uint32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+type QuorumSignature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Sig:
+	//	*QuorumSignature_ECDSASigs
+	//	*QuorumSignature_BLS12Sig
+	//	*QuorumSignature_ThresholdBLSShares
+	//	*QuorumSignature_ThresholdBLSGroupSig
+	//	*QuorumSignature_ED25519Sigs
+	Sig isQuorumSignature_Sig `protobuf_oneof:"Sig"`
+}
+
+func (x *QuorumSignature) Reset() {
+	*x = QuorumSignature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuorumSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuorumSignature) ProtoMessage() {}
+
+func (x *QuorumSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuorumSignature.ProtoReflect.Descriptor instead.
+func (*QuorumSignature) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{16}
+}
+
+func (m *QuorumSignature) GetSig() isQuorumSignature_Sig {
+	if m != nil {
+		return m.Sig
+	}
+	return nil
+}
+
+func (x *QuorumSignature) GetECDSASigs() *ECDSAMultiSignature {
+	if x, ok := x.GetSig().(*QuorumSignature_ECDSASigs); ok {
 		return x.ECDSASigs
 	}
 	return nil
@@ -614,6 +1031,27 @@ func (x *QuorumSignature) GetBLS12Sig() *BLS12AggregateSignature {
 	return nil
 }
 
+func (x *QuorumSignature) GetThresholdBLSShares() *ThresholdBLSShares {
+	if x, ok := x.GetSig().(*QuorumSignature_ThresholdBLSShares); ok {
+		return x.ThresholdBLSShares
+	}
+	return nil
+}
+
+func (x *QuorumSignature) GetThresholdBLSGroupSig() *ThresholdBLSGroupSignature {
+	if x, ok := x.GetSig().(*QuorumSignature_ThresholdBLSGroupSig); ok {
+		return x.ThresholdBLSGroupSig
+	}
+	return nil
+}
+
+func (x *QuorumSignature) GetED25519Sigs() *ED25519MultiSignature {
+	if x, ok := x.GetSig().(*QuorumSignature_ED25519Sigs); ok {
+		return x.ED25519Sigs
+	}
+	return nil
+}
+
 type isQuorumSignature_Sig interface {
 	isQuorumSignature_Sig()
 }
@@ -626,24 +1064,43 @@ type QuorumSignature_BLS12Sig struct {
 	BLS12Sig *BLS12AggregateSignature `protobuf:"bytes,2,opt,name=BLS12Sig,proto3,oneof"`
 }
 
+type QuorumSignature_ThresholdBLSShares struct {
+	ThresholdBLSShares *ThresholdBLSShares `protobuf:"bytes,3,opt,name=ThresholdBLSShares,proto3,oneof"`
+}
+
+type QuorumSignature_ThresholdBLSGroupSig struct {
+	ThresholdBLSGroupSig *ThresholdBLSGroupSignature `protobuf:"bytes,4,opt,name=ThresholdBLSGroupSig,proto3,oneof"`
+}
+
+type QuorumSignature_ED25519Sigs struct {
+	ED25519Sigs *ED25519MultiSignature `protobuf:"bytes,5,opt,name=ED25519Sigs,proto3,oneof"`
+}
+
 func (*QuorumSignature_ECDSASigs) isQuorumSignature_Sig() {}
 
 func (*QuorumSignature_BLS12Sig) isQuorumSignature_Sig() {}
 
+func (*QuorumSignature_ThresholdBLSShares) isQuorumSignature_Sig() {}
+
+func (*QuorumSignature_ThresholdBLSGroupSig) isQuorumSignature_Sig() {}
+
+func (*QuorumSignature_ED25519Sigs) isQuorumSignature_Sig() {}
+
 type QuorumCert struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sig  *QuorumSignature `protobuf:"bytes,1,opt,name=Sig,proto3" json:"Sig,omitempty"`
-	View uint64           `protobuf:"varint,2,opt,name=View,proto3" json:"View,omitempty"`
-	Hash []byte           `protobuf:"bytes,3,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Sig   *QuorumSignature `protobuf:"bytes,1,opt,name=Sig,proto3" json:"Sig,omitempty"`
+	View  uint64           `protobuf:"varint,2,opt,name=View,proto3" json:"View,omitempty"`
+	Hash  []byte           `protobuf:"bytes,3,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Epoch uint64           `protobuf:"varint,4,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
 }
 
 func (x *QuorumCert) Reset() {
 	*x = QuorumCert{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[10]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -656,7 +1113,7 @@ func (x *QuorumCert) String() string {
 func (*QuorumCert) ProtoMessage() {}
 
 func (x *QuorumCert) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[10]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -669,7 +1126,7 @@ func (x *QuorumCert) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QuorumCert.ProtoReflect.Descriptor instead.
 func (*QuorumCert) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{10}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *QuorumCert) GetSig() *QuorumSignature {
@@ -693,32 +1150,43 @@ func (x *QuorumCert) GetHash() []byte {
 	return nil
 }
 
-type TimeoutCert struct {
+func (x *QuorumCert) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type Checkpoint struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sig  *QuorumSignature `protobuf:"bytes,1,opt,name=Sig,proto3" json:"Sig,omitempty"`
-	View uint64           `protobuf:"varint,2,opt,name=View,proto3" json:"View,omitempty"`
+	// The number of committed blocks up to and including the block.
+	Height    uint64 `protobuf:"varint,1,opt,name=Height,proto3" json:"Height,omitempty"`
+	View      uint64 `protobuf:"varint,2,opt,name=View,proto3" json:"View,omitempty"`
+	BlockHash []byte `protobuf:"bytes,3,opt,name=BlockHash,proto3" json:"BlockHash,omitempty"`
+	// The digest of the application state after the block was executed.
+	StateHash []byte `protobuf:"bytes,4,opt,name=StateHash,proto3" json:"StateHash,omitempty"`
 }
 
-func (x *TimeoutCert) Reset() {
-	*x = TimeoutCert{}
+func (x *Checkpoint) Reset() {
+	*x = Checkpoint{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[11]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TimeoutCert) String() string {
+func (x *Checkpoint) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TimeoutCert) ProtoMessage() {}
+func (*Checkpoint) ProtoMessage() {}
 
-func (x *TimeoutCert) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[11]
+func (x *Checkpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -729,53 +1197,66 @@ func (x *TimeoutCert) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TimeoutCert.ProtoReflect.Descriptor instead.
-func (*TimeoutCert) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use Checkpoint.ProtoReflect.Descriptor instead.
+func (*Checkpoint) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *TimeoutCert) GetSig() *QuorumSignature {
+func (x *Checkpoint) GetHeight() uint64 {
 	if x != nil {
-		return x.Sig
+		return x.Height
 	}
-	return nil
+	return 0
 }
 
-func (x *TimeoutCert) GetView() uint64 {
+func (x *Checkpoint) GetView() uint64 {
 	if x != nil {
 		return x.View
 	}
 	return 0
 }
 
-type TimeoutMsg struct {
+func (x *Checkpoint) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *Checkpoint) GetStateHash() []byte {
+	if x != nil {
+		return x.StateHash
+	}
+	return nil
+}
+
+type CheckpointCert struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	View     uint64           `protobuf:"varint,1,opt,name=View,proto3" json:"View,omitempty"`
-	SyncInfo *SyncInfo        `protobuf:"bytes,2,opt,name=SyncInfo,proto3" json:"SyncInfo,omitempty"`
-	ViewSig  *QuorumSignature `protobuf:"bytes,3,opt,name=ViewSig,proto3" json:"ViewSig,omitempty"`
-	MsgSig   *QuorumSignature `protobuf:"bytes,4,opt,name=MsgSig,proto3,oneof" json:"MsgSig,omitempty"`
+	Checkpoint *Checkpoint      `protobuf:"bytes,1,opt,name=Checkpoint,proto3" json:"Checkpoint,omitempty"`
+	Sig        *QuorumSignature `protobuf:"bytes,2,opt,name=Sig,proto3" json:"Sig,omitempty"`
+	Epoch      uint64           `protobuf:"varint,3,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
 }
 
-func (x *TimeoutMsg) Reset() {
-	*x = TimeoutMsg{}
+func (x *CheckpointCert) Reset() {
+	*x = CheckpointCert{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[12]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TimeoutMsg) String() string {
+func (x *CheckpointCert) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TimeoutMsg) ProtoMessage() {}
+func (*CheckpointCert) ProtoMessage() {}
 
-func (x *TimeoutMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[12]
+func (x *CheckpointCert) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -786,66 +1267,693 @@ func (x *TimeoutMsg) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TimeoutMsg.ProtoReflect.Descriptor instead.
-func (*TimeoutMsg) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use CheckpointCert.ProtoReflect.Descriptor instead.
+func (*CheckpointCert) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *TimeoutMsg) GetView() uint64 {
+func (x *CheckpointCert) GetCheckpoint() *Checkpoint {
 	if x != nil {
-		return x.View
+		return x.Checkpoint
 	}
-	return 0
+	return nil
 }
 
-func (x *TimeoutMsg) GetSyncInfo() *SyncInfo {
+func (x *CheckpointCert) GetSig() *QuorumSignature {
 	if x != nil {
-		return x.SyncInfo
+		return x.Sig
 	}
 	return nil
 }
 
-func (x *TimeoutMsg) GetViewSig() *QuorumSignature {
+func (x *CheckpointCert) GetEpoch() uint64 {
 	if x != nil {
-		return x.ViewSig
+		return x.Epoch
+	}
+	return 0
+}
+
+// CommitProof is a chain of blocks ordered from the committed block to the
+// block whose QC satisfies the commit rule of the consensus protocol.
+type CommitProof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Blocks []*Block `protobuf:"bytes,1,rep,name=Blocks,proto3" json:"Blocks,omitempty"`
+}
+
+func (x *CommitProof) Reset() {
+	*x = CommitProof{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommitProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitProof) ProtoMessage() {}
+
+func (x *CommitProof) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitProof.ProtoReflect.Descriptor instead.
+func (*CommitProof) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CommitProof) GetBlocks() []*Block {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+type TimeoutCert struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sig     *QuorumSignature  `protobuf:"bytes,1,opt,name=Sig,proto3" json:"Sig,omitempty"`
+	View    uint64            `protobuf:"varint,2,opt,name=View,proto3" json:"View,omitempty"`
+	QCViews map[uint32]uint64 `protobuf:"bytes,3,rep,name=QCViews,proto3" json:"QCViews,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *TimeoutCert) Reset() {
+	*x = TimeoutCert{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeoutCert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeoutCert) ProtoMessage() {}
+
+func (x *TimeoutCert) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeoutCert.ProtoReflect.Descriptor instead.
+func (*TimeoutCert) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TimeoutCert) GetSig() *QuorumSignature {
+	if x != nil {
+		return x.Sig
+	}
+	return nil
+}
+
+func (x *TimeoutCert) GetView() uint64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *TimeoutCert) GetQCViews() map[uint32]uint64 {
+	if x != nil {
+		return x.QCViews
+	}
+	return nil
+}
+
+type TimeoutMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	View     uint64           `protobuf:"varint,1,opt,name=View,proto3" json:"View,omitempty"`
+	SyncInfo *SyncInfo        `protobuf:"bytes,2,opt,name=SyncInfo,proto3" json:"SyncInfo,omitempty"`
+	ViewSig  *QuorumSignature `protobuf:"bytes,3,opt,name=ViewSig,proto3" json:"ViewSig,omitempty"`
+	MsgSig   *QuorumSignature `protobuf:"bytes,4,opt,name=MsgSig,proto3,oneof" json:"MsgSig,omitempty"`
+}
+
+func (x *TimeoutMsg) Reset() {
+	*x = TimeoutMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeoutMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeoutMsg) ProtoMessage() {}
+
+func (x *TimeoutMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeoutMsg.ProtoReflect.Descriptor instead.
+func (*TimeoutMsg) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TimeoutMsg) GetView() uint64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *TimeoutMsg) GetSyncInfo() *SyncInfo {
+	if x != nil {
+		return x.SyncInfo
+	}
+	return nil
+}
+
+func (x *TimeoutMsg) GetViewSig() *QuorumSignature {
+	if x != nil {
+		return x.ViewSig
+	}
+	return nil
+}
+
+func (x *TimeoutMsg) GetMsgSig() *QuorumSignature {
+	if x != nil {
+		return x.MsgSig
+	}
+	return nil
+}
+
+type SyncInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QC    *QuorumCert  `protobuf:"bytes,1,opt,name=QC,proto3,oneof" json:"QC,omitempty"`
+	TC    *TimeoutCert `protobuf:"bytes,2,opt,name=TC,proto3,oneof" json:"TC,omitempty"`
+	AggQC *AggQC       `protobuf:"bytes,3,opt,name=AggQC,proto3,oneof" json:"AggQC,omitempty"`
+}
+
+func (x *SyncInfo) Reset() {
+	*x = SyncInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncInfo) ProtoMessage() {}
+
+func (x *SyncInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncInfo.ProtoReflect.Descriptor instead.
+func (*SyncInfo) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SyncInfo) GetQC() *QuorumCert {
+	if x != nil {
+		return x.QC
+	}
+	return nil
+}
+
+func (x *SyncInfo) GetTC() *TimeoutCert {
+	if x != nil {
+		return x.TC
+	}
+	return nil
+}
+
+func (x *SyncInfo) GetAggQC() *AggQC {
+	if x != nil {
+		return x.AggQC
+	}
+	return nil
+}
+
+type AggQC struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QCs  map[uint32]*QuorumCert `protobuf:"bytes,1,rep,name=QCs,proto3" json:"QCs,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Sig  *QuorumSignature       `protobuf:"bytes,2,opt,name=Sig,proto3" json:"Sig,omitempty"`
+	View uint64                 `protobuf:"varint,3,opt,name=View,proto3" json:"View,omitempty"`
+}
+
+func (x *AggQC) Reset() {
+	*x = AggQC{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggQC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggQC) ProtoMessage() {}
+
+func (x *AggQC) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggQC.ProtoReflect.Descriptor instead.
+func (*AggQC) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AggQC) GetQCs() map[uint32]*QuorumCert {
+	if x != nil {
+		return x.QCs
+	}
+	return nil
+}
+
+func (x *AggQC) GetSig() *QuorumSignature {
+	if x != nil {
+		return x.Sig
+	}
+	return nil
+}
+
+func (x *AggQC) GetView() uint64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+type ConsensusState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LastVote   uint64 `protobuf:"varint,1,opt,name=LastVote,proto3" json:"LastVote,omitempty"`
+	BExec      *Block `protobuf:"bytes,2,opt,name=BExec,proto3" json:"BExec,omitempty"`
+	LockedView uint64 `protobuf:"varint,3,opt,name=LockedView,proto3" json:"LockedView,omitempty"`
+}
+
+func (x *ConsensusState) Reset() {
+	*x = ConsensusState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConsensusState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsensusState) ProtoMessage() {}
+
+func (x *ConsensusState) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsensusState.ProtoReflect.Descriptor instead.
+func (*ConsensusState) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ConsensusState) GetLastVote() uint64 {
+	if x != nil {
+		return x.LastVote
+	}
+	return 0
+}
+
+func (x *ConsensusState) GetBExec() *Block {
+	if x != nil {
+		return x.BExec
+	}
+	return nil
+}
+
+func (x *ConsensusState) GetLockedView() uint64 {
+	if x != nil {
+		return x.LockedView
+	}
+	return 0
+}
+
+type SynchronizerState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CurrentView uint64       `protobuf:"varint,1,opt,name=CurrentView,proto3" json:"CurrentView,omitempty"`
+	HighQC      *QuorumCert  `protobuf:"bytes,2,opt,name=HighQC,proto3" json:"HighQC,omitempty"`
+	HighTC      *TimeoutCert `protobuf:"bytes,3,opt,name=HighTC,proto3" json:"HighTC,omitempty"`
+	LastTimeout *TimeoutMsg  `protobuf:"bytes,4,opt,name=LastTimeout,proto3,oneof" json:"LastTimeout,omitempty"`
+}
+
+func (x *SynchronizerState) Reset() {
+	*x = SynchronizerState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SynchronizerState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SynchronizerState) ProtoMessage() {}
+
+func (x *SynchronizerState) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SynchronizerState.ProtoReflect.Descriptor instead.
+func (*SynchronizerState) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SynchronizerState) GetCurrentView() uint64 {
+	if x != nil {
+		return x.CurrentView
+	}
+	return 0
+}
+
+func (x *SynchronizerState) GetHighQC() *QuorumCert {
+	if x != nil {
+		return x.HighQC
+	}
+	return nil
+}
+
+func (x *SynchronizerState) GetHighTC() *TimeoutCert {
+	if x != nil {
+		return x.HighTC
+	}
+	return nil
+}
+
+func (x *SynchronizerState) GetLastTimeout() *TimeoutMsg {
+	if x != nil {
+		return x.LastTimeout
+	}
+	return nil
+}
+
+type PBFTPrePrepare struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	View  uint64 `protobuf:"varint,1,opt,name=View,proto3" json:"View,omitempty"`
+	Block *Block `protobuf:"bytes,2,opt,name=Block,proto3" json:"Block,omitempty"`
+}
+
+func (x *PBFTPrePrepare) Reset() {
+	*x = PBFTPrePrepare{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PBFTPrePrepare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PBFTPrePrepare) ProtoMessage() {}
+
+func (x *PBFTPrePrepare) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PBFTPrePrepare.ProtoReflect.Descriptor instead.
+func (*PBFTPrePrepare) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PBFTPrePrepare) GetView() uint64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *PBFTPrePrepare) GetBlock() *Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+// PBFTVote is used for both prepare and commit messages.
+// The sequence number is the view of the block that is voted for.
+type PBFTVote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	View     uint64           `protobuf:"varint,1,opt,name=View,proto3" json:"View,omitempty"`
+	Hash     []byte           `protobuf:"bytes,2,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Sig      *QuorumSignature `protobuf:"bytes,3,opt,name=Sig,proto3" json:"Sig,omitempty"`
+	Sequence uint64           `protobuf:"varint,4,opt,name=Sequence,proto3" json:"Sequence,omitempty"`
+}
+
+func (x *PBFTVote) Reset() {
+	*x = PBFTVote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PBFTVote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PBFTVote) ProtoMessage() {}
+
+func (x *PBFTVote) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PBFTVote.ProtoReflect.Descriptor instead.
+func (*PBFTVote) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PBFTVote) GetView() uint64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *PBFTVote) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *PBFTVote) GetSig() *QuorumSignature {
+	if x != nil {
+		return x.Sig
+	}
+	return nil
+}
+
+func (x *PBFTVote) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type PBFTPreparedCert struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	View  uint64           `protobuf:"varint,1,opt,name=View,proto3" json:"View,omitempty"`
+	Block *Block           `protobuf:"bytes,2,opt,name=Block,proto3" json:"Block,omitempty"`
+	Sig   *QuorumSignature `protobuf:"bytes,3,opt,name=Sig,proto3" json:"Sig,omitempty"`
+}
+
+func (x *PBFTPreparedCert) Reset() {
+	*x = PBFTPreparedCert{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PBFTPreparedCert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PBFTPreparedCert) ProtoMessage() {}
+
+func (x *PBFTPreparedCert) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PBFTPreparedCert.ProtoReflect.Descriptor instead.
+func (*PBFTPreparedCert) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PBFTPreparedCert) GetView() uint64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *PBFTPreparedCert) GetBlock() *Block {
+	if x != nil {
+		return x.Block
 	}
 	return nil
 }
 
-func (x *TimeoutMsg) GetMsgSig() *QuorumSignature {
+func (x *PBFTPreparedCert) GetSig() *QuorumSignature {
 	if x != nil {
-		return x.MsgSig
+		return x.Sig
 	}
 	return nil
 }
 
-type SyncInfo struct {
+type PBFTViewChange struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	QC    *QuorumCert  `protobuf:"bytes,1,opt,name=QC,proto3,oneof" json:"QC,omitempty"`
-	TC    *TimeoutCert `protobuf:"bytes,2,opt,name=TC,proto3,oneof" json:"TC,omitempty"`
-	AggQC *AggQC       `protobuf:"bytes,3,opt,name=AggQC,proto3,oneof" json:"AggQC,omitempty"`
+	View     uint64            `protobuf:"varint,1,opt,name=View,proto3" json:"View,omitempty"`
+	Prepared *PBFTPreparedCert `protobuf:"bytes,2,opt,name=Prepared,proto3,oneof" json:"Prepared,omitempty"`
+	Sig      *QuorumSignature  `protobuf:"bytes,3,opt,name=Sig,proto3" json:"Sig,omitempty"`
 }
 
-func (x *SyncInfo) Reset() {
-	*x = SyncInfo{}
+func (x *PBFTViewChange) Reset() {
+	*x = PBFTViewChange{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[13]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SyncInfo) String() string {
+func (x *PBFTViewChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SyncInfo) ProtoMessage() {}
+func (*PBFTViewChange) ProtoMessage() {}
 
-func (x *SyncInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[13]
+func (x *PBFTViewChange) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -856,59 +1964,59 @@ func (x *SyncInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SyncInfo.ProtoReflect.Descriptor instead.
-func (*SyncInfo) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use PBFTViewChange.ProtoReflect.Descriptor instead.
+func (*PBFTViewChange) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *SyncInfo) GetQC() *QuorumCert {
+func (x *PBFTViewChange) GetView() uint64 {
 	if x != nil {
-		return x.QC
+		return x.View
 	}
-	return nil
+	return 0
 }
 
-func (x *SyncInfo) GetTC() *TimeoutCert {
+func (x *PBFTViewChange) GetPrepared() *PBFTPreparedCert {
 	if x != nil {
-		return x.TC
+		return x.Prepared
 	}
 	return nil
 }
 
-func (x *SyncInfo) GetAggQC() *AggQC {
+func (x *PBFTViewChange) GetSig() *QuorumSignature {
 	if x != nil {
-		return x.AggQC
+		return x.Sig
 	}
 	return nil
 }
 
-type AggQC struct {
+type PBFTNewView struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	QCs  map[uint32]*QuorumCert `protobuf:"bytes,1,rep,name=QCs,proto3" json:"QCs,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Sig  *QuorumSignature       `protobuf:"bytes,2,opt,name=Sig,proto3" json:"Sig,omitempty"`
-	View uint64                 `protobuf:"varint,3,opt,name=View,proto3" json:"View,omitempty"`
+	View        uint64            `protobuf:"varint,1,opt,name=View,proto3" json:"View,omitempty"`
+	ViewChanges []*PBFTViewChange `protobuf:"bytes,2,rep,name=ViewChanges,proto3" json:"ViewChanges,omitempty"`
+	Block       *Block            `protobuf:"bytes,3,opt,name=Block,proto3,oneof" json:"Block,omitempty"`
 }
 
-func (x *AggQC) Reset() {
-	*x = AggQC{}
+func (x *PBFTNewView) Reset() {
+	*x = PBFTNewView{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[14]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *AggQC) String() string {
+func (x *PBFTNewView) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AggQC) ProtoMessage() {}
+func (*PBFTNewView) ProtoMessage() {}
 
-func (x *AggQC) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[14]
+func (x *PBFTNewView) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -919,30 +2027,30 @@ func (x *AggQC) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AggQC.ProtoReflect.Descriptor instead.
-func (*AggQC) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use PBFTNewView.ProtoReflect.Descriptor instead.
+func (*PBFTNewView) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *AggQC) GetQCs() map[uint32]*QuorumCert {
+func (x *PBFTNewView) GetView() uint64 {
 	if x != nil {
-		return x.QCs
+		return x.View
 	}
-	return nil
+	return 0
 }
 
-func (x *AggQC) GetSig() *QuorumSignature {
+func (x *PBFTNewView) GetViewChanges() []*PBFTViewChange {
 	if x != nil {
-		return x.Sig
+		return x.ViewChanges
 	}
 	return nil
 }
 
-func (x *AggQC) GetView() uint64 {
+func (x *PBFTNewView) GetBlock() *Block {
 	if x != nil {
-		return x.View
+		return x.Block
 	}
-	return 0
+	return nil
 }
 
 var File_internal_proto_hotstuffpb_hotstuff_proto protoreflect.FileDescriptor
@@ -954,137 +2062,297 @@ var file_internal_proto_hotstuffpb_hotstuff_proto_rawDesc = []byte{
 	0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x1a, 0x0c, 0x67, 0x6f, 0x72, 0x75, 0x6d, 0x73, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0x6b, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x27, 0x0a,
-	0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x68,
-	0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52,
-	0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x2c, 0x0a, 0x05, 0x41, 0x67, 0x67, 0x51, 0x43, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66,
-	0x70, 0x62, 0x2e, 0x41, 0x67, 0x67, 0x51, 0x43, 0x48, 0x00, 0x52, 0x05, 0x41, 0x67, 0x67, 0x51,
-	0x43, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x41, 0x67, 0x67, 0x51, 0x43, 0x22, 0x1f,
-	0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x48,
-	0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x22,
-	0x91, 0x01, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x61, 0x72,
-	0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x50, 0x61, 0x72, 0x65, 0x6e,
-	0x74, 0x12, 0x26, 0x0a, 0x02, 0x51, 0x43, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
-	0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x6f, 0x72, 0x75,
-	0x6d, 0x43, 0x65, 0x72, 0x74, 0x52, 0x02, 0x51, 0x43, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65,
-	0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x18, 0x0a,
-	0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
-	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x70, 0x6f,
-	0x73, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x50, 0x72, 0x6f, 0x70, 0x6f,
-	0x73, 0x65, 0x72, 0x22, 0x44, 0x0a, 0x0e, 0x45, 0x43, 0x44, 0x53, 0x41, 0x53, 0x69, 0x67, 0x6e,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x12, 0x0c, 0x0a,
-	0x01, 0x52, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x52, 0x12, 0x0c, 0x0a, 0x01, 0x53,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x53, 0x22, 0x22, 0x0a, 0x0e, 0x42, 0x4c, 0x53,
-	0x31, 0x32, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x53,
-	0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x53, 0x69, 0x67, 0x22, 0x86, 0x01,
-	0x0a, 0x09, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x38, 0x0a, 0x08, 0x45,
-	0x43, 0x44, 0x53, 0x41, 0x53, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
-	0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x45, 0x43, 0x44, 0x53, 0x41,
-	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x08, 0x45, 0x43, 0x44,
-	0x53, 0x41, 0x53, 0x69, 0x67, 0x12, 0x38, 0x0a, 0x08, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x53, 0x69,
-	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75,
-	0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74,
-	0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x08, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x53, 0x69, 0x67, 0x42,
-	0x05, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x22, 0x50, 0x0a, 0x0b, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61,
-	0x6c, 0x43, 0x65, 0x72, 0x74, 0x12, 0x2d, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e,
-	0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52,
-	0x03, 0x53, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x22, 0x45, 0x0a, 0x13, 0x45, 0x43, 0x44, 0x53,
-	0x41, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12,
-	0x2e, 0x0a, 0x04, 0x53, 0x69, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
-	0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x45, 0x43, 0x44, 0x53, 0x41,
-	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x04, 0x53, 0x69, 0x67, 0x73, 0x22,
-	0x4f, 0x0a, 0x17, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
-	0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x53, 0x69,
-	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x53, 0x69, 0x67, 0x12, 0x22, 0x0a, 0x0c,
-	0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x0c, 0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73,
-	0x22, 0x9c, 0x01, 0x0a, 0x0f, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x61,
-	0x74, 0x75, 0x72, 0x65, 0x12, 0x3f, 0x0a, 0x09, 0x45, 0x43, 0x44, 0x53, 0x41, 0x53, 0x69, 0x67,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75,
-	0x66, 0x66, 0x70, 0x62, 0x2e, 0x45, 0x43, 0x44, 0x53, 0x41, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x53,
-	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x09, 0x45, 0x43, 0x44, 0x53,
-	0x41, 0x53, 0x69, 0x67, 0x73, 0x12, 0x41, 0x0a, 0x08, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x53, 0x69,
-	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75,
-	0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67,
-	0x61, 0x74, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x08,
-	0x42, 0x4c, 0x53, 0x31, 0x32, 0x53, 0x69, 0x67, 0x42, 0x05, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x22,
-	0x63, 0x0a, 0x0a, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x43, 0x65, 0x72, 0x74, 0x12, 0x2d, 0x0a,
-	0x03, 0x53, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x68, 0x6f, 0x74,
-	0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x69,
-	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x03, 0x53, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04,
-	0x56, 0x69, 0x65, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77,
-	0x12, 0x12, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
-	0x48, 0x61, 0x73, 0x68, 0x22, 0x50, 0x0a, 0x0b, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x43,
-	0x65, 0x72, 0x74, 0x12, 0x2d, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1b, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x51, 0x75,
-	0x6f, 0x72, 0x75, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x03, 0x53,
-	0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x22, 0xce, 0x01, 0x0a, 0x0a, 0x54, 0x69, 0x6d, 0x65, 0x6f,
-	0x75, 0x74, 0x4d, 0x73, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x30, 0x0a, 0x08, 0x53, 0x79, 0x6e,
-	0x63, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x68, 0x6f,
-	0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x49, 0x6e, 0x66,
-	0x6f, 0x52, 0x08, 0x53, 0x79, 0x6e, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x35, 0x0a, 0x07, 0x56,
-	0x69, 0x65, 0x77, 0x53, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x68,
+	0x6f, 0x22, 0xf4, 0x01, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x27,
+	0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x52, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x2c, 0x0a, 0x05, 0x41, 0x67, 0x67, 0x51, 0x43,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66,
+	0x66, 0x70, 0x62, 0x2e, 0x41, 0x67, 0x67, 0x51, 0x43, 0x48, 0x00, 0x52, 0x05, 0x41, 0x67, 0x67,
+	0x51, 0x43, 0x88, 0x01, 0x01, 0x12, 0x2c, 0x0a, 0x02, 0x54, 0x43, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x43, 0x65, 0x72, 0x74, 0x48, 0x01, 0x52, 0x02, 0x54, 0x43,
+	0x88, 0x01, 0x01, 0x12, 0x42, 0x0a, 0x0b, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74,
+	0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x02, 0x52, 0x0b, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x41, 0x67, 0x67, 0x51,
+	0x43, 0x42, 0x05, 0x0a, 0x03, 0x5f, 0x54, 0x43, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x4c, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x1f, 0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x22, 0x5e, 0x0a, 0x0a, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x41, 0x6e, 0x63, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x08, 0x41, 0x6e, 0x63, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x4d,
+	0x61, 0x78, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09,
+	0x4d, 0x61, 0x78, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x22, 0x33, 0x0a, 0x06, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x73, 0x12, 0x29, 0x0a, 0x06, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62,
+	0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x06, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x22, 0xa7,
+	0x01, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74,
+	0x12, 0x26, 0x0a, 0x02, 0x51, 0x43, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x68,
 	0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d,
-	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x07, 0x56, 0x69, 0x65, 0x77, 0x53,
-	0x69, 0x67, 0x12, 0x38, 0x0a, 0x06, 0x4d, 0x73, 0x67, 0x53, 0x69, 0x67, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e,
-	0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x48,
-	0x00, 0x52, 0x06, 0x4d, 0x73, 0x67, 0x53, 0x69, 0x67, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07,
-	0x5f, 0x4d, 0x73, 0x67, 0x53, 0x69, 0x67, 0x22, 0xab, 0x01, 0x0a, 0x08, 0x53, 0x79, 0x6e, 0x63,
-	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x2b, 0x0a, 0x02, 0x51, 0x43, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x16, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x51, 0x75,
-	0x6f, 0x72, 0x75, 0x6d, 0x43, 0x65, 0x72, 0x74, 0x48, 0x00, 0x52, 0x02, 0x51, 0x43, 0x88, 0x01,
-	0x01, 0x12, 0x2c, 0x0a, 0x02, 0x54, 0x43, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x43, 0x65, 0x72, 0x74, 0x52, 0x02, 0x51, 0x43, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x18, 0x0a, 0x07,
+	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x43,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73,
+	0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73,
+	0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x22, 0x44, 0x0a, 0x0e, 0x45, 0x43, 0x44, 0x53,
+	0x41, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x69,
+	0x67, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x53, 0x69, 0x67, 0x6e,
+	0x65, 0x72, 0x12, 0x0c, 0x0a, 0x01, 0x52, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x52,
+	0x12, 0x0c, 0x0a, 0x01, 0x53, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x53, 0x22, 0x22,
+	0x0a, 0x0e, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x53,
+	0x69, 0x67, 0x22, 0x3c, 0x0a, 0x10, 0x45, 0x44, 0x32, 0x35, 0x35, 0x31, 0x39, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x12, 0x10,
+	0x0a, 0x03, 0x53, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x53, 0x69, 0x67,
+	0x22, 0xc6, 0x01, 0x0a, 0x09, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x38,
+	0x0a, 0x08, 0x45, 0x43, 0x44, 0x53, 0x41, 0x53, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x45, 0x43,
+	0x44, 0x53, 0x41, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x08,
+	0x45, 0x43, 0x44, 0x53, 0x41, 0x53, 0x69, 0x67, 0x12, 0x38, 0x0a, 0x08, 0x42, 0x4c, 0x53, 0x31,
+	0x32, 0x53, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x68, 0x6f, 0x74,
+	0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x08, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x53,
+	0x69, 0x67, 0x12, 0x3e, 0x0a, 0x0a, 0x45, 0x44, 0x32, 0x35, 0x35, 0x31, 0x39, 0x53, 0x69, 0x67,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66,
+	0x66, 0x70, 0x62, 0x2e, 0x45, 0x44, 0x32, 0x35, 0x35, 0x31, 0x39, 0x53, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x45, 0x44, 0x32, 0x35, 0x35, 0x31, 0x39, 0x53,
+	0x69, 0x67, 0x42, 0x05, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x22, 0x50, 0x0a, 0x0b, 0x50, 0x61, 0x72,
+	0x74, 0x69, 0x61, 0x6c, 0x43, 0x65, 0x72, 0x74, 0x12, 0x2d, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66,
+	0x70, 0x62, 0x2e, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x52, 0x03, 0x53, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x22, 0x45, 0x0a, 0x13, 0x45,
+	0x43, 0x44, 0x53, 0x41, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x53, 0x69, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x45, 0x43,
+	0x44, 0x53, 0x41, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x04, 0x53, 0x69,
+	0x67, 0x73, 0x22, 0x49, 0x0a, 0x15, 0x45, 0x44, 0x32, 0x35, 0x35, 0x31, 0x39, 0x4d, 0x75, 0x6c,
+	0x74, 0x69, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x30, 0x0a, 0x04, 0x53,
+	0x69, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x68, 0x6f, 0x74, 0x73,
+	0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x45, 0x44, 0x32, 0x35, 0x35, 0x31, 0x39, 0x53, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x04, 0x53, 0x69, 0x67, 0x73, 0x22, 0x4f, 0x0a,
+	0x17, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x53,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x53, 0x69, 0x67, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x61,
+	0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0c, 0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x22, 0x3d,
+	0x0a, 0x11, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x42, 0x4c, 0x53, 0x53, 0x68,
+	0x61, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x53,
+	0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x53, 0x69, 0x67, 0x22, 0x4b, 0x0a,
+	0x12, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x42, 0x4c, 0x53, 0x53, 0x68, 0x61,
+	0x72, 0x65, 0x73, 0x12, 0x35, 0x0a, 0x06, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62,
+	0x2e, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x42, 0x4c, 0x53, 0x53, 0x68, 0x61,
+	0x72, 0x65, 0x52, 0x06, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x22, 0x4c, 0x0a, 0x1a, 0x54, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x42, 0x4c, 0x53, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x53, 0x69, 0x67, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x54,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x22, 0x93, 0x03, 0x0a, 0x0f, 0x51, 0x75, 0x6f,
+	0x72, 0x75, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x3f, 0x0a, 0x09,
+	0x45, 0x43, 0x44, 0x53, 0x41, 0x53, 0x69, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x45, 0x43, 0x44,
+	0x53, 0x41, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x48, 0x00, 0x52, 0x09, 0x45, 0x43, 0x44, 0x53, 0x41, 0x53, 0x69, 0x67, 0x73, 0x12, 0x41, 0x0a,
+	0x08, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x53, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x23, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x4c, 0x53,
+	0x31, 0x32, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x08, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x53, 0x69, 0x67,
+	0x12, 0x50, 0x0a, 0x12, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x42, 0x4c, 0x53,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68,
+	0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68,
+	0x6f, 0x6c, 0x64, 0x42, 0x4c, 0x53, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x48, 0x00, 0x52, 0x12,
+	0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x42, 0x4c, 0x53, 0x53, 0x68, 0x61, 0x72,
+	0x65, 0x73, 0x12, 0x5c, 0x0a, 0x14, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x42,
+	0x4c, 0x53, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x69, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x26, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x54, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x42, 0x4c, 0x53, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x14, 0x54, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x42, 0x4c, 0x53, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x69, 0x67,
+	0x12, 0x45, 0x0a, 0x0b, 0x45, 0x44, 0x32, 0x35, 0x35, 0x31, 0x39, 0x53, 0x69, 0x67, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66,
+	0x70, 0x62, 0x2e, 0x45, 0x44, 0x32, 0x35, 0x35, 0x31, 0x39, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x53,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x45, 0x44, 0x32, 0x35,
+	0x35, 0x31, 0x39, 0x53, 0x69, 0x67, 0x73, 0x42, 0x05, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x22, 0x79,
+	0x0a, 0x0a, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x43, 0x65, 0x72, 0x74, 0x12, 0x2d, 0x0a, 0x03,
+	0x53, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x68, 0x6f, 0x74, 0x73,
+	0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x03, 0x53, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x56,
+	0x69, 0x65, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12,
+	0x12, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x22, 0x74, 0x0a, 0x0a, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x48, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56,
+	0x69, 0x65, 0x77, 0x12, 0x1c, 0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x1c, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x61, 0x73, 0x68, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x61, 0x73, 0x68, 0x22,
+	0x8d, 0x01, 0x0a, 0x0e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x43, 0x65,
+	0x72, 0x74, 0x12, 0x36, 0x0a, 0x0a, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66,
+	0x66, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0a,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x03, 0x53, 0x69,
+	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75,
+	0x66, 0x66, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x52, 0x03, 0x53, 0x69, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x70, 0x6f,
+	0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x22,
+	0x38, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x29,
+	0x0a, 0x06, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x52, 0x06, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x22, 0xcc, 0x01, 0x0a, 0x0b, 0x54, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x43, 0x65, 0x72, 0x74, 0x12, 0x2d, 0x0a, 0x03, 0x53, 0x69, 0x67,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66,
+	0x66, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x52, 0x03, 0x53, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x3e, 0x0a, 0x07,
+	0x51, 0x43, 0x56, 0x69, 0x65, 0x77, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e,
 	0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6f,
-	0x75, 0x74, 0x43, 0x65, 0x72, 0x74, 0x48, 0x01, 0x52, 0x02, 0x54, 0x43, 0x88, 0x01, 0x01, 0x12,
-	0x2c, 0x0a, 0x05, 0x41, 0x67, 0x67, 0x51, 0x43, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x75, 0x74, 0x43, 0x65, 0x72, 0x74, 0x2e, 0x51, 0x43, 0x56, 0x69, 0x65, 0x77, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x07, 0x51, 0x43, 0x56, 0x69, 0x65, 0x77, 0x73, 0x1a, 0x3a, 0x0a, 0x0c,
+	0x51, 0x43, 0x56, 0x69, 0x65, 0x77, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xce, 0x01, 0x0a, 0x0a, 0x54, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x30, 0x0a, 0x08, 0x53,
+	0x79, 0x6e, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x08, 0x53, 0x79, 0x6e, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x35, 0x0a,
+	0x07, 0x56, 0x69, 0x65, 0x77, 0x53, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x6f, 0x72,
+	0x75, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x07, 0x56, 0x69, 0x65,
+	0x77, 0x53, 0x69, 0x67, 0x12, 0x38, 0x0a, 0x06, 0x4d, 0x73, 0x67, 0x53, 0x69, 0x67, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70,
+	0x62, 0x2e, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x48, 0x00, 0x52, 0x06, 0x4d, 0x73, 0x67, 0x53, 0x69, 0x67, 0x88, 0x01, 0x01, 0x42, 0x09,
+	0x0a, 0x07, 0x5f, 0x4d, 0x73, 0x67, 0x53, 0x69, 0x67, 0x22, 0xab, 0x01, 0x0a, 0x08, 0x53, 0x79,
+	0x6e, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x2b, 0x0a, 0x02, 0x51, 0x43, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e,
+	0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x43, 0x65, 0x72, 0x74, 0x48, 0x00, 0x52, 0x02, 0x51, 0x43,
+	0x88, 0x01, 0x01, 0x12, 0x2c, 0x0a, 0x02, 0x54, 0x43, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x43, 0x65, 0x72, 0x74, 0x48, 0x01, 0x52, 0x02, 0x54, 0x43, 0x88, 0x01,
+	0x01, 0x12, 0x2c, 0x0a, 0x05, 0x41, 0x67, 0x67, 0x51, 0x43, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x41, 0x67,
+	0x67, 0x51, 0x43, 0x48, 0x02, 0x52, 0x05, 0x41, 0x67, 0x67, 0x51, 0x43, 0x88, 0x01, 0x01, 0x42,
+	0x05, 0x0a, 0x03, 0x5f, 0x51, 0x43, 0x42, 0x05, 0x0a, 0x03, 0x5f, 0x54, 0x43, 0x42, 0x08, 0x0a,
+	0x06, 0x5f, 0x41, 0x67, 0x67, 0x51, 0x43, 0x22, 0xc8, 0x01, 0x0a, 0x05, 0x41, 0x67, 0x67, 0x51,
+	0x43, 0x12, 0x2c, 0x0a, 0x03, 0x51, 0x43, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a,
 	0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x41, 0x67, 0x67, 0x51,
-	0x43, 0x48, 0x02, 0x52, 0x05, 0x41, 0x67, 0x67, 0x51, 0x43, 0x88, 0x01, 0x01, 0x42, 0x05, 0x0a,
-	0x03, 0x5f, 0x51, 0x43, 0x42, 0x05, 0x0a, 0x03, 0x5f, 0x54, 0x43, 0x42, 0x08, 0x0a, 0x06, 0x5f,
-	0x41, 0x67, 0x67, 0x51, 0x43, 0x22, 0xc8, 0x01, 0x0a, 0x05, 0x41, 0x67, 0x67, 0x51, 0x43, 0x12,
-	0x2c, 0x0a, 0x03, 0x51, 0x43, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x68,
-	0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x41, 0x67, 0x67, 0x51, 0x43, 0x2e,
-	0x51, 0x43, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x51, 0x43, 0x73, 0x12, 0x2d, 0x0a,
-	0x03, 0x53, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x68, 0x6f, 0x74,
-	0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x69,
-	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x03, 0x53, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04,
-	0x56, 0x69, 0x65, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77,
-	0x1a, 0x4e, 0x0a, 0x08, 0x51, 0x43, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x43, 0x2e, 0x51, 0x43, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x51, 0x43, 0x73, 0x12,
+	0x2d, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x68,
+	0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d,
+	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x03, 0x53, 0x69, 0x67, 0x12, 0x12,
+	0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69,
+	0x65, 0x77, 0x1a, 0x4e, 0x0a, 0x08, 0x51, 0x43, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x6f,
+	0x72, 0x75, 0x6d, 0x43, 0x65, 0x72, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x75, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x61, 0x73, 0x74, 0x56, 0x6f, 0x74, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x61, 0x73, 0x74, 0x56, 0x6f, 0x74, 0x65,
+	0x12, 0x27, 0x0a, 0x05, 0x42, 0x45, 0x78, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x52, 0x05, 0x42, 0x45, 0x78, 0x65, 0x63, 0x12, 0x1e, 0x0a, 0x0a, 0x4c, 0x6f, 0x63,
+	0x6b, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x4c,
+	0x6f, 0x63, 0x6b, 0x65, 0x64, 0x56, 0x69, 0x65, 0x77, 0x22, 0xe5, 0x01, 0x0a, 0x11, 0x53, 0x79,
+	0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x20, 0x0a, 0x0b, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x56, 0x69, 0x65, 0x77, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x56, 0x69, 0x65,
+	0x77, 0x12, 0x2e, 0x0a, 0x06, 0x48, 0x69, 0x67, 0x68, 0x51, 0x43, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x51,
+	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x43, 0x65, 0x72, 0x74, 0x52, 0x06, 0x48, 0x69, 0x67, 0x68, 0x51,
+	0x43, 0x12, 0x2f, 0x0a, 0x06, 0x48, 0x69, 0x67, 0x68, 0x54, 0x43, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x43, 0x65, 0x72, 0x74, 0x52, 0x06, 0x48, 0x69, 0x67, 0x68,
+	0x54, 0x43, 0x12, 0x3d, 0x0a, 0x0b, 0x4c, 0x61, 0x73, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75,
+	0x66, 0x66, 0x70, 0x62, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x67, 0x48,
+	0x00, 0x52, 0x0b, 0x4c, 0x61, 0x73, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x88, 0x01,
+	0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x4c, 0x61, 0x73, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x22, 0x4d, 0x0a, 0x0e, 0x50, 0x42, 0x46, 0x54, 0x50, 0x72, 0x65, 0x50, 0x72, 0x65, 0x70,
+	0x61, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x27, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66,
+	0x66, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x22, 0x7d, 0x0a, 0x08, 0x50, 0x42, 0x46, 0x54, 0x56, 0x6f, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x56, 0x69, 0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77,
+	0x12, 0x12, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x48, 0x61, 0x73, 0x68, 0x12, 0x2d, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x51,
+	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x03,
+	0x53, 0x69, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22,
+	0x7e, 0x0a, 0x10, 0x50, 0x42, 0x46, 0x54, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x43,
+	0x65, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x27, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66,
+	0x66, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x12, 0x2d, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
 	0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x6f, 0x72, 0x75,
-	0x6d, 0x43, 0x65, 0x72, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x32, 0xc1, 0x02, 0x0a, 0x08, 0x48, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x12, 0x3d, 0x0a,
-	0x07, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x12, 0x14, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74,
-	0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x1a, 0x16,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x04, 0x98, 0xb5, 0x18, 0x01, 0x12, 0x3d, 0x0a, 0x04,
-	0x56, 0x6f, 0x74, 0x65, 0x12, 0x17, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70,
-	0x62, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x43, 0x65, 0x72, 0x74, 0x1a, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x04, 0x90, 0xb5, 0x18, 0x01, 0x12, 0x3f, 0x0a, 0x07, 0x54,
-	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66,
-	0x66, 0x70, 0x62, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x67, 0x1a, 0x16,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x04, 0x98, 0xb5, 0x18, 0x01, 0x12, 0x3d, 0x0a, 0x07,
-	0x4e, 0x65, 0x77, 0x56, 0x69, 0x65, 0x77, 0x12, 0x14, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75,
-	0x66, 0x66, 0x70, 0x62, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x04, 0x90, 0xb5, 0x18, 0x01, 0x12, 0x37, 0x0a, 0x05, 0x46,
-	0x65, 0x74, 0x63, 0x68, 0x12, 0x15, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70,
-	0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x1a, 0x11, 0x2e, 0x68, 0x6f,
-	0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x04,
-	0xa0, 0xb5, 0x18, 0x01, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x72, 0x65, 0x6c, 0x61, 0x62, 0x2f, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66,
-	0x66, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2f, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x6d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x03, 0x53, 0x69, 0x67, 0x22,
+	0x9f, 0x01, 0x0a, 0x0e, 0x50, 0x42, 0x46, 0x54, 0x56, 0x69, 0x65, 0x77, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x3d, 0x0a, 0x08, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74,
+	0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72,
+	0x65, 0x64, 0x43, 0x65, 0x72, 0x74, 0x48, 0x00, 0x52, 0x08, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72,
+	0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e,
+	0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52,
+	0x03, 0x53, 0x69, 0x67, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65,
+	0x64, 0x22, 0x97, 0x01, 0x0a, 0x0b, 0x50, 0x42, 0x46, 0x54, 0x4e, 0x65, 0x77, 0x56, 0x69, 0x65,
+	0x77, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x3c, 0x0a, 0x0b, 0x56, 0x69, 0x65, 0x77, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x68, 0x6f, 0x74,
+	0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x56, 0x69, 0x65, 0x77,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0b, 0x56, 0x69, 0x65, 0x77, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x88, 0x01,
+	0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x32, 0xc3, 0x03, 0x0a, 0x08,
+	0x48, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x12, 0x3d, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x70,
+	0x6f, 0x73, 0x65, 0x12, 0x14, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62,
+	0x2e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x22, 0x04, 0x98, 0xb5, 0x18, 0x01, 0x12, 0x3d, 0x0a, 0x04, 0x56, 0x6f, 0x74, 0x65, 0x12,
+	0x17, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x50, 0x61, 0x72,
+	0x74, 0x69, 0x61, 0x6c, 0x43, 0x65, 0x72, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x22, 0x04, 0x90, 0xb5, 0x18, 0x01, 0x12, 0x40, 0x0a, 0x07, 0x56, 0x6f, 0x74, 0x65, 0x41, 0x6c,
+	0x6c, 0x12, 0x17, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x50,
+	0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x43, 0x65, 0x72, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0x04, 0x98, 0xb5, 0x18, 0x01, 0x12, 0x3f, 0x0a, 0x07, 0x54, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x12, 0x16, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x67, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x22, 0x04, 0x98, 0xb5, 0x18, 0x01, 0x12, 0x3d, 0x0a, 0x07, 0x4e, 0x65, 0x77,
+	0x56, 0x69, 0x65, 0x77, 0x12, 0x14, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70,
+	0x62, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0x04, 0x90, 0xb5, 0x18, 0x01, 0x12, 0x37, 0x0a, 0x05, 0x46, 0x65, 0x74, 0x63,
+	0x68, 0x12, 0x15, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x1a, 0x11, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74,
+	0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x04, 0xa0, 0xb5, 0x18,
+	0x01, 0x12, 0x3e, 0x0a, 0x0a, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12,
+	0x16, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x12, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75,
+	0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x22, 0x04, 0xa0, 0xb5, 0x18,
+	0x01, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x72, 0x65, 0x6c, 0x61, 0x62, 0x2f, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x2f, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x68, 0x6f,
+	0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1099,62 +2367,109 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP() []byte {
 	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescData
 }
 
-var file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_internal_proto_hotstuffpb_hotstuff_proto_goTypes = []interface{}{
-	(*Proposal)(nil),                // 0: hotstuffpb.Proposal
-	(*BlockHash)(nil),               // 1: hotstuffpb.BlockHash
-	(*Block)(nil),                   // 2: hotstuffpb.Block
-	(*ECDSASignature)(nil),          // 3: hotstuffpb.ECDSASignature
-	(*BLS12Signature)(nil),          // 4: hotstuffpb.BLS12Signature
-	(*Signature)(nil),               // 5: hotstuffpb.Signature
-	(*PartialCert)(nil),             // 6: hotstuffpb.PartialCert
-	(*ECDSAMultiSignature)(nil),     // 7: hotstuffpb.ECDSAMultiSignature
-	(*BLS12AggregateSignature)(nil), // 8: hotstuffpb.BLS12AggregateSignature
-	(*QuorumSignature)(nil),         // 9: hotstuffpb.QuorumSignature
-	(*QuorumCert)(nil),              // 10: hotstuffpb.QuorumCert
-	(*TimeoutCert)(nil),             // 11: hotstuffpb.TimeoutCert
-	(*TimeoutMsg)(nil),              // 12: hotstuffpb.TimeoutMsg
-	(*SyncInfo)(nil),                // 13: hotstuffpb.SyncInfo
-	(*AggQC)(nil),                   // 14: hotstuffpb.AggQC
-	nil,                             // 15: hotstuffpb.AggQC.QCsEntry
-	(*emptypb.Empty)(nil),           // 16: google.protobuf.Empty
+	(*Proposal)(nil),                   // 0: hotstuffpb.Proposal
+	(*BlockHash)(nil),                  // 1: hotstuffpb.BlockHash
+	(*BlockRange)(nil),                 // 2: hotstuffpb.BlockRange
+	(*Blocks)(nil),                     // 3: hotstuffpb.Blocks
+	(*Block)(nil),                      // 4: hotstuffpb.Block
+	(*ECDSASignature)(nil),             // 5: hotstuffpb.ECDSASignature
+	(*BLS12Signature)(nil),             // 6: hotstuffpb.BLS12Signature
+	(*ED25519Signature)(nil),           // 7: hotstuffpb.ED25519Signature
+	(*Signature)(nil),                  // 8: hotstuffpb.Signature
+	(*PartialCert)(nil),                // 9: hotstuffpb.PartialCert
+	(*ECDSAMultiSignature)(nil),        // 10: hotstuffpb.ECDSAMultiSignature
+	(*ED25519MultiSignature)(nil),      // 11: hotstuffpb.ED25519MultiSignature
+	(*BLS12AggregateSignature)(nil),    // 12: hotstuffpb.BLS12AggregateSignature
+	(*ThresholdBLSShare)(nil),          // 13: hotstuffpb.ThresholdBLSShare
+	(*ThresholdBLSShares)(nil),         // 14: hotstuffpb.ThresholdBLSShares
+	(*ThresholdBLSGroupSignature)(nil), // 15: hotstuffpb.ThresholdBLSGroupSignature
+	(*QuorumSignature)(nil),            // 16: hotstuffpb.QuorumSignature
+	(*QuorumCert)(nil),                 // 17: hotstuffpb.QuorumCert
+	(*Checkpoint)(nil),                 // 18: hotstuffpb.Checkpoint
+	(*CheckpointCert)(nil),             // 19: hotstuffpb.CheckpointCert
+	(*CommitProof)(nil),                // 20: hotstuffpb.CommitProof
+	(*TimeoutCert)(nil),                // 21: hotstuffpb.TimeoutCert
+	(*TimeoutMsg)(nil),                 // 22: hotstuffpb.TimeoutMsg
+	(*SyncInfo)(nil),                   // 23: hotstuffpb.SyncInfo
+	(*AggQC)(nil),                      // 24: hotstuffpb.AggQC
+	(*ConsensusState)(nil),             // 25: hotstuffpb.ConsensusState
+	(*SynchronizerState)(nil),          // 26: hotstuffpb.SynchronizerState
+	(*PBFTPrePrepare)(nil),             // 27: hotstuffpb.PBFTPrePrepare
+	(*PBFTVote)(nil),                   // 28: hotstuffpb.PBFTVote
+	(*PBFTPreparedCert)(nil),           // 29: hotstuffpb.PBFTPreparedCert
+	(*PBFTViewChange)(nil),             // 30: hotstuffpb.PBFTViewChange
+	(*PBFTNewView)(nil),                // 31: hotstuffpb.PBFTNewView
+	nil,                                // 32: hotstuffpb.TimeoutCert.QCViewsEntry
+	nil,                                // 33: hotstuffpb.AggQC.QCsEntry
+	(*emptypb.Empty)(nil),              // 34: google.protobuf.Empty
 }
 var file_internal_proto_hotstuffpb_hotstuff_proto_depIdxs = []int32{
-	2,  // 0: hotstuffpb.Proposal.Block:type_name -> hotstuffpb.Block
-	14, // 1: hotstuffpb.Proposal.AggQC:type_name -> hotstuffpb.AggQC
-	10, // 2: hotstuffpb.Block.QC:type_name -> hotstuffpb.QuorumCert
-	3,  // 3: hotstuffpb.Signature.ECDSASig:type_name -> hotstuffpb.ECDSASignature
-	4,  // 4: hotstuffpb.Signature.BLS12Sig:type_name -> hotstuffpb.BLS12Signature
-	9,  // 5: hotstuffpb.PartialCert.Sig:type_name -> hotstuffpb.QuorumSignature
-	3,  // 6: hotstuffpb.ECDSAMultiSignature.Sigs:type_name -> hotstuffpb.ECDSASignature
-	7,  // 7: hotstuffpb.QuorumSignature.ECDSASigs:type_name -> hotstuffpb.ECDSAMultiSignature
-	8,  // 8: hotstuffpb.QuorumSignature.BLS12Sig:type_name -> hotstuffpb.BLS12AggregateSignature
-	9,  // 9: hotstuffpb.QuorumCert.Sig:type_name -> hotstuffpb.QuorumSignature
-	9,  // 10: hotstuffpb.TimeoutCert.Sig:type_name -> hotstuffpb.QuorumSignature
-	13, // 11: hotstuffpb.TimeoutMsg.SyncInfo:type_name -> hotstuffpb.SyncInfo
-	9,  // 12: hotstuffpb.TimeoutMsg.ViewSig:type_name -> hotstuffpb.QuorumSignature
-	9,  // 13: hotstuffpb.TimeoutMsg.MsgSig:type_name -> hotstuffpb.QuorumSignature
-	10, // 14: hotstuffpb.SyncInfo.QC:type_name -> hotstuffpb.QuorumCert
-	11, // 15: hotstuffpb.SyncInfo.TC:type_name -> hotstuffpb.TimeoutCert
-	14, // 16: hotstuffpb.SyncInfo.AggQC:type_name -> hotstuffpb.AggQC
-	15, // 17: hotstuffpb.AggQC.QCs:type_name -> hotstuffpb.AggQC.QCsEntry
-	9,  // 18: hotstuffpb.AggQC.Sig:type_name -> hotstuffpb.QuorumSignature
-	10, // 19: hotstuffpb.AggQC.QCsEntry.value:type_name -> hotstuffpb.QuorumCert
-	0,  // 20: hotstuffpb.Hotstuff.Propose:input_type -> hotstuffpb.Proposal
-	6,  // 21: hotstuffpb.Hotstuff.Vote:input_type -> hotstuffpb.PartialCert
-	12, // 22: hotstuffpb.Hotstuff.Timeout:input_type -> hotstuffpb.TimeoutMsg
-	13, // 23: hotstuffpb.Hotstuff.NewView:input_type -> hotstuffpb.SyncInfo
-	1,  // 24: hotstuffpb.Hotstuff.Fetch:input_type -> hotstuffpb.BlockHash
-	16, // 25: hotstuffpb.Hotstuff.Propose:output_type -> google.protobuf.Empty
-	16, // 26: hotstuffpb.Hotstuff.Vote:output_type -> google.protobuf.Empty
-	16, // 27: hotstuffpb.Hotstuff.Timeout:output_type -> google.protobuf.Empty
-	16, // 28: hotstuffpb.Hotstuff.NewView:output_type -> google.protobuf.Empty
-	2,  // 29: hotstuffpb.Hotstuff.Fetch:output_type -> hotstuffpb.Block
-	25, // [25:30] is the sub-list for method output_type
-	20, // [20:25] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	4,  // 0: hotstuffpb.Proposal.Block:type_name -> hotstuffpb.Block
+	24, // 1: hotstuffpb.Proposal.AggQC:type_name -> hotstuffpb.AggQC
+	21, // 2: hotstuffpb.Proposal.TC:type_name -> hotstuffpb.TimeoutCert
+	16, // 3: hotstuffpb.Proposal.LeaderProof:type_name -> hotstuffpb.QuorumSignature
+	4,  // 4: hotstuffpb.Blocks.Blocks:type_name -> hotstuffpb.Block
+	17, // 5: hotstuffpb.Block.QC:type_name -> hotstuffpb.QuorumCert
+	5,  // 6: hotstuffpb.Signature.ECDSASig:type_name -> hotstuffpb.ECDSASignature
+	6,  // 7: hotstuffpb.Signature.BLS12Sig:type_name -> hotstuffpb.BLS12Signature
+	7,  // 8: hotstuffpb.Signature.ED25519Sig:type_name -> hotstuffpb.ED25519Signature
+	16, // 9: hotstuffpb.PartialCert.Sig:type_name -> hotstuffpb.QuorumSignature
+	5,  // 10: hotstuffpb.ECDSAMultiSignature.Sigs:type_name -> hotstuffpb.ECDSASignature
+	7,  // 11: hotstuffpb.ED25519MultiSignature.Sigs:type_name -> hotstuffpb.ED25519Signature
+	13, // 12: hotstuffpb.ThresholdBLSShares.Shares:type_name -> hotstuffpb.ThresholdBLSShare
+	10, // 13: hotstuffpb.QuorumSignature.ECDSASigs:type_name -> hotstuffpb.ECDSAMultiSignature
+	12, // 14: hotstuffpb.QuorumSignature.BLS12Sig:type_name -> hotstuffpb.BLS12AggregateSignature
+	14, // 15: hotstuffpb.QuorumSignature.ThresholdBLSShares:type_name -> hotstuffpb.ThresholdBLSShares
+	15, // 16: hotstuffpb.QuorumSignature.ThresholdBLSGroupSig:type_name -> hotstuffpb.ThresholdBLSGroupSignature
+	11, // 17: hotstuffpb.QuorumSignature.ED25519Sigs:type_name -> hotstuffpb.ED25519MultiSignature
+	16, // 18: hotstuffpb.QuorumCert.Sig:type_name -> hotstuffpb.QuorumSignature
+	18, // 19: hotstuffpb.CheckpointCert.Checkpoint:type_name -> hotstuffpb.Checkpoint
+	16, // 20: hotstuffpb.CheckpointCert.Sig:type_name -> hotstuffpb.QuorumSignature
+	4,  // 21: hotstuffpb.CommitProof.Blocks:type_name -> hotstuffpb.Block
+	16, // 22: hotstuffpb.TimeoutCert.Sig:type_name -> hotstuffpb.QuorumSignature
+	32, // 23: hotstuffpb.TimeoutCert.QCViews:type_name -> hotstuffpb.TimeoutCert.QCViewsEntry
+	23, // 24: hotstuffpb.TimeoutMsg.SyncInfo:type_name -> hotstuffpb.SyncInfo
+	16, // 25: hotstuffpb.TimeoutMsg.ViewSig:type_name -> hotstuffpb.QuorumSignature
+	16, // 26: hotstuffpb.TimeoutMsg.MsgSig:type_name -> hotstuffpb.QuorumSignature
+	17, // 27: hotstuffpb.SyncInfo.QC:type_name -> hotstuffpb.QuorumCert
+	21, // 28: hotstuffpb.SyncInfo.TC:type_name -> hotstuffpb.TimeoutCert
+	24, // 29: hotstuffpb.SyncInfo.AggQC:type_name -> hotstuffpb.AggQC
+	33, // 30: hotstuffpb.AggQC.QCs:type_name -> hotstuffpb.AggQC.QCsEntry
+	16, // 31: hotstuffpb.AggQC.Sig:type_name -> hotstuffpb.QuorumSignature
+	4,  // 32: hotstuffpb.ConsensusState.BExec:type_name -> hotstuffpb.Block
+	17, // 33: hotstuffpb.SynchronizerState.HighQC:type_name -> hotstuffpb.QuorumCert
+	21, // 34: hotstuffpb.SynchronizerState.HighTC:type_name -> hotstuffpb.TimeoutCert
+	22, // 35: hotstuffpb.SynchronizerState.LastTimeout:type_name -> hotstuffpb.TimeoutMsg
+	4,  // 36: hotstuffpb.PBFTPrePrepare.Block:type_name -> hotstuffpb.Block
+	16, // 37: hotstuffpb.PBFTVote.Sig:type_name -> hotstuffpb.QuorumSignature
+	4,  // 38: hotstuffpb.PBFTPreparedCert.Block:type_name -> hotstuffpb.Block
+	16, // 39: hotstuffpb.PBFTPreparedCert.Sig:type_name -> hotstuffpb.QuorumSignature
+	29, // 40: hotstuffpb.PBFTViewChange.Prepared:type_name -> hotstuffpb.PBFTPreparedCert
+	16, // 41: hotstuffpb.PBFTViewChange.Sig:type_name -> hotstuffpb.QuorumSignature
+	30, // 42: hotstuffpb.PBFTNewView.ViewChanges:type_name -> hotstuffpb.PBFTViewChange
+	4,  // 43: hotstuffpb.PBFTNewView.Block:type_name -> hotstuffpb.Block
+	17, // 44: hotstuffpb.AggQC.QCsEntry.value:type_name -> hotstuffpb.QuorumCert
+	0,  // 45: hotstuffpb.Hotstuff.Propose:input_type -> hotstuffpb.Proposal
+	9,  // 46: hotstuffpb.Hotstuff.Vote:input_type -> hotstuffpb.PartialCert
+	9,  // 47: hotstuffpb.Hotstuff.VoteAll:input_type -> hotstuffpb.PartialCert
+	22, // 48: hotstuffpb.Hotstuff.Timeout:input_type -> hotstuffpb.TimeoutMsg
+	23, // 49: hotstuffpb.Hotstuff.NewView:input_type -> hotstuffpb.SyncInfo
+	1,  // 50: hotstuffpb.Hotstuff.Fetch:input_type -> hotstuffpb.BlockHash
+	2,  // 51: hotstuffpb.Hotstuff.FetchRange:input_type -> hotstuffpb.BlockRange
+	34, // 52: hotstuffpb.Hotstuff.Propose:output_type -> google.protobuf.Empty
+	34, // 53: hotstuffpb.Hotstuff.Vote:output_type -> google.protobuf.Empty
+	34, // 54: hotstuffpb.Hotstuff.VoteAll:output_type -> google.protobuf.Empty
+	34, // 55: hotstuffpb.Hotstuff.Timeout:output_type -> google.protobuf.Empty
+	34, // 56: hotstuffpb.Hotstuff.NewView:output_type -> google.protobuf.Empty
+	4,  // 57: hotstuffpb.Hotstuff.Fetch:output_type -> hotstuffpb.Block
+	3,  // 58: hotstuffpb.Hotstuff.FetchRange:output_type -> hotstuffpb.Blocks
+	52, // [52:59] is the sub-list for method output_type
+	45, // [45:52] is the sub-list for method input_type
+	45, // [45:45] is the sub-list for extension type_name
+	45, // [45:45] is the sub-list for extension extendee
+	0,  // [0:45] is the sub-list for field type_name
 }
 
 func init() { file_internal_proto_hotstuffpb_hotstuff_proto_init() }
@@ -1188,7 +2503,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Block); i {
+			switch v := v.(*BlockRange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1200,7 +2515,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ECDSASignature); i {
+			switch v := v.(*Blocks); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1212,7 +2527,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BLS12Signature); i {
+			switch v := v.(*Block); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1224,7 +2539,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Signature); i {
+			switch v := v.(*ECDSASignature); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1236,7 +2551,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PartialCert); i {
+			switch v := v.(*BLS12Signature); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1248,7 +2563,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ECDSAMultiSignature); i {
+			switch v := v.(*ED25519Signature); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1260,7 +2575,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BLS12AggregateSignature); i {
+			switch v := v.(*Signature); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1272,7 +2587,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QuorumSignature); i {
+			switch v := v.(*PartialCert); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1284,7 +2599,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QuorumCert); i {
+			switch v := v.(*ECDSAMultiSignature); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1296,7 +2611,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TimeoutCert); i {
+			switch v := v.(*ED25519MultiSignature); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1308,7 +2623,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TimeoutMsg); i {
+			switch v := v.(*BLS12AggregateSignature); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1320,7 +2635,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SyncInfo); i {
+			switch v := v.(*ThresholdBLSShare); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1332,6 +2647,126 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ThresholdBLSShares); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ThresholdBLSGroupSignature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuorumSignature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuorumCert); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Checkpoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckpointCert); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommitProof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimeoutCert); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimeoutMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AggQC); i {
 			case 0:
 				return &v.state
@@ -1343,25 +2778,116 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 				return nil
 			}
 		}
+		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConsensusState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SynchronizerState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PBFTPrePrepare); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PBFTVote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PBFTPreparedCert); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PBFTViewChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PBFTNewView); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[0].OneofWrappers = []interface{}{}
-	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[5].OneofWrappers = []interface{}{
+	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[8].OneofWrappers = []interface{}{
 		(*Signature_ECDSASig)(nil),
 		(*Signature_BLS12Sig)(nil),
+		(*Signature_ED25519Sig)(nil),
 	}
-	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[9].OneofWrappers = []interface{}{
+	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[16].OneofWrappers = []interface{}{
 		(*QuorumSignature_ECDSASigs)(nil),
 		(*QuorumSignature_BLS12Sig)(nil),
-	}
-	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[12].OneofWrappers = []interface{}{}
-	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[13].OneofWrappers = []interface{}{}
+		(*QuorumSignature_ThresholdBLSShares)(nil),
+		(*QuorumSignature_ThresholdBLSGroupSig)(nil),
+		(*QuorumSignature_ED25519Sigs)(nil),
+	}
+	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[22].OneofWrappers = []interface{}{}
+	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[23].OneofWrappers = []interface{}{}
+	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[26].OneofWrappers = []interface{}{}
+	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[30].OneofWrappers = []interface{}{}
+	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[31].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_proto_hotstuffpb_hotstuff_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
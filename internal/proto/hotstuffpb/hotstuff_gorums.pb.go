@@ -161,6 +161,19 @@ func (c *Configuration) Propose(ctx context.Context, in *Proposal, opts ...gorum
 // Reference imports to suppress errors if they are not otherwise used.
 var _ emptypb.Empty
 
+// VoteAll sends a vote to all replicas instead of only the next leader.
+func (c *Configuration) VoteAll(ctx context.Context, in *PartialCert, opts ...gorums.CallOption) {
+	cd := gorums.QuorumCallData{
+		Message: in,
+		Method:  "hotstuffpb.Hotstuff.VoteAll",
+	}
+
+	c.RawConfiguration.Multicast(ctx, cd, opts...)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ emptypb.Empty
+
 // Timeout is a quorum call invoked on all nodes in configuration c,
 // with the same argument in, and returns a combined result.
 func (c *Configuration) Timeout(ctx context.Context, in *TimeoutMsg, opts ...gorums.CallOption) {
@@ -182,6 +195,13 @@ type QuorumSpec interface {
 	// be used by the quorum function. If the in parameter is not needed
 	// you should implement your quorum function with '_ *BlockHash'.
 	FetchQF(in *BlockHash, replies map[uint32]*Block) (*Block, bool)
+
+	// FetchRangeQF is the quorum function for the FetchRange
+	// quorum call method. The in parameter is the request object
+	// supplied to the FetchRange method at call time, and may or may not
+	// be used by the quorum function. If the in parameter is not needed
+	// you should implement your quorum function with '_ *BlockRange'.
+	FetchRangeQF(in *BlockRange, replies map[uint32]*Blocks) (*Blocks, bool)
 }
 
 // Fetch is a quorum call invoked on all nodes in configuration c,
@@ -206,13 +226,37 @@ func (c *Configuration) Fetch(ctx context.Context, in *BlockHash) (resp *Block,
 	return res.(*Block), err
 }
 
+// FetchRange is a quorum call invoked on all nodes in configuration c,
+// with the same argument in, and returns a combined result.
+func (c *Configuration) FetchRange(ctx context.Context, in *BlockRange) (resp *Blocks, err error) {
+	cd := gorums.QuorumCallData{
+		Message: in,
+		Method:  "hotstuffpb.Hotstuff.FetchRange",
+	}
+	cd.QuorumFunction = func(req protoreflect.ProtoMessage, replies map[uint32]protoreflect.ProtoMessage) (protoreflect.ProtoMessage, bool) {
+		r := make(map[uint32]*Blocks, len(replies))
+		for k, v := range replies {
+			r[k] = v.(*Blocks)
+		}
+		return c.qspec.FetchRangeQF(req.(*BlockRange), r)
+	}
+
+	res, err := c.RawConfiguration.QuorumCall(ctx, cd)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*Blocks), err
+}
+
 // Hotstuff is the server-side API for the Hotstuff Service
 type Hotstuff interface {
 	Propose(ctx gorums.ServerCtx, request *Proposal)
 	Vote(ctx gorums.ServerCtx, request *PartialCert)
+	VoteAll(ctx gorums.ServerCtx, request *PartialCert)
 	Timeout(ctx gorums.ServerCtx, request *TimeoutMsg)
 	NewView(ctx gorums.ServerCtx, request *SyncInfo)
 	Fetch(ctx gorums.ServerCtx, request *BlockHash) (response *Block, err error)
+	FetchRange(ctx gorums.ServerCtx, request *BlockRange) (response *Blocks, err error)
 }
 
 func RegisterHotstuffServer(srv *gorums.Server, impl Hotstuff) {
@@ -226,6 +270,11 @@ func RegisterHotstuffServer(srv *gorums.Server, impl Hotstuff) {
 		defer ctx.Release()
 		impl.Vote(ctx, req)
 	})
+	srv.RegisterHandler("hotstuffpb.Hotstuff.VoteAll", func(ctx gorums.ServerCtx, in *gorums.Message, _ chan<- *gorums.Message) {
+		req := in.Message.(*PartialCert)
+		defer ctx.Release()
+		impl.VoteAll(ctx, req)
+	})
 	srv.RegisterHandler("hotstuffpb.Hotstuff.Timeout", func(ctx gorums.ServerCtx, in *gorums.Message, _ chan<- *gorums.Message) {
 		req := in.Message.(*TimeoutMsg)
 		defer ctx.Release()
@@ -242,6 +291,12 @@ func RegisterHotstuffServer(srv *gorums.Server, impl Hotstuff) {
 		resp, err := impl.Fetch(ctx, req)
 		gorums.SendMessage(ctx, finished, gorums.WrapMessage(in.Metadata, resp, err))
 	})
+	srv.RegisterHandler("hotstuffpb.Hotstuff.FetchRange", func(ctx gorums.ServerCtx, in *gorums.Message, finished chan<- *gorums.Message) {
+		req := in.Message.(*BlockRange)
+		defer ctx.Release()
+		resp, err := impl.FetchRange(ctx, req)
+		gorums.SendMessage(ctx, finished, gorums.WrapMessage(in.Metadata, resp, err))
+	})
 }
 
 type internalBlock struct {
@@ -250,6 +305,12 @@ type internalBlock struct {
 	err   error
 }
 
+type internalBlocks struct {
+	nid   uint32
+	reply *Blocks
+	err   error
+}
+
 // Reference imports to suppress errors if they are not otherwise used.
 var _ emptypb.Empty
 
@@ -0,0 +1,84 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.19.4
+// source: internal/proto/kvpb/kv.proto
+
+package kvpb
+
+import (
+	_ "github.com/relab/gorums"
+	clientpb "github.com/relab/hotstuff/internal/proto/clientpb"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_internal_proto_kvpb_kv_proto protoreflect.FileDescriptor
+
+var file_internal_proto_kvpb_kv_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x6b, 0x76, 0x70, 0x62, 0x2f, 0x6b, 0x76, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04,
+	0x6b, 0x76, 0x70, 0x62, 0x1a, 0x0c, 0x67, 0x6f, 0x72, 0x75, 0x6d, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x15, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x70, 0x62, 0x2f, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x7b, 0x0a, 0x08, 0x4b, 0x65, 0x79,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x36, 0x0a, 0x07, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65,
+	0x12, 0x11, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x1a, 0x12, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x4b,
+	0x56, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x04, 0xa0, 0xb5, 0x18, 0x01, 0x12, 0x37, 0x0a,
+	0x04, 0x52, 0x65, 0x61, 0x64, 0x12, 0x15, 0x2e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x70, 0x62,
+	0x2e, 0x4b, 0x56, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x12, 0x2e, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x70, 0x62, 0x2e, 0x4b, 0x56, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x22, 0x04, 0xa0, 0xb5, 0x18, 0x01, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x65, 0x6c, 0x61, 0x62, 0x2f, 0x68, 0x6f, 0x74, 0x73, 0x74,
+	0x75, 0x66, 0x66, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x6b, 0x76, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var file_internal_proto_kvpb_kv_proto_goTypes = []interface{}{
+	(*clientpb.Command)(nil),     // 0: clientpb.Command
+	(*clientpb.KVOperation)(nil), // 1: clientpb.KVOperation
+	(*clientpb.KVResult)(nil),    // 2: clientpb.KVResult
+}
+var file_internal_proto_kvpb_kv_proto_depIdxs = []int32{
+	0, // 0: kvpb.KeyValue.Execute:input_type -> clientpb.Command
+	1, // 1: kvpb.KeyValue.Read:input_type -> clientpb.KVOperation
+	2, // 2: kvpb.KeyValue.Execute:output_type -> clientpb.KVResult
+	2, // 3: kvpb.KeyValue.Read:output_type -> clientpb.KVResult
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_internal_proto_kvpb_kv_proto_init() }
+func file_internal_proto_kvpb_kv_proto_init() {
+	if File_internal_proto_kvpb_kv_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internal_proto_kvpb_kv_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_internal_proto_kvpb_kv_proto_goTypes,
+		DependencyIndexes: file_internal_proto_kvpb_kv_proto_depIdxs,
+	}.Build()
+	File_internal_proto_kvpb_kv_proto = out.File
+	file_internal_proto_kvpb_kv_proto_rawDesc = nil
+	file_internal_proto_kvpb_kv_proto_goTypes = nil
+	file_internal_proto_kvpb_kv_proto_depIdxs = nil
+}
@@ -0,0 +1,110 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.19.4
+// source: internal/proto/pbftpb/pbft.proto
+
+package pbftpb
+
+import (
+	_ "github.com/relab/gorums"
+	hotstuffpb "github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_internal_proto_pbftpb_pbft_proto protoreflect.FileDescriptor
+
+var file_internal_proto_pbftpb_pbft_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x70, 0x62, 0x66, 0x74, 0x70, 0x62, 0x2f, 0x70, 0x62, 0x66, 0x74, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x06, 0x70, 0x62, 0x66, 0x74, 0x70, 0x62, 0x1a, 0x0c, 0x67, 0x6f, 0x72, 0x75,
+	0x6d, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75,
+	0x66, 0x66, 0x70, 0x62, 0x2f, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x32, 0xd5, 0x02, 0x0a, 0x04, 0x50, 0x42, 0x46, 0x54, 0x12, 0x46, 0x0a, 0x0a, 0x50, 0x72, 0x65,
+	0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x1a, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75,
+	0x66, 0x66, 0x70, 0x62, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x50, 0x72, 0x65, 0x50, 0x72, 0x65, 0x70,
+	0x61, 0x72, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x04, 0x98, 0xb5, 0x18,
+	0x01, 0x12, 0x3d, 0x0a, 0x07, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x14, 0x2e, 0x68,
+	0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x56, 0x6f,
+	0x74, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x04, 0x98, 0xb5, 0x18, 0x01,
+	0x12, 0x3c, 0x0a, 0x06, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x14, 0x2e, 0x68, 0x6f, 0x74,
+	0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x56, 0x6f, 0x74, 0x65,
+	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x04, 0x98, 0xb5, 0x18, 0x01, 0x12, 0x46,
+	0x0a, 0x0a, 0x56, 0x69, 0x65, 0x77, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1a, 0x2e, 0x68,
+	0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x56, 0x69,
+	0x65, 0x77, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x22, 0x04, 0x98, 0xb5, 0x18, 0x01, 0x12, 0x40, 0x0a, 0x07, 0x4e, 0x65, 0x77, 0x56, 0x69, 0x65,
+	0x77, 0x12, 0x17, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x50,
+	0x42, 0x46, 0x54, 0x4e, 0x65, 0x77, 0x56, 0x69, 0x65, 0x77, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0x04, 0x98, 0xb5, 0x18, 0x01, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x65, 0x6c, 0x61, 0x62, 0x2f, 0x68, 0x6f, 0x74,
+	0x73, 0x74, 0x75, 0x66, 0x66, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x62, 0x66, 0x74, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var file_internal_proto_pbftpb_pbft_proto_goTypes = []interface{}{
+	(*hotstuffpb.PBFTPrePrepare)(nil), // 0: hotstuffpb.PBFTPrePrepare
+	(*hotstuffpb.PBFTVote)(nil),       // 1: hotstuffpb.PBFTVote
+	(*hotstuffpb.PBFTViewChange)(nil), // 2: hotstuffpb.PBFTViewChange
+	(*hotstuffpb.PBFTNewView)(nil),    // 3: hotstuffpb.PBFTNewView
+	(*emptypb.Empty)(nil),             // 4: google.protobuf.Empty
+}
+var file_internal_proto_pbftpb_pbft_proto_depIdxs = []int32{
+	0, // 0: pbftpb.PBFT.PrePrepare:input_type -> hotstuffpb.PBFTPrePrepare
+	1, // 1: pbftpb.PBFT.Prepare:input_type -> hotstuffpb.PBFTVote
+	1, // 2: pbftpb.PBFT.Commit:input_type -> hotstuffpb.PBFTVote
+	2, // 3: pbftpb.PBFT.ViewChange:input_type -> hotstuffpb.PBFTViewChange
+	3, // 4: pbftpb.PBFT.NewView:input_type -> hotstuffpb.PBFTNewView
+	4, // 5: pbftpb.PBFT.PrePrepare:output_type -> google.protobuf.Empty
+	4, // 6: pbftpb.PBFT.Prepare:output_type -> google.protobuf.Empty
+	4, // 7: pbftpb.PBFT.Commit:output_type -> google.protobuf.Empty
+	4, // 8: pbftpb.PBFT.ViewChange:output_type -> google.protobuf.Empty
+	4, // 9: pbftpb.PBFT.NewView:output_type -> google.protobuf.Empty
+	5, // [5:10] is the sub-list for method output_type
+	0, // [0:5] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_internal_proto_pbftpb_pbft_proto_init() }
+func file_internal_proto_pbftpb_pbft_proto_init() {
+	if File_internal_proto_pbftpb_pbft_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internal_proto_pbftpb_pbft_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_internal_proto_pbftpb_pbft_proto_goTypes,
+		DependencyIndexes: file_internal_proto_pbftpb_pbft_proto_depIdxs,
+	}.Build()
+	File_internal_proto_pbftpb_pbft_proto = out.File
+	file_internal_proto_pbftpb_pbft_proto_rawDesc = nil
+	file_internal_proto_pbftpb_pbft_proto_goTypes = nil
+	file_internal_proto_pbftpb_pbft_proto_depIdxs = nil
+}
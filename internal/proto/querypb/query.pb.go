@@ -0,0 +1,266 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.19.4
+// source: internal/proto/querypb/query.proto
+
+package querypb
+
+import (
+	hotstuffpb "github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Height struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height uint64 `protobuf:"varint,1,opt,name=Height,proto3" json:"Height,omitempty"`
+}
+
+func (x *Height) Reset() {
+	*x = Height{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_querypb_query_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Height) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Height) ProtoMessage() {}
+
+func (x *Height) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_querypb_query_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Height.ProtoReflect.Descriptor instead.
+func (*Height) Descriptor() ([]byte, []int) {
+	return file_internal_proto_querypb_query_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Height) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// CommittedBlock is a block together with its position in the chain of
+// committed blocks.
+type CommittedBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Block *hotstuffpb.Block `protobuf:"bytes,1,opt,name=Block,proto3" json:"Block,omitempty"`
+	Hash  []byte            `protobuf:"bytes,2,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	// Height is the number of committed blocks up to and including the block.
+	Height uint64 `protobuf:"varint,3,opt,name=Height,proto3" json:"Height,omitempty"`
+}
+
+func (x *CommittedBlock) Reset() {
+	*x = CommittedBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_querypb_query_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommittedBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommittedBlock) ProtoMessage() {}
+
+func (x *CommittedBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_querypb_query_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommittedBlock.ProtoReflect.Descriptor instead.
+func (*CommittedBlock) Descriptor() ([]byte, []int) {
+	return file_internal_proto_querypb_query_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CommittedBlock) GetBlock() *hotstuffpb.Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *CommittedBlock) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *CommittedBlock) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+var File_internal_proto_querypb_query_proto protoreflect.FileDescriptor
+
+var file_internal_proto_querypb_query_proto_rawDesc = []byte{
+	0x0a, 0x22, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x70, 0x62, 0x2f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x71, 0x75, 0x65, 0x72, 0x79, 0x70, 0x62, 0x1a, 0x1b, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65,
+	0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x68, 0x6f, 0x74, 0x73,
+	0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2f, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x20, 0x0a, 0x06, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x06, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x65, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x27, 0x0a, 0x05, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74,
+	0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x05, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x32, 0x94,
+	0x02, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x3e, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x79, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x0f, 0x2e, 0x71,
+	0x75, 0x65, 0x72, 0x79, 0x70, 0x62, 0x2e, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x1a, 0x17, 0x2e,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65,
+	0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x79, 0x48, 0x61, 0x73, 0x68, 0x12, 0x15, 0x2e, 0x68, 0x6f, 0x74,
+	0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73,
+	0x68, 0x1a, 0x17, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x10,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64,
+	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x10, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x0f, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x70,
+	0x62, 0x2e, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x1a, 0x17, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x22, 0x00, 0x30, 0x01, 0x42, 0x32, 0x5a, 0x30, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x65, 0x6c, 0x61, 0x62, 0x2f, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75,
+	0x66, 0x66, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_internal_proto_querypb_query_proto_rawDescOnce sync.Once
+	file_internal_proto_querypb_query_proto_rawDescData = file_internal_proto_querypb_query_proto_rawDesc
+)
+
+func file_internal_proto_querypb_query_proto_rawDescGZIP() []byte {
+	file_internal_proto_querypb_query_proto_rawDescOnce.Do(func() {
+		file_internal_proto_querypb_query_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_proto_querypb_query_proto_rawDescData)
+	})
+	return file_internal_proto_querypb_query_proto_rawDescData
+}
+
+var file_internal_proto_querypb_query_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_internal_proto_querypb_query_proto_goTypes = []interface{}{
+	(*Height)(nil),               // 0: querypb.Height
+	(*CommittedBlock)(nil),       // 1: querypb.CommittedBlock
+	(*hotstuffpb.Block)(nil),     // 2: hotstuffpb.Block
+	(*hotstuffpb.BlockHash)(nil), // 3: hotstuffpb.BlockHash
+	(*emptypb.Empty)(nil),        // 4: google.protobuf.Empty
+}
+var file_internal_proto_querypb_query_proto_depIdxs = []int32{
+	2, // 0: querypb.CommittedBlock.Block:type_name -> hotstuffpb.Block
+	0, // 1: querypb.Query.GetBlockByHeight:input_type -> querypb.Height
+	3, // 2: querypb.Query.GetBlockByHash:input_type -> hotstuffpb.BlockHash
+	4, // 3: querypb.Query.GetCommittedHead:input_type -> google.protobuf.Empty
+	0, // 4: querypb.Query.SubscribeCommits:input_type -> querypb.Height
+	1, // 5: querypb.Query.GetBlockByHeight:output_type -> querypb.CommittedBlock
+	1, // 6: querypb.Query.GetBlockByHash:output_type -> querypb.CommittedBlock
+	1, // 7: querypb.Query.GetCommittedHead:output_type -> querypb.CommittedBlock
+	1, // 8: querypb.Query.SubscribeCommits:output_type -> querypb.CommittedBlock
+	5, // [5:9] is the sub-list for method output_type
+	1, // [1:5] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_internal_proto_querypb_query_proto_init() }
+func file_internal_proto_querypb_query_proto_init() {
+	if File_internal_proto_querypb_query_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_internal_proto_querypb_query_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Height); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_querypb_query_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommittedBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internal_proto_querypb_query_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_internal_proto_querypb_query_proto_goTypes,
+		DependencyIndexes: file_internal_proto_querypb_query_proto_depIdxs,
+		MessageInfos:      file_internal_proto_querypb_query_proto_msgTypes,
+	}.Build()
+	File_internal_proto_querypb_query_proto = out.File
+	file_internal_proto_querypb_query_proto_rawDesc = nil
+	file_internal_proto_querypb_query_proto_goTypes = nil
+	file_internal_proto_querypb_query_proto_depIdxs = nil
+}
@@ -0,0 +1,255 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: internal/proto/querypb/query.proto
+
+package querypb
+
+import (
+	context "context"
+	hotstuffpb "github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// QueryClient is the client API for Query service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type QueryClient interface {
+	// GetBlockByHeight returns the committed block at the given height.
+	GetBlockByHeight(ctx context.Context, in *Height, opts ...grpc.CallOption) (*CommittedBlock, error)
+	// GetBlockByHash returns the committed block with the given hash.
+	GetBlockByHash(ctx context.Context, in *hotstuffpb.BlockHash, opts ...grpc.CallOption) (*CommittedBlock, error)
+	// GetCommittedHead returns the most recently committed block.
+	GetCommittedHead(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CommittedBlock, error)
+	// SubscribeCommits streams the committed blocks from the given height, and
+	// then each block as it is committed. The stream is closed if the client
+	// does not keep up with the replica.
+	SubscribeCommits(ctx context.Context, in *Height, opts ...grpc.CallOption) (Query_SubscribeCommitsClient, error)
+}
+
+type queryClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewQueryClient(cc grpc.ClientConnInterface) QueryClient {
+	return &queryClient{cc}
+}
+
+func (c *queryClient) GetBlockByHeight(ctx context.Context, in *Height, opts ...grpc.CallOption) (*CommittedBlock, error) {
+	out := new(CommittedBlock)
+	err := c.cc.Invoke(ctx, "/querypb.Query/GetBlockByHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) GetBlockByHash(ctx context.Context, in *hotstuffpb.BlockHash, opts ...grpc.CallOption) (*CommittedBlock, error) {
+	out := new(CommittedBlock)
+	err := c.cc.Invoke(ctx, "/querypb.Query/GetBlockByHash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) GetCommittedHead(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CommittedBlock, error) {
+	out := new(CommittedBlock)
+	err := c.cc.Invoke(ctx, "/querypb.Query/GetCommittedHead", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) SubscribeCommits(ctx context.Context, in *Height, opts ...grpc.CallOption) (Query_SubscribeCommitsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Query_ServiceDesc.Streams[0], "/querypb.Query/SubscribeCommits", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &querySubscribeCommitsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Query_SubscribeCommitsClient interface {
+	Recv() (*CommittedBlock, error)
+	grpc.ClientStream
+}
+
+type querySubscribeCommitsClient struct {
+	grpc.ClientStream
+}
+
+func (x *querySubscribeCommitsClient) Recv() (*CommittedBlock, error) {
+	m := new(CommittedBlock)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// QueryServer is the server API for Query service.
+// All implementations must embed UnimplementedQueryServer
+// for forward compatibility
+type QueryServer interface {
+	// GetBlockByHeight returns the committed block at the given height.
+	GetBlockByHeight(context.Context, *Height) (*CommittedBlock, error)
+	// GetBlockByHash returns the committed block with the given hash.
+	GetBlockByHash(context.Context, *hotstuffpb.BlockHash) (*CommittedBlock, error)
+	// GetCommittedHead returns the most recently committed block.
+	GetCommittedHead(context.Context, *emptypb.Empty) (*CommittedBlock, error)
+	// SubscribeCommits streams the committed blocks from the given height, and
+	// then each block as it is committed. The stream is closed if the client
+	// does not keep up with the replica.
+	SubscribeCommits(*Height, Query_SubscribeCommitsServer) error
+	mustEmbedUnimplementedQueryServer()
+}
+
+// UnimplementedQueryServer must be embedded to have forward compatible implementations.
+type UnimplementedQueryServer struct {
+}
+
+func (UnimplementedQueryServer) GetBlockByHeight(context.Context, *Height) (*CommittedBlock, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockByHeight not implemented")
+}
+func (UnimplementedQueryServer) GetBlockByHash(context.Context, *hotstuffpb.BlockHash) (*CommittedBlock, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockByHash not implemented")
+}
+func (UnimplementedQueryServer) GetCommittedHead(context.Context, *emptypb.Empty) (*CommittedBlock, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommittedHead not implemented")
+}
+func (UnimplementedQueryServer) SubscribeCommits(*Height, Query_SubscribeCommitsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeCommits not implemented")
+}
+func (UnimplementedQueryServer) mustEmbedUnimplementedQueryServer() {}
+
+// UnsafeQueryServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to QueryServer will
+// result in compilation errors.
+type UnsafeQueryServer interface {
+	mustEmbedUnimplementedQueryServer()
+}
+
+func RegisterQueryServer(s grpc.ServiceRegistrar, srv QueryServer) {
+	s.RegisterService(&Query_ServiceDesc, srv)
+}
+
+func _Query_GetBlockByHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Height)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).GetBlockByHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/querypb.Query/GetBlockByHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).GetBlockByHeight(ctx, req.(*Height))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_GetBlockByHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(hotstuffpb.BlockHash)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).GetBlockByHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/querypb.Query/GetBlockByHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).GetBlockByHash(ctx, req.(*hotstuffpb.BlockHash))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_GetCommittedHead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).GetCommittedHead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/querypb.Query/GetCommittedHead",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).GetCommittedHead(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_SubscribeCommits_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Height)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QueryServer).SubscribeCommits(m, &querySubscribeCommitsServer{stream})
+}
+
+type Query_SubscribeCommitsServer interface {
+	Send(*CommittedBlock) error
+	grpc.ServerStream
+}
+
+type querySubscribeCommitsServer struct {
+	grpc.ServerStream
+}
+
+func (x *querySubscribeCommitsServer) Send(m *CommittedBlock) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Query_ServiceDesc is the grpc.ServiceDesc for Query service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Query_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "querypb.Query",
+	HandlerType: (*QueryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBlockByHeight",
+			Handler:    _Query_GetBlockByHeight_Handler,
+		},
+		{
+			MethodName: "GetBlockByHash",
+			Handler:    _Query_GetBlockByHash_Handler,
+		},
+		{
+			MethodName: "GetCommittedHead",
+			Handler:    _Query_GetCommittedHead_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeCommits",
+			Handler:       _Query_SubscribeCommits_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "internal/proto/querypb/query.proto",
+}
@@ -0,0 +1,409 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.19.4
+// source: internal/proto/safetyrulespb/safetyrules.proto
+
+package safetyrulespb
+
+import (
+	hotstuffpb "github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type VoteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Block *hotstuffpb.Block `protobuf:"bytes,1,opt,name=Block,proto3" json:"Block,omitempty"`
+	// QCBlock is the block certified by the QC of the block.
+	QCBlock *hotstuffpb.Block `protobuf:"bytes,2,opt,name=QCBlock,proto3" json:"QCBlock,omitempty"`
+}
+
+func (x *VoteRequest) Reset() {
+	*x = VoteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_safetyrulespb_safetyrules_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteRequest) ProtoMessage() {}
+
+func (x *VoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_safetyrulespb_safetyrules_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteRequest.ProtoReflect.Descriptor instead.
+func (*VoteRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_safetyrulespb_safetyrules_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *VoteRequest) GetBlock() *hotstuffpb.Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *VoteRequest) GetQCBlock() *hotstuffpb.Block {
+	if x != nil {
+		return x.QCBlock
+	}
+	return nil
+}
+
+type TimeoutRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timeout *hotstuffpb.TimeoutMsg `protobuf:"bytes,1,opt,name=Timeout,proto3" json:"Timeout,omitempty"`
+	// UseQCViewTC and UseAggQC are the protocol options of the replica
+	// that determine which signatures are needed.
+	UseQCViewTC bool `protobuf:"varint,2,opt,name=UseQCViewTC,proto3" json:"UseQCViewTC,omitempty"`
+	UseAggQC    bool `protobuf:"varint,3,opt,name=UseAggQC,proto3" json:"UseAggQC,omitempty"`
+}
+
+func (x *TimeoutRequest) Reset() {
+	*x = TimeoutRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_safetyrulespb_safetyrules_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeoutRequest) ProtoMessage() {}
+
+func (x *TimeoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_safetyrulespb_safetyrules_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeoutRequest.ProtoReflect.Descriptor instead.
+func (*TimeoutRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_safetyrulespb_safetyrules_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TimeoutRequest) GetTimeout() *hotstuffpb.TimeoutMsg {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *TimeoutRequest) GetUseQCViewTC() bool {
+	if x != nil {
+		return x.UseQCViewTC
+	}
+	return false
+}
+
+func (x *TimeoutRequest) GetUseAggQC() bool {
+	if x != nil {
+		return x.UseAggQC
+	}
+	return false
+}
+
+type View struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	View uint64 `protobuf:"varint,1,opt,name=View,proto3" json:"View,omitempty"`
+}
+
+func (x *View) Reset() {
+	*x = View{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_safetyrulespb_safetyrules_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *View) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*View) ProtoMessage() {}
+
+func (x *View) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_safetyrulespb_safetyrules_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use View.ProtoReflect.Descriptor instead.
+func (*View) Descriptor() ([]byte, []int) {
+	return file_internal_proto_safetyrulespb_safetyrules_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *View) GetView() uint64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+// State is the state of a safety rules server that is stored across restarts.
+type State struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LastVote   uint64 `protobuf:"varint,1,opt,name=LastVote,proto3" json:"LastVote,omitempty"`
+	LockedView uint64 `protobuf:"varint,2,opt,name=LockedView,proto3" json:"LockedView,omitempty"`
+}
+
+func (x *State) Reset() {
+	*x = State{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_safetyrulespb_safetyrules_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *State) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*State) ProtoMessage() {}
+
+func (x *State) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_safetyrulespb_safetyrules_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use State.ProtoReflect.Descriptor instead.
+func (*State) Descriptor() ([]byte, []int) {
+	return file_internal_proto_safetyrulespb_safetyrules_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *State) GetLastVote() uint64 {
+	if x != nil {
+		return x.LastVote
+	}
+	return 0
+}
+
+func (x *State) GetLockedView() uint64 {
+	if x != nil {
+		return x.LockedView
+	}
+	return 0
+}
+
+var File_internal_proto_safetyrulespb_safetyrules_proto protoreflect.FileDescriptor
+
+var file_internal_proto_safetyrulespb_safetyrules_proto_rawDesc = []byte{
+	0x0a, 0x2e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x73, 0x61, 0x66, 0x65, 0x74, 0x79, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x70, 0x62, 0x2f, 0x73,
+	0x61, 0x66, 0x65, 0x74, 0x79, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x0d, 0x73, 0x61, 0x66, 0x65, 0x74, 0x79, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x70, 0x62, 0x1a,
+	0x19, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2f, 0x68, 0x6f, 0x74, 0x73,
+	0x74, 0x75, 0x66, 0x66, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x63, 0x0a, 0x0b, 0x56, 0x6f,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x05, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74,
+	0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x05, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x12, 0x2b, 0x0a, 0x07, 0x51, 0x43, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62,
+	0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x07, 0x51, 0x43, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x22,
+	0x80, 0x01, 0x0a, 0x0e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x30, 0x0a, 0x07, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x67, 0x52, 0x07, 0x54, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x55, 0x73, 0x65, 0x51, 0x43, 0x56, 0x69, 0x65,
+	0x77, 0x54, 0x43, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x55, 0x73, 0x65, 0x51, 0x43,
+	0x56, 0x69, 0x65, 0x77, 0x54, 0x43, 0x12, 0x1a, 0x0a, 0x08, 0x55, 0x73, 0x65, 0x41, 0x67, 0x67,
+	0x51, 0x43, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x55, 0x73, 0x65, 0x41, 0x67, 0x67,
+	0x51, 0x43, 0x22, 0x1a, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69,
+	0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x22, 0x43,
+	0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x61, 0x73, 0x74, 0x56,
+	0x6f, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x61, 0x73, 0x74, 0x56,
+	0x6f, 0x74, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x56, 0x69, 0x65,
+	0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x56,
+	0x69, 0x65, 0x77, 0x32, 0xd2, 0x01, 0x0a, 0x0b, 0x53, 0x61, 0x66, 0x65, 0x74, 0x79, 0x52, 0x75,
+	0x6c, 0x65, 0x73, 0x12, 0x41, 0x0a, 0x08, 0x53, 0x69, 0x67, 0x6e, 0x56, 0x6f, 0x74, 0x65, 0x12,
+	0x1a, 0x2e, 0x73, 0x61, 0x66, 0x65, 0x74, 0x79, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x70, 0x62, 0x2e,
+	0x56, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x68, 0x6f,
+	0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c,
+	0x43, 0x65, 0x72, 0x74, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x0b, 0x53, 0x69, 0x67, 0x6e, 0x54, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x1d, 0x2e, 0x73, 0x61, 0x66, 0x65, 0x74, 0x79, 0x72, 0x75,
+	0x6c, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70,
+	0x62, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x67, 0x22, 0x00, 0x12, 0x38,
+	0x0a, 0x0a, 0x53, 0x74, 0x6f, 0x70, 0x56, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x13, 0x2e, 0x73,
+	0x61, 0x66, 0x65, 0x74, 0x79, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x56, 0x69, 0x65,
+	0x77, 0x1a, 0x13, 0x2e, 0x73, 0x61, 0x66, 0x65, 0x74, 0x79, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x70,
+	0x62, 0x2e, 0x56, 0x69, 0x65, 0x77, 0x22, 0x00, 0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x65, 0x6c, 0x61, 0x62, 0x2f, 0x68, 0x6f, 0x74,
+	0x73, 0x74, 0x75, 0x66, 0x66, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x61, 0x66, 0x65, 0x74, 0x79, 0x72, 0x75, 0x6c, 0x65, 0x73,
+	0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_internal_proto_safetyrulespb_safetyrules_proto_rawDescOnce sync.Once
+	file_internal_proto_safetyrulespb_safetyrules_proto_rawDescData = file_internal_proto_safetyrulespb_safetyrules_proto_rawDesc
+)
+
+func file_internal_proto_safetyrulespb_safetyrules_proto_rawDescGZIP() []byte {
+	file_internal_proto_safetyrulespb_safetyrules_proto_rawDescOnce.Do(func() {
+		file_internal_proto_safetyrulespb_safetyrules_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_proto_safetyrulespb_safetyrules_proto_rawDescData)
+	})
+	return file_internal_proto_safetyrulespb_safetyrules_proto_rawDescData
+}
+
+var file_internal_proto_safetyrulespb_safetyrules_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_internal_proto_safetyrulespb_safetyrules_proto_goTypes = []interface{}{
+	(*VoteRequest)(nil),            // 0: safetyrulespb.VoteRequest
+	(*TimeoutRequest)(nil),         // 1: safetyrulespb.TimeoutRequest
+	(*View)(nil),                   // 2: safetyrulespb.View
+	(*State)(nil),                  // 3: safetyrulespb.State
+	(*hotstuffpb.Block)(nil),       // 4: hotstuffpb.Block
+	(*hotstuffpb.TimeoutMsg)(nil),  // 5: hotstuffpb.TimeoutMsg
+	(*hotstuffpb.PartialCert)(nil), // 6: hotstuffpb.PartialCert
+}
+var file_internal_proto_safetyrulespb_safetyrules_proto_depIdxs = []int32{
+	4, // 0: safetyrulespb.VoteRequest.Block:type_name -> hotstuffpb.Block
+	4, // 1: safetyrulespb.VoteRequest.QCBlock:type_name -> hotstuffpb.Block
+	5, // 2: safetyrulespb.TimeoutRequest.Timeout:type_name -> hotstuffpb.TimeoutMsg
+	0, // 3: safetyrulespb.SafetyRules.SignVote:input_type -> safetyrulespb.VoteRequest
+	1, // 4: safetyrulespb.SafetyRules.SignTimeout:input_type -> safetyrulespb.TimeoutRequest
+	2, // 5: safetyrulespb.SafetyRules.StopVoting:input_type -> safetyrulespb.View
+	6, // 6: safetyrulespb.SafetyRules.SignVote:output_type -> hotstuffpb.PartialCert
+	5, // 7: safetyrulespb.SafetyRules.SignTimeout:output_type -> hotstuffpb.TimeoutMsg
+	2, // 8: safetyrulespb.SafetyRules.StopVoting:output_type -> safetyrulespb.View
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_internal_proto_safetyrulespb_safetyrules_proto_init() }
+func file_internal_proto_safetyrulespb_safetyrules_proto_init() {
+	if File_internal_proto_safetyrulespb_safetyrules_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_internal_proto_safetyrulespb_safetyrules_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VoteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_safetyrulespb_safetyrules_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimeoutRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_safetyrulespb_safetyrules_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*View); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_safetyrulespb_safetyrules_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*State); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internal_proto_safetyrulespb_safetyrules_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_internal_proto_safetyrulespb_safetyrules_proto_goTypes,
+		DependencyIndexes: file_internal_proto_safetyrulespb_safetyrules_proto_depIdxs,
+		MessageInfos:      file_internal_proto_safetyrulespb_safetyrules_proto_msgTypes,
+	}.Build()
+	File_internal_proto_safetyrulespb_safetyrules_proto = out.File
+	file_internal_proto_safetyrulespb_safetyrules_proto_rawDesc = nil
+	file_internal_proto_safetyrulespb_safetyrules_proto_goTypes = nil
+	file_internal_proto_safetyrulespb_safetyrules_proto_depIdxs = nil
+}
@@ -0,0 +1,186 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: internal/proto/safetyrulespb/safetyrules.proto
+
+package safetyrulespb
+
+import (
+	context "context"
+	hotstuffpb "github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SafetyRulesClient is the client API for SafetyRules service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SafetyRulesClient interface {
+	// SignVote returns a partial certificate for the block.
+	SignVote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*hotstuffpb.PartialCert, error)
+	// SignTimeout returns the timeout message with its signatures filled in.
+	SignTimeout(ctx context.Context, in *TimeoutRequest, opts ...grpc.CallOption) (*hotstuffpb.TimeoutMsg, error)
+	// StopVoting ensures that no votes are signed for the given view or earlier views.
+	// It returns the latest view in which a vote or timeout was signed.
+	StopVoting(ctx context.Context, in *View, opts ...grpc.CallOption) (*View, error)
+}
+
+type safetyRulesClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSafetyRulesClient(cc grpc.ClientConnInterface) SafetyRulesClient {
+	return &safetyRulesClient{cc}
+}
+
+func (c *safetyRulesClient) SignVote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*hotstuffpb.PartialCert, error) {
+	out := new(hotstuffpb.PartialCert)
+	err := c.cc.Invoke(ctx, "/safetyrulespb.SafetyRules/SignVote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *safetyRulesClient) SignTimeout(ctx context.Context, in *TimeoutRequest, opts ...grpc.CallOption) (*hotstuffpb.TimeoutMsg, error) {
+	out := new(hotstuffpb.TimeoutMsg)
+	err := c.cc.Invoke(ctx, "/safetyrulespb.SafetyRules/SignTimeout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *safetyRulesClient) StopVoting(ctx context.Context, in *View, opts ...grpc.CallOption) (*View, error) {
+	out := new(View)
+	err := c.cc.Invoke(ctx, "/safetyrulespb.SafetyRules/StopVoting", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SafetyRulesServer is the server API for SafetyRules service.
+// All implementations must embed UnimplementedSafetyRulesServer
+// for forward compatibility
+type SafetyRulesServer interface {
+	// SignVote returns a partial certificate for the block.
+	SignVote(context.Context, *VoteRequest) (*hotstuffpb.PartialCert, error)
+	// SignTimeout returns the timeout message with its signatures filled in.
+	SignTimeout(context.Context, *TimeoutRequest) (*hotstuffpb.TimeoutMsg, error)
+	// StopVoting ensures that no votes are signed for the given view or earlier views.
+	// It returns the latest view in which a vote or timeout was signed.
+	StopVoting(context.Context, *View) (*View, error)
+	mustEmbedUnimplementedSafetyRulesServer()
+}
+
+// UnimplementedSafetyRulesServer must be embedded to have forward compatible implementations.
+type UnimplementedSafetyRulesServer struct {
+}
+
+func (UnimplementedSafetyRulesServer) SignVote(context.Context, *VoteRequest) (*hotstuffpb.PartialCert, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignVote not implemented")
+}
+func (UnimplementedSafetyRulesServer) SignTimeout(context.Context, *TimeoutRequest) (*hotstuffpb.TimeoutMsg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignTimeout not implemented")
+}
+func (UnimplementedSafetyRulesServer) StopVoting(context.Context, *View) (*View, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopVoting not implemented")
+}
+func (UnimplementedSafetyRulesServer) mustEmbedUnimplementedSafetyRulesServer() {}
+
+// UnsafeSafetyRulesServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SafetyRulesServer will
+// result in compilation errors.
+type UnsafeSafetyRulesServer interface {
+	mustEmbedUnimplementedSafetyRulesServer()
+}
+
+func RegisterSafetyRulesServer(s grpc.ServiceRegistrar, srv SafetyRulesServer) {
+	s.RegisterService(&SafetyRules_ServiceDesc, srv)
+}
+
+func _SafetyRules_SignVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SafetyRulesServer).SignVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/safetyrulespb.SafetyRules/SignVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SafetyRulesServer).SignVote(ctx, req.(*VoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SafetyRules_SignTimeout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimeoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SafetyRulesServer).SignTimeout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/safetyrulespb.SafetyRules/SignTimeout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SafetyRulesServer).SignTimeout(ctx, req.(*TimeoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SafetyRules_StopVoting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(View)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SafetyRulesServer).StopVoting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/safetyrulespb.SafetyRules/StopVoting",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SafetyRulesServer).StopVoting(ctx, req.(*View))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SafetyRules_ServiceDesc is the grpc.ServiceDesc for SafetyRules service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SafetyRules_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "safetyrulespb.SafetyRules",
+	HandlerType: (*SafetyRulesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SignVote",
+			Handler:    _SafetyRules_SignVote_Handler,
+		},
+		{
+			MethodName: "SignTimeout",
+			Handler:    _SafetyRules_SignTimeout_Handler,
+		},
+		{
+			MethodName: "StopVoting",
+			Handler:    _SafetyRules_StopVoting_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/proto/safetyrulespb/safetyrules.proto",
+}
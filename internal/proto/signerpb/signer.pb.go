@@ -0,0 +1,515 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.19.4
+// source: internal/proto/signerpb/signer.proto
+
+package signerpb
+
+import (
+	hotstuffpb "github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Kind is the kind of message that was signed in a view.
+type Kind int32
+
+const (
+	Kind_VOTE    Kind = 0
+	Kind_TIMEOUT Kind = 1
+)
+
+// Enum value maps for Kind.
+var (
+	Kind_name = map[int32]string{
+		0: "VOTE",
+		1: "TIMEOUT",
+	}
+	Kind_value = map[string]int32{
+		"VOTE":    0,
+		"TIMEOUT": 1,
+	}
+)
+
+func (x Kind) Enum() *Kind {
+	p := new(Kind)
+	*p = x
+	return p
+}
+
+func (x Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_proto_signerpb_signer_proto_enumTypes[0].Descriptor()
+}
+
+func (Kind) Type() protoreflect.EnumType {
+	return &file_internal_proto_signerpb_signer_proto_enumTypes[0]
+}
+
+func (x Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Kind.Descriptor instead.
+func (Kind) EnumDescriptor() ([]byte, []int) {
+	return file_internal_proto_signerpb_signer_proto_rawDescGZIP(), []int{0}
+}
+
+type SignRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Request:
+	//	*SignRequest_Message
+	//	*SignRequest_Vote
+	//	*SignRequest_Timeout
+	Request isSignRequest_Request `protobuf_oneof:"Request"`
+}
+
+func (x *SignRequest) Reset() {
+	*x = SignRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_signerpb_signer_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignRequest) ProtoMessage() {}
+
+func (x *SignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_signerpb_signer_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignRequest.ProtoReflect.Descriptor instead.
+func (*SignRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_signerpb_signer_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *SignRequest) GetRequest() isSignRequest_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (x *SignRequest) GetMessage() []byte {
+	if x, ok := x.GetRequest().(*SignRequest_Message); ok {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *SignRequest) GetVote() *hotstuffpb.Block {
+	if x, ok := x.GetRequest().(*SignRequest_Vote); ok {
+		return x.Vote
+	}
+	return nil
+}
+
+func (x *SignRequest) GetTimeout() *TimeoutMessage {
+	if x, ok := x.GetRequest().(*SignRequest_Timeout); ok {
+		return x.Timeout
+	}
+	return nil
+}
+
+type isSignRequest_Request interface {
+	isSignRequest_Request()
+}
+
+type SignRequest_Message struct {
+	// Message is a message that is neither a vote nor a timeout.
+	// It must start with one of the domain tags that the signer accepts.
+	Message []byte `protobuf:"bytes,2,opt,name=Message,proto3,oneof"`
+}
+
+type SignRequest_Vote struct {
+	// Vote is a block to vote for.
+	Vote *hotstuffpb.Block `protobuf:"bytes,3,opt,name=Vote,proto3,oneof"`
+}
+
+type SignRequest_Timeout struct {
+	// Timeout is a message that belongs to a timeout.
+	Timeout *TimeoutMessage `protobuf:"bytes,4,opt,name=Timeout,proto3,oneof"`
+}
+
+func (*SignRequest_Message) isSignRequest_Request() {}
+
+func (*SignRequest_Vote) isSignRequest_Request() {}
+
+func (*SignRequest_Timeout) isSignRequest_Request() {}
+
+type TimeoutMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timeout *hotstuffpb.TimeoutMsg `protobuf:"bytes,1,opt,name=Timeout,proto3" json:"Timeout,omitempty"`
+	Message []byte                 `protobuf:"bytes,2,opt,name=Message,proto3" json:"Message,omitempty"`
+}
+
+func (x *TimeoutMessage) Reset() {
+	*x = TimeoutMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_signerpb_signer_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeoutMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeoutMessage) ProtoMessage() {}
+
+func (x *TimeoutMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_signerpb_signer_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeoutMessage.ProtoReflect.Descriptor instead.
+func (*TimeoutMessage) Descriptor() ([]byte, []int) {
+	return file_internal_proto_signerpb_signer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TimeoutMessage) GetTimeout() *hotstuffpb.TimeoutMsg {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *TimeoutMessage) GetMessage() []byte {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+// State is the last vote or timeout that the signer has signed, which is stored across restarts.
+type State struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	View uint64 `protobuf:"varint,1,opt,name=View,proto3" json:"View,omitempty"`
+	Kind Kind   `protobuf:"varint,2,opt,name=Kind,proto3,enum=signerpb.Kind" json:"Kind,omitempty"`
+	// Hash is the hash of the block or timeout message.
+	Hash []byte `protobuf:"bytes,3,opt,name=Hash,proto3" json:"Hash,omitempty"`
+}
+
+func (x *State) Reset() {
+	*x = State{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_signerpb_signer_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *State) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*State) ProtoMessage() {}
+
+func (x *State) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_signerpb_signer_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use State.ProtoReflect.Descriptor instead.
+func (*State) Descriptor() ([]byte, []int) {
+	return file_internal_proto_signerpb_signer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *State) GetView() uint64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *State) GetKind() Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return Kind_VOTE
+}
+
+func (x *State) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+type Metadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries map[string]string `protobuf:"bytes,1,rep,name=Entries,proto3" json:"Entries,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Metadata) Reset() {
+	*x = Metadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_signerpb_signer_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Metadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metadata) ProtoMessage() {}
+
+func (x *Metadata) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_signerpb_signer_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
+func (*Metadata) Descriptor() ([]byte, []int) {
+	return file_internal_proto_signerpb_signer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Metadata) GetEntries() map[string]string {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+var File_internal_proto_signerpb_signer_proto protoreflect.FileDescriptor
+
+var file_internal_proto_signerpb_signer_proto_rawDesc = []byte{
+	0x0a, 0x24, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x70, 0x62, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x70, 0x62,
+	0x1a, 0x19, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2f, 0x68, 0x6f, 0x74,
+	0x73, 0x74, 0x75, 0x66, 0x66, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70,
+	0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x99, 0x01, 0x0a, 0x0b, 0x53, 0x69, 0x67,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x07, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x56, 0x6f, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x04, 0x56, 0x6f, 0x74, 0x65, 0x12, 0x34, 0x0a,
+	0x07, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x07, 0x54, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4a, 0x04,
+	0x08, 0x01, 0x10, 0x02, 0x22, 0x5c, 0x0a, 0x0e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75,
+	0x66, 0x66, 0x70, 0x62, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x67, 0x52,
+	0x07, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0x53, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x56,
+	0x69, 0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12,
+	0x22, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e,
+	0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x4b,
+	0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x22, 0x81, 0x01, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x39, 0x0a, 0x07, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x70, 0x62,
+	0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x1a,
+	0x3a, 0x0a, 0x0c, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a, 0x1d, 0x0a, 0x04, 0x4b,
+	0x69, 0x6e, 0x64, 0x12, 0x08, 0x0a, 0x04, 0x56, 0x4f, 0x54, 0x45, 0x10, 0x00, 0x12, 0x0b, 0x0a,
+	0x07, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54, 0x10, 0x01, 0x32, 0x8a, 0x01, 0x0a, 0x06, 0x53,
+	0x69, 0x67, 0x6e, 0x65, 0x72, 0x12, 0x3c, 0x0a, 0x04, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x15, 0x2e,
+	0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70,
+	0x62, 0x2e, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x12, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x00, 0x42, 0x33, 0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x65, 0x6c, 0x61, 0x62, 0x2f, 0x68, 0x6f, 0x74, 0x73,
+	0x74, 0x75, 0x66, 0x66, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_internal_proto_signerpb_signer_proto_rawDescOnce sync.Once
+	file_internal_proto_signerpb_signer_proto_rawDescData = file_internal_proto_signerpb_signer_proto_rawDesc
+)
+
+func file_internal_proto_signerpb_signer_proto_rawDescGZIP() []byte {
+	file_internal_proto_signerpb_signer_proto_rawDescOnce.Do(func() {
+		file_internal_proto_signerpb_signer_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_proto_signerpb_signer_proto_rawDescData)
+	})
+	return file_internal_proto_signerpb_signer_proto_rawDescData
+}
+
+var file_internal_proto_signerpb_signer_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_internal_proto_signerpb_signer_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_internal_proto_signerpb_signer_proto_goTypes = []interface{}{
+	(Kind)(0),                          // 0: signerpb.Kind
+	(*SignRequest)(nil),                // 1: signerpb.SignRequest
+	(*TimeoutMessage)(nil),             // 2: signerpb.TimeoutMessage
+	(*State)(nil),                      // 3: signerpb.State
+	(*Metadata)(nil),                   // 4: signerpb.Metadata
+	nil,                                // 5: signerpb.Metadata.EntriesEntry
+	(*hotstuffpb.Block)(nil),           // 6: hotstuffpb.Block
+	(*hotstuffpb.TimeoutMsg)(nil),      // 7: hotstuffpb.TimeoutMsg
+	(*emptypb.Empty)(nil),              // 8: google.protobuf.Empty
+	(*hotstuffpb.QuorumSignature)(nil), // 9: hotstuffpb.QuorumSignature
+}
+var file_internal_proto_signerpb_signer_proto_depIdxs = []int32{
+	6, // 0: signerpb.SignRequest.Vote:type_name -> hotstuffpb.Block
+	2, // 1: signerpb.SignRequest.Timeout:type_name -> signerpb.TimeoutMessage
+	7, // 2: signerpb.TimeoutMessage.Timeout:type_name -> hotstuffpb.TimeoutMsg
+	0, // 3: signerpb.State.Kind:type_name -> signerpb.Kind
+	5, // 4: signerpb.Metadata.Entries:type_name -> signerpb.Metadata.EntriesEntry
+	1, // 5: signerpb.Signer.Sign:input_type -> signerpb.SignRequest
+	8, // 6: signerpb.Signer.ConnectionMetadata:input_type -> google.protobuf.Empty
+	9, // 7: signerpb.Signer.Sign:output_type -> hotstuffpb.QuorumSignature
+	4, // 8: signerpb.Signer.ConnectionMetadata:output_type -> signerpb.Metadata
+	7, // [7:9] is the sub-list for method output_type
+	5, // [5:7] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_internal_proto_signerpb_signer_proto_init() }
+func file_internal_proto_signerpb_signer_proto_init() {
+	if File_internal_proto_signerpb_signer_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_internal_proto_signerpb_signer_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_signerpb_signer_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimeoutMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_signerpb_signer_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*State); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_signerpb_signer_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Metadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_internal_proto_signerpb_signer_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*SignRequest_Message)(nil),
+		(*SignRequest_Vote)(nil),
+		(*SignRequest_Timeout)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internal_proto_signerpb_signer_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_internal_proto_signerpb_signer_proto_goTypes,
+		DependencyIndexes: file_internal_proto_signerpb_signer_proto_depIdxs,
+		EnumInfos:         file_internal_proto_signerpb_signer_proto_enumTypes,
+		MessageInfos:      file_internal_proto_signerpb_signer_proto_msgTypes,
+	}.Build()
+	File_internal_proto_signerpb_signer_proto = out.File
+	file_internal_proto_signerpb_signer_proto_rawDesc = nil
+	file_internal_proto_signerpb_signer_proto_goTypes = nil
+	file_internal_proto_signerpb_signer_proto_depIdxs = nil
+}
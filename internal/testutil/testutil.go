@@ -18,16 +18,19 @@ import (
 	"github.com/relab/hotstuff/crypto"
 	"github.com/relab/hotstuff/crypto/bls12"
 	"github.com/relab/hotstuff/crypto/ecdsa"
+	"github.com/relab/hotstuff/crypto/ed25519"
 	"github.com/relab/hotstuff/crypto/keygen"
+	"github.com/relab/hotstuff/crypto/thresholdbls"
 	"github.com/relab/hotstuff/internal/mocks"
 	"github.com/relab/hotstuff/leaderrotation"
 	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/safetyrules"
 	"github.com/relab/hotstuff/synchronizer"
 	"github.com/relab/hotstuff/twins"
 )
 
 // TestModules registers default modules for testing to the given builder.
-func TestModules(t *testing.T, ctrl *gomock.Controller, id hotstuff.ID, privkey hotstuff.PrivateKey, builder *modules.Builder) {
+func TestModules(t testing.TB, ctrl *gomock.Controller, id hotstuff.ID, privkey hotstuff.PrivateKey, builder *modules.Builder) {
 	t.Helper()
 
 	acceptor := mocks.NewMockAcceptor(ctrl)
@@ -62,6 +65,7 @@ func TestModules(t *testing.T, ctrl *gomock.Controller, id hotstuff.ID, privkey
 		synchronizer,
 		config,
 		signer,
+		safetyrules.New(),
 		acceptor,
 		modules.ExtendedExecutor(executor),
 		commandQ,
@@ -114,7 +118,7 @@ func (hl HotStuffList) Keys() (keys []hotstuff.PrivateKey) {
 }
 
 // CreateBuilders creates n builders with default consensus. Configurations are initialized with replicas.
-func CreateBuilders(t *testing.T, ctrl *gomock.Controller, n int, keys ...hotstuff.PrivateKey) (builders BuilderList) {
+func CreateBuilders(t testing.TB, ctrl *gomock.Controller, n int, keys ...hotstuff.PrivateKey) (builders BuilderList) {
 	t.Helper()
 	network := twins.NewSimpleNetwork()
 	builders = make([]*modules.Builder, n)
@@ -137,7 +141,7 @@ func CreateBuilders(t *testing.T, ctrl *gomock.Controller, n int, keys ...hotstu
 }
 
 // CreateTCPListener creates a net.Listener on a random port.
-func CreateTCPListener(t *testing.T) net.Listener {
+func CreateTCPListener(t testing.TB) net.Listener {
 	t.Helper()
 	lis, err := net.Listen("tcp", "localhost:0")
 	if err != nil {
@@ -147,7 +151,7 @@ func CreateTCPListener(t *testing.T) net.Listener {
 }
 
 // Sign creates a signature using the given signer.
-func Sign(t *testing.T, message []byte, signer modules.Crypto) hotstuff.QuorumSignature {
+func Sign(t testing.TB, message []byte, signer modules.Crypto) hotstuff.QuorumSignature {
 	t.Helper()
 	sig, err := signer.Sign(message)
 	if err != nil {
@@ -157,7 +161,7 @@ func Sign(t *testing.T, message []byte, signer modules.Crypto) hotstuff.QuorumSi
 }
 
 // CreateSignatures creates partial certificates from multiple signers.
-func CreateSignatures(t *testing.T, message []byte, signers []modules.Crypto) []hotstuff.QuorumSignature {
+func CreateSignatures(t testing.TB, message []byte, signers []modules.Crypto) []hotstuff.QuorumSignature {
 	t.Helper()
 	sigs := make([]hotstuff.QuorumSignature, 0, len(signers))
 	for _, signer := range signers {
@@ -176,7 +180,7 @@ func signer(s hotstuff.QuorumSignature) hotstuff.ID {
 }
 
 // CreateTimeouts creates a set of TimeoutMsg messages from the given signers.
-func CreateTimeouts(t *testing.T, view hotstuff.View, signers []modules.Crypto) (timeouts []hotstuff.TimeoutMsg) {
+func CreateTimeouts(t testing.TB, view hotstuff.View, signers []modules.Crypto) (timeouts []hotstuff.TimeoutMsg) {
 	t.Helper()
 	timeouts = make([]hotstuff.TimeoutMsg, 0, len(signers))
 	viewSigs := CreateSignatures(t, view.ToBytes(), signers)
@@ -195,7 +199,7 @@ func CreateTimeouts(t *testing.T, view hotstuff.View, signers []modules.Crypto)
 }
 
 // CreatePC creates a partial certificate using the given signer.
-func CreatePC(t *testing.T, block *hotstuff.Block, signer modules.Crypto) hotstuff.PartialCert {
+func CreatePC(t testing.TB, block *hotstuff.Block, signer modules.Crypto) hotstuff.PartialCert {
 	t.Helper()
 	pc, err := signer.CreatePartialCert(block)
 	if err != nil {
@@ -205,7 +209,7 @@ func CreatePC(t *testing.T, block *hotstuff.Block, signer modules.Crypto) hotstu
 }
 
 // CreatePCs creates one partial certificate using each of the given signers.
-func CreatePCs(t *testing.T, block *hotstuff.Block, signers []modules.Crypto) []hotstuff.PartialCert {
+func CreatePCs(t testing.TB, block *hotstuff.Block, signers []modules.Crypto) []hotstuff.PartialCert {
 	t.Helper()
 	pcs := make([]hotstuff.PartialCert, 0, len(signers))
 	for _, signer := range signers {
@@ -215,7 +219,7 @@ func CreatePCs(t *testing.T, block *hotstuff.Block, signers []modules.Crypto) []
 }
 
 // CreateQC creates a QC using the given signers.
-func CreateQC(t *testing.T, block *hotstuff.Block, signers []modules.Crypto) hotstuff.QuorumCert {
+func CreateQC(t testing.TB, block *hotstuff.Block, signers []modules.Crypto) hotstuff.QuorumCert {
 	t.Helper()
 	if len(signers) == 0 {
 		return hotstuff.QuorumCert{}
@@ -228,7 +232,7 @@ func CreateQC(t *testing.T, block *hotstuff.Block, signers []modules.Crypto) hot
 }
 
 // CreateTC generates a TC using the given signers.
-func CreateTC(t *testing.T, view hotstuff.View, signers []modules.Crypto) hotstuff.TimeoutCert {
+func CreateTC(t testing.TB, view hotstuff.View, signers []modules.Crypto) hotstuff.TimeoutCert {
 	t.Helper()
 	if len(signers) == 0 {
 		return hotstuff.TimeoutCert{}
@@ -241,7 +245,7 @@ func CreateTC(t *testing.T, view hotstuff.View, signers []modules.Crypto) hotstu
 }
 
 // GenerateECDSAKey generates an ECDSA private key for use in tests.
-func GenerateECDSAKey(t *testing.T) hotstuff.PrivateKey {
+func GenerateECDSAKey(t testing.TB) hotstuff.PrivateKey {
 	t.Helper()
 	key, err := keygen.GenerateECDSAPrivateKey()
 	if err != nil {
@@ -250,8 +254,18 @@ func GenerateECDSAKey(t *testing.T) hotstuff.PrivateKey {
 	return key
 }
 
+// GenerateED25519Key generates an Ed25519 private key for use in tests.
+func GenerateED25519Key(t testing.TB) hotstuff.PrivateKey {
+	t.Helper()
+	key, err := ed25519.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate private key: %v", err)
+	}
+	return key
+}
+
 // GenerateBLS12Key generates a BLS12-381 private key for use in tests.
-func GenerateBLS12Key(t *testing.T) hotstuff.PrivateKey {
+func GenerateBLS12Key(t testing.TB) hotstuff.PrivateKey {
 	t.Helper()
 	key, err := bls12.GeneratePrivateKey()
 	if err != nil {
@@ -260,8 +274,26 @@ func GenerateBLS12Key(t *testing.T) hotstuff.PrivateKey {
 	return key
 }
 
+// GenerateThresholdBLSKeys deals threshold BLS keys to n replicas with IDs from 1 to n, for use in tests.
+func GenerateThresholdBLSKeys(t testing.TB, n int) (keys []hotstuff.PrivateKey) {
+	t.Helper()
+	ids := make([]hotstuff.ID, n)
+	for i := range ids {
+		ids[i] = hotstuff.ID(i + 1)
+	}
+	shares, err := thresholdbls.Deal(ids, hotstuff.QuorumSize(n))
+	if err != nil {
+		t.Fatalf("Failed to deal keys: %v", err)
+	}
+	keys = make([]hotstuff.PrivateKey, n)
+	for i, id := range ids {
+		keys[i] = shares[id]
+	}
+	return keys
+}
+
 // GenerateKeys generates n keys.
-func GenerateKeys(t *testing.T, n int, keyFunc func(t *testing.T) hotstuff.PrivateKey) (keys []hotstuff.PrivateKey) {
+func GenerateKeys(t testing.TB, n int, keyFunc func(t testing.TB) hotstuff.PrivateKey) (keys []hotstuff.PrivateKey) {
 	keys = make([]hotstuff.PrivateKey, n)
 	for i := 0; i < n; i++ {
 		keys[i] = keyFunc(t)
@@ -275,7 +307,7 @@ func NewProposeMsg(parent hotstuff.Hash, qc hotstuff.QuorumCert, cmd hotstuff.Co
 }
 
 type leaderRotation struct {
-	t     *testing.T
+	t     testing.TB
 	order []hotstuff.ID
 }
 
@@ -292,7 +324,7 @@ func (l leaderRotation) GetLeader(v hotstuff.View) hotstuff.ID {
 }
 
 // NewLeaderRotation returns a leader rotation implementation that will return leaders in the specified order.
-func NewLeaderRotation(t *testing.T, order ...hotstuff.ID) modules.LeaderRotation {
+func NewLeaderRotation(t testing.TB, order ...hotstuff.ID) modules.LeaderRotation {
 	t.Helper()
 	return leaderRotation{t, order}
 }
@@ -0,0 +1,156 @@
+package kvstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/relab/gorums"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/internal/proto/clientpb"
+	"github.com/relab/hotstuff/internal/proto/kvpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+)
+
+// Client executes operations on the replicated key-value store.
+// The operations of a client are executed one at a time.
+type Client struct {
+	id  hotstuff.ID
+	mgr *kvpb.Manager
+	cfg *kvpb.Configuration
+
+	mut            sync.Mutex
+	sequenceNumber uint64
+}
+
+// NewClient returns a new client with the given ID.
+// The ID should not be used by other clients at the same time.
+// If creds is nil, the connections to the replicas are not encrypted.
+func NewClient(id hotstuff.ID, creds credentials.TransportCredentials, opts ...gorums.ManagerOption) *Client {
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+	opts = append(opts, gorums.WithGrpcDialOptions(
+		grpc.WithBlock(),
+		grpc.WithReturnConnectionError(),
+		grpc.WithTransportCredentials(creds),
+	))
+	return &Client{
+		id:  id,
+		mgr: kvpb.NewManager(opts...),
+		// the sequence numbers start from the clock, such that the replicas do not consider the commands to be old
+		// if the client's ID has been used before.
+		sequenceNumber: uint64(time.Now().UnixNano()),
+	}
+}
+
+// Connect connects the client to the client servers of the replicas.
+func (c *Client) Connect(replicas []backend.ReplicaInfo) (err error) {
+	nodes := make(map[string]uint32, len(replicas))
+	for _, r := range replicas {
+		nodes[r.Address] = uint32(r.ID)
+	}
+	c.cfg, err = c.mgr.NewConfiguration(qspec{faulty: hotstuff.NumFaulty(len(replicas))}, gorums.WithNodeMap(nodes))
+	if err != nil {
+		c.mgr.Close()
+		return err
+	}
+	return nil
+}
+
+// Close closes the connections to the replicas.
+func (c *Client) Close() {
+	c.mgr.Close()
+}
+
+// Get returns the value of the key. The read is ordered by the consensus protocol, so it is linearizable.
+func (c *Client) Get(ctx context.Context, key string) (value []byte, found bool, err error) {
+	result, err := c.execute(ctx, &clientpb.KVOperation{Type: clientpb.KVOperation_GET, Key: key})
+	return result.GetValue(), result.GetFound(), err
+}
+
+// LocalGet returns the value of the key from the current state of the replicas, without ordering the read through
+// the consensus protocol. The value may be stale, and the read fails if f+1 replicas do not return the same value.
+func (c *Client) LocalGet(ctx context.Context, key string) (value []byte, found bool, err error) {
+	result, err := c.cfg.Read(ctx, &clientpb.KVOperation{Type: clientpb.KVOperation_GET, Key: key})
+	return result.GetValue(), result.GetFound(), err
+}
+
+// Put sets the value of the key.
+func (c *Client) Put(ctx context.Context, key string, value []byte) error {
+	_, err := c.execute(ctx, &clientpb.KVOperation{Type: clientpb.KVOperation_PUT, Key: key, Value: value})
+	return err
+}
+
+// Delete deletes the key.
+func (c *Client) Delete(ctx context.Context, key string) error {
+	_, err := c.execute(ctx, &clientpb.KVOperation{Type: clientpb.KVOperation_DELETE, Key: key})
+	return err
+}
+
+// CompareAndSwap sets the value of the key if its current value is expected, and reports whether it did.
+// An empty expected value also matches a key that does not exist.
+func (c *Client) CompareAndSwap(ctx context.Context, key string, expected, value []byte) (swapped bool, err error) {
+	result, err := c.execute(ctx, &clientpb.KVOperation{
+		Type:     clientpb.KVOperation_CAS,
+		Key:      key,
+		Value:    value,
+		Expected: expected,
+	})
+	return result.GetOK(), err
+}
+
+// execute sends the operation in a command to the replicas, and waits until f+1 replicas have executed it.
+func (c *Client) execute(ctx context.Context, op *clientpb.KVOperation) (*clientpb.KVResult, error) {
+	data, err := proto.Marshal(op)
+	if err != nil {
+		return nil, err
+	}
+
+	// The replicas drop commands whose sequence numbers are lower than those of the client's proposed commands,
+	// so the operations are sent one at a time to avoid reordering them.
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.sequenceNumber++
+
+	return c.cfg.Execute(ctx, &clientpb.Command{
+		ClientID:       uint32(c.id),
+		SequenceNumber: c.sequenceNumber,
+		Data:           data,
+	})
+}
+
+type qspec struct {
+	faulty int
+}
+
+// ExecuteQF is the quorum function for the Execute quorum call method.
+// It waits for f+1 matching results, such that at least one of them is from a correct replica.
+func (q qspec) ExecuteQF(_ *clientpb.Command, replies map[uint32]*clientpb.KVResult) (*clientpb.KVResult, bool) {
+	return q.matching(replies)
+}
+
+// ReadQF is the quorum function for the Read quorum call method.
+// It waits for f+1 matching results, such that at least one of them is from a correct replica.
+func (q qspec) ReadQF(_ *clientpb.KVOperation, replies map[uint32]*clientpb.KVResult) (*clientpb.KVResult, bool) {
+	return q.matching(replies)
+}
+
+func (q qspec) matching(replies map[uint32]*clientpb.KVResult) (*clientpb.KVResult, bool) {
+	for _, reply := range replies {
+		n := 0
+		for _, other := range replies {
+			if proto.Equal(reply, other) {
+				n++
+			}
+		}
+		if n > q.faulty {
+			return reply, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,190 @@
+package kvstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/relab/gorums"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/blockchain"
+	"github.com/relab/hotstuff/client"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/consensus/chainedhotstuff"
+	"github.com/relab/hotstuff/crypto"
+	"github.com/relab/hotstuff/crypto/ecdsa"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/internal/proto/clientpb"
+	"github.com/relab/hotstuff/internal/testutil"
+	"github.com/relab/hotstuff/leaderrotation"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+	"github.com/relab/hotstuff/replica"
+	"github.com/relab/hotstuff/safetyrules"
+	"github.com/relab/hotstuff/synchronizer"
+	"google.golang.org/protobuf/proto"
+)
+
+// startReplicas starts n replicas that run the key-value store, and returns the addresses of their client servers.
+func startReplicas(t *testing.T, n int) []backend.ReplicaInfo {
+	t.Helper()
+	keys := testutil.GenerateKeys(t, n, testutil.GenerateECDSAKey)
+	replicas := make([]*replica.Replica, 0, n)
+	infos := make([]backend.ReplicaInfo, 0, n)
+	clientInfos := make([]backend.ReplicaInfo, 0, n)
+	for i := 0; i < n; i++ {
+		id := hotstuff.ID(i + 1)
+		builder := modules.NewBuilder(id, keys[i])
+		builder.Add(
+			eventloop.New(1000),
+			logging.New(fmt.Sprintf("hs%d", id)),
+			blockchain.New(),
+			consensus.New(chainedhotstuff.New()),
+			consensus.NewVotingMachine(),
+			safetyrules.New(),
+			synchronizer.New(testutil.FixedTimeout(100*time.Millisecond)),
+			crypto.NewCache(ecdsa.New(), 100),
+			leaderrotation.NewFixed(1),
+		)
+		store := New()
+		r := replica.New(replica.Config{
+			ID:             id,
+			PrivateKey:     keys[i],
+			BatchSize:      1,
+			StateMachine:   store,
+			ManagerOptions: []gorums.ManagerOption{gorums.WithDialTimeout(time.Second)},
+		}, builder)
+		Register(r, store)
+		replicaListener := testutil.CreateTCPListener(t)
+		clientListener := testutil.CreateTCPListener(t)
+		r.StartServers(replicaListener, clientListener)
+		replicas = append(replicas, r)
+		infos = append(infos, backend.ReplicaInfo{ID: id, Address: replicaListener.Addr().String(), PubKey: keys[i].Public()})
+		clientInfos = append(clientInfos, backend.ReplicaInfo{ID: id, Address: clientListener.Addr().String()})
+	}
+	for _, r := range replicas {
+		if err := r.Connect(infos); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, r := range replicas {
+		r.Start()
+		t.Cleanup(r.Stop)
+	}
+	startLoad(t, clientInfos)
+	return clientInfos
+}
+
+// startLoad starts a benchmark client that sends empty commands,
+// such that the replicas keep proposing blocks that commit the operations of the test.
+func startLoad(t *testing.T, replicas []backend.ReplicaInfo) {
+	t.Helper()
+	builder := modules.NewBuilder(100, nil)
+	builder.Add(eventloop.New(1000), logging.New("cli100"))
+	load := client.New(client.Config{
+		MaxConcurrent:  10,
+		Input:          io.NopCloser(rand.Reader),
+		RateLimit:      math.Inf(1),
+		Timeout:        time.Second,
+		ManagerOptions: []gorums.ManagerOption{gorums.WithDialTimeout(time.Second)},
+	}, builder)
+	if err := load.Connect(replicas); err != nil {
+		t.Fatal(err)
+	}
+	load.Start()
+	t.Cleanup(load.Stop)
+}
+
+func TestClient(t *testing.T) {
+	replicas := startReplicas(t, 4)
+	c := NewClient(1, nil, gorums.WithDialTimeout(time.Second))
+	if err := c.Connect(replicas); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := c.Put(ctx, "a", []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+	if value, found, err := c.Get(ctx, "a"); err != nil || !found || !bytes.Equal(value, []byte("1")) {
+		t.Errorf("Get: got %q (found: %v, err: %v), want \"1\"", value, found, err)
+	}
+	if swapped, err := c.CompareAndSwap(ctx, "a", []byte("2"), []byte("3")); err != nil || swapped {
+		t.Errorf("CompareAndSwap with wrong value: got %v (err: %v), want false", swapped, err)
+	}
+	if swapped, err := c.CompareAndSwap(ctx, "a", []byte("1"), []byte("3")); err != nil || !swapped {
+		t.Errorf("CompareAndSwap with expected value: got %v (err: %v), want true", swapped, err)
+	}
+
+	// the replicas may not all have executed the last operation yet, so the local read is retried.
+	for {
+		value, found, err := c.LocalGet(ctx, "a")
+		if err == nil && found && bytes.Equal(value, []byte("3")) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatalf("LocalGet: got %q (found: %v, err: %v), want \"3\"", value, found, err)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	if err := c.Delete(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, found, err := c.Get(ctx, "a"); err != nil || found {
+		t.Errorf("Get after Delete: found: %v, err: %v", found, err)
+	}
+}
+
+func TestClientExec(t *testing.T) {
+	replicas := startReplicas(t, 4)
+	builder := modules.NewBuilder(2, nil)
+	builder.Add(eventloop.New(1000), logging.New("cli2"))
+	c := client.New(client.Config{
+		ManagerOptions: []gorums.ManagerOption{gorums.WithDialTimeout(time.Second)},
+	}, builder)
+	if err := c.Connect(replicas); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var results []*clientpb.KVResult
+	for _, line := range []string{"put a 1", "get a"} {
+		op, err := ParseOperation(line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := proto.Marshal(op)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := c.Exec(ctx, data)
+		if err != nil {
+			t.Fatalf("%s: %v", line, err)
+		}
+		if len(res.GetBlockHash()) == 0 || res.GetHeight() == 0 {
+			t.Errorf("%s: the result does not identify the committed block: %v", line, res)
+		}
+		result := new(clientpb.KVResult)
+		if err := proto.Unmarshal(res.GetResult(), result); err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, result)
+	}
+	if !results[1].GetFound() || !bytes.Equal(results[1].GetValue(), []byte("1")) {
+		t.Errorf("get a: got %v, want \"1\"", results[1])
+	}
+}
@@ -0,0 +1,88 @@
+package kvstore
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/relab/hotstuff/internal/proto/clientpb"
+)
+
+// ParseOperation parses an operation written as one of the following lines:
+//
+//	get <key>
+//	put <key> <value>
+//	delete <key>
+//	cas <key> <expected> <value>
+//
+// Keys and values that contain spaces, or are empty, can be written as double-quoted Go strings.
+func ParseOperation(line string) (*clientpb.KVOperation, error) {
+	args, err := splitArgs(line)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, errors.New("empty operation")
+	}
+
+	var (
+		op   = new(clientpb.KVOperation)
+		want int
+	)
+	switch strings.ToLower(args[0]) {
+	case "get":
+		op.Type, want = clientpb.KVOperation_GET, 2
+	case "put":
+		op.Type, want = clientpb.KVOperation_PUT, 3
+	case "delete":
+		op.Type, want = clientpb.KVOperation_DELETE, 2
+	case "cas":
+		op.Type, want = clientpb.KVOperation_CAS, 4
+	default:
+		return nil, fmt.Errorf("unknown operation: '%s'", args[0])
+	}
+	if len(args) != want {
+		return nil, fmt.Errorf("%s takes %d arguments, got %d", args[0], want-1, len(args)-1)
+	}
+
+	op.Key = args[1]
+	switch op.Type {
+	case clientpb.KVOperation_PUT:
+		op.Value = []byte(args[2])
+	case clientpb.KVOperation_CAS:
+		op.Expected = []byte(args[2])
+		op.Value = []byte(args[3])
+	}
+	return op, nil
+}
+
+// splitArgs splits the line at spaces, except within double-quoted strings, which are unquoted.
+func splitArgs(line string) (args []string, err error) {
+	for {
+		line = strings.TrimLeftFunc(line, unicode.IsSpace)
+		if line == "" {
+			return args, nil
+		}
+		var arg string
+		if line[0] == '"' {
+			quoted, err := strconv.QuotedPrefix(line)
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted string: %s", line)
+			}
+			line = line[len(quoted):]
+			if line != "" && !unicode.IsSpace(rune(line[0])) {
+				return nil, errors.New("quoted strings must be followed by a space")
+			}
+			arg, _ = strconv.Unquote(quoted)
+		} else {
+			end := strings.IndexFunc(line, unicode.IsSpace)
+			if end < 0 {
+				end = len(line)
+			}
+			arg, line = line[:end], line[end:]
+		}
+		args = append(args, arg)
+	}
+}
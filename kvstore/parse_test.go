@@ -0,0 +1,38 @@
+package kvstore
+
+import (
+	"testing"
+
+	"github.com/relab/hotstuff/internal/proto/clientpb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseOperation(t *testing.T) {
+	tests := []struct {
+		line string
+		want *clientpb.KVOperation
+	}{
+		{"get a", &clientpb.KVOperation{Type: clientpb.KVOperation_GET, Key: "a"}},
+		{"  PUT a 1 ", &clientpb.KVOperation{Type: clientpb.KVOperation_PUT, Key: "a", Value: []byte("1")}},
+		{`put "a b" "c\td"`, &clientpb.KVOperation{Type: clientpb.KVOperation_PUT, Key: "a b", Value: []byte("c\td")}},
+		{"delete a", &clientpb.KVOperation{Type: clientpb.KVOperation_DELETE, Key: "a"}},
+		{`cas a "" 1`, &clientpb.KVOperation{Type: clientpb.KVOperation_CAS, Key: "a", Value: []byte("1")}},
+		{"cas a 1 2", &clientpb.KVOperation{Type: clientpb.KVOperation_CAS, Key: "a", Expected: []byte("1"), Value: []byte("2")}},
+	}
+	for _, test := range tests {
+		got, err := ParseOperation(test.line)
+		if err != nil {
+			t.Errorf("ParseOperation(%q): %v", test.line, err)
+			continue
+		}
+		if !proto.Equal(got, test.want) {
+			t.Errorf("ParseOperation(%q) = %v, want %v", test.line, got, test.want)
+		}
+	}
+
+	for _, line := range []string{"", "get", "get a b", "put a", "inc a", `get "a`, `get "a"b`} {
+		if _, err := ParseOperation(line); err == nil {
+			t.Errorf("ParseOperation(%q): expected an error", line)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package kvstore
+
+import (
+	"github.com/relab/gorums"
+	"github.com/relab/hotstuff/internal/proto/clientpb"
+	"github.com/relab/hotstuff/internal/proto/kvpb"
+	"github.com/relab/hotstuff/replica"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Register registers the key-value service with the client server of the replica.
+// The store must be the state machine of the replica, and Register must be called before the servers are started.
+func Register(r *replica.Replica, store *Store) {
+	kvpb.RegisterKeyValueServer(r.ClientServer(), serviceImpl{r, store})
+}
+
+type serviceImpl struct {
+	replica *replica.Replica
+	store   *Store
+}
+
+func (impl serviceImpl) Execute(ctx gorums.ServerCtx, cmd *clientpb.Command) (*clientpb.KVResult, error) {
+	if cmd.GetReconfiguration() != nil || cmd.GetKeyAnnouncement() != nil {
+		return nil, status.Error(codes.InvalidArgument, "command is not a key-value operation")
+	}
+	if err := proto.Unmarshal(cmd.GetData(), new(clientpb.KVOperation)); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid key-value operation: %v", err)
+	}
+	res, err := impl.replica.Execute(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	result := new(clientpb.KVResult)
+	if err := proto.Unmarshal(res.GetResult(), result); err != nil {
+		return nil, status.Errorf(codes.Internal, "invalid result: %v", err)
+	}
+	return result, nil
+}
+
+func (impl serviceImpl) Read(_ gorums.ServerCtx, op *clientpb.KVOperation) (*clientpb.KVResult, error) {
+	if op.GetType() != clientpb.KVOperation_GET {
+		return nil, status.Error(codes.InvalidArgument, "only get operations can be read without consensus")
+	}
+	value, found := impl.store.Get(op.GetKey())
+	return &clientpb.KVResult{Value: value, Found: found, OK: true}, nil
+}
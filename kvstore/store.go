@@ -0,0 +1,164 @@
+// Package kvstore implements a replicated key-value store on top of the replica package.
+//
+// The operations of the store are get, put, delete, and compare-and-swap (CAS). Each operation is marshaled into the
+// data of a client command, such that the operations are batched and ordered by the consensus protocol like any other
+// command, and applied to the store by the executor of the replicas when a block is committed.
+// Since reads are ordered in the same way as writes, they are linearizable.
+// Clients that can tolerate stale values may instead read from the current state of the replicas,
+// which avoids the latency of the consensus protocol.
+// Like other commands, an operation is only committed once the replicas have more commands to propose after it,
+// so the store relies on a steady flow of commands from its clients.
+//
+// The store is used by a replica by setting it as the state machine of the replica,
+// and registering the key-value service with the client server of the replica before the servers are started:
+//
+//	store := kvstore.New()
+//	r := replica.New(replica.Config{StateMachine: store, ...}, builder)
+//	kvstore.Register(r, store)
+//
+// The benchmark clients send key-value operations instead of random payloads with the `--workload` flag:
+//
+//	./hotstuff run --workload kv
+package kvstore
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/internal/proto/clientpb"
+	"github.com/relab/hotstuff/modules"
+	"google.golang.org/protobuf/proto"
+)
+
+// Store is an in-memory key-value store that implements the modules.StateMachine interface.
+type Store struct {
+	mut  sync.RWMutex
+	data map[string][]byte
+}
+
+// New returns a new empty store.
+func New() *Store {
+	return &Store{data: make(map[string][]byte)}
+}
+
+// Get returns the current value of the key. The returned value must not be modified.
+func (s *Store) Get(key string) (value []byte, found bool) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	value, found = s.data[key]
+	return value, found
+}
+
+// Apply applies the operations in the commands of the block to the store.
+// The result of each operation is a marshaled clientpb.KVResult.
+// Reconfigurations, key announcements, and commands whose data is not an operation have an empty result.
+func (s *Store) Apply(block *hotstuff.Block) [][]byte {
+	batch := new(clientpb.Batch)
+	err := proto.UnmarshalOptions{AllowPartial: true}.Unmarshal([]byte(block.Command()), batch)
+	if err != nil {
+		return nil
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	results := make([][]byte, len(batch.GetCommands()))
+	for i, cmd := range batch.GetCommands() {
+		if cmd.GetReconfiguration() != nil || cmd.GetKeyAnnouncement() != nil {
+			continue
+		}
+		op := new(clientpb.KVOperation)
+		if err := proto.Unmarshal(cmd.GetData(), op); err != nil {
+			continue
+		}
+		results[i], _ = proto.Marshal(s.apply(op))
+	}
+	return results
+}
+
+// apply applies a single operation. The caller must hold the lock.
+func (s *Store) apply(op *clientpb.KVOperation) *clientpb.KVResult {
+	value, found := s.data[op.GetKey()]
+	result := &clientpb.KVResult{Value: value, Found: found, OK: true}
+
+	switch op.GetType() {
+	case clientpb.KVOperation_GET:
+	case clientpb.KVOperation_PUT:
+		s.data[op.GetKey()] = op.GetValue()
+	case clientpb.KVOperation_DELETE:
+		delete(s.data, op.GetKey())
+	case clientpb.KVOperation_CAS:
+		if found && bytes.Equal(value, op.GetExpected()) || !found && len(op.GetExpected()) == 0 {
+			s.data[op.GetKey()] = op.GetValue()
+		} else {
+			result.OK = false
+		}
+	default:
+		result.OK = false
+	}
+	return result
+}
+
+// Snapshot returns the contents of the store, and the hash of the contents.
+// The keys are sorted, such that replicas with the same contents produce the same snapshot.
+func (s *Store) Snapshot() (snapshot []byte, digest hotstuff.Hash) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	keys := make([]string, 0, len(s.data))
+	for key := range s.data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var lenBytes [binary.MaxVarintLen64]byte
+	for _, key := range keys {
+		value := s.data[key]
+		n := binary.PutUvarint(lenBytes[:], uint64(len(key)))
+		snapshot = append(snapshot, lenBytes[:n]...)
+		snapshot = append(snapshot, key...)
+		n = binary.PutUvarint(lenBytes[:], uint64(len(value)))
+		snapshot = append(snapshot, lenBytes[:n]...)
+		snapshot = append(snapshot, value...)
+	}
+	return snapshot, sha256.Sum256(snapshot)
+}
+
+// Restore replaces the contents of the store with the contents of the snapshot.
+func (s *Store) Restore(snapshot []byte) error {
+	data := make(map[string][]byte)
+	for len(snapshot) > 0 {
+		key, rest, err := readBytes(snapshot)
+		if err != nil {
+			return err
+		}
+		value, rest, err := readBytes(rest)
+		if err != nil {
+			return err
+		}
+		data[string(key)] = value
+		snapshot = rest
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.data = data
+	return nil
+}
+
+// readBytes reads a length-prefixed byte slice from b, and returns it along with the rest of b.
+func readBytes(b []byte) (field, rest []byte, err error) {
+	length, n := binary.Uvarint(b)
+	if n <= 0 || uint64(len(b)-n) < length {
+		return nil, nil, errors.New("malformed snapshot")
+	}
+	end := n + int(length)
+	return b[n:end:end], b[end:], nil
+}
+
+var _ modules.StateMachine = (*Store)(nil)
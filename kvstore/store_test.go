@@ -0,0 +1,111 @@
+package kvstore
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/internal/proto/clientpb"
+	"google.golang.org/protobuf/proto"
+)
+
+// block returns a block with a command for each of the operations.
+func block(ops ...*clientpb.KVOperation) *hotstuff.Block {
+	batch := new(clientpb.Batch)
+	for i, op := range ops {
+		data, err := proto.Marshal(op)
+		if err != nil {
+			panic(err)
+		}
+		batch.Commands = append(batch.Commands, &clientpb.Command{ClientID: 1, SequenceNumber: uint64(i + 1), Data: data})
+	}
+	b, err := proto.Marshal(batch)
+	if err != nil {
+		panic(err)
+	}
+	return hotstuff.NewBlock(hotstuff.GetGenesis().Hash(), hotstuff.QuorumCert{}, hotstuff.Command(b), 1, 1)
+}
+
+func TestApply(t *testing.T) {
+	s := New()
+	results := s.Apply(block(
+		&clientpb.KVOperation{Type: clientpb.KVOperation_PUT, Key: "a", Value: []byte("1")},
+		&clientpb.KVOperation{Type: clientpb.KVOperation_GET, Key: "a"},
+		&clientpb.KVOperation{Type: clientpb.KVOperation_CAS, Key: "a", Expected: []byte("2"), Value: []byte("3")},
+		&clientpb.KVOperation{Type: clientpb.KVOperation_CAS, Key: "a", Expected: []byte("1"), Value: []byte("3")},
+		&clientpb.KVOperation{Type: clientpb.KVOperation_CAS, Key: "b", Value: []byte("4")},
+		&clientpb.KVOperation{Type: clientpb.KVOperation_DELETE, Key: "a"},
+		&clientpb.KVOperation{Type: clientpb.KVOperation_GET, Key: "a"},
+	))
+
+	want := []*clientpb.KVResult{
+		{OK: true},
+		{Value: []byte("1"), Found: true, OK: true},
+		{Value: []byte("1"), Found: true, OK: false},
+		{Value: []byte("1"), Found: true, OK: true},
+		{OK: true},
+		{Value: []byte("3"), Found: true, OK: true},
+		{OK: true},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i, b := range results {
+		got := new(clientpb.KVResult)
+		if err := proto.Unmarshal(b, got); err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(got, want[i]) {
+			t.Errorf("result %d: got %v, want %v", i, got, want[i])
+		}
+	}
+	if value, found := s.Get("b"); !found || !bytes.Equal(value, []byte("4")) {
+		t.Errorf("got %q (found: %v) for key b, want \"4\"", value, found)
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	s := New()
+	s.Apply(block(
+		&clientpb.KVOperation{Type: clientpb.KVOperation_PUT, Key: "a", Value: []byte("1")},
+		&clientpb.KVOperation{Type: clientpb.KVOperation_PUT, Key: "b", Value: []byte("2")},
+		&clientpb.KVOperation{Type: clientpb.KVOperation_PUT, Key: "c"},
+	))
+	snapshot, digest := s.Snapshot()
+
+	restored := New()
+	if err := restored.Restore(snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if _, got := restored.Snapshot(); got != digest {
+		t.Errorf("restored store has digest %.8x, want %.8x", got, digest)
+	}
+	if value, found := restored.Get("b"); !found || !bytes.Equal(value, []byte("2")) {
+		t.Errorf("got %q (found: %v) for key b, want \"2\"", value, found)
+	}
+	if _, found := restored.Get("c"); !found {
+		t.Error("key with empty value was not restored")
+	}
+
+	if err := restored.Restore(snapshot[:len(snapshot)-1]); err == nil {
+		t.Error("expected truncated snapshot to be rejected")
+	}
+}
+
+func TestWorkload(t *testing.T) {
+	w := NewWorkload(10, 0.5, 1)
+	p := make([]byte, 100)
+	for i := 0; i < 100; i++ {
+		n, err := w.Read(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		op := new(clientpb.KVOperation)
+		if err := proto.Unmarshal(p[:n], op); err != nil {
+			t.Fatalf("invalid operation: %v", err)
+		}
+	}
+	if _, err := w.Read(make([]byte, MinPayloadSize)); err != nil {
+		t.Errorf("operation did not fit in the minimum payload size: %v", err)
+	}
+}
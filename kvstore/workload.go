@@ -0,0 +1,71 @@
+package kvstore
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+
+	"github.com/relab/hotstuff/internal/proto/clientpb"
+	"google.golang.org/protobuf/proto"
+)
+
+// MinPayloadSize is the smallest payload size that fits any operation of a workload with fewer than a million keys.
+const MinPayloadSize = 32
+
+// Workload generates random key-value operations for the benchmark client.
+// Each call to Read writes a single marshaled operation, so that it can replace the random payloads of the client.
+type Workload struct {
+	keys      int
+	readRatio float64
+	rnd       *rand.Rand
+}
+
+// NewWorkload returns a workload with operations on the given number of keys.
+// The given fraction of the operations are gets, and the rest are mostly puts, with some deletes and CAS operations.
+func NewWorkload(keys int, readRatio float64, seed int64) *Workload {
+	return &Workload{
+		keys:      keys,
+		readRatio: readRatio,
+		rnd:       rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Read writes a marshaled operation into p. The values of puts and CAS operations are sized to fill p.
+func (w *Workload) Read(p []byte) (n int, err error) {
+	op := &clientpb.KVOperation{Key: fmt.Sprintf("key%d", w.rnd.Intn(w.keys))}
+	switch r := w.rnd.Float64(); {
+	case r < w.readRatio:
+		op.Type = clientpb.KVOperation_GET
+	case r < w.readRatio+0.1*(1-w.readRatio):
+		op.Type = clientpb.KVOperation_DELETE
+	case r < w.readRatio+0.2*(1-w.readRatio):
+		// only succeeds if the key does not exist.
+		op.Type = clientpb.KVOperation_CAS
+	default:
+		op.Type = clientpb.KVOperation_PUT
+	}
+
+	if op.Type == clientpb.KVOperation_PUT || op.Type == clientpb.KVOperation_CAS {
+		// leave room for the tag and length of the value.
+		if size := len(p) - proto.Size(op) - 4; size > 0 {
+			op.Value = make([]byte, size)
+			_, _ = w.rnd.Read(op.Value)
+		}
+	}
+
+	b, err := proto.Marshal(op)
+	if err != nil {
+		return 0, err
+	}
+	if len(b) > len(p) {
+		return 0, io.ErrShortBuffer
+	}
+	return copy(p, b), nil
+}
+
+// Close does nothing. It lets the workload be used as the input of the benchmark client.
+func (w *Workload) Close() error {
+	return nil
+}
+
+var _ io.ReadCloser = (*Workload)(nil)
@@ -36,12 +36,12 @@ func (c carousel) GetLeader(round hotstuff.View) hotstuff.ID {
 
 	if commitHead.QuorumCert().Signature() == nil {
 		c.logger.Debug("in startup; using round-robin")
-		return chooseRoundRobin(round, c.configuration.Len())
+		return chooseRoundRobin(round, c.configuration)
 	}
 
 	if commitHead.View() != round-hotstuff.View(c.consensus.ChainLength()) {
 		c.logger.Debugf("fallback to round-robin (view=%d, commitHead=%d)", round, commitHead.View())
-		return chooseRoundRobin(round, c.configuration.Len())
+		return chooseRoundRobin(round, c.configuration)
 	}
 
 	c.logger.Debug("proceeding with carousel")
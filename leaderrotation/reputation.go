@@ -52,7 +52,7 @@ func (r *repBased) GetLeader(view hotstuff.View) hotstuff.ID {
 	numReplicas := r.configuration.Len()
 	// use round-robin for the first few views until we get a signature
 	if block.QuorumCert().Signature() == nil {
-		return chooseRoundRobin(view, numReplicas)
+		return chooseRoundRobin(view, r.configuration)
 	}
 
 	voters := block.QuorumCert().Signature().Participants()
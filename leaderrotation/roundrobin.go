@@ -19,9 +19,7 @@ func (rr *roundRobin) InitModule(mods *modules.Core) {
 
 // GetLeader returns the id of the leader in the given view
 func (rr roundRobin) GetLeader(view hotstuff.View) hotstuff.ID {
-	// TODO: does not support reconfiguration
-	// assume IDs start at 1
-	return chooseRoundRobin(view, rr.configuration.Len())
+	return chooseRoundRobin(view, rr.configuration)
 }
 
 // NewRoundRobin returns a new round-robin leader rotation implementation.
@@ -29,6 +27,13 @@ func NewRoundRobin() modules.LeaderRotation {
 	return &roundRobin{}
 }
 
-func chooseRoundRobin(view hotstuff.View, numReplicas int) hotstuff.ID {
-	return hotstuff.ID(view%hotstuff.View(numReplicas) + 1)
+// chooseRoundRobin returns the leader of the view among the replicas that participate in the view.
+func chooseRoundRobin(view hotstuff.View, configuration modules.Configuration) hotstuff.ID {
+	if m, ok := configuration.(modules.Membership); ok {
+		if members := m.Members(view); len(members) > 0 {
+			return members[view%hotstuff.View(len(members))]
+		}
+	}
+	// assume IDs start at 1
+	return hotstuff.ID(view%hotstuff.View(configuration.Len()) + 1)
 }
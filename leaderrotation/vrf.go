@@ -0,0 +1,115 @@
+package leaderrotation
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+)
+
+func init() {
+	modules.RegisterModule("vrf", NewVRF)
+}
+
+// vrfDomain separates the input of the VRF from the other messages that the replicas sign.
+const vrfDomain = "hotstuff-vrf"
+
+// vrf is a leader election where each replica learns whether it is a leader of a view by evaluating a verifiable
+// random function (VRF) of the view with its private key. The VRF is computed by signing the view, and its output is
+// the hash of the signature, which also serves as the proof. A replica is a leader if its output is below a threshold
+// that makes one leader per view expected, so the other replicas cannot know the leaders before they propose.
+//
+// Since the number of leaders is random, some views have no leader, and some views have several leaders that may
+// split the votes. Both cases are resolved by a view timeout. The output can only be unpredictable if signatures are
+// unique, such as with the bls12 crypto, as a replica could otherwise choose among several signatures of the view.
+// Therefore, the module cannot be used with other crypto implementations.
+type vrf struct {
+	configuration modules.Configuration
+	crypto        modules.Crypto
+	logger        logging.Logger
+	opts          *modules.Options
+
+	// the proof of the latest view that ProveLeader was called for.
+	view  hotstuff.View
+	proof hotstuff.QuorumSignature
+}
+
+// InitModule gives the module access to the other modules.
+// It panics if the crypto implementation does not create unique signatures.
+func (v *vrf) InitModule(mods *modules.Core) {
+	mods.Get(
+		&v.configuration,
+		&v.crypto,
+		&v.logger,
+		&v.opts,
+	)
+	if signer, ok := v.crypto.(modules.UniqueSigner); !ok || !signer.UniqueSignatures() {
+		v.logger.Panic("vrf: the leader election requires a crypto implementation with unique signatures, such as bls12")
+	}
+	// the next leader is not known, so the votes must be sent to all replicas.
+	v.opts.SetShouldVoteToAll()
+}
+
+// GetLeader returns 0, as the leaders of a view are not known before they propose.
+func (v *vrf) GetLeader(_ hotstuff.View) hotstuff.ID {
+	return 0
+}
+
+// ProveLeader returns a proof that this replica is a leader in the view, or false if it is not a leader.
+func (v *vrf) ProveLeader(view hotstuff.View) (proof hotstuff.QuorumSignature, ok bool) {
+	if v.proof == nil || v.view != view {
+		proof, err := v.crypto.Sign(v.input(view))
+		if err != nil {
+			v.logger.Errorf("Failed to compute VRF proof for view %d: %v", view, err)
+			return nil, false
+		}
+		v.view, v.proof = view, proof
+	}
+	return v.proof, v.elected(view, v.proof)
+}
+
+// VerifyLeader returns true if the proof shows that the replica with the given ID is a leader in the view.
+func (v *vrf) VerifyLeader(id hotstuff.ID, view hotstuff.View, proof hotstuff.QuorumSignature) bool {
+	if proof == nil || proof.Participants().Len() != 1 || !proof.Participants().Contains(id) {
+		return false
+	}
+	if !v.crypto.VerifyInEpoch(modules.EpochAt(v.configuration, view), proof, v.input(view)) {
+		return false
+	}
+	return v.elected(view, proof)
+}
+
+// input returns the message that the replicas sign to evaluate the VRF for the view.
+// It includes the shared seed, such that the outputs differ between experiments.
+func (v *vrf) input(view hotstuff.View) []byte {
+	b := make([]byte, len(vrfDomain)+16)
+	copy(b, vrfDomain)
+	binary.LittleEndian.PutUint64(b[len(vrfDomain):], uint64(v.opts.SharedRandomSeed()))
+	binary.LittleEndian.PutUint64(b[len(vrfDomain)+8:], uint64(view))
+	return b
+}
+
+// elected returns true if the output of the proof is below the threshold of the view.
+func (v *vrf) elected(view hotstuff.View, proof hotstuff.QuorumSignature) bool {
+	n := v.configuration.Len()
+	if m, ok := v.configuration.(modules.Membership); ok {
+		if members := m.Members(view); len(members) > 0 {
+			n = len(members)
+		}
+	}
+	if n == 0 {
+		return false
+	}
+	output := sha256.Sum256(proof.ToBytes())
+	return binary.BigEndian.Uint64(output[:8]) < math.MaxUint64/uint64(n)
+}
+
+// NewVRF returns a new leader election based on a verifiable random function.
+func NewVRF() modules.LeaderRotation {
+	return &vrf{}
+}
+
+var _ modules.LeaderElection = (*vrf)(nil)
@@ -0,0 +1,112 @@
+package leaderrotation_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/crypto"
+	"github.com/relab/hotstuff/crypto/bls12"
+	"github.com/relab/hotstuff/internal/testutil"
+	"github.com/relab/hotstuff/leaderrotation"
+	"github.com/relab/hotstuff/modules"
+)
+
+// createElections creates a VRF leader election for each of n replicas with bls12 keys.
+func createElections(t *testing.T, n int) ([]modules.LeaderElection, []modules.Crypto) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	keys := testutil.GenerateKeys(t, n, testutil.GenerateBLS12Key)
+	builders := testutil.CreateBuilders(t, ctrl, n, keys...)
+	for _, builder := range builders {
+		builder.Add(crypto.New(bls12.New()), leaderrotation.NewVRF())
+	}
+	elections := make([]modules.LeaderElection, n)
+	signers := make([]modules.Crypto, n)
+	for i, mods := range builders.Build() {
+		var leaderRotation modules.LeaderRotation
+		mods.Get(&leaderRotation, &signers[i])
+		elections[i] = leaderRotation.(modules.LeaderElection)
+	}
+	return elections, signers
+}
+
+func TestVRF(t *testing.T) {
+	const n = 4
+	elections, _ := createElections(t, n)
+
+	leaders := 0
+	for view := hotstuff.View(1); view <= 50; view++ {
+		for i, election := range elections {
+			id := hotstuff.ID(i + 1)
+			proof, isLeader := election.ProveLeader(view)
+			if proof == nil {
+				t.Fatalf("replica %d did not create a proof for view %d", id, view)
+			}
+			if isLeader {
+				leaders++
+			}
+			// the other replicas reach the same conclusion from the proof, whether or not the replica is a leader.
+			verifier := elections[(i+1)%n]
+			if got := verifier.VerifyLeader(id, view, proof); got != isLeader {
+				t.Errorf("VerifyLeader(%d, %d) = %v, want %v", id, view, got, isLeader)
+			}
+			if !isLeader {
+				continue
+			}
+			if verifier.VerifyLeader(hotstuff.ID((i+1)%n+1), view, proof) {
+				t.Errorf("accepted the proof of replica %d in view %d for another replica", id, view)
+			}
+			if verifier.VerifyLeader(id, view+1, proof) {
+				t.Errorf("accepted the proof of replica %d for view %d in view %d", id, view, view+1)
+			}
+		}
+	}
+	// one leader is expected per view, so having no leaders in 50 views is practically impossible.
+	if leaders == 0 {
+		t.Error("expected some replicas to be leaders")
+	}
+}
+
+func TestVRFForgedProof(t *testing.T) {
+	elections, signers := createElections(t, 4)
+	verifier := elections[1]
+
+	// a signature of another message does not prove anything, even if its output happens to be below the threshold.
+	for view := hotstuff.View(1); view <= 20; view++ {
+		forged, err := signers[0].Sign([]byte("not the vrf input"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if verifier.VerifyLeader(1, view, forged) {
+			t.Errorf("accepted a forged proof in view %d", view)
+		}
+	}
+	if verifier.VerifyLeader(1, 1, nil) {
+		t.Error("accepted a missing proof")
+	}
+
+	// a combination of proofs is not a proof for any single replica.
+	proof1, _ := elections[0].ProveLeader(1)
+	proof3, _ := elections[2].ProveLeader(1)
+	combined, err := signers[0].Combine(proof1, proof3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verifier.VerifyLeader(1, 1, combined) || verifier.VerifyLeader(3, 1, combined) {
+		t.Error("accepted a combined proof")
+	}
+}
+
+func TestVRFRequiresUniqueSignatures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	// the test modules use the ecdsa crypto, whose signatures are not unique.
+	builders := testutil.CreateBuilders(t, ctrl, 1)
+	builders[0].Add(leaderrotation.NewVRF())
+	defer func() {
+		if recover() == nil {
+			t.Error("expected the vrf module to refuse the ecdsa crypto")
+		}
+	}()
+	builders.Build()
+}
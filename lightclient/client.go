@@ -0,0 +1,90 @@
+package lightclient
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/relab/gorums"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	"github.com/relab/hotstuff/internal/proto/lightclientpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Client requests commit proofs from the replicas and verifies them.
+type Client struct {
+	verifier *Verifier
+	mgr      *lightclientpb.Manager
+	cfg      *lightclientpb.Configuration
+}
+
+// NewClient returns a new client that verifies the proofs with the given verifier.
+// If creds is nil, the connections to the replicas are not encrypted.
+func NewClient(verifier *Verifier, creds credentials.TransportCredentials, opts ...gorums.ManagerOption) *Client {
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+	opts = append(opts, gorums.WithGrpcDialOptions(
+		grpc.WithBlock(),
+		grpc.WithReturnConnectionError(),
+		grpc.WithTransportCredentials(creds),
+	))
+	return &Client{
+		verifier: verifier,
+		mgr:      lightclientpb.NewManager(opts...),
+	}
+}
+
+// Connect connects the client to the client servers of the replicas.
+func (c *Client) Connect(replicas []backend.ReplicaInfo) (err error) {
+	nodes := make(map[string]uint32, len(replicas))
+	for _, r := range replicas {
+		nodes[r.Address] = uint32(r.ID)
+	}
+	c.cfg, err = c.mgr.NewConfiguration(qspec{c.verifier}, gorums.WithNodeMap(nodes))
+	if err != nil {
+		c.mgr.Close()
+		return err
+	}
+	return nil
+}
+
+// Close closes the connections to the replicas.
+func (c *Client) Close() {
+	c.mgr.Close()
+}
+
+// CommitProof returns a verified proof that the block with the given hash was committed.
+// The call fails if none of the replicas can prove that the block was committed.
+func (c *Client) CommitProof(ctx context.Context, hash hotstuff.Hash) (hotstuff.CommitProof, error) {
+	reply, err := c.cfg.CommitProof(ctx, &hotstuffpb.BlockHash{Hash: hash[:]})
+	if err != nil {
+		return hotstuff.CommitProof{}, err
+	}
+	return hotstuffpb.CommitProofFromProto(reply), nil
+}
+
+type qspec struct {
+	verifier *Verifier
+}
+
+// CommitProofQF is the quorum function for the CommitProof quorum call method.
+// It waits for a proof of the requested block that can be verified,
+// so a single correct replica that has committed the block is enough.
+func (q qspec) CommitProofQF(in *hotstuffpb.BlockHash, replies map[uint32]*hotstuffpb.CommitProof) (*hotstuffpb.CommitProof, bool) {
+	for _, reply := range replies {
+		proof := hotstuffpb.CommitProofFromProto(reply)
+		block := proof.Block()
+		if block == nil {
+			continue
+		}
+		hash := block.Hash()
+		if bytes.Equal(hash[:], in.GetHash()) && q.verifier.Verify(proof) == nil {
+			return reply, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,190 @@
+package lightclient
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/relab/gorums"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/blockchain"
+	"github.com/relab/hotstuff/client"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/consensus/chainedhotstuff"
+	"github.com/relab/hotstuff/consensus/fasthotstuff"
+	"github.com/relab/hotstuff/consensus/hotstuff2"
+	"github.com/relab/hotstuff/consensus/jolteon"
+	"github.com/relab/hotstuff/consensus/simplehotstuff"
+	"github.com/relab/hotstuff/consensus/streamlet"
+	"github.com/relab/hotstuff/crypto"
+	"github.com/relab/hotstuff/crypto/ecdsa"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/internal/testutil"
+	"github.com/relab/hotstuff/leaderrotation"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+	"github.com/relab/hotstuff/replica"
+	"github.com/relab/hotstuff/safetyrules"
+	"github.com/relab/hotstuff/synchronizer"
+)
+
+// startReplicas starts n replicas that run the given protocol and serve commit proofs.
+// It returns the replicas, the addresses of their client servers, and their keys.
+func startReplicas(t *testing.T, n int, rules func() consensus.Rules) ([]*replica.Replica, []backend.ReplicaInfo, []backend.ReplicaInfo) {
+	t.Helper()
+	keys := testutil.GenerateKeys(t, n, testutil.GenerateECDSAKey)
+	replicas := make([]*replica.Replica, 0, n)
+	infos := make([]backend.ReplicaInfo, 0, n)
+	clientInfos := make([]backend.ReplicaInfo, 0, n)
+	for i := 0; i < n; i++ {
+		id := hotstuff.ID(i + 1)
+		builder := modules.NewBuilder(id, keys[i])
+		builder.Add(
+			eventloop.New(1000),
+			logging.New(fmt.Sprintf("hs%d", id)),
+			blockchain.New(),
+			consensus.New(rules()),
+			consensus.NewVotingMachine(),
+			safetyrules.New(),
+			synchronizer.New(testutil.FixedTimeout(100*time.Millisecond)),
+			crypto.NewCache(ecdsa.New(), 100),
+			leaderrotation.NewRoundRobin(),
+		)
+		r := replica.New(replica.Config{
+			ID:             id,
+			PrivateKey:     keys[i],
+			BatchSize:      1,
+			ManagerOptions: []gorums.ManagerOption{gorums.WithDialTimeout(time.Second)},
+		}, builder)
+		Register(r)
+		replicaListener := testutil.CreateTCPListener(t)
+		clientListener := testutil.CreateTCPListener(t)
+		r.StartServers(replicaListener, clientListener)
+		replicas = append(replicas, r)
+		infos = append(infos, backend.ReplicaInfo{ID: id, Address: replicaListener.Addr().String(), PubKey: keys[i].Public()})
+		clientInfos = append(clientInfos, backend.ReplicaInfo{ID: id, Address: clientListener.Addr().String()})
+	}
+	for _, r := range replicas {
+		if err := r.Connect(infos); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, r := range replicas {
+		r.Start()
+		t.Cleanup(r.Stop)
+	}
+	startLoad(t, clientInfos)
+	return replicas, clientInfos, infos
+}
+
+// startLoad starts a benchmark client, such that the replicas keep committing blocks.
+func startLoad(t *testing.T, replicas []backend.ReplicaInfo) {
+	t.Helper()
+	builder := modules.NewBuilder(100, nil)
+	builder.Add(eventloop.New(1000), logging.New("cli100"))
+	load := client.New(client.Config{
+		MaxConcurrent:  10,
+		Input:          io.NopCloser(rand.Reader),
+		RateLimit:      math.Inf(1),
+		Timeout:        time.Second,
+		ManagerOptions: []gorums.ManagerOption{gorums.WithDialTimeout(time.Second)},
+	}, builder)
+	if err := load.Connect(replicas); err != nil {
+		t.Fatal(err)
+	}
+	load.Start()
+	t.Cleanup(load.Stop)
+}
+
+// waitForCommit waits until the replica has committed a block in a view after the given view.
+func waitForCommit(ctx context.Context, t *testing.T, r *replica.Replica, after hotstuff.View) *hotstuff.Block {
+	t.Helper()
+	var cs modules.Consensus
+	r.Modules().Get(&cs)
+	for {
+		if block := cs.CommittedBlock(); block.View() > after {
+			return block
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatal("no block was committed")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestCommitProof(t *testing.T) {
+	protocols := []struct {
+		name  string
+		rules func() consensus.Rules
+	}{
+		{"ChainedHotStuff", chainedhotstuff.New},
+		{"Fast-HotStuff", fasthotstuff.New},
+		{"Simple-HotStuff", simplehotstuff.New},
+		{"HotStuff-2", hotstuff2.New},
+		{"Jolteon", jolteon.New},
+		{"Streamlet", streamlet.New},
+	}
+	for _, p := range protocols {
+		t.Run(p.name, func(t *testing.T) {
+			replicas, clientInfos, infos := startReplicas(t, 4, p.rules)
+
+			verifier, err := NewVerifier(Config{Rules: p.rules(), Crypto: ecdsa.New(), Replicas: infos})
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := NewClient(verifier, nil, gorums.WithDialTimeout(time.Second))
+			if err := c.Connect(clientInfos); err != nil {
+				t.Fatal(err)
+			}
+			defer c.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			// the proof of an older block includes the committed blocks between it and the latest committed block.
+			old := waitForCommit(ctx, t, replicas[0], 0)
+			waitForCommit(ctx, t, replicas[0], old.View())
+			for _, block := range []*hotstuff.Block{old, waitForCommit(ctx, t, replicas[0], 0)} {
+				proof, err := c.CommitProof(ctx, block.Hash())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if proof.Block().Hash() != block.Hash() {
+					t.Errorf("got proof of block %.8s, want %.8s", proof.Block().Hash(), block.Hash())
+				}
+			}
+
+			proof, err := replicas[0].CommitProof(old.Hash())
+			if err != nil {
+				t.Fatal(err)
+			}
+			blocks := proof.Blocks()
+			if err := verifier.Verify(hotstuff.NewCommitProof(blocks[:1])); err == nil {
+				t.Error("proof with only the committed block was accepted")
+			}
+			if err := verifier.Verify(hotstuff.NewCommitProof(blocks[1:])); err != nil {
+				t.Errorf("proof of a later block was rejected: %v", err)
+			}
+
+			// a verifier that knows other keys must reject the proof.
+			otherKeys := testutil.GenerateKeys(t, len(infos), testutil.GenerateECDSAKey)
+			others := make([]backend.ReplicaInfo, len(infos))
+			for i, info := range infos {
+				others[i] = backend.ReplicaInfo{ID: info.ID, PubKey: otherKeys[i].Public()}
+			}
+			other, err := NewVerifier(Config{Rules: p.rules(), Crypto: ecdsa.New(), Replicas: others})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := other.Verify(proof); err == nil {
+				t.Error("proof was accepted with the wrong keys")
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package lightclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/modules"
+)
+
+// The verifier runs the commit rule and the QC verification of the replicas,
+// which depend on the modules below. They only provide what is needed to check a proof.
+
+// proofChain is a block chain that holds the blocks of the proof that is being verified.
+type proofChain struct {
+	blocks map[hotstuff.Hash]*hotstuff.Block
+}
+
+func (c *proofChain) Store(*hotstuff.Block) {}
+
+func (c *proofChain) Get(hash hotstuff.Hash) (*hotstuff.Block, bool) {
+	return c.LocalGet(hash)
+}
+
+func (c *proofChain) LocalGet(hash hotstuff.Hash) (*hotstuff.Block, bool) {
+	block, ok := c.blocks[hash]
+	return block, ok
+}
+
+func (c *proofChain) Extends(block, target *hotstuff.Block) bool {
+	for block.View() > target.View() {
+		var ok bool
+		if block, ok = c.LocalGet(block.Parent()); !ok {
+			return false
+		}
+	}
+	return block.Hash() == target.Hash()
+}
+
+func (c *proofChain) PruneToHeight(hotstuff.View) []*hotstuff.Block {
+	return nil
+}
+
+// configuration holds the keys of the replicas, and does not send any messages.
+type configuration struct {
+	replicas map[hotstuff.ID]modules.Replica
+}
+
+func newConfiguration(infos []backend.ReplicaInfo) *configuration {
+	cfg := &configuration{replicas: make(map[hotstuff.ID]modules.Replica, len(infos))}
+	for _, info := range infos {
+		cfg.replicas[info.ID] = member{id: info.ID, pubKey: info.PubKey}
+	}
+	return cfg
+}
+
+func (cfg *configuration) Replicas() map[hotstuff.ID]modules.Replica {
+	return cfg.replicas
+}
+
+func (cfg *configuration) Replica(id hotstuff.ID) (modules.Replica, bool) {
+	r, ok := cfg.replicas[id]
+	return r, ok
+}
+
+func (cfg *configuration) Len() int {
+	return len(cfg.replicas)
+}
+
+func (cfg *configuration) QuorumSize() int {
+	return hotstuff.QuorumSize(len(cfg.replicas))
+}
+
+func (cfg *configuration) Propose(hotstuff.ProposeMsg) {}
+
+func (cfg *configuration) Timeout(hotstuff.TimeoutMsg) {}
+
+func (cfg *configuration) Vote(hotstuff.PartialCert) {}
+
+func (cfg *configuration) Fetch(context.Context, hotstuff.Hash) (*hotstuff.Block, bool) {
+	return nil, false
+}
+
+func (cfg *configuration) FetchRange(context.Context, hotstuff.Hash, hotstuff.Hash) ([]*hotstuff.Block, bool) {
+	return nil, false
+}
+
+func (cfg *configuration) SubConfig([]hotstuff.ID) (modules.Configuration, error) {
+	return nil, errors.New("not supported by the light client")
+}
+
+// member is a replica whose key is known to the light client.
+type member struct {
+	id     hotstuff.ID
+	pubKey hotstuff.PublicKey
+}
+
+func (r member) ID() hotstuff.ID               { return r.id }
+func (r member) PublicKey() hotstuff.PublicKey { return r.pubKey }
+func (r member) Vote(hotstuff.PartialCert)     {}
+func (r member) NewView(hotstuff.SyncInfo)     {}
+func (r member) Metadata() map[string]string   { return nil }
+
+// viewSynchronizer is required by the rules of some protocols, but it is only used when voting and proposing.
+type viewSynchronizer struct{}
+
+func (viewSynchronizer) AdvanceView(hotstuff.SyncInfo) {}
+
+func (viewSynchronizer) View() hotstuff.View { return 0 }
+
+func (viewSynchronizer) ViewContext() context.Context { return context.Background() }
+
+func (viewSynchronizer) HighQC() hotstuff.QuorumCert {
+	return hotstuff.NewQuorumCert(nil, 0, hotstuff.GetGenesis().Hash())
+}
+
+func (viewSynchronizer) LeafBlock() *hotstuff.Block { return hotstuff.GetGenesis() }
+
+func (viewSynchronizer) Start(context.Context) {}
+
+var (
+	_ modules.BlockChain    = (*proofChain)(nil)
+	_ modules.Configuration = (*configuration)(nil)
+	_ modules.Synchronizer  = viewSynchronizer{}
+)
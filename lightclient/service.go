@@ -0,0 +1,34 @@
+package lightclient
+
+import (
+	"github.com/relab/gorums"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	"github.com/relab/hotstuff/internal/proto/lightclientpb"
+	"github.com/relab/hotstuff/replica"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Register registers the service that serves commit proofs with the client server of the replica.
+// It must be called before the servers are started.
+func Register(r *replica.Replica) {
+	lightclientpb.RegisterLightClientServer(r.ClientServer(), serviceImpl{r})
+}
+
+type serviceImpl struct {
+	replica *replica.Replica
+}
+
+func (impl serviceImpl) CommitProof(_ gorums.ServerCtx, req *hotstuffpb.BlockHash) (*hotstuffpb.CommitProof, error) {
+	var hash hotstuff.Hash
+	if len(req.GetHash()) != len(hash) {
+		return nil, status.Error(codes.InvalidArgument, "invalid block hash")
+	}
+	copy(hash[:], req.GetHash())
+	proof, err := impl.replica.CommitProof(hash)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return hotstuffpb.CommitProofToProto(proof), nil
+}
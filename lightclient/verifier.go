@@ -0,0 +1,127 @@
+// Package lightclient lets clients check that blocks were committed without running the consensus protocol.
+//
+// A replica proves that a block was committed with a chain of blocks that starts with the committed block.
+// The QCs embedded in the chain certify enough of its blocks to satisfy the commit rule of the consensus protocol.
+// The Verifier applies the protocol's own commit rule to the chain, after verifying the QCs with the public keys of
+// the replicas, so a client only needs to know the keys, and can trust a proof from any single replica.
+//
+// The replicas serve commit proofs to clients once the service is registered with Register.
+// Protocols whose blocks do not carry QCs, such as PBFT, do not provide commit proofs.
+package lightclient
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/crypto"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+)
+
+// Config holds the information that the verifier needs to check commit proofs.
+type Config struct {
+	// Rules is a new instance of the rules of the consensus protocol that the replicas run.
+	Rules consensus.Rules
+	// Crypto is a new instance of the crypto implementation that the replicas use.
+	Crypto modules.CryptoBase
+	// Replicas holds the IDs and public keys of the replicas. The addresses are not used.
+	Replicas []backend.ReplicaInfo
+	// KeyProofs holds proofs of possession of the replicas' keys, if the crypto implementation requires them.
+	KeyProofs map[hotstuff.ID][]byte
+}
+
+// Verifier verifies commit proofs against a fixed set of replica keys.
+// Proofs that contain QCs that were signed after the replicas or their keys changed cannot be verified.
+type Verifier struct {
+	mut    sync.Mutex
+	rules  consensus.Rules
+	crypto modules.Crypto
+	chain  *proofChain
+}
+
+// NewVerifier returns a new verifier for the given configuration.
+func NewVerifier(cfg Config) (*Verifier, error) {
+	v := &Verifier{
+		rules: cfg.Rules,
+		chain: &proofChain{},
+	}
+	builder := modules.NewBuilder(0, nil)
+	builder.Add(
+		logging.New("lightclient"),
+		crypto.New(cfg.Crypto),
+		cfg.Rules,
+		v.chain,
+		newConfiguration(cfg.Replicas),
+		viewSynchronizer{},
+	)
+	mods := builder.Build()
+	mods.Get(&v.crypto)
+
+	prover, ok := v.crypto.(modules.KeyProver)
+	for id, proof := range cfg.KeyProofs {
+		if !ok {
+			break
+		}
+		replica, found := findReplica(cfg.Replicas, id)
+		if !found {
+			return nil, fmt.Errorf("key proof for unknown replica %d", id)
+		}
+		if !prover.VerifyKeyProof(replica.PubKey, proof) {
+			return nil, fmt.Errorf("invalid key proof for replica %d", id)
+		}
+	}
+	return v, nil
+}
+
+// Verify returns nil if the proof shows that its first block was committed.
+func (v *Verifier) Verify(proof hotstuff.CommitProof) error {
+	blocks := proof.Blocks()
+	if len(blocks) == 0 {
+		return errors.New("the proof contains no blocks")
+	}
+
+	v.mut.Lock()
+	defer v.mut.Unlock()
+
+	v.chain.blocks = make(map[hotstuff.Hash]*hotstuff.Block, len(blocks))
+	defer func() { v.chain.blocks = nil }()
+
+	for i, block := range blocks {
+		if i > 0 && block.Parent() != blocks[i-1].Hash() {
+			return fmt.Errorf("block %.8s does not extend the previous block of the proof", block.Hash())
+		}
+		v.chain.blocks[block.Hash()] = block
+	}
+
+	// the commit rule can only look up the blocks of the proof,
+	// so the QCs that refer to them are the only ones that it can use.
+	for _, block := range blocks[1:] {
+		qc := block.QuorumCert()
+		if _, ok := v.chain.blocks[qc.BlockHash()]; ok && !v.crypto.VerifyQuorumCert(qc) {
+			return fmt.Errorf("invalid QC in block %.8s", block.Hash())
+		}
+	}
+
+	// every block of the proof extends the first block, so the first block is committed with any of them.
+	committed := v.rules.CommitRule(blocks[len(blocks)-1])
+	if committed == nil {
+		return errors.New("the proof does not satisfy the commit rule")
+	}
+	if _, ok := v.chain.blocks[committed.Hash()]; !ok {
+		return errors.New("the committed block is not part of the proof")
+	}
+	return nil
+}
+
+func findReplica(replicas []backend.ReplicaInfo, id hotstuff.ID) (backend.ReplicaInfo, bool) {
+	for _, replica := range replicas {
+		if replica.ID == id {
+			return replica, true
+		}
+	}
+	return backend.ReplicaInfo{}, false
+}
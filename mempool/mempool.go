@@ -0,0 +1,790 @@
+// Package mempool implements a DAG-based mempool in the style of Narwhal.
+//
+// Without a mempool, the leader batches client commands and ships the full batches inside its proposals.
+// With the mempool, every replica batches the client commands that it receives and disseminates the batches
+// to the other replicas. A replica that stores a batch replies with a signed acknowledgement,
+// and the author of the batch combines a quorum of acknowledgements into an availability certificate,
+// which it sends to all replicas. Since a quorum of replicas have stored a certified batch,
+// at least one correct replica can provide the batch to the replicas that are missing it.
+//
+// Each batch references the certificates that its author had received before creating the batch,
+// such that the certificates form a directed acyclic graph (DAG). A replica only acknowledges a batch
+// if it has the certificates of all the batches that it references.
+// The leader proposes the certificates that are not yet referenced by other certificates,
+// so the proposals carry only the digests and certificates of the batches instead of the commands themselves.
+// When a block is committed, the executor orders the causal history of the proposed certificates deterministically,
+// resolves the digests back to commands, and executes each command once.
+// The inner executor is given a copy of the committed block, where the certificates are replaced by the commands,
+// and which keeps the hash of the committed block. If some of the batches are missing, they are fetched in the background,
+// and the committed blocks are queued until their batches have been fetched, such that they are executed in order.
+//
+// The batches are garbage collected by round: once a batch of an author has been executed,
+// the batches of the same author that are gcRounds rounds older are deleted, and are never executed
+// if they have not been executed already. Since the parents of a header carry the author and round of each batch,
+// all replicas agree on which batches are too old to be executed without having their headers.
+//
+// The mempool is enabled with the `--mempool` flag:
+//
+//	./hotstuff run --mempool
+package mempool
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/internal/proto/clientpb"
+	"github.com/relab/hotstuff/internal/proto/mempoolpb"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+	"google.golang.org/protobuf/proto"
+)
+
+// cmdID is a unique identifier for a command.
+type cmdID struct {
+	clientID    uint32
+	sequenceNum uint64
+}
+
+// roundID identifies the batch of an author in a round.
+type roundID struct {
+	author hotstuff.ID
+	round  uint64
+}
+
+const (
+	// gcRounds is the number of rounds that the batches of an author are kept after a later batch
+	// of the same author has been executed.
+	gcRounds = 100
+
+	// maxWaiting is the number of batches of each author that can wait for the certificates that they reference.
+	maxWaiting = 10
+
+	// cmdWindow is the number of sequence numbers below the latest executed command of a client
+	// for which the executed commands are remembered. Older commands are treated as executed.
+	cmdWindow = 1000
+
+	// fetchRetryDelay is the time to wait before trying to fetch a missing batch again.
+	fetchRetryDelay = 100 * time.Millisecond
+)
+
+// fetchedEvent is added to the event loop when the missing batches of the pending blocks have been fetched.
+type fetchedEvent struct {
+	ctx context.Context
+}
+
+// Mempool disseminates batches of client commands and collects availability certificates for them.
+// It implements the CommandQueue, Acceptor, ExecutorExt, and ForkHandlerExt modules.
+type Mempool struct {
+	configuration *backend.Config
+	server        *backend.Server
+
+	crypto    modules.Crypto
+	eventLoop *eventloop.EventLoop
+	logger    logging.Logger
+	opts      *modules.Options
+
+	// the local batches of client commands are taken from the inner command queue.
+	cmdQueue modules.CommandQueue
+	acceptor modules.Acceptor
+	executor modules.ExecutorExt
+
+	ctx       context.Context
+	connected chan struct{}
+	ready     chan struct{} // signals Get that a new certificate was added.
+
+	// the committed blocks that are waiting for their batches to be fetched.
+	// They are only accessed from the event loop.
+	pending     []*hotstuff.Block
+	cancelFetch context.CancelFunc // non-nil while the missing batches are being fetched
+
+	mut       sync.Mutex
+	cfg       *mempoolpb.Configuration
+	nodes     map[hotstuff.ID]*mempoolpb.Node
+	round     uint64
+	headers   map[hotstuff.Hash]header
+	batches   map[hotstuff.Hash][]byte
+	acked     map[roundID]hotstuff.Hash
+	waiting   map[hotstuff.Hash]header // headers that reference certificates that we do not have yet
+	acks      map[hotstuff.Hash]map[hotstuff.ID]hotstuff.QuorumSignature
+	certs     map[hotstuff.Hash]certificate
+	parents   []reference                // the certificates that the next local batch will reference
+	tips      map[hotstuff.Hash]struct{} // the certificates that are not referenced by other certificates
+	refs      map[hotstuff.Hash]roundID  // the certificates that are referenced by other certificates
+	proposed  map[hotstuff.Hash]roundID  // the certificates that have been proposed
+	executed  map[hotstuff.Hash]roundID  // the batches that have been executed, until they are garbage collected
+	lastRound map[hotstuff.ID]uint64     // the latest executed round of each author
+	cmds      map[cmdID]struct{}         // the commands that have been executed, until they are garbage collected
+	latestCmd map[uint32]uint64          // the sequence number of the latest executed command of each client
+	lastView  hotstuff.View              // the view of the latest executed block
+
+	marshaler   proto.MarshalOptions
+	unmarshaler proto.UnmarshalOptions
+}
+
+// New returns a new mempool. The local batches are taken from the command queue,
+// the acceptor is told about the batches received from other replicas,
+// and the executor executes the commands of the committed batches.
+// The command queue and acceptor are initialized by the mempool,
+// while the executor must be added to the module system separately.
+func New(cmdQueue modules.CommandQueue, acceptor modules.Acceptor, executor modules.ExecutorExt) *Mempool {
+	return &Mempool{
+		cmdQueue: cmdQueue,
+		acceptor: acceptor,
+		executor: executor,
+
+		ctx:       context.Background(),
+		connected: make(chan struct{}),
+		ready:     make(chan struct{}, 1),
+
+		nodes:     make(map[hotstuff.ID]*mempoolpb.Node),
+		headers:   make(map[hotstuff.Hash]header),
+		batches:   make(map[hotstuff.Hash][]byte),
+		acked:     make(map[roundID]hotstuff.Hash),
+		waiting:   make(map[hotstuff.Hash]header),
+		acks:      make(map[hotstuff.Hash]map[hotstuff.ID]hotstuff.QuorumSignature),
+		certs:     make(map[hotstuff.Hash]certificate),
+		tips:      make(map[hotstuff.Hash]struct{}),
+		refs:      make(map[hotstuff.Hash]roundID),
+		proposed:  make(map[hotstuff.Hash]roundID),
+		executed:  make(map[hotstuff.Hash]roundID),
+		lastRound: make(map[hotstuff.ID]uint64),
+		cmds:      make(map[cmdID]struct{}),
+		latestCmd: make(map[uint32]uint64),
+
+		marshaler:   proto.MarshalOptions{Deterministic: true},
+		unmarshaler: proto.UnmarshalOptions{DiscardUnknown: true},
+	}
+}
+
+// InitModule gives the module access to the other modules.
+func (m *Mempool) InitModule(mods *modules.Core) {
+	mods.Get(
+		&m.configuration,
+		&m.server,
+
+		&m.crypto,
+		&m.eventLoop,
+		&m.logger,
+		&m.opts,
+	)
+
+	for _, inner := range []any{m.cmdQueue, m.acceptor} {
+		if module, ok := inner.(modules.Module); ok {
+			module.InitModule(mods)
+		}
+	}
+
+	mempoolpb.RegisterMempoolServer(m.server.GetGorumsServer(), serviceImpl{m})
+
+	m.eventLoop.RegisterObserver(backend.ConnectedEvent{}, func(_ any) {
+		m.postInit()
+	})
+	m.eventLoop.RegisterHandler(fetchedEvent{}, func(event any) {
+		m.onFetched(event.(fetchedEvent))
+	})
+}
+
+// SetExecutor replaces the executor that executes the commands of the committed batches.
+// It must be called before the mempool is added to the module system.
+func (m *Mempool) SetExecutor(executor modules.ExecutorExt) {
+	m.executor = executor
+}
+
+func (m *Mempool) postInit() {
+	cfg := mempoolpb.ConfigurationFromRaw(m.configuration.GetRawConfiguration(), qspec{})
+
+	m.mut.Lock()
+	m.cfg = cfg
+	for _, n := range cfg.Nodes() {
+		m.nodes[hotstuff.ID(n.ID())] = n
+	}
+	m.mut.Unlock()
+
+	close(m.connected)
+}
+
+// Start starts disseminating the local batches once the replica has connected to the other replicas.
+// It must be called before the event loop is started.
+func (m *Mempool) Start(ctx context.Context) {
+	m.ctx = ctx
+	go m.run(ctx)
+}
+
+func (m *Mempool) run(ctx context.Context) {
+	select {
+	case <-m.connected:
+	case <-ctx.Done():
+		return
+	}
+	for {
+		cmd, ok := m.cmdQueue.Get(ctx)
+		if !ok {
+			return
+		}
+		m.disseminate(ctx, []byte(cmd))
+	}
+}
+
+// disseminate sends a local batch to the other replicas.
+func (m *Mempool) disseminate(ctx context.Context, commands []byte) {
+	m.mut.Lock()
+	m.round++
+	b := batch{
+		header: header{
+			author:  m.opts.ID(),
+			round:   m.round,
+			batch:   hashBatch(commands),
+			parents: m.parents,
+		},
+		commands: commands,
+	}
+	m.parents = nil
+	digest := b.header.digest()
+	m.headers[digest] = b.header
+	m.batches[digest] = commands
+	m.acked[roundID{b.header.author, b.header.round}] = digest
+	m.acks[digest] = make(map[hotstuff.ID]hotstuff.QuorumSignature)
+	cfg := m.cfg
+	m.mut.Unlock()
+
+	m.logger.Debugf("Disseminate: batch %d (%.8s)", b.header.round, digest)
+	cfg.Disseminate(ctx, batchToProto(b))
+	m.sendAck(b.header.author, digest)
+}
+
+// onBatch stores a batch that was received from its author, and acknowledges it if possible.
+func (m *Mempool) onBatch(b batch) {
+	if hashBatch(b.commands) != b.header.batch {
+		m.logger.Infof("Batch from %d: commands do not match the digest", b.header.author)
+		return
+	}
+	digest := b.header.digest()
+
+	m.mut.Lock()
+	if _, ok := m.acked[roundID{b.header.author, b.header.round}]; ok || m.collected(b.header.author, b.header.round) {
+		// only one batch per author and round is acknowledged.
+		m.mut.Unlock()
+		return
+	}
+	if _, ok := m.waiting[digest]; ok {
+		m.mut.Unlock()
+		return
+	}
+	if !m.hasParents(b.header) {
+		if m.numWaiting(b.header.author) >= maxWaiting {
+			m.mut.Unlock()
+			m.logger.Infof("Batch from %d: too many batches are waiting for certificates", b.header.author)
+			return
+		}
+		m.headers[digest] = b.header
+		m.batches[digest] = b.commands
+		m.waiting[digest] = b.header
+		m.mut.Unlock()
+		return
+	}
+	m.headers[digest] = b.header
+	m.batches[digest] = b.commands
+	m.acked[roundID{b.header.author, b.header.round}] = digest
+	m.mut.Unlock()
+
+	// the commands of the batch no longer need to be batched by this replica.
+	m.acceptor.Proposed(hotstuff.Command(b.commands))
+	m.sendAck(b.header.author, digest)
+}
+
+// hasParents returns true if we have the certificates of all the batches that the header references,
+// except for the batches that are too old to be executed.
+// The caller must hold the lock.
+func (m *Mempool) hasParents(h header) bool {
+	for _, p := range h.parents {
+		if m.collected(p.author, p.round) {
+			continue
+		}
+		c, ok := m.certs[p.digest]
+		if !ok || c.header.author != p.author || c.header.round != p.round {
+			return false
+		}
+	}
+	return true
+}
+
+// numWaiting returns the number of batches of the author that are waiting for certificates.
+// The caller must hold the lock.
+func (m *Mempool) numWaiting(author hotstuff.ID) (n int) {
+	for _, h := range m.waiting {
+		if h.author == author {
+			n++
+		}
+	}
+	return n
+}
+
+// collected returns true if the batch of the author in the given round is too old to be executed,
+// because a batch of the same author that is at least gcRounds rounds later has been executed.
+// The caller must hold the lock.
+func (m *Mempool) collected(author hotstuff.ID, round uint64) bool {
+	return round+gcRounds <= m.lastRound[author]
+}
+
+func (m *Mempool) sendAck(author hotstuff.ID, digest hotstuff.Hash) {
+	sig, err := m.crypto.Sign(ackBytes(digest))
+	if err != nil {
+		m.logger.Errorf("Failed to sign acknowledgement: %v", err)
+		return
+	}
+	a := ack{digest: digest, sig: sig}
+	if author == m.opts.ID() {
+		m.onAck(author, a)
+		return
+	}
+	m.mut.Lock()
+	node, ok := m.nodes[author]
+	m.mut.Unlock()
+	if ok {
+		node.Ack(context.Background(), ackToProto(a))
+	}
+}
+
+// onAck collects the acknowledgements of a local batch, and sends the certificate once there is a quorum.
+func (m *Mempool) onAck(sender hotstuff.ID, a ack) {
+	if a.sig == nil || a.sig.Participants().Len() != 1 || !a.sig.Participants().Contains(sender) {
+		return
+	}
+	if !m.crypto.Verify(a.sig, ackBytes(a.digest)) {
+		m.logger.Infof("Ack from %d: invalid signature", sender)
+		return
+	}
+
+	m.mut.Lock()
+	acks, ok := m.acks[a.digest]
+	if !ok {
+		m.mut.Unlock()
+		return
+	}
+	acks[sender] = a.sig
+	if len(acks) < m.configuration.QuorumSize() {
+		m.mut.Unlock()
+		return
+	}
+	delete(m.acks, a.digest)
+	h := m.headers[a.digest]
+	cfg := m.cfg
+	sigs := make([]hotstuff.QuorumSignature, 0, len(acks))
+	for _, sig := range acks {
+		sigs = append(sigs, sig)
+	}
+	m.mut.Unlock()
+
+	sig, err := m.crypto.Combine(sigs...)
+	if err != nil {
+		m.logger.Errorf("Failed to combine acknowledgements: %v", err)
+		return
+	}
+	c := certificate{header: h, sig: sig}
+	cfg.Certify(context.Background(), certificateToProto(c))
+	m.addCertificate(c)
+}
+
+// verifyCertificate returns true if the certificate is signed by a quorum of replicas.
+func (m *Mempool) verifyCertificate(c certificate) bool {
+	if c.sig == nil || c.sig.Participants().Len() < m.configuration.QuorumSize() {
+		return false
+	}
+	return m.crypto.Verify(c.sig, ackBytes(c.header.digest()))
+}
+
+// onCertificate verifies and adds a certificate that was received from another replica.
+func (m *Mempool) onCertificate(c certificate) {
+	digest := c.header.digest()
+	m.mut.Lock()
+	_, ok := m.certs[digest]
+	m.mut.Unlock()
+	if ok {
+		return
+	}
+	if !m.verifyCertificate(c) {
+		m.logger.Infof("Certificate from %d: invalid signature", c.header.author)
+		return
+	}
+	m.addCertificate(c)
+}
+
+// addCertificate adds a verified certificate to the DAG,
+// and acknowledges the batches that were waiting for it.
+func (m *Mempool) addCertificate(c certificate) {
+	digest := c.header.digest()
+
+	m.mut.Lock()
+	if _, ok := m.certs[digest]; ok || m.collected(c.header.author, c.header.round) {
+		m.mut.Unlock()
+		return
+	}
+	m.certs[digest] = c
+	if _, ok := m.headers[digest]; !ok {
+		m.headers[digest] = c.header
+	}
+	m.parents = append(m.parents, c.header.reference())
+	if _, ok := m.refs[digest]; !ok {
+		m.tips[digest] = struct{}{}
+	}
+	for _, p := range c.header.parents {
+		m.refs[p.digest] = roundID{p.author, p.round}
+		delete(m.tips, p.digest)
+	}
+
+	var ready []batch
+	for d, h := range m.waiting {
+		if !m.hasParents(h) {
+			continue
+		}
+		delete(m.waiting, d)
+		id := roundID{h.author, h.round}
+		if _, ok := m.acked[id]; ok {
+			continue
+		}
+		m.acked[id] = d
+		ready = append(ready, batch{header: h, commands: m.batches[d]})
+	}
+	m.mut.Unlock()
+
+	select {
+	case m.ready <- struct{}{}:
+	default:
+	}
+
+	for _, b := range ready {
+		m.acceptor.Proposed(hotstuff.Command(b.commands))
+		m.sendAck(b.header.author, b.header.digest())
+	}
+}
+
+// Get returns the certificates that are not referenced by other certificates, and that have not been proposed yet.
+func (m *Mempool) Get(ctx context.Context) (cmd hotstuff.Command, ok bool) {
+	m.mut.Lock()
+	certs := m.unproposedTips()
+	for len(certs.GetCertificates()) == 0 {
+		m.mut.Unlock()
+		select {
+		case <-m.ready:
+		case <-ctx.Done():
+			return
+		}
+		m.mut.Lock()
+		certs = m.unproposedTips()
+	}
+	m.mut.Unlock()
+
+	b, err := m.marshaler.Marshal(certs)
+	if err != nil {
+		m.logger.Errorf("Failed to marshal certificates: %v", err)
+		return "", false
+	}
+	return hotstuff.Command(b), true
+}
+
+// unproposedTips returns the tips of the DAG that have not been proposed, ordered by their digests.
+// The caller must hold the lock.
+func (m *Mempool) unproposedTips() *mempoolpb.Certificates {
+	digests := make([]hotstuff.Hash, 0, len(m.tips))
+	for d := range m.tips {
+		if _, ok := m.proposed[d]; !ok {
+			digests = append(digests, d)
+		}
+	}
+	sort.Slice(digests, func(i, j int) bool {
+		return string(digests[i][:]) < string(digests[j][:])
+	})
+	certs := new(mempoolpb.Certificates)
+	for _, d := range digests {
+		certs.Certificates = append(certs.Certificates, certificateToProto(m.certs[d]))
+	}
+	return certs
+}
+
+// unmarshalCertificates returns the certificates of a proposed command.
+func (m *Mempool) unmarshalCertificates(cmd hotstuff.Command) ([]certificate, bool) {
+	pb := new(mempoolpb.Certificates)
+	if err := m.unmarshaler.Unmarshal([]byte(cmd), pb); err != nil {
+		m.logger.Errorf("Failed to unmarshal certificates: %v", err)
+		return nil, false
+	}
+	certs := make([]certificate, 0, len(pb.GetCertificates()))
+	for _, c := range pb.GetCertificates() {
+		certs = append(certs, certificateFromProto(c))
+	}
+	return certs, true
+}
+
+// Accept returns true if all the certificates are valid and have not been proposed before.
+func (m *Mempool) Accept(cmd hotstuff.Command) bool {
+	certs, ok := m.unmarshalCertificates(cmd)
+	if !ok || len(certs) == 0 {
+		return false
+	}
+	for _, c := range certs {
+		m.mut.Lock()
+		_, proposed := m.proposed[c.header.digest()]
+		m.mut.Unlock()
+		if proposed {
+			return false
+		}
+		if !m.verifyCertificate(c) {
+			return false
+		}
+	}
+	// the certificates may be needed to acknowledge other batches.
+	for _, c := range certs {
+		m.addCertificate(c)
+	}
+	return true
+}
+
+// Proposed marks the certificates as proposed, such that they will not be proposed again.
+func (m *Mempool) Proposed(cmd hotstuff.Command) {
+	certs, ok := m.unmarshalCertificates(cmd)
+	if !ok {
+		return
+	}
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	for _, c := range certs {
+		m.proposed[c.header.digest()] = roundID{c.header.author, c.header.round}
+	}
+}
+
+// Exec resolves the certificates of the committed block to commands and executes them.
+// The batches in the causal history of each certificate are executed before the batch of the certificate itself,
+// and commands that were included in several batches are only executed once.
+// If some of the batches are missing, the block is executed once they have been fetched.
+func (m *Mempool) Exec(block *hotstuff.Block) {
+	m.pending = append(m.pending, block)
+	m.execPending()
+}
+
+// execPending executes the pending blocks in order, until a block references batches that must be fetched first.
+func (m *Mempool) execPending() {
+	for len(m.pending) > 0 && m.cancelFetch == nil {
+		block := m.pending[0]
+		m.mut.Lock()
+		restored := block.View() <= m.lastView
+		m.mut.Unlock()
+		if restored {
+			// the commands of the block are part of a restored snapshot.
+			m.pending = m.pending[1:]
+			continue
+		}
+		certs, _ := m.unmarshalCertificates(block.Command())
+		order, missing := m.orderHistory(certs)
+		if len(missing) > 0 {
+			m.startFetch(missing)
+			return
+		}
+		m.pending = m.pending[1:]
+		m.execute(block, order)
+	}
+}
+
+// orderHistory returns the digests of the batches in the causal history of the certificates that have not been executed,
+// such that each batch comes after the batches that it references. The batches that are too old to be executed are skipped.
+// If some of the batches are missing, their digests are returned, and the order is incomplete.
+func (m *Mempool) orderHistory(certs []certificate) (order, missing []hotstuff.Hash) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	visited := make(map[hotstuff.Hash]struct{})
+	var visit func(ref reference)
+	visit = func(ref reference) {
+		if _, ok := visited[ref.digest]; ok {
+			return
+		}
+		visited[ref.digest] = struct{}{}
+		if _, ok := m.executed[ref.digest]; ok || m.collected(ref.author, ref.round) {
+			return
+		}
+		h, ok := m.headers[ref.digest]
+		if _, found := m.batches[ref.digest]; !ok || !found {
+			missing = append(missing, ref.digest)
+		}
+		if !ok {
+			return
+		}
+		for _, p := range h.parents {
+			visit(p)
+		}
+		order = append(order, ref.digest)
+	}
+	for _, c := range certs {
+		ref := c.header.reference()
+		if _, ok := m.headers[ref.digest]; !ok {
+			m.headers[ref.digest] = c.header
+		}
+		visit(ref)
+	}
+	return order, missing
+}
+
+// execute executes the commands of the ordered batches that have not been executed before,
+// and garbage collects the batches and commands that are too old to be executed.
+func (m *Mempool) execute(block *hotstuff.Block, order []hotstuff.Hash) {
+	batch := new(clientpb.Batch)
+
+	m.mut.Lock()
+	for _, digest := range order {
+		h := m.headers[digest]
+		m.executed[digest] = roundID{h.author, h.round}
+		if h.round > m.lastRound[h.author] {
+			m.lastRound[h.author] = h.round
+		}
+		b := new(clientpb.Batch)
+		if err := m.unmarshaler.Unmarshal(m.batches[digest], b); err != nil {
+			m.logger.Errorf("Failed to unmarshal batch: %v", err)
+			continue
+		}
+		for _, cmd := range b.GetCommands() {
+			id := cmdID{cmd.GetClientID(), cmd.GetSequenceNumber()}
+			if _, ok := m.cmds[id]; ok || id.sequenceNum+cmdWindow <= m.latestCmd[id.clientID] {
+				continue
+			}
+			m.cmds[id] = struct{}{}
+			if id.sequenceNum > m.latestCmd[id.clientID] {
+				m.latestCmd[id.clientID] = id.sequenceNum
+			}
+			batch.Commands = append(batch.Commands, cmd)
+		}
+	}
+	m.lastView = block.View()
+	m.collectGarbage()
+	m.mut.Unlock()
+
+	b, err := m.marshaler.Marshal(batch)
+	if err != nil {
+		m.logger.Errorf("Failed to marshal batch: %v", err)
+		return
+	}
+	m.executor.Exec(block.WithCommand(hotstuff.Command(b)))
+}
+
+// collectGarbage deletes the batches that are too old to be executed,
+// and the commands that are too old to be executed again.
+// The caller must hold the lock.
+func (m *Mempool) collectGarbage() {
+	for digest, h := range m.headers {
+		if m.collected(h.author, h.round) {
+			delete(m.headers, digest)
+			delete(m.batches, digest)
+			delete(m.waiting, digest)
+			delete(m.acks, digest)
+			delete(m.certs, digest)
+			delete(m.tips, digest)
+		}
+	}
+	for _, ids := range []map[hotstuff.Hash]roundID{m.refs, m.proposed, m.executed} {
+		for digest, id := range ids {
+			if m.collected(id.author, id.round) {
+				delete(ids, digest)
+			}
+		}
+	}
+	for id := range m.acked {
+		if m.collected(id.author, id.round) {
+			delete(m.acked, id)
+		}
+	}
+	parents := m.parents[:0]
+	for _, p := range m.parents {
+		if !m.collected(p.author, p.round) {
+			parents = append(parents, p)
+		}
+	}
+	m.parents = parents
+	for id := range m.cmds {
+		if id.sequenceNum+cmdWindow <= m.latestCmd[id.clientID] {
+			delete(m.cmds, id)
+		}
+	}
+}
+
+// startFetch fetches the missing batches in the background, and continues executing the pending blocks
+// once they have been fetched. Since the batches are stored by a quorum of replicas, we keep trying until we get them.
+func (m *Mempool) startFetch(digests []hotstuff.Hash) {
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.cancelFetch = cancel
+	go func() {
+		for _, digest := range digests {
+			if !m.fetch(ctx, digest) {
+				return
+			}
+		}
+		m.eventLoop.AddEvent(fetchedEvent{ctx})
+	}()
+}
+
+// onFetched continues executing the pending blocks, unless the fetch was canceled.
+func (m *Mempool) onFetched(e fetchedEvent) {
+	if e.ctx.Err() != nil {
+		return
+	}
+	m.stopFetching()
+	m.execPending()
+}
+
+// stopFetching cancels the fetching of missing batches, if any.
+// It must be called from the event loop.
+func (m *Mempool) stopFetching() {
+	if m.cancelFetch != nil {
+		m.cancelFetch()
+		m.cancelFetch = nil
+	}
+}
+
+// fetch requests a certified batch from the other replicas until it succeeds or the context is canceled.
+func (m *Mempool) fetch(ctx context.Context, digest hotstuff.Hash) bool {
+	for {
+		m.mut.Lock()
+		cfg := m.cfg
+		m.mut.Unlock()
+		pb, err := cfg.Fetch(ctx, &mempoolpb.Digest{Digest: digest[:]})
+		if err == nil {
+			b := batchFromProto(pb)
+			m.mut.Lock()
+			m.headers[digest] = b.header
+			m.batches[digest] = b.commands
+			m.mut.Unlock()
+			return true
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+		m.logger.Infof("Failed to fetch batch %.8s: %v", digest, err)
+		select {
+		case <-time.After(fetchRetryDelay):
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// Fork allows the certificates of a forked block to be proposed again.
+func (m *Mempool) Fork(block *hotstuff.Block) {
+	certs, ok := m.unmarshalCertificates(block.Command())
+	if !ok {
+		return
+	}
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	for _, c := range certs {
+		delete(m.proposed, c.header.digest())
+	}
+}
+
+var (
+	_ modules.CommandQueue   = (*Mempool)(nil)
+	_ modules.Acceptor       = (*Mempool)(nil)
+	_ modules.ExecutorExt    = (*Mempool)(nil)
+	_ modules.ForkHandlerExt = (*Mempool)(nil)
+)
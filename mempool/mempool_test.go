@@ -0,0 +1,212 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/internal/proto/clientpb"
+	"github.com/relab/hotstuff/internal/proto/mempoolpb"
+	"github.com/relab/hotstuff/logging"
+	"google.golang.org/protobuf/proto"
+)
+
+type executorFunc func(block *hotstuff.Block)
+
+func (f executorFunc) Exec(block *hotstuff.Block) { f(block) }
+
+// addBatch stores a certified batch with the given commands in the first round, and returns its digest.
+func addBatch(m *Mempool, author hotstuff.ID, parents []hotstuff.Hash, cmds ...*clientpb.Command) hotstuff.Hash {
+	return addRound(m, author, 1, parents, cmds...)
+}
+
+// addRound stores a certified batch with the given commands in the given round, and returns its digest.
+func addRound(m *Mempool, author hotstuff.ID, round uint64, parents []hotstuff.Hash, cmds ...*clientpb.Command) hotstuff.Hash {
+	commands, err := proto.Marshal(&clientpb.Batch{Commands: cmds})
+	if err != nil {
+		panic(err)
+	}
+	refs := make([]reference, 0, len(parents))
+	for _, p := range parents {
+		refs = append(refs, m.headers[p].reference())
+	}
+	h := header{author: author, round: round, batch: hashBatch(commands), parents: refs}
+	digest := h.digest()
+	m.headers[digest] = h
+	m.batches[digest] = commands
+	m.certs[digest] = certificate{header: h}
+	return digest
+}
+
+// proposal returns a block that proposes the certificates with the given digests.
+func proposal(m *Mempool, digests ...hotstuff.Hash) *hotstuff.Block {
+	certs := new(mempoolpb.Certificates)
+	for _, d := range digests {
+		certs.Certificates = append(certs.Certificates, certificateToProto(m.certs[d]))
+	}
+	b, err := proto.Marshal(certs)
+	if err != nil {
+		panic(err)
+	}
+	// the block is in the view after the latest executed block, such that it is executed.
+	return hotstuff.NewBlock(hotstuff.GetGenesis().Hash(), hotstuff.QuorumCert{}, hotstuff.Command(b), m.lastView+1, 1)
+}
+
+func TestDigestIncludesParentRounds(t *testing.T) {
+	a := header{author: 1, parents: []reference{{author: 2, round: 1, digest: hotstuff.Hash{1}}, {digest: hotstuff.Hash{3}}}}
+	b := header{author: 1, parents: []reference{{author: 2, round: 2, digest: hotstuff.Hash{1}}, {digest: hotstuff.Hash{3}}}}
+	if a.digest() == b.digest() {
+		t.Error("expected headers with different parent rounds to have different digests")
+	}
+	if got := headerFromProto(headerToProto(a)); got.digest() != a.digest() {
+		t.Error("expected the digest to be preserved by the proto conversion")
+	}
+}
+
+func TestExecCausalHistory(t *testing.T) {
+	var executed [][]*clientpb.Command
+	m := New(nil, nil, executorFunc(func(block *hotstuff.Block) {
+		batch := new(clientpb.Batch)
+		if err := proto.Unmarshal([]byte(block.Command()), batch); err != nil {
+			t.Fatal(err)
+		}
+		executed = append(executed, batch.GetCommands())
+	}))
+
+	cmd := func(client uint32, seq uint64) *clientpb.Command {
+		return &clientpb.Command{ClientID: client, SequenceNumber: seq}
+	}
+
+	a := addBatch(m, 1, nil, cmd(1, 1), cmd(1, 2))
+	b := addBatch(m, 2, nil, cmd(1, 2), cmd(2, 1))
+	c := addBatch(m, 3, []hotstuff.Hash{a, b}, cmd(3, 1))
+	d := addBatch(m, 4, []hotstuff.Hash{b}, cmd(4, 1))
+
+	m.Exec(proposal(m, c))
+	// d references b, which has already been executed.
+	m.Exec(proposal(m, d))
+
+	want := [][]*clientpb.Command{
+		{cmd(1, 1), cmd(1, 2), cmd(2, 1), cmd(3, 1)},
+		{cmd(4, 1)},
+	}
+	if len(executed) != len(want) {
+		t.Fatalf("expected %d executions, got %d", len(want), len(executed))
+	}
+	for i := range want {
+		if len(executed[i]) != len(want[i]) {
+			t.Fatalf("execution %d: expected %d commands, got %d", i, len(want[i]), len(executed[i]))
+		}
+		for j := range want[i] {
+			if !proto.Equal(executed[i][j], want[i][j]) {
+				t.Errorf("execution %d: expected command %v, got %v", i, want[i][j], executed[i][j])
+			}
+		}
+	}
+}
+
+type nopState struct{}
+
+func (nopState) Snapshot() ([]byte, hotstuff.Hash) { return nil, hotstuff.Hash{} }
+func (nopState) Restore(_ []byte) error            { return nil }
+
+func TestStateRestore(t *testing.T) {
+	var executed []*clientpb.Command
+	m := New(nil, nil, executorFunc(func(block *hotstuff.Block) {
+		batch := new(clientpb.Batch)
+		if err := proto.Unmarshal([]byte(block.Command()), batch); err != nil {
+			t.Fatal(err)
+		}
+		executed = append(executed, batch.GetCommands()...)
+	}))
+	other := New(nil, nil, executorFunc(func(*hotstuff.Block) {}))
+
+	cmd := func(client uint32, seq uint64) *clientpb.Command {
+		return &clientpb.Command{ClientID: client, SequenceNumber: seq}
+	}
+	var digests []hotstuff.Hash
+	for _, pool := range []*Mempool{m, other} {
+		a := addBatch(pool, 1, nil, cmd(1, 1))
+		b := addBatch(pool, 2, []hotstuff.Hash{a}, cmd(1, 1), cmd(2, 1))
+		digests = []hotstuff.Hash{a, b}
+	}
+
+	// the other mempool executes a, and the first mempool restores its state instead of executing a itself.
+	other.Exec(proposal(other, digests[0]))
+	snapshot, digest := other.State(nopState{}).Snapshot()
+	if err := m.State(nopState{}).Restore(snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if _, got := m.State(nopState{}).Snapshot(); got != digest {
+		t.Errorf("restored mempool has digest %.8x, want %.8x", got, digest)
+	}
+
+	m.Exec(proposal(m, digests[1]))
+	if len(executed) != 1 || !proto.Equal(executed[0], cmd(2, 1)) {
+		t.Errorf("expected only the command that was not executed before the snapshot, got %v", executed)
+	}
+
+	if err := m.State(nopState{}).Restore(snapshot[:len(snapshot)-1]); err == nil {
+		t.Error("expected truncated snapshot to be rejected")
+	}
+}
+
+func TestGarbageCollection(t *testing.T) {
+	var executed []*clientpb.Command
+	m := New(nil, nil, executorFunc(func(block *hotstuff.Block) {
+		batch := new(clientpb.Batch)
+		if err := proto.Unmarshal([]byte(block.Command()), batch); err != nil {
+			t.Fatal(err)
+		}
+		executed = append(executed, batch.GetCommands()...)
+	}))
+
+	cmd := func(client uint32, seq uint64) *clientpb.Command {
+		return &clientpb.Command{ClientID: client, SequenceNumber: seq}
+	}
+
+	old := addRound(m, 1, 1, nil, cmd(1, 1))
+	m.proposed[old] = roundID{1, 1}
+	latest := addRound(m, 1, 1+gcRounds, nil, cmd(1, 2))
+	// the batch of another author references the old batch, which must not be fetched once it has been collected.
+	other := addBatch(m, 2, []hotstuff.Hash{old}, cmd(2, 1))
+
+	m.Exec(proposal(m, latest))
+	for name, ok := range map[string]bool{
+		"headers":  m.headers[old].round != 0,
+		"batches":  m.batches[old] != nil,
+		"certs":    m.certs[old].sig != nil || m.certs[old].header.round != 0,
+		"proposed": m.proposed[old] != roundID{},
+	} {
+		if ok {
+			t.Errorf("expected the old batch to be deleted from %s", name)
+		}
+	}
+
+	m.Exec(proposal(m, other))
+	if len(executed) != 2 || !proto.Equal(executed[0], cmd(1, 2)) || !proto.Equal(executed[1], cmd(2, 1)) {
+		t.Errorf("expected the collected batch to be skipped, got %v", executed)
+	}
+	if len(m.pending) != 0 {
+		t.Errorf("expected no pending blocks, got %d", len(m.pending))
+	}
+}
+
+func TestWaitingIsBounded(t *testing.T) {
+	m := New(nil, nil, nil)
+	m.logger = logging.New("test")
+
+	missing := reference{author: 2, round: 1, digest: hotstuff.Hash{1}}
+	for round := uint64(1); round <= 2*maxWaiting; round++ {
+		commands := []byte{byte(round)}
+		m.onBatch(batch{
+			header:   header{author: 1, round: round, batch: hashBatch(commands), parents: []reference{missing}},
+			commands: commands,
+		})
+	}
+	if n := m.numWaiting(1); n != maxWaiting {
+		t.Errorf("expected %d waiting batches, got %d", maxWaiting, n)
+	}
+	if len(m.batches) != maxWaiting {
+		t.Errorf("expected only the waiting batches to be stored, got %d", len(m.batches))
+	}
+}
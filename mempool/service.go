@@ -0,0 +1,69 @@
+package mempool
+
+import (
+	"github.com/relab/gorums"
+	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/internal/proto/mempoolpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type serviceImpl struct {
+	m *Mempool
+}
+
+func (impl serviceImpl) Disseminate(ctx gorums.ServerCtx, msg *mempoolpb.Batch) {
+	id, err := backend.GetPeerIDFromContext(ctx, impl.m.configuration)
+	if err != nil {
+		impl.m.logger.Error(err)
+		return
+	}
+	b := batchFromProto(msg)
+	if b.header.author != id {
+		impl.m.logger.Infof("Batch from %d: sender is not the author", id)
+		return
+	}
+	impl.m.onBatch(b)
+}
+
+func (impl serviceImpl) Ack(ctx gorums.ServerCtx, msg *mempoolpb.BatchAck) {
+	id, err := backend.GetPeerIDFromContext(ctx, impl.m.configuration)
+	if err != nil {
+		impl.m.logger.Error(err)
+		return
+	}
+	impl.m.onAck(id, ackFromProto(msg))
+}
+
+func (impl serviceImpl) Certify(_ gorums.ServerCtx, msg *mempoolpb.Certificate) {
+	impl.m.onCertificate(certificateFromProto(msg))
+}
+
+func (impl serviceImpl) Fetch(_ gorums.ServerCtx, msg *mempoolpb.Digest) (*mempoolpb.Batch, error) {
+	digest := hashFromBytes(msg.GetDigest())
+
+	impl.m.mut.Lock()
+	h, ok := impl.m.headers[digest]
+	commands, found := impl.m.batches[digest]
+	impl.m.mut.Unlock()
+
+	if !ok || !found {
+		return nil, status.Errorf(codes.NotFound, "requested batch was not found")
+	}
+	return batchToProto(batch{header: h, commands: commands}), nil
+}
+
+type qspec struct{}
+
+// FetchQF is the quorum function for the Fetch quorum call method.
+// It returns the first reply that matches the requested digest.
+func (q qspec) FetchQF(in *mempoolpb.Digest, replies map[uint32]*mempoolpb.Batch) (*mempoolpb.Batch, bool) {
+	digest := hashFromBytes(in.GetDigest())
+	for _, reply := range replies {
+		b := batchFromProto(reply)
+		if b.header.digest() == digest && hashBatch(b.commands) == b.header.batch {
+			return reply, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,176 @@
+package mempool
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"sort"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/checkpoint"
+)
+
+// State returns the checkpoint state of a replica that executes its commands through the mempool.
+// The snapshots of the inner state are extended with the batches and commands that the mempool has executed,
+// since a replica that restores a snapshot must not execute them again when later certificates reference them.
+func (m *Mempool) State(inner checkpoint.State) checkpoint.State {
+	return state{m, inner}
+}
+
+type state struct {
+	m     *Mempool
+	inner checkpoint.State
+}
+
+// Snapshot returns the sorted digests, authors and rounds of the executed batches, the sorted IDs of the executed commands,
+// and the view of the latest executed block, followed by the snapshot of the inner state. The digest covers both parts.
+func (s state) Snapshot() (snapshot []byte, digest hotstuff.Hash) {
+	type executedBatch struct {
+		digest hotstuff.Hash
+		id     roundID
+	}
+
+	s.m.mut.Lock()
+	executed := make([]executedBatch, 0, len(s.m.executed))
+	for digest, id := range s.m.executed {
+		executed = append(executed, executedBatch{digest, id})
+	}
+	cmds := make([]cmdID, 0, len(s.m.cmds))
+	for id := range s.m.cmds {
+		cmds = append(cmds, id)
+	}
+	lastView := s.m.lastView
+	s.m.mut.Unlock()
+
+	sort.Slice(executed, func(i, j int) bool { return bytes.Compare(executed[i].digest[:], executed[j].digest[:]) < 0 })
+	sort.Slice(cmds, func(i, j int) bool {
+		if cmds[i].clientID != cmds[j].clientID {
+			return cmds[i].clientID < cmds[j].clientID
+		}
+		return cmds[i].sequenceNum < cmds[j].sequenceNum
+	})
+
+	var buf [binary.MaxVarintLen64]byte
+	appendUvarint := func(x uint64) {
+		n := binary.PutUvarint(buf[:], x)
+		snapshot = append(snapshot, buf[:n]...)
+	}
+	appendUvarint(uint64(len(executed)))
+	for _, b := range executed {
+		snapshot = append(snapshot, b.digest[:]...)
+		appendUvarint(uint64(b.id.author))
+		appendUvarint(b.id.round)
+	}
+	appendUvarint(uint64(len(cmds)))
+	for _, id := range cmds {
+		appendUvarint(uint64(id.clientID))
+		appendUvarint(id.sequenceNum)
+	}
+	appendUvarint(uint64(lastView))
+
+	inner, innerDigest := s.inner.Snapshot()
+	hash := sha256.New()
+	_, _ = hash.Write(snapshot)
+	_, _ = hash.Write(innerDigest[:])
+	copy(digest[:], hash.Sum(nil))
+	return append(snapshot, inner...), digest
+}
+
+// Restore replaces the executed batches and commands of the mempool, and restores the inner state.
+// The pending blocks up to the view of the snapshot are skipped, since their commands are part of the snapshot.
+func (s state) Restore(snapshot []byte) error {
+	errMalformed := errors.New("malformed mempool snapshot")
+
+	readUvarint := func() (uint64, bool) {
+		x, n := binary.Uvarint(snapshot)
+		if n <= 0 {
+			return 0, false
+		}
+		snapshot = snapshot[n:]
+		return x, true
+	}
+
+	count, ok := readUvarint()
+	if !ok || count > uint64(len(snapshot)/(len(hotstuff.Hash{})+2)) {
+		return errMalformed
+	}
+	executed := make(map[hotstuff.Hash]roundID, count)
+	lastRound := make(map[hotstuff.ID]uint64)
+	for i := uint64(0); i < count; i++ {
+		var digest hotstuff.Hash
+		if len(snapshot) < len(digest) {
+			return errMalformed
+		}
+		copy(digest[:], snapshot)
+		snapshot = snapshot[len(digest):]
+		author, ok := readUvarint()
+		if !ok {
+			return errMalformed
+		}
+		round, ok := readUvarint()
+		if !ok {
+			return errMalformed
+		}
+		executed[digest] = roundID{hotstuff.ID(author), round}
+		if round > lastRound[hotstuff.ID(author)] {
+			lastRound[hotstuff.ID(author)] = round
+		}
+	}
+
+	count, ok = readUvarint()
+	if !ok {
+		return errMalformed
+	}
+	cmds := make(map[cmdID]struct{})
+	latestCmd := make(map[uint32]uint64)
+	for i := uint64(0); i < count; i++ {
+		clientID, ok := readUvarint()
+		if !ok {
+			return errMalformed
+		}
+		sequenceNum, ok := readUvarint()
+		if !ok {
+			return errMalformed
+		}
+		cmds[cmdID{uint32(clientID), sequenceNum}] = struct{}{}
+		if sequenceNum > latestCmd[uint32(clientID)] {
+			latestCmd[uint32(clientID)] = sequenceNum
+		}
+	}
+
+	lastView, ok := readUvarint()
+	if !ok {
+		return errMalformed
+	}
+
+	if err := s.inner.Restore(snapshot); err != nil {
+		return err
+	}
+	s.m.mut.Lock()
+	s.m.executed = executed
+	s.m.lastRound = lastRound
+	s.m.cmds = cmds
+	s.m.latestCmd = latestCmd
+	s.m.lastView = hotstuff.View(lastView)
+	s.m.collectGarbage()
+	s.m.mut.Unlock()
+
+	// the batches that were being fetched may no longer be needed, or may no longer be available.
+	if s.m.cancelFetch != nil {
+		s.m.stopFetching()
+		s.m.eventLoop.AddEvent(fetchedEvent{context.Background()})
+	}
+	return nil
+}
+
+// ApplyRestored applies the deferred part of the inner state, if any.
+func (s state) ApplyRestored() error {
+	if applier, ok := s.inner.(checkpoint.Applier); ok {
+		return applier.ApplyRestored()
+	}
+	return nil
+}
+
+var _ checkpoint.Applier = state{}
@@ -0,0 +1,131 @@
+package mempool
+
+import (
+	"crypto/sha256"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	"github.com/relab/hotstuff/internal/proto/mempoolpb"
+	"google.golang.org/protobuf/proto"
+)
+
+// header describes a batch of client commands and its position in the DAG.
+type header struct {
+	author  hotstuff.ID
+	round   uint64
+	batch   hotstuff.Hash // the hash of the commands in the batch
+	parents []reference   // the certificates that the author had received
+}
+
+// reference identifies the certificate of a batch, along with the author and round of the batch.
+type reference struct {
+	author hotstuff.ID
+	round  uint64
+	digest hotstuff.Hash
+}
+
+// reference returns a reference to the certificate of the batch described by the header.
+func (h header) reference() reference {
+	return reference{author: h.author, round: h.round, digest: h.digest()}
+}
+
+// digest returns the hash of the header, which identifies both the header and its certificate.
+func (h header) digest() hotstuff.Hash {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(headerToProto(h))
+	if err != nil {
+		// marshaling a header with only scalar and bytes fields does not fail.
+		panic(err)
+	}
+	return sha256.Sum256(b)
+}
+
+// certificate proves that a quorum of replicas have stored the batch described by the header.
+type certificate struct {
+	header header
+	sig    hotstuff.QuorumSignature
+}
+
+// batch is a header along with the marshaled clientpb.Batch that it describes.
+type batch struct {
+	header   header
+	commands []byte
+}
+
+// ack is a signed acknowledgement that a replica has stored a batch.
+type ack struct {
+	digest hotstuff.Hash
+	sig    hotstuff.QuorumSignature
+}
+
+// ackBytes returns the message that is signed by a replica to acknowledge the batch with the given digest.
+func ackBytes(digest hotstuff.Hash) []byte {
+	return append([]byte("mempool-ack"), digest[:]...)
+}
+
+// hashBatch returns the digest of the commands in a batch.
+func hashBatch(commands []byte) hotstuff.Hash {
+	return sha256.Sum256(commands)
+}
+
+func hashFromBytes(b []byte) (h hotstuff.Hash) {
+	copy(h[:], b)
+	return h
+}
+
+func headerToProto(h header) *mempoolpb.Header {
+	parents := make([]*mempoolpb.Reference, 0, len(h.parents))
+	for i := range h.parents {
+		parents = append(parents, &mempoolpb.Reference{
+			Author: uint32(h.parents[i].author),
+			Round:  h.parents[i].round,
+			Digest: h.parents[i].digest[:],
+		})
+	}
+	return &mempoolpb.Header{
+		Author:      uint32(h.author),
+		Round:       h.round,
+		BatchDigest: h.batch[:],
+		Parents:     parents,
+	}
+}
+
+func headerFromProto(h *mempoolpb.Header) header {
+	parents := make([]reference, 0, len(h.GetParents()))
+	for _, p := range h.GetParents() {
+		parents = append(parents, reference{
+			author: hotstuff.ID(p.GetAuthor()),
+			round:  p.GetRound(),
+			digest: hashFromBytes(p.GetDigest()),
+		})
+	}
+	return header{
+		author:  hotstuff.ID(h.GetAuthor()),
+		round:   h.GetRound(),
+		batch:   hashFromBytes(h.GetBatchDigest()),
+		parents: parents,
+	}
+}
+
+func batchToProto(b batch) *mempoolpb.Batch {
+	return &mempoolpb.Batch{Header: headerToProto(b.header), Commands: b.commands}
+}
+
+func batchFromProto(b *mempoolpb.Batch) batch {
+	return batch{header: headerFromProto(b.GetHeader()), commands: b.GetCommands()}
+}
+
+func ackToProto(a ack) *mempoolpb.BatchAck {
+	return &mempoolpb.BatchAck{Digest: a.digest[:], Sig: hotstuffpb.QuorumSignatureToProto(a.sig)}
+}
+
+func ackFromProto(a *mempoolpb.BatchAck) ack {
+	return ack{digest: hashFromBytes(a.GetDigest()), sig: hotstuffpb.QuorumSignatureFromProto(a.GetSig())}
+}
+
+func certificateToProto(c certificate) *mempoolpb.Certificate {
+	return &mempoolpb.Certificate{Header: headerToProto(c.header), Sig: hotstuffpb.QuorumSignatureToProto(c.sig)}
+}
+
+func certificateFromProto(c *mempoolpb.Certificate) certificate {
+	return certificate{header: headerFromProto(c.GetHeader()), sig: hotstuffpb.QuorumSignatureFromProto(c.GetSig())}
+}
@@ -122,7 +122,7 @@ func NewBuilder(id hotstuff.ID, pk hotstuff.PrivateKey) Builder {
 	bl := Builder{
 		opts: &Options{
 			id:                 id,
-			privateKey:         pk,
+			privateKey:         &privateKey{key: pk},
 			connectionMetadata: make(map[string]string),
 		},
 	}
@@ -12,6 +12,37 @@ type CryptoBase interface {
 	Verify(signature hotstuff.QuorumSignature, message []byte) bool
 	// BatchVerify verifies the given quorum signature against the batch of messages.
 	BatchVerify(signature hotstuff.QuorumSignature, batch map[hotstuff.ID][]byte) bool
+	// VerifyInEpoch verifies the given quorum signature against the message, using the keys of the given epoch.
+	VerifyInEpoch(epoch hotstuff.Epoch, signature hotstuff.QuorumSignature, message []byte) bool
+	// BatchVerifyInEpoch verifies the given quorum signature against the batch of messages,
+	// using the keys of the given epoch.
+	BatchVerifyInEpoch(epoch hotstuff.Epoch, signature hotstuff.QuorumSignature, batch map[hotstuff.ID][]byte) bool
+}
+
+// KeyProver is implemented by CryptoBase implementations that need a proof that a replica knows the private key
+// of a public key that it announces, such as to prevent rogue key attacks on aggregated signatures.
+type KeyProver interface {
+	// ProveKey returns a proof that the caller knows the given private key.
+	ProveKey(key hotstuff.PrivateKey) (proof []byte, err error)
+	// VerifyKeyProof returns true if the proof shows knowledge of the private key of the given public key.
+	VerifyKeyProof(key hotstuff.PublicKey, proof []byte) bool
+}
+
+// UniqueSigner is implemented by CryptoBase implementations whose signatures are unique, that is, a replica can only
+// create one valid signature of each message. A unique signature can serve as the output of a verifiable random function.
+type UniqueSigner interface {
+	// UniqueSignatures returns true if the signatures are unique.
+	UniqueSignatures() bool
+}
+
+// SafetySigner is implemented by CryptoBase implementations that check votes and timeouts before signing them,
+// such as a signer that holds the private key in a separate process.
+// The SafetyRules module signs votes and timeouts through these methods instead of Sign.
+type SafetySigner interface {
+	// SignVote signs the block as a vote. The signed message is block.ToBytes().
+	SignVote(block *hotstuff.Block) (signature hotstuff.QuorumSignature, err error)
+	// SignTimeout signs the message, which must be one of the messages that is signed for the timeout.
+	SignTimeout(timeout hotstuff.TimeoutMsg, message []byte) (signature hotstuff.QuorumSignature, err error)
 }
 
 // Crypto implements the methods required to create and verify signatures and certificates.
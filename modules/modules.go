@@ -2,6 +2,7 @@ package modules
 
 import (
 	"context"
+	"sort"
 
 	"github.com/relab/hotstuff"
 )
@@ -46,6 +47,21 @@ type ExecutorExt interface {
 	Exec(block *hotstuff.Block)
 }
 
+// StateMachine is a replicated application whose state is only changed by applying committed blocks.
+// Since all replicas apply the same blocks in the same order, they all produce the same results and the same state.
+//
+// Unlike the ExecutorExt interface, a StateMachine returns the results of the commands,
+// and its state can be transferred to a replica that has not applied the blocks itself.
+type StateMachine interface {
+	// Apply applies the commands in the block to the state, and returns the result of each command,
+	// in the same order as the commands in the block.
+	Apply(block *hotstuff.Block) (results [][]byte)
+	// Snapshot returns a serialized copy of the state, and a digest that identifies the state.
+	Snapshot() (snapshot []byte, digest hotstuff.Hash)
+	// Restore replaces the state with the state of a snapshot.
+	Restore(snapshot []byte) error
+}
+
 //go:generate mockgen -destination=../internal/mocks/forkhandler_mock.go -package=mocks . ForkHandler
 
 // ForkHandler handles commands that do not get committed due to a forked blockchain.
@@ -85,6 +101,38 @@ type BlockChain interface {
 	PruneToHeight(height hotstuff.View) (forkedBlocks []*hotstuff.Block)
 }
 
+// LocalRange returns the chain of blocks leading from the ancestor block up to and including the target block,
+// ordered from oldest to newest, as returned by Configuration.FetchRange.
+// The chain is found by following the parent hashes from the target block back to the ancestor.
+// If a block is missing, or if there are more than maxBlocks blocks in the chain,
+// only the newest part of the chain is returned. If maxBlocks is 0, the chain is not limited.
+func LocalRange(blockChain BlockChain, ancestor, target hotstuff.Hash, maxBlocks int) (blocks []*hotstuff.Block) {
+	for hash := target; hash != ancestor && (maxBlocks == 0 || len(blocks) < maxBlocks); {
+		block, ok := blockChain.LocalGet(hash)
+		if !ok {
+			break
+		}
+		blocks = append(blocks, block)
+		hash = block.Parent()
+	}
+	// reverse the order such that the oldest block comes first.
+	for i, j := 0, len(blocks)-1; i < j; i, j = i+1, j-1 {
+		blocks[i], blocks[j] = blocks[j], blocks[i]
+	}
+	return blocks
+}
+
+// CheckpointStore is an optional interface for block chains that can store checkpoint certificates.
+// Once a checkpoint is certified, the committed blocks that precede its block are no longer needed
+// to prove the state of the replicas, and can be discarded.
+type CheckpointStore interface {
+	// StoreCheckpoint stores a verified checkpoint certificate,
+	// and discards the committed blocks that are no longer needed by the replicas.
+	StoreCheckpoint(cert hotstuff.CheckpointCert)
+	// LatestCheckpoint returns the latest stored checkpoint certificate, if any.
+	LatestCheckpoint() (cert hotstuff.CheckpointCert, ok bool)
+}
+
 //go:generate mockgen -destination=../internal/mocks/replica_mock.go -package=mocks . Replica
 
 // Replica represents a remote replica participating in the consensus protocol.
@@ -119,12 +167,75 @@ type Configuration interface {
 	Propose(proposal hotstuff.ProposeMsg)
 	// Timeout sends the timeout message to all replicas.
 	Timeout(msg hotstuff.TimeoutMsg)
+	// Vote sends the partial certificate to all replicas.
+	Vote(cert hotstuff.PartialCert)
 	// Fetch requests a block from all the replicas in the configuration.
 	Fetch(ctx context.Context, hash hotstuff.Hash) (block *hotstuff.Block, ok bool)
+	// FetchRange requests the chain of blocks leading from the ancestor block up to and including the target block.
+	// The blocks are ordered from oldest to newest, and the ancestor is not included.
+	// If the chain is long, only the newest part of the chain may be returned,
+	// in which case the parent of the first block is not the ancestor.
+	FetchRange(ctx context.Context, ancestor, target hotstuff.Hash) (blocks []*hotstuff.Block, ok bool)
 	// SubConfig returns a subconfiguration containing the replicas specified in the ids slice.
 	SubConfig(ids []hotstuff.ID) (sub Configuration, err error)
 }
 
+// Membership is implemented by configurations whose set of replicas or their keys can change while the protocol is
+// running. These only change at the start of an epoch, which begins in a view that all replicas agree on.
+// The methods of Configuration that do not take a view refer to the replicas of the current epoch.
+type Membership interface {
+	// Members returns the IDs of the replicas that participate in the given view, in ascending order.
+	Members(view hotstuff.View) []hotstuff.ID
+	// Epoch returns the epoch that the given view belongs to.
+	Epoch(view hotstuff.View) hotstuff.Epoch
+	// PublicKey returns the public key that the replica uses in the given epoch.
+	PublicKey(id hotstuff.ID, epoch hotstuff.Epoch) (pubKey hotstuff.PublicKey, ok bool)
+}
+
+// QuorumSizeAt returns the size of a quorum in the given view.
+func QuorumSizeAt(cfg Configuration, view hotstuff.View) int {
+	if m, ok := cfg.(Membership); ok {
+		return hotstuff.QuorumSize(len(m.Members(view)))
+	}
+	return cfg.QuorumSize()
+}
+
+// IsMember returns true if the replica participates in the given view.
+// If the configuration does not implement Membership, every replica is assumed to participate in every view.
+func IsMember(cfg Configuration, id hotstuff.ID, view hotstuff.View) bool {
+	m, ok := cfg.(Membership)
+	if !ok {
+		return true
+	}
+	members := m.Members(view)
+	i := sort.Search(len(members), func(i int) bool { return members[i] >= id })
+	return i < len(members) && members[i] == id
+}
+
+//go:generate mockgen -destination=../internal/mocks/consensus_mock.go -package=mocks . Consensus
+
+// EpochAt returns the epoch that the given view belongs to.
+// If the configuration does not implement Membership, every view belongs to epoch 0.
+func EpochAt(cfg Configuration, view hotstuff.View) hotstuff.Epoch {
+	if m, ok := cfg.(Membership); ok {
+		return m.Epoch(view)
+	}
+	return 0
+}
+
+// PublicKeyAt returns the public key that the replica uses in the given epoch.
+// If the configuration does not implement Membership, the replica's only key is returned.
+func PublicKeyAt(cfg Configuration, id hotstuff.ID, epoch hotstuff.Epoch) (hotstuff.PublicKey, bool) {
+	if m, ok := cfg.(Membership); ok {
+		return m.PublicKey(id, epoch)
+	}
+	replica, ok := cfg.Replica(id)
+	if !ok {
+		return nil, false
+	}
+	return replica.PublicKey(), true
+}
+
 //go:generate mockgen -destination=../internal/mocks/consensus_mock.go -package=mocks . Consensus
 
 // Consensus implements a byzantine consensus protocol, such as HotStuff.
@@ -141,12 +252,38 @@ type Consensus interface {
 	ChainLength() int
 }
 
+// Bootstrapper is an optional interface for Consensus modules that can continue from a checkpoint
+// instead of the genesis block, such that a new replica does not need the blocks that precede the checkpoint.
+type Bootstrapper interface {
+	// Bootstrap makes the given block the most recently committed block, without executing it or its ancestors.
+	// The executor's state must already match the state produced by the block.
+	Bootstrap(block *hotstuff.Block)
+}
+
+// CommitProver is an optional interface for Consensus modules that can prove that a block was committed.
+type CommitProver interface {
+	// CommitProof returns a proof that the block with the given hash was committed.
+	// An error is returned if the block is not committed, or if its proof is no longer available.
+	CommitProof(hash hotstuff.Hash) (proof hotstuff.CommitProof, err error)
+}
+
 // LeaderRotation implements a leader rotation scheme.
 type LeaderRotation interface {
 	// GetLeader returns the id of the leader in the given view.
 	GetLeader(hotstuff.View) hotstuff.ID
 }
 
+// LeaderElection is an optional interface for LeaderRotation modules where the leaders of a view cannot be
+// predicted by the other replicas. Instead, a leader includes a proof of its leadership in its proposal.
+// Since the next leader is not known, votes are sent to all replicas, and no new view messages are sent.
+type LeaderElection interface {
+	// ProveLeader returns a proof that this replica is a leader in the view, or false if it is not a leader.
+	ProveLeader(view hotstuff.View) (proof hotstuff.QuorumSignature, ok bool)
+	// VerifyLeader returns true if the proof shows that the replica with the given ID is a leader in the view.
+	// It may be called concurrently with the other methods.
+	VerifyLeader(id hotstuff.ID, view hotstuff.View, proof hotstuff.QuorumSignature) bool
+}
+
 //go:generate mockgen -destination=../internal/mocks/synchronizer_mock.go -package=mocks . Synchronizer
 
 // Synchronizer synchronizes replicas to the same view.
@@ -172,6 +309,66 @@ type Handel interface {
 	Begin(s hotstuff.PartialCert)
 }
 
+// Verifier verifies the signatures of incoming messages concurrently, such that the event loop is not blocked by
+// the verification. It is used when the VerificationWorkers option is greater than zero.
+type Verifier interface {
+	// Verify runs the verify function on a worker, and adds the event to the event loop if the function returns true.
+	// The function must be safe to call from other goroutines than the event loop.
+	Verify(event any, verify func() bool)
+	// Start starts the workers with the given context.
+	Start(context.Context)
+}
+
+// SafetyRules holds the replica's signing key and decides what it is safe to sign.
+// Keeping these rules apart from the consensus logic allows them to run in a separate process,
+// such that the key is only used in accordance with the rules, even if the rest of the replica is compromised.
+type SafetyRules interface {
+	// SignVote returns a partial certificate for the block, unless a vote or timeout has already been signed
+	// in the block's view or a later view, or the block's QC is older than the locked QC.
+	// The qcBlock must be the block that is certified by the block's QC.
+	SignVote(block, qcBlock *hotstuff.Block) (hotstuff.PartialCert, error)
+	// SignTimeout returns the timeout message with its signatures filled in.
+	// No votes will be signed for the timeout's view or earlier views afterwards.
+	SignTimeout(timeout hotstuff.TimeoutMsg) (hotstuff.TimeoutMsg, error)
+	// StopVoting ensures that no votes are signed for `view` or earlier views.
+	StopVoting(view hotstuff.View) error
+	// LastVote returns the latest view in which a vote or timeout was signed.
+	LastVote() hotstuff.View
+}
+
+// ConsensusState is the part of the consensus module's state that must survive a restart.
+type ConsensusState struct {
+	// LastVote is the view of the last block that was voted for.
+	LastVote hotstuff.View
+	// BExec is the last block that was executed.
+	BExec *hotstuff.Block
+	// LockedView is the view of the QC that the safety rules are locked on.
+	LockedView hotstuff.View
+}
+
+// SynchronizerState is the part of the synchronizer's state that must survive a restart.
+type SynchronizerState struct {
+	CurrentView hotstuff.View
+	HighQC      hotstuff.QuorumCert
+	HighTC      hotstuff.TimeoutCert
+	// LastTimeout is the last timeout message that was sent, if any.
+	LastTimeout *hotstuff.TimeoutMsg
+}
+
+// SafetyStateStore stores the state that a replica must remember across restarts in order to avoid equivocating.
+// The Store methods must not return until the state has been written to stable storage,
+// such that the state can be stored before sending any message that depends on it.
+type SafetyStateStore interface {
+	// LoadConsensusState returns the stored consensus state, if any.
+	LoadConsensusState() (state ConsensusState, ok bool)
+	// StoreConsensusState stores the consensus state.
+	StoreConsensusState(state ConsensusState) error
+	// LoadSynchronizerState returns the stored synchronizer state, if any.
+	LoadSynchronizerState() (state SynchronizerState, ok bool)
+	// StoreSynchronizerState stores the synchronizer state.
+	StoreSynchronizerState(state SynchronizerState) error
+}
+
 // ExtendedExecutor turns the given Executor into an ExecutorExt.
 func ExtendedExecutor(executor Executor) ExecutorExt {
 	return executorWrapper{executor}
@@ -191,6 +388,25 @@ func (ew executorWrapper) Exec(block *hotstuff.Block) {
 	ew.executor.Exec(block.Command())
 }
 
+// StateMachineExecutor turns the given StateMachine into an ExecutorExt, which discards the results of the commands.
+func StateMachineExecutor(stateMachine StateMachine) ExecutorExt {
+	return stateMachineWrapper{stateMachine}
+}
+
+type stateMachineWrapper struct {
+	stateMachine StateMachine
+}
+
+func (smw stateMachineWrapper) InitModule(mods *Core) {
+	if m, ok := smw.stateMachine.(Module); ok {
+		m.InitModule(mods)
+	}
+}
+
+func (smw stateMachineWrapper) Exec(block *hotstuff.Block) {
+	smw.stateMachine.Apply(block)
+}
+
 // ExtendedForkHandler turns the given ForkHandler into a ForkHandlerExt.
 func ExtendedForkHandler(forkHandler ForkHandler) ForkHandlerExt {
 	return forkHandlerWrapper{forkHandler}
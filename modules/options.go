@@ -1,15 +1,22 @@
 package modules
 
-import "github.com/relab/hotstuff"
+import (
+	"sync"
+
+	"github.com/relab/hotstuff"
+)
 
 // Options stores runtime configuration settings.
 type Options struct {
 	id         hotstuff.ID
-	privateKey hotstuff.PrivateKey
+	privateKey *privateKey
 
 	shouldUseAggQC        bool
+	shouldUseQCViewTC     bool
 	shouldUseHandel       bool
 	shouldVerifyVotesSync bool
+	shouldVoteToAll       bool
+	verificationWorkers   int
 
 	sharedRandomSeed   int64
 	connectionMetadata map[string]string
@@ -22,7 +29,24 @@ func (opts Options) ID() hotstuff.ID {
 
 // PrivateKey returns the private key.
 func (opts Options) PrivateKey() hotstuff.PrivateKey {
-	return opts.privateKey
+	opts.privateKey.mut.RLock()
+	defer opts.privateKey.mut.RUnlock()
+	return opts.privateKey.key
+}
+
+// SetPrivateKey replaces the private key, such that new signatures are created with the new key.
+// Unlike the other options, the private key may be replaced while the modules are running.
+// This is used when the replica starts to use a key that it has announced for a new epoch.
+func (opts *Options) SetPrivateKey(key hotstuff.PrivateKey) {
+	opts.privateKey.mut.Lock()
+	defer opts.privateKey.mut.Unlock()
+	opts.privateKey.key = key
+}
+
+// privateKey holds the private key, which can be replaced while other modules use it.
+type privateKey struct {
+	mut sync.RWMutex
+	key hotstuff.PrivateKey
 }
 
 // ShouldUseAggQC returns true if aggregated quorum certificates should be used.
@@ -31,17 +55,36 @@ func (opts Options) ShouldUseAggQC() bool {
 	return opts.shouldUseAggQC
 }
 
+// ShouldUseQCViewTC returns true if timeout messages should sign the view of the sender's highQC,
+// such that timeout certificates can prove the view of the highest QC known to a quorum.
+// This is true for Jolteon: https://arxiv.org/abs/2106.10362
+func (opts Options) ShouldUseQCViewTC() bool {
+	return opts.shouldUseQCViewTC
+}
+
 // ShouldUseHandel returns true if the Handel signature aggregation protocol should be used.
 func (opts Options) ShouldUseHandel() bool {
 	return opts.shouldUseHandel
 }
 
+// ShouldVoteToAll returns true if votes should be sent to all replicas instead of only the next leader.
+// Every replica then collects the votes and creates the quorum certificates itself.
+func (opts Options) ShouldVoteToAll() bool {
+	return opts.shouldVoteToAll
+}
+
 // ShouldVerifyVotesSync returns true if votes should be verified synchronously.
 // Enabling this should make the voting machine process votes synchronously.
 func (opts Options) ShouldVerifyVotesSync() bool {
 	return opts.shouldVerifyVotesSync
 }
 
+// VerificationWorkers returns the number of workers that verify incoming votes, proposals, and timeout messages
+// concurrently, off the event loop. If zero, the messages are verified by their handlers.
+func (opts Options) VerificationWorkers() int {
+	return opts.verificationWorkers
+}
+
 // SharedRandomSeed returns a random number that is shared between all replicas.
 func (opts Options) SharedRandomSeed() int64 {
 	return opts.sharedRandomSeed
@@ -57,16 +100,31 @@ func (opts *Options) SetShouldUseAggQC() {
 	opts.shouldUseAggQC = true
 }
 
+// SetShouldUseQCViewTC sets the ShouldUseQCViewTC setting to true.
+func (opts *Options) SetShouldUseQCViewTC() {
+	opts.shouldUseQCViewTC = true
+}
+
 // SetShouldUseHandel sets the ShouldUseHandel setting to true.
 func (opts *Options) SetShouldUseHandel() {
 	opts.shouldUseHandel = true
 }
 
+// SetShouldVoteToAll sets the ShouldVoteToAll setting to true.
+func (opts *Options) SetShouldVoteToAll() {
+	opts.shouldVoteToAll = true
+}
+
 // SetShouldVerifyVotesSync sets the ShouldVerifyVotesSync setting to true.
 func (opts *Options) SetShouldVerifyVotesSync() {
 	opts.shouldVerifyVotesSync = true
 }
 
+// SetVerificationWorkers sets the number of workers that verify incoming messages off the event loop.
+func (opts *Options) SetVerificationWorkers(workers int) {
+	opts.verificationWorkers = workers
+}
+
 // SetSharedRandomSeed sets the shared random seed.
 func (opts *Options) SetSharedRandomSeed(seed int64) {
 	opts.sharedRandomSeed = seed
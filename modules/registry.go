@@ -16,8 +16,8 @@ var (
 // For example:
 //  RegisterModule("chainedhotstuff", func() consensus.Rules { return chainedhotstuff.New() })
 func RegisterModule[T any](name string, constructor func() T) {
-	var t T
-	moduleType := reflect.TypeOf(t)
+	// TypeOf on a nil interface value returns nil, so we must use the pointer type to find the interface type.
+	moduleType := reflect.TypeOf((*T)(nil)).Elem()
 
 	registryMut.Lock()
 	defer registryMut.Unlock()
@@ -41,7 +41,7 @@ func RegisterModule[T any](name string, constructor func() T) {
 // For example:
 //  rules, ok := GetModule[consensus.Rules]("chainedhotstuff")
 func GetModule[T any](name string) (out T, ok bool) {
-	targetType := reflect.TypeOf(out)
+	targetType := reflect.TypeOf((*T)(nil)).Elem()
 
 	registryMut.Lock()
 	defer registryMut.Unlock()
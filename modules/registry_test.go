@@ -23,12 +23,33 @@ func TestModuleRegistry(t *testing.T) {
 	}
 }
 
+func TestModuleRegistryWrongType(t *testing.T) {
+	modules.RegisterModule("defrobulator", func() otherIface {
+		return module{}
+	})
+
+	if _, ok := modules.GetModule[moduleIface]("defrobulator"); ok {
+		t.Error("module was found with the wrong interface type")
+	}
+	if _, ok := modules.GetModule[otherIface]("defrobulator"); !ok {
+		t.Error("module was not found")
+	}
+}
+
 type moduleIface interface {
 	frobulate(i *int)
 }
 
+type otherIface interface {
+	defrobulate(i *int)
+}
+
 type module struct{}
 
 func (module) frobulate(i *int) {
 	*i++
 }
+
+func (module) defrobulate(i *int) {
+	*i--
+}
@@ -1,43 +1,106 @@
 package replica
 
 import (
-	"crypto/sha256"
-	"hash"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
 	"net"
 	"sync"
+	"time"
 
 	"github.com/relab/hotstuff"
 
 	"github.com/relab/gorums"
+	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/crypto/keygen"
 	"github.com/relab/hotstuff/eventloop"
 	"github.com/relab/hotstuff/internal/proto/clientpb"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
 	"github.com/relab/hotstuff/logging"
 	"github.com/relab/hotstuff/modules"
+	"github.com/relab/hotstuff/synchronizer"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/proto"
-	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+// reconfigurationDelay is the number of views from the view of the block that commits a reconfiguration or a key
+// announcement, until the view where the new set of replicas starts to participate, or the new key is used.
+// It must be large enough that the block is committed by most replicas before the new set of replicas takes over.
+//
+// Since the replicas commit the block at different times, the change is only decided once the chain has been extended
+// by enough committed blocks to commit the block itself. If the last of those blocks is already in the view where
+// the change would take effect, or later, the change is rejected. Because every replica commits the same chain,
+// they all make the same decision.
+const reconfigurationDelay hotstuff.View = 10
+
+// replicaClientID is the client ID of the commands that the replicas create themselves.
+// Clients are numbered from 1.
+const replicaClientID = 0
+
+// cmdResult is the outcome of executing a command.
+type cmdResult struct {
+	result *clientpb.CommandResult
+	err    error
+}
+
 // clientSrv serves a client.
 type clientSrv struct {
-	eventLoop *eventloop.EventLoop
-	logger    logging.Logger
+	configuration *backend.Config
+	crypto        modules.Crypto
+	eventLoop     *eventloop.EventLoop
+	logger        logging.Logger
+	opts          *modules.Options
 
 	mut          sync.Mutex
 	srv          *gorums.Server
-	awaitingCmds map[cmdID]chan<- error
+	awaitingCmds map[cmdID]chan<- cmdResult
 	cmdCache     *cmdCache
-	hash         hash.Hash
+	stateMachine modules.StateMachine
+	height       uint64 // the number of committed blocks
+
+	operatorKey hotstuff.PublicKey // the key that reconfigurations must be signed with, if any
+	chainLength int                // the number of committed blocks that must follow a change before it is decided
+	pending     []pendingChange    // changes that have been committed, but not decided
+	changes     []decidedChange    // the changes that have been decided and accepted, in order
+	applied     int                // the number of changes that have been applied to the configuration
+	lastReconf  uint64             // the sequence number of the last reconfiguration that was decided
+
+	announcedKeys map[string]hotstuff.PrivateKey // keys announced by this replica, by their PEM encoded public key
+	nextKey       hotstuff.PrivateKey            // the key that this replica starts to use in nextKeyView
+	nextKeyView   hotstuff.View
+}
+
+// pendingChange is a committed change of the configuration that has not been decided yet.
+type pendingChange struct {
+	cmd    *clientpb.Command
+	start  hotstuff.View // the view where the change takes effect
+	wait   int           // the number of committed blocks to wait for before deciding
+	result *clientpb.CommandResult
+}
+
+// decidedChange is a change of the configuration that has been decided and accepted.
+// The changes are part of the checkpoint state, such that a replica that restores a checkpoint
+// can repeat them to create the same epochs as the other replicas.
+type decidedChange struct {
+	cmd   *clientpb.Command
+	start hotstuff.View
 }
 
 // newClientServer returns a new client server.
+// The commands are applied to the state machine of the configuration, or to a hash of the commands if there is none.
 func newClientServer(conf Config, srvOpts []gorums.ServerOption) (srv *clientSrv) {
 	srv = &clientSrv{
-		awaitingCmds: make(map[cmdID]chan<- error),
-		srv:          gorums.NewServer(srvOpts...),
-		cmdCache:     newCmdCache(int(conf.BatchSize)),
-		hash:         sha256.New(),
+		awaitingCmds:  make(map[cmdID]chan<- cmdResult),
+		srv:           gorums.NewServer(srvOpts...),
+		cmdCache:      newCmdCache(int(conf.BatchSize)),
+		stateMachine:  conf.StateMachine,
+		operatorKey:   conf.OperatorKey,
+		chainLength:   1,
+		announcedKeys: make(map[string]hotstuff.PrivateKey),
+	}
+	if srv.stateMachine == nil {
+		srv.stateMachine = newCommandHash()
 	}
 	clientpb.RegisterClientServer(srv.srv, srv)
 	return srv
@@ -46,10 +109,24 @@ func newClientServer(conf Config, srvOpts []gorums.ServerOption) (srv *clientSrv
 // InitModule gives the module access to the other modules.
 func (srv *clientSrv) InitModule(mods *modules.Core) {
 	mods.Get(
+		&srv.configuration,
+		&srv.crypto,
 		&srv.eventLoop,
 		&srv.logger,
+		&srv.opts,
 	)
 	srv.cmdCache.InitModule(mods)
+	var consensus modules.Consensus
+	if mods.TryGet(&consensus) {
+		srv.chainLength = consensus.ChainLength()
+	}
+	if module, ok := srv.stateMachine.(modules.Module); ok {
+		module.InitModule(mods)
+	}
+
+	srv.eventLoop.RegisterObserver(synchronizer.ViewChangeEvent{}, func(event any) {
+		srv.useNextKey(event.(synchronizer.ViewChangeEvent).View)
+	})
 }
 
 func (srv *clientSrv) Start(addr string) error {
@@ -74,47 +151,167 @@ func (srv *clientSrv) Stop() {
 	srv.srv.Stop()
 }
 
-func (srv *clientSrv) ExecCommand(ctx gorums.ServerCtx, cmd *clientpb.Command) (*emptypb.Empty, error) {
+// ExecCommand executes the command, and returns its result together with the block that committed it.
+func (srv *clientSrv) ExecCommand(ctx gorums.ServerCtx, cmd *clientpb.Command) (*clientpb.CommandResult, error) {
+	return srv.execute(ctx, cmd)
+}
+
+// execute queues the command and waits until it has been executed, and returns the result from the state machine.
+// The server context is released once the command is queued, such that the commands of a client are queued in the
+// order that they were received.
+func (srv *clientSrv) execute(ctx gorums.ServerCtx, cmd *clientpb.Command) (*clientpb.CommandResult, error) {
 	id := cmdID{cmd.ClientID, cmd.SequenceNumber}
 
-	c := make(chan error)
+	// the channel is buffered, such that Exec does not block if we stop waiting.
+	c := make(chan cmdResult, 1)
 	srv.mut.Lock()
 	srv.awaitingCmds[id] = c
 	srv.mut.Unlock()
 
 	srv.cmdCache.addCommand(cmd)
 	ctx.Release()
-	err := <-c
-	return &emptypb.Empty{}, err
+
+	select {
+	case r := <-c:
+		return r.result, r.err
+	case <-ctx.Done():
+		srv.mut.Lock()
+		delete(srv.awaitingCmds, id)
+		srv.mut.Unlock()
+		return nil, ctx.Err()
+	}
 }
 
-func (srv *clientSrv) Exec(cmd hotstuff.Command) {
-	batch := new(clientpb.Batch)
-	err := proto.UnmarshalOptions{AllowPartial: true}.Unmarshal([]byte(cmd), batch)
+// Exec applies the commands in the block to the state machine, and then handles the commands that are directed at
+// the replicas themselves.
+func (srv *clientSrv) Exec(block *hotstuff.Block) {
+	srv.mut.Lock()
+	srv.height++
+	height := srv.height
+	srv.mut.Unlock()
+
+	srv.decidePending(block)
+
+	batch, err := unmarshalBatch(block)
 	if err != nil {
 		srv.logger.Errorf("Failed to unmarshal command: %v", err)
+		srv.eventLoop.AddEvent(hotstuff.CommitEvent{Block: block, Height: height})
 		return
 	}
 
-	srv.eventLoop.AddEvent(hotstuff.CommitEvent{Commands: len(batch.GetCommands())})
+	srv.eventLoop.AddEvent(hotstuff.CommitEvent{Commands: len(batch.GetCommands()), Block: block, Height: height})
+	results := srv.stateMachine.Apply(block)
 
-	for _, cmd := range batch.GetCommands() {
-		_, _ = srv.hash.Write(cmd.Data)
-		srv.mut.Lock()
-		id := cmdID{cmd.GetClientID(), cmd.GetSequenceNumber()}
-		if done, ok := srv.awaitingCmds[id]; ok {
-			done <- nil
-			delete(srv.awaitingCmds, id)
+	for i, cmd := range batch.GetCommands() {
+		hash := block.Hash()
+		result := &clientpb.CommandResult{BlockHash: hash[:], Height: height}
+		if i < len(results) {
+			result.Result = results[i]
 		}
-		srv.mut.Unlock()
+		var err error
+		if r := cmd.GetReconfiguration(); r != nil {
+			if err = srv.checkReconfiguration(block, r); err == nil {
+				// the result is sent once the change has been decided.
+				srv.addPending(block, cmd, result)
+				continue
+			}
+			srv.logger.Errorf("Rejected reconfiguration: %v", err)
+		}
+		if a := cmd.GetKeyAnnouncement(); a != nil {
+			if err = srv.checkKeyAnnouncement(block, a); err == nil {
+				// the result is sent once the change has been decided.
+				srv.addPending(block, cmd, result)
+				continue
+			}
+			srv.logger.Errorf("Rejected key announcement from replica %d: %v", a.GetID(), err)
+		}
+		srv.sendResult(cmd, result, err)
 	}
+}
 
-	srv.logger.Debugf("Hash: %.8x", srv.hash.Sum(nil))
+// sendResult sends the result of the command to the client that is waiting for it, if any.
+func (srv *clientSrv) sendResult(cmd *clientpb.Command, result *clientpb.CommandResult, err error) {
+	srv.mut.Lock()
+	defer srv.mut.Unlock()
+	id := cmdID{cmd.GetClientID(), cmd.GetSequenceNumber()}
+	if done, ok := srv.awaitingCmds[id]; ok {
+		done <- cmdResult{result, err}
+		delete(srv.awaitingCmds, id)
+	}
+}
+
+// addPending adds a change that was committed in the block, which is decided once srv.chainLength more blocks have
+// been committed.
+func (srv *clientSrv) addPending(block *hotstuff.Block, cmd *clientpb.Command, result *clientpb.CommandResult) {
+	srv.pending = append(srv.pending, pendingChange{
+		cmd:    cmd,
+		start:  block.View() + reconfigurationDelay,
+		wait:   srv.chainLength,
+		result: result,
+	})
+}
+
+// decidePending decides the pending changes that have been followed by enough committed blocks.
+// A change is rejected if the block is already in the view where the change would take effect, or later.
+func (srv *clientSrv) decidePending(block *hotstuff.Block) {
+	remaining := srv.pending[:0]
+	for _, change := range srv.pending {
+		change.wait--
+		if change.wait > 0 {
+			remaining = append(remaining, change)
+			continue
+		}
+		r := change.cmd.GetReconfiguration()
+		var err error
+		if block.View() >= change.start {
+			err = fmt.Errorf("view %d was reached before the change was decided", change.start)
+		} else if r != nil {
+			// the same reconfiguration may have been committed twice before the first one was decided.
+			err = srv.checkSequence(r)
+		}
+		if err == nil {
+			err = srv.applyChange(change.cmd, change.start)
+		}
+		if r != nil && r.GetSequence() > srv.lastReconf {
+			// the sequence number is used up, whether or not the reconfiguration was accepted.
+			srv.lastReconf = r.GetSequence()
+		}
+		if err != nil {
+			srv.logger.Errorf("Rejected change of configuration: %v", err)
+		} else {
+			srv.changes = append(srv.changes, decidedChange{cmd: change.cmd, start: change.start})
+			srv.applied++
+		}
+		srv.sendResult(change.cmd, change.result, err)
+	}
+	srv.pending = remaining
+}
+
+// applyChange applies a decided change to the configuration, starting from the given view.
+func (srv *clientSrv) applyChange(cmd *clientpb.Command, start hotstuff.View) error {
+	if r := cmd.GetReconfiguration(); r != nil {
+		return srv.reconfigure(start, r)
+	}
+	if a := cmd.GetKeyAnnouncement(); a != nil {
+		return srv.changeKey(start, a)
+	}
+	return nil
+}
+
+// applyRestored applies the changes of a restored checkpoint that have not been applied to the configuration yet.
+func (srv *clientSrv) applyRestored() error {
+	for ; srv.applied < len(srv.changes); srv.applied++ {
+		change := srv.changes[srv.applied]
+		if err := srv.applyChange(change.cmd, change.start); err != nil {
+			return fmt.Errorf("failed to apply the change to view %d: %w", change.start, err)
+		}
+	}
+	return nil
 }
 
-func (srv *clientSrv) Fork(cmd hotstuff.Command) {
-	batch := new(clientpb.Batch)
-	err := proto.UnmarshalOptions{AllowPartial: true}.Unmarshal([]byte(cmd), batch)
+// Fork aborts the commands in a block that was not committed.
+func (srv *clientSrv) Fork(block *hotstuff.Block) {
+	batch, err := unmarshalBatch(block)
 	if err != nil {
 		srv.logger.Errorf("Failed to unmarshal command: %v", err)
 		return
@@ -124,9 +321,195 @@ func (srv *clientSrv) Fork(cmd hotstuff.Command) {
 		srv.mut.Lock()
 		id := cmdID{cmd.GetClientID(), cmd.GetSequenceNumber()}
 		if done, ok := srv.awaitingCmds[id]; ok {
-			done <- status.Error(codes.Aborted, "blockchain was forked")
+			done <- cmdResult{err: status.Error(codes.Aborted, "blockchain was forked")}
 			delete(srv.awaitingCmds, id)
 		}
 		srv.mut.Unlock()
 	}
 }
+
+// checkReconfiguration returns an error if the reconfiguration that was committed in the block
+// was not signed by the operator in the epoch of the block, or if it has been decided before.
+func (srv *clientSrv) checkReconfiguration(block *hotstuff.Block, r *clientpb.Reconfiguration) error {
+	if srv.operatorKey == nil {
+		return errors.New("no operator key is configured")
+	}
+	if err := srv.checkSequence(r); err != nil {
+		return err
+	}
+	epoch := modules.EpochAt(srv.configuration, block.View())
+	if hotstuff.Epoch(r.GetEpoch()) != epoch {
+		return fmt.Errorf("reconfiguration was signed in epoch %d, but committed in epoch %d", r.GetEpoch(), epoch)
+	}
+	if !r.Verify(srv.operatorKey) {
+		return errors.New("invalid operator signature")
+	}
+	for _, replica := range r.GetAdd() {
+		if _, err := keygen.ParsePublicKey(replica.GetPublicKey()); err != nil {
+			return fmt.Errorf("invalid public key for replica %d: %w", replica.GetID(), err)
+		}
+	}
+	return nil
+}
+
+// checkSequence returns an error if the sequence number of the reconfiguration is not higher than that of the last
+// reconfiguration that was decided, such that a signed reconfiguration cannot be committed again in the same epoch.
+func (srv *clientSrv) checkSequence(r *clientpb.Reconfiguration) error {
+	if r.GetSequence() <= srv.lastReconf {
+		return fmt.Errorf("reconfiguration %d is not newer than the last decided reconfiguration %d", r.GetSequence(), srv.lastReconf)
+	}
+	return nil
+}
+
+// reconfigure changes the set of replicas from the given view.
+// Since all replicas execute the same commands, they all make the same change, or reject it for the same reason.
+func (srv *clientSrv) reconfigure(view hotstuff.View, r *clientpb.Reconfiguration) error {
+	add := make([]backend.ReplicaInfo, 0, len(r.GetAdd()))
+	for _, replica := range r.GetAdd() {
+		pubKey, err := keygen.ParsePublicKey(replica.GetPublicKey())
+		if err != nil {
+			return fmt.Errorf("invalid public key for replica %d: %w", replica.GetID(), err)
+		}
+		add = append(add, backend.ReplicaInfo{
+			ID:      hotstuff.ID(replica.GetID()),
+			Address: replica.GetAddress(),
+			PubKey:  pubKey,
+		})
+	}
+	remove := make([]hotstuff.ID, 0, len(r.GetRemove()))
+	for _, id := range r.GetRemove() {
+		remove = append(remove, hotstuff.ID(id))
+	}
+	return srv.configuration.Reconfigure(view, add, remove)
+}
+
+// announceKey announces a new private key for this replica, and waits until the announcement has been executed.
+func (srv *clientSrv) announceKey(ctx context.Context, key hotstuff.PrivateKey) error {
+	pubKey, err := keygen.PublicKeyToPEM(key.Public())
+	if err != nil {
+		return err
+	}
+	announcement := &clientpb.KeyAnnouncement{
+		ID:        uint32(srv.opts.ID()),
+		Epoch:     uint64(srv.configuration.CurrentEpoch()),
+		PublicKey: pubKey,
+	}
+	if prover, ok := srv.crypto.(modules.KeyProver); ok {
+		announcement.Proof, err = prover.ProveKey(key)
+		if err != nil {
+			return fmt.Errorf("failed to prove possession of the key: %w", err)
+		}
+	}
+	sig, err := srv.crypto.Sign(keyAnnouncementBytes(announcement))
+	if err != nil {
+		return fmt.Errorf("failed to sign key announcement: %w", err)
+	}
+	announcement.Signature = hotstuffpb.QuorumSignatureToProto(sig)
+
+	cmd := &clientpb.Command{
+		ClientID:        replicaClientID,
+		SequenceNumber:  uint64(time.Now().UnixNano()),
+		KeyAnnouncement: announcement,
+	}
+	id := cmdID{cmd.ClientID, cmd.SequenceNumber}
+
+	// the channel is buffered, such that Exec does not block if we stop waiting.
+	c := make(chan cmdResult, 1)
+	srv.mut.Lock()
+	srv.awaitingCmds[id] = c
+	srv.announcedKeys[string(pubKey)] = key
+	srv.mut.Unlock()
+
+	srv.cmdCache.addCommand(cmd)
+
+	select {
+	case r := <-c:
+		return r.err
+	case <-ctx.Done():
+		srv.mut.Lock()
+		delete(srv.awaitingCmds, id)
+		srv.mut.Unlock()
+		return ctx.Err()
+	}
+}
+
+// checkKeyAnnouncement returns an error if the key announcement that was committed in the block was not signed with
+// the replica's key in the epoch of the block, or if the new key is invalid.
+func (srv *clientSrv) checkKeyAnnouncement(block *hotstuff.Block, a *clientpb.KeyAnnouncement) error {
+	id := hotstuff.ID(a.GetID())
+	epoch := modules.EpochAt(srv.configuration, block.View())
+	if hotstuff.Epoch(a.GetEpoch()) != epoch {
+		return fmt.Errorf("announcement was signed in epoch %d, but committed in epoch %d", a.GetEpoch(), epoch)
+	}
+	sig := hotstuffpb.QuorumSignatureFromProto(a.GetSignature())
+	if sig == nil || sig.Participants().Len() != 1 || !sig.Participants().Contains(id) {
+		return errors.New("announcement must be signed by the replica alone")
+	}
+	if !srv.crypto.VerifyInEpoch(epoch, sig, keyAnnouncementBytes(a)) {
+		return errors.New("invalid signature")
+	}
+	pubKey, err := keygen.ParsePublicKey(a.GetPublicKey())
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	if prover, ok := srv.crypto.(modules.KeyProver); ok && !prover.VerifyKeyProof(pubKey, a.GetProof()) {
+		return errors.New("invalid proof of possession")
+	}
+	return nil
+}
+
+// changeKey changes the key of the replica from the given view.
+// If the announcement was made by this replica, it starts to sign with the new key in that view.
+// Since all replicas execute the same commands, they all make the same change, or reject it for the same reason.
+func (srv *clientSrv) changeKey(view hotstuff.View, a *clientpb.KeyAnnouncement) error {
+	id := hotstuff.ID(a.GetID())
+	pubKey, err := keygen.ParsePublicKey(a.GetPublicKey())
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	if err := srv.configuration.RotateKey(view, id, pubKey); err != nil {
+		return err
+	}
+
+	if id == srv.opts.ID() {
+		srv.mut.Lock()
+		if key, ok := srv.announcedKeys[string(a.GetPublicKey())]; ok {
+			delete(srv.announcedKeys, string(a.GetPublicKey()))
+			srv.nextKey = key
+			srv.nextKeyView = view
+		}
+		srv.mut.Unlock()
+	}
+	return nil
+}
+
+// useNextKey starts to sign with the announced key once the replica has reached the view where the key takes effect.
+// Votes that are signed for that view before the replica has observed the view change still use the previous key,
+// and will be rejected like lost votes.
+func (srv *clientSrv) useNextKey(view hotstuff.View) {
+	srv.mut.Lock()
+	defer srv.mut.Unlock()
+	if srv.nextKey == nil || view < srv.nextKeyView {
+		return
+	}
+	srv.opts.SetPrivateKey(srv.nextKey)
+	srv.nextKey = nil
+	srv.logger.Infof("Signing with the new key from view %d", view)
+}
+
+// keyAnnouncementBytes returns the part of the key announcement that is signed by the replica.
+func keyAnnouncementBytes(a *clientpb.KeyAnnouncement) []byte {
+	var idBytes [4]byte
+	binary.LittleEndian.PutUint32(idBytes[:], a.GetID())
+	b := []byte("hotstuff-key-announcement")
+	b = append(b, idBytes[:]...)
+	b = append(b, hotstuff.Epoch(a.GetEpoch()).ToBytes()...)
+	b = append(b, a.GetPublicKey()...)
+	b = append(b, a.GetProof()...)
+	return b
+}
+
+var (
+	_ modules.ExecutorExt    = (*clientSrv)(nil)
+	_ modules.ForkHandlerExt = (*clientSrv)(nil)
+)
@@ -0,0 +1,241 @@
+package replica
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	"github.com/relab/hotstuff/internal/proto/querypb"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// subscriberBuffer is the number of committed blocks that can be queued for a subscriber.
+// A subscriber whose queue is full has fallen behind, and its stream is closed.
+const subscriberBuffer = 1000
+
+// querySrv serves the committed blocks to clients such as indexers.
+// It indexes the hashes of the blocks by height as they are committed, and reads the blocks from the block chain.
+type querySrv struct {
+	blockChain modules.BlockChain
+	eventLoop  *eventloop.EventLoop
+	logger     logging.Logger
+
+	srv *grpc.Server
+
+	mut         sync.Mutex
+	hashes      map[uint64]hotstuff.Hash // the hashes of the indexed blocks, by height
+	heights     map[hotstuff.Hash]uint64 // the heights of the indexed blocks, by hash
+	oldest      uint64                   // the lowest height that may be indexed
+	head        uint64                   // the height of the latest committed block
+	subscribers map[chan *querypb.CommittedBlock]struct{}
+}
+
+// newQueryServer returns a new query server.
+func newQueryServer(opts ...grpc.ServerOption) *querySrv {
+	srv := &querySrv{
+		srv:         grpc.NewServer(opts...),
+		hashes:      make(map[uint64]hotstuff.Hash),
+		heights:     make(map[hotstuff.Hash]uint64),
+		subscribers: make(map[chan *querypb.CommittedBlock]struct{}),
+	}
+	querypb.RegisterQueryServer(srv.srv, queryService{srv: srv})
+	return srv
+}
+
+// InitModule gives the module access to the other modules.
+func (srv *querySrv) InitModule(mods *modules.Core) {
+	mods.Get(
+		&srv.blockChain,
+		&srv.eventLoop,
+		&srv.logger,
+	)
+
+	srv.eventLoop.RegisterObserver(hotstuff.CommitEvent{}, func(event any) {
+		srv.onCommit(event.(hotstuff.CommitEvent))
+	})
+}
+
+func (srv *querySrv) StartOnListener(lis net.Listener) {
+	go func() {
+		err := srv.srv.Serve(lis)
+		if err != nil {
+			srv.logger.Error(err)
+		}
+	}()
+}
+
+func (srv *querySrv) Stop() {
+	srv.srv.Stop()
+}
+
+// onCommit indexes the committed block and sends it to the subscribers.
+func (srv *querySrv) onCommit(event hotstuff.CommitEvent) {
+	if event.Block == nil {
+		return
+	}
+	hash := event.Block.Hash()
+	// the executor may have replaced the command of the block, so the original is read from the block chain.
+	block, ok := srv.blockChain.LocalGet(hash)
+	if !ok {
+		block = event.Block
+	}
+	msg := committedBlock(block, event.Height)
+
+	srv.mut.Lock()
+	defer srv.mut.Unlock()
+
+	srv.hashes[event.Height] = hash
+	srv.heights[hash] = event.Height
+	srv.head = event.Height
+	if srv.oldest == 0 {
+		srv.oldest = event.Height
+	}
+	// the index only holds the blocks that the block chain has not discarded.
+	// Heights may be missing after the replica has restored a checkpoint.
+	for ; srv.oldest < srv.head; srv.oldest++ {
+		oldHash, ok := srv.hashes[srv.oldest]
+		if ok {
+			if _, ok := srv.blockChain.LocalGet(oldHash); ok {
+				break
+			}
+			delete(srv.heights, oldHash)
+		}
+		delete(srv.hashes, srv.oldest)
+	}
+
+	for c := range srv.subscribers {
+		select {
+		case c <- msg:
+		default:
+			delete(srv.subscribers, c)
+			close(c)
+		}
+	}
+}
+
+// blockAt returns the committed block at the given height.
+func (srv *querySrv) blockAt(height uint64) (*querypb.CommittedBlock, error) {
+	srv.mut.Lock()
+	hash, ok := srv.hashes[height]
+	srv.mut.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no committed block at height %d", height)
+	}
+	return srv.block(hash, height)
+}
+
+// block returns the committed block with the given hash and height.
+func (srv *querySrv) block(hash hotstuff.Hash, height uint64) (*querypb.CommittedBlock, error) {
+	block, ok := srv.blockChain.LocalGet(hash)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "the block at height %d was discarded", height)
+	}
+	return committedBlock(block, height), nil
+}
+
+// subscribe returns a channel that receives the blocks that are committed after the current head,
+// and the height of the current head.
+func (srv *querySrv) subscribe() (c chan *querypb.CommittedBlock, head uint64) {
+	c = make(chan *querypb.CommittedBlock, subscriberBuffer)
+	srv.mut.Lock()
+	srv.subscribers[c] = struct{}{}
+	head = srv.head
+	srv.mut.Unlock()
+	return c, head
+}
+
+func (srv *querySrv) unsubscribe(c chan *querypb.CommittedBlock) {
+	srv.mut.Lock()
+	delete(srv.subscribers, c)
+	srv.mut.Unlock()
+}
+
+func committedBlock(block *hotstuff.Block, height uint64) *querypb.CommittedBlock {
+	hash := block.Hash()
+	return &querypb.CommittedBlock{
+		Block:  hotstuffpb.BlockToProto(block),
+		Hash:   hash[:],
+		Height: height,
+	}
+}
+
+type queryService struct {
+	querypb.UnimplementedQueryServer
+
+	srv *querySrv
+}
+
+func (impl queryService) GetBlockByHeight(_ context.Context, req *querypb.Height) (*querypb.CommittedBlock, error) {
+	return impl.srv.blockAt(req.GetHeight())
+}
+
+func (impl queryService) GetBlockByHash(_ context.Context, req *hotstuffpb.BlockHash) (*querypb.CommittedBlock, error) {
+	var hash hotstuff.Hash
+	if len(req.GetHash()) != len(hash) {
+		return nil, status.Error(codes.InvalidArgument, "invalid block hash")
+	}
+	copy(hash[:], req.GetHash())
+
+	impl.srv.mut.Lock()
+	height, ok := impl.srv.heights[hash]
+	impl.srv.mut.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "block %.8s is not committed", hash)
+	}
+	return impl.srv.block(hash, height)
+}
+
+func (impl queryService) GetCommittedHead(_ context.Context, _ *emptypb.Empty) (*querypb.CommittedBlock, error) {
+	impl.srv.mut.Lock()
+	head := impl.srv.head
+	impl.srv.mut.Unlock()
+	if head == 0 {
+		return nil, status.Error(codes.NotFound, "no blocks have been committed")
+	}
+	return impl.srv.blockAt(head)
+}
+
+func (impl queryService) SubscribeCommits(req *querypb.Height, stream querypb.Query_SubscribeCommitsServer) error {
+	c, head := impl.srv.subscribe()
+	defer impl.srv.unsubscribe(c)
+
+	from := req.GetHeight()
+	if from == 0 {
+		from = 1
+	}
+	// the blocks up to the head were committed before the subscription started.
+	for height := from; height <= head; height++ {
+		block, err := impl.srv.blockAt(height)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(block); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case block, ok := <-c:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "the subscriber fell behind the committed blocks")
+			}
+			if block.GetHeight() < from {
+				continue
+			}
+			if err := stream.Send(block); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
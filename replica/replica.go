@@ -5,17 +5,22 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
+	"io"
 	"net"
 
+	"github.com/relab/hotstuff/checkpoint"
 	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/internal/proto/clientpb"
+	"github.com/relab/hotstuff/mempool"
 	"github.com/relab/hotstuff/modules"
+	"github.com/relab/hotstuff/verifier"
 
 	"github.com/relab/gorums"
 	"github.com/relab/hotstuff"
 	"github.com/relab/hotstuff/backend"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
-	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 // cmdID is a unique identifier for a command
@@ -38,24 +43,42 @@ type Config struct {
 	RootCAs *x509.CertPool
 	// The number of client commands that should be batched together in a block.
 	BatchSize uint32
+	// Controls whether the batches are disseminated through the DAG mempool instead of being proposed directly.
+	Mempool bool
+	// The number of workers that verify incoming votes, proposals, and timeout messages off the event loop.
+	// Zero verifies the messages on the event loop.
+	VerificationWorkers int
+	// The number of committed blocks between checkpoints of the application state. Zero disables checkpoints.
+	CheckpointInterval uint64
+	// The public key of the operator, which must sign the commands that add or remove replicas.
+	// If nil, such commands are rejected.
+	OperatorKey hotstuff.PublicKey
+	// The application that executes the client commands.
+	// If nil, the replica only keeps a hash of the commands that it has executed.
+	StateMachine modules.StateMachine
 	// Options for the client server.
 	ClientServerOptions []gorums.ServerOption
 	// Options for the replica server.
 	ReplicaServerOptions []gorums.ServerOption
 	// Options for the replica manager.
 	ManagerOptions []gorums.ManagerOption
+	// Options for the query server.
+	QueryServerOptions []grpc.ServerOption
 }
 
 // Replica is a participant in the consensus protocol.
 type Replica struct {
-	clientSrv *clientSrv
-	cfg       *backend.Config
-	hsSrv     *backend.Server
-	hs        *modules.Core
+	clientSrv   *clientSrv
+	querySrv    *querySrv
+	cfg         *backend.Config
+	hsSrv       *backend.Server
+	mempool     *mempool.Mempool
+	verifier    *verifier.Verifier
+	checkpoints *checkpoint.Checkpointer
+	hs          *modules.Core
 
-	execHandlers map[cmdID]func(*emptypb.Empty, error)
-	cancel       context.CancelFunc
-	done         chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
 }
 
 // New returns a new replica.
@@ -70,11 +93,16 @@ func New(conf Config, builder modules.Builder) (replica *Replica) {
 
 	clientSrv := newClientServer(conf, clientSrvOpts)
 
+	querySrvOpts := conf.QueryServerOptions
+	if conf.TLS {
+		querySrvOpts = append(querySrvOpts, grpc.Creds(credentials.NewServerTLSFromCert(conf.Certificate)))
+	}
+
 	srv := &Replica{
-		clientSrv:    clientSrv,
-		execHandlers: make(map[cmdID]func(*emptypb.Empty, error)),
-		cancel:       func() {},
-		done:         make(chan struct{}),
+		clientSrv: clientSrv,
+		querySrv:  newQueryServer(querySrvOpts...),
+		cancel:    func() {},
+		done:      make(chan struct{}),
 	}
 
 	replicaSrvOpts := conf.ReplicaServerOptions
@@ -101,14 +129,41 @@ func New(conf Config, builder modules.Builder) (replica *Replica) {
 	srv.cfg = backend.NewConfig(creds, managerOpts...)
 
 	builder.Add(
-		srv.cfg,   // configuration
-		srv.hsSrv, // event handling
-
-		modules.ExtendedExecutor(srv.clientSrv),
-		modules.ExtendedForkHandler(srv.clientSrv),
-		srv.clientSrv.cmdCache,
-		srv.clientSrv.cmdCache,
+		srv.cfg,      // configuration
+		srv.hsSrv,    // event handling
+		srv.querySrv, // committed blocks
 	)
+	var state checkpoint.State = heightState{srv.clientSrv}
+	if conf.Mempool {
+		// the mempool disseminates the batches of the command cache and executes them through the client server.
+		srv.mempool = mempool.New(srv.clientSrv.cmdCache, srv.clientSrv.cmdCache, srv.clientSrv)
+		state = srv.mempool.State(state)
+		builder.Add(srv.clientSrv)
+	} else {
+		builder.Add(
+			srv.clientSrv,
+			srv.clientSrv.cmdCache,
+			srv.clientSrv.cmdCache,
+		)
+	}
+	if conf.VerificationWorkers > 0 {
+		builder.Options().SetVerificationWorkers(conf.VerificationWorkers)
+		srv.verifier = verifier.New(1000)
+		builder.Add(srv.verifier)
+	}
+	if conf.CheckpointInterval > 0 {
+		// the checkpointer is added after the client server, such that the consensus module executes the blocks through it.
+		srv.checkpoints = checkpoint.New(conf.CheckpointInterval, srv.clientSrv, state)
+		builder.Add(srv.checkpoints)
+		if srv.mempool != nil {
+			// the blocks are checkpointed once the mempool has fetched their batches.
+			srv.mempool.SetExecutor(srv.checkpoints)
+		}
+	}
+	if srv.mempool != nil {
+		// the mempool is added last, since it resolves the committed blocks before they are checkpointed and executed.
+		builder.Add(srv.mempool)
+	}
 	srv.hs = builder.Build()
 
 	return srv
@@ -125,6 +180,26 @@ func (srv *Replica) StartServers(replicaListen, clientListen net.Listener) {
 	srv.clientSrv.StartOnListener(clientListen)
 }
 
+// StartQueryServer starts the server that lets clients look up the committed blocks and subscribe to new commits.
+func (srv *Replica) StartQueryServer(lis net.Listener) {
+	srv.querySrv.StartOnListener(lis)
+}
+
+// ClientServer returns the server that serves the clients.
+// Additional client-facing services, such as the one of the kvstore package,
+// can be registered with it before the servers are started.
+func (srv *Replica) ClientServer() *gorums.Server {
+	return srv.clientSrv.srv
+}
+
+// Execute queues a command and waits until it has been executed, and returns its result from the state machine
+// together with the hash and height of the block that committed it.
+// It is meant to be called by the handlers of client-facing services, and releases the server context once the
+// command is queued, such that the commands of a client are ordered in the same way as with ExecCommand.
+func (srv *Replica) Execute(ctx gorums.ServerCtx, cmd *clientpb.Command) (*clientpb.CommandResult, error) {
+	return srv.clientSrv.execute(ctx, cmd)
+}
+
 // Connect connects to the other replicas.
 func (srv *Replica) Connect(replicas []backend.ReplicaInfo) error {
 	return srv.cfg.Connect(replicas)
@@ -156,17 +231,73 @@ func (srv *Replica) Run(ctx context.Context) {
 	srv.hs.Get(&synchronizer, &eventLoop)
 
 	synchronizer.Start(ctx)
+	if srv.mempool != nil {
+		srv.mempool.Start(ctx)
+	}
+	if srv.verifier != nil {
+		srv.verifier.Start(ctx)
+	}
 	eventLoop.Run(ctx)
 }
 
 // Close closes the connections and stops the servers used by the replica.
+// If the blockchain is stored on disk, or the safety rules are accessed remotely, they are closed as well.
 func (srv *Replica) Close() {
 	srv.clientSrv.Stop()
+	srv.querySrv.Stop()
 	srv.cfg.Close()
 	srv.hsSrv.Stop()
+
+	var blockChain modules.BlockChain
+	if srv.hs.TryGet(&blockChain) {
+		if closer, ok := blockChain.(io.Closer); ok {
+			_ = closer.Close()
+		}
+	}
+
+	var safetyRules modules.SafetyRules
+	if srv.hs.TryGet(&safetyRules) {
+		if closer, ok := safetyRules.(io.Closer); ok {
+			_ = closer.Close()
+		}
+	}
+}
+
+// RotateKey announces a new private key for the replica, and waits until the announcement has been committed.
+// The replicas verify the replica's signatures with the new key from a fixed number of views after the view of the
+// block that commits the announcement, and the replica starts to sign with the new key in that view.
+// The announcement is rejected if the epoch changes before it is committed, or if the replicas reach that view
+// before the announcement has been decided, in which case it must be repeated.
+func (srv *Replica) RotateKey(ctx context.Context, key hotstuff.PrivateKey) error {
+	return srv.clientSrv.announceKey(ctx, key)
+}
+
+// Bootstrap continues from the latest checkpoint certificate of the other replicas instead of the genesis block.
+// The state machine is restored from a snapshot that matches the certificate,
+// such that only the blocks that are committed after the checkpoint need to be executed.
+// It must be called after Connect and before Start, and requires that checkpoints are enabled.
+func (srv *Replica) Bootstrap(ctx context.Context) (hotstuff.CheckpointCert, error) {
+	if srv.checkpoints == nil {
+		return hotstuff.CheckpointCert{}, errors.New("checkpoints are not enabled")
+	}
+	return srv.checkpoints.Bootstrap(ctx)
+}
+
+// CommitProof returns a proof that the block with the given hash was committed,
+// which clients can verify without running the protocol, such as with the lightclient package.
+// It requires that the consensus protocol implements modules.CommitProver.
+func (srv *Replica) CommitProof(hash hotstuff.Hash) (hotstuff.CommitProof, error) {
+	var consensus modules.Consensus
+	srv.hs.Get(&consensus)
+	prover, ok := consensus.(modules.CommitProver)
+	if !ok {
+		return hotstuff.CommitProof{}, errors.New("the consensus protocol does not provide commit proofs")
+	}
+	return prover.CommitProof(hash)
 }
 
-// GetHash returns the hash of all executed commands.
+// GetHash returns the digest of the state machine, which is the hash of all executed commands by default.
 func (srv *Replica) GetHash() (b []byte) {
-	return srv.clientSrv.hash.Sum(b)
+	_, digest := srv.clientSrv.stateMachine.Snapshot()
+	return append(b, digest[:]...)
 }
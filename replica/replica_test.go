@@ -0,0 +1,456 @@
+package replica
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/relab/gorums"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/backend"
+	"github.com/relab/hotstuff/blockchain"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/consensus/chainedhotstuff"
+	"github.com/relab/hotstuff/crypto"
+	"github.com/relab/hotstuff/crypto/ecdsa"
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/internal/proto/clientpb"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	"github.com/relab/hotstuff/internal/proto/querypb"
+	"github.com/relab/hotstuff/internal/testutil"
+	"github.com/relab/hotstuff/leaderrotation"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+	"github.com/relab/hotstuff/safetyrules"
+	"github.com/relab/hotstuff/synchronizer"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// createReplicas creates n replicas with checkpoints, where replica 1 is the fixed leader.
+func createReplicas(t *testing.T, n int, checkpointInterval uint64) ([]*Replica, []backend.ReplicaInfo) {
+	t.Helper()
+	keys := testutil.GenerateKeys(t, n, testutil.GenerateECDSAKey)
+	replicas := make([]*Replica, 0, n)
+	infos := make([]backend.ReplicaInfo, 0, n)
+	for i := 0; i < n; i++ {
+		id := hotstuff.ID(i + 1)
+		builder := modules.NewBuilder(id, keys[i])
+		builder.Add(
+			eventloop.New(1000),
+			logging.New(fmt.Sprintf("hs%d", id)),
+			blockchain.New(),
+			consensus.New(chainedhotstuff.New()),
+			consensus.NewVotingMachine(),
+			safetyrules.New(),
+			synchronizer.New(testutil.FixedTimeout(100*time.Millisecond)),
+			crypto.NewCache(ecdsa.New(), 100),
+			leaderrotation.NewFixed(1),
+		)
+		r := New(Config{
+			ID:                 id,
+			PrivateKey:         keys[i],
+			BatchSize:          1,
+			CheckpointInterval: checkpointInterval,
+			ManagerOptions:     []gorums.ManagerOption{gorums.WithDialTimeout(time.Second)},
+		}, builder)
+		replicaListener := testutil.CreateTCPListener(t)
+		r.StartServers(replicaListener, testutil.CreateTCPListener(t))
+		replicas = append(replicas, r)
+		infos = append(infos, backend.ReplicaInfo{
+			ID:      id,
+			Address: replicaListener.Addr().String(),
+			PubKey:  keys[i].Public(),
+		})
+	}
+	return replicas, infos
+}
+
+func TestBootstrapFromCheckpoint(t *testing.T) {
+	replicas, infos := createReplicas(t, 4, 5)
+
+	// the first three replicas form a quorum, and commit blocks while the last replica is not running.
+	for _, r := range replicas[:3] {
+		if err := r.Connect(infos); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, r := range replicas[:3] {
+		r.Start()
+		defer r.Stop()
+	}
+
+	var cert hotstuff.CheckpointCert
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for seq := uint64(1); ; seq++ {
+		replicas[0].clientSrv.cmdCache.addCommand(&clientpb.Command{ClientID: 1, SequenceNumber: seq, Data: []byte{byte(seq)}})
+		var ok bool
+		if cert, ok = replicas[0].checkpoints.Latest(); ok {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for a checkpoint certificate")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	bootstrapping := replicas[3]
+	defer bootstrapping.Close()
+	if err := bootstrapping.Connect(infos); err != nil {
+		t.Fatal(err)
+	}
+	got, err := bootstrapping.Bootstrap(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Checkpoint().Height() < cert.Checkpoint().Height() {
+		t.Errorf("bootstrapped from height %d, want at least %d", got.Checkpoint().Height(), cert.Checkpoint().Height())
+	}
+	state := got.Checkpoint().StateHash()
+	if _, hash := (heightState{bootstrapping.clientSrv}).Snapshot(); hash != state {
+		t.Errorf("restored state %.8x does not match the checkpoint state %.8x", hash, state)
+	}
+	if height := bootstrapping.clientSrv.height; height != got.Checkpoint().Height() {
+		t.Errorf("restored height is %d, want %d", height, got.Checkpoint().Height())
+	}
+	var cs modules.Consensus
+	bootstrapping.Modules().Get(&cs)
+	if committed := cs.CommittedBlock(); committed.Hash() != got.Checkpoint().BlockHash() {
+		t.Errorf("committed block is %.8s, want the block of the checkpoint %.8s", committed.Hash(), got.Checkpoint().BlockHash())
+	}
+}
+
+func TestExecResult(t *testing.T) {
+	replicas, infos := createReplicas(t, 4, 0)
+	for _, r := range replicas {
+		if err := r.Connect(infos); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, r := range replicas {
+		r.Start()
+		defer r.Stop()
+	}
+
+	// every replica reports the result of the first command, which is followed by more commands until it commits.
+	results := make([]chan cmdResult, len(replicas))
+	for i, r := range replicas {
+		results[i] = make(chan cmdResult, 1)
+		r.clientSrv.mut.Lock()
+		r.clientSrv.awaitingCmds[cmdID{1, 1}] = results[i]
+		r.clientSrv.mut.Unlock()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	go func() {
+		for seq := uint64(1); ctx.Err() == nil; seq++ {
+			replicas[0].clientSrv.cmdCache.addCommand(&clientpb.Command{ClientID: 1, SequenceNumber: seq, Data: []byte{byte(seq)}})
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	var want *clientpb.CommandResult
+	for i, c := range results {
+		var r cmdResult
+		select {
+		case r = <-c:
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for the result of replica %d", i+1)
+		}
+		if r.err != nil {
+			t.Fatalf("replica %d: %v", i+1, r.err)
+		}
+		if want == nil {
+			want = r.result
+			continue
+		}
+		if !proto.Equal(r.result, want) {
+			t.Errorf("replica %d returned %v, want %v", i+1, r.result, want)
+		}
+	}
+
+	var blockChain modules.BlockChain
+	replicas[0].Modules().Get(&blockChain)
+	var hash hotstuff.Hash
+	copy(hash[:], want.GetBlockHash())
+	block, ok := blockChain.LocalGet(hash)
+	if !ok {
+		t.Fatalf("the block %.8x of the result was not found", want.GetBlockHash())
+	}
+	if want.GetHeight() == 0 || want.GetHeight() > uint64(block.View()) {
+		t.Errorf("got height %d for a block in view %d", want.GetHeight(), block.View())
+	}
+}
+
+func TestQuery(t *testing.T) {
+	replicas, infos := createReplicas(t, 4, 0)
+	lis := testutil.CreateTCPListener(t)
+	replicas[0].StartQueryServer(lis)
+	for _, r := range replicas {
+		if err := r.Connect(infos); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, r := range replicas {
+		r.Start()
+		defer r.Stop()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	go func() {
+		for seq := uint64(1); ctx.Err() == nil; seq++ {
+			replicas[0].clientSrv.cmdCache.addCommand(&clientpb.Command{ClientID: 1, SequenceNumber: seq, Data: []byte{byte(seq)}})
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	conn, err := grpc.DialContext(ctx, lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := querypb.NewQueryClient(conn)
+
+	stream, err := client.SubscribeCommits(ctx, &querypb.Height{Height: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var blocks []*querypb.CommittedBlock
+	for len(blocks) < 5 {
+		block, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := uint64(len(blocks) + 1); block.GetHeight() != want {
+			t.Fatalf("got block at height %d, want %d", block.GetHeight(), want)
+		}
+		if len(blocks) > 0 && !bytes.Equal(block.GetBlock().GetParent(), blocks[len(blocks)-1].GetHash()) {
+			t.Errorf("block at height %d does not extend the previous block", block.GetHeight())
+		}
+		blocks = append(blocks, block)
+	}
+
+	for _, want := range blocks {
+		got, err := client.GetBlockByHeight(ctx, &querypb.Height{Height: want.GetHeight()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got.GetHash(), want.GetHash()) {
+			t.Errorf("GetBlockByHeight(%d): got block %.8x, want %.8x", want.GetHeight(), got.GetHash(), want.GetHash())
+		}
+		got, err = client.GetBlockByHash(ctx, &hotstuffpb.BlockHash{Hash: want.GetHash()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.GetHeight() != want.GetHeight() {
+			t.Errorf("GetBlockByHash(%.8x): got height %d, want %d", want.GetHash(), got.GetHeight(), want.GetHeight())
+		}
+	}
+
+	head, err := client.GetCommittedHead(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if head.GetHeight() < uint64(len(blocks)) {
+		t.Errorf("got head at height %d, want at least %d", head.GetHeight(), len(blocks))
+	}
+	if _, err := client.GetBlockByHeight(ctx, &querypb.Height{Height: head.GetHeight() + 1000}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetBlockByHeight of an uncommitted height: got %v, want NotFound", err)
+	}
+}
+
+// execReconfiguration commits the reconfiguration through the first replica, and returns the error of each replica.
+func execReconfiguration(t *testing.T, replicas []*Replica, seq uint64, r *clientpb.Reconfiguration) []error {
+	t.Helper()
+	results := make([]chan cmdResult, len(replicas))
+	for i, replica := range replicas {
+		results[i] = make(chan cmdResult, 1)
+		replica.clientSrv.mut.Lock()
+		replica.clientSrv.awaitingCmds[cmdID{1, seq}] = results[i]
+		replica.clientSrv.mut.Unlock()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	replicas[0].clientSrv.cmdCache.addCommand(&clientpb.Command{ClientID: 1, SequenceNumber: seq, Reconfiguration: r})
+	go func() {
+		// the change is decided once the block of the command has been followed by more committed blocks.
+		for fill := uint64(1); ctx.Err() == nil; fill++ {
+			replicas[0].clientSrv.cmdCache.addCommand(&clientpb.Command{ClientID: 2, SequenceNumber: seq<<16 + fill})
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	errs := make([]error, len(replicas))
+	for i, c := range results {
+		select {
+		case r := <-c:
+			errs[i] = r.err
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for the result of replica %d", i+1)
+		}
+	}
+	return errs
+}
+
+func TestReconfigure(t *testing.T) {
+	replicas, infos := createReplicas(t, 4, 0)
+	operator := testutil.GenerateECDSAKey(t)
+	for _, r := range replicas {
+		r.clientSrv.operatorKey = operator.Public()
+		if err := r.Connect(infos); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, r := range replicas {
+		r.Start()
+		defer r.Stop()
+	}
+
+	forged := &clientpb.Reconfiguration{Remove: []uint32{4}, Sequence: 1}
+	if err := forged.Sign(testutil.GenerateECDSAKey(t)); err != nil {
+		t.Fatal(err)
+	}
+	for i, err := range execReconfiguration(t, replicas, 1, forged) {
+		if err == nil {
+			t.Errorf("replica %d accepted a reconfiguration that was not signed by the operator", i+1)
+		}
+	}
+
+	signed := &clientpb.Reconfiguration{Remove: []uint32{4}, Sequence: 1}
+	if err := signed.Sign(operator); err != nil {
+		t.Fatal(err)
+	}
+	for i, err := range execReconfiguration(t, replicas, 2, signed) {
+		if err != nil {
+			t.Fatalf("replica %d rejected the reconfiguration: %v", i+1, err)
+		}
+	}
+	for i, r := range replicas {
+		// the latest epoch applies to all views after its start.
+		if members := r.cfg.Members(math.MaxUint64); len(members) != 3 || slices.Contains(members, 4) {
+			t.Errorf("replica %d: got members %v in the latest epoch, want replicas 1-3", i+1, members)
+		}
+	}
+
+	// replica 4 has been removed.
+	for i, err := range execReconfiguration(t, replicas[:3], 3, signed) {
+		if err == nil {
+			t.Errorf("replica %d accepted a reconfiguration that was decided before", i+1)
+		}
+	}
+}
+
+func TestRejectLateChange(t *testing.T) {
+	changes := map[string]*clientpb.Command{
+		"reconfiguration":  {ClientID: 1, SequenceNumber: 1, Reconfiguration: &clientpb.Reconfiguration{}},
+		"key announcement": {ClientID: 1, SequenceNumber: 1, KeyAnnouncement: &clientpb.KeyAnnouncement{ID: 1}},
+	}
+	for name, cmd := range changes {
+		t.Run(name, func(t *testing.T) {
+			srv := newClientServer(Config{}, nil)
+			srv.logger = logging.New("test")
+			result := make(chan cmdResult, 1)
+			srv.awaitingCmds[cmdID{1, 1}] = result
+
+			genesis := hotstuff.GetGenesis()
+			block := hotstuff.NewBlock(genesis.Hash(), hotstuff.NewQuorumCert(nil, 0, genesis.Hash()), "", 1, 1)
+			srv.addPending(block, cmd, &clientpb.CommandResult{})
+
+			// the next committed block is already in the view where the change would take effect.
+			late := hotstuff.NewBlock(block.Hash(), hotstuff.NewQuorumCert(nil, 1, block.Hash()), "", 1+reconfigurationDelay, 1)
+			srv.decidePending(late)
+			select {
+			case r := <-result:
+				if r.err == nil {
+					t.Error("expected the change to be rejected")
+				}
+			default:
+				t.Error("expected the change to be decided")
+			}
+			if len(srv.pending) != 0 {
+				t.Errorf("got %d pending changes after the decision, want 0", len(srv.pending))
+			}
+		})
+	}
+}
+
+func TestRejectReplayedReconfiguration(t *testing.T) {
+	srv := newClientServer(Config{}, nil)
+	srv.logger = logging.New("test")
+	srv.lastReconf = 2
+
+	genesis := hotstuff.GetGenesis()
+	block := hotstuff.NewBlock(genesis.Hash(), hotstuff.NewQuorumCert(nil, 0, genesis.Hash()), "", 1, 1)
+	next := hotstuff.NewBlock(block.Hash(), hotstuff.NewQuorumCert(nil, 1, block.Hash()), "", 2, 1)
+	for seq := uint64(1); seq <= 2; seq++ {
+		result := make(chan cmdResult, 1)
+		srv.awaitingCmds[cmdID{1, seq}] = result
+		cmd := &clientpb.Command{ClientID: 1, SequenceNumber: seq, Reconfiguration: &clientpb.Reconfiguration{Sequence: seq}}
+		srv.addPending(block, cmd, &clientpb.CommandResult{})
+		srv.decidePending(next)
+		if r := <-result; r.err == nil {
+			t.Errorf("expected reconfiguration %d to be rejected after reconfiguration 2 was decided", seq)
+		}
+	}
+	if srv.lastReconf != 2 || len(srv.changes) != 0 {
+		t.Errorf("got last reconfiguration %d and %d changes, want 2 and 0", srv.lastReconf, len(srv.changes))
+	}
+}
+
+func TestRestoreChanges(t *testing.T) {
+	reconfiguration := &clientpb.Command{ClientID: 1, SequenceNumber: 1, Reconfiguration: &clientpb.Reconfiguration{Remove: []uint32{4}}}
+	announcement := &clientpb.Command{ClientID: 0, SequenceNumber: 2, KeyAnnouncement: &clientpb.KeyAnnouncement{ID: 2}}
+
+	srv := newClientServer(Config{}, nil)
+	srv.height = 7
+	srv.changes = []decidedChange{{cmd: reconfiguration, start: 12}}
+	srv.applied = 1
+	srv.lastReconf = 1
+	srv.pending = []pendingChange{{cmd: announcement, start: 16, wait: 1, result: &clientpb.CommandResult{Height: 6}}}
+	snapshot, digest := heightState{srv}.Snapshot()
+
+	restored := newClientServer(Config{}, nil)
+	if err := (heightState{restored}).Restore(snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if _, got := (heightState{restored}).Snapshot(); got != digest {
+		t.Errorf("restored state %.8x does not match the snapshot %.8x", got, digest)
+	}
+	if restored.applied != 0 || len(restored.changes) != 1 || len(restored.pending) != 1 || restored.lastReconf != 1 {
+		t.Errorf("restored %d changes and %d pending changes with %d applied and last reconfiguration %d, want 1, 1, 0 and 1",
+			len(restored.changes), len(restored.pending), restored.applied, restored.lastReconf)
+	}
+
+	// the digest covers the changes, such that replicas that disagree on the epochs create different checkpoints.
+	other := newClientServer(Config{}, nil)
+	other.height = 7
+	other.pending = srv.pending
+	if _, got := (heightState{other}).Snapshot(); got == digest {
+		t.Error("expected the digest to depend on the decided changes")
+	}
+	other.changes = srv.changes
+	if _, got := (heightState{other}).Snapshot(); got == digest {
+		t.Error("expected the digest to depend on the last decided reconfiguration")
+	}
+
+	// a replica cannot restore a snapshot that does not start with the changes it has applied.
+	other.changes = []decidedChange{{cmd: announcement, start: 12}}
+	other.applied = 1
+	if err := (heightState{other}).Restore(snapshot); err == nil {
+		t.Error("expected a snapshot with conflicting changes to be rejected")
+	}
+	if err := (heightState{newClientServer(Config{}, nil)}).Restore(snapshot[:20]); err == nil {
+		t.Error("expected a truncated snapshot to be rejected")
+	}
+}
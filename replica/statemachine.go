@@ -0,0 +1,230 @@
+package replica
+
+import (
+	"crypto/sha256"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"sync"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/checkpoint"
+	"github.com/relab/hotstuff/internal/proto/clientpb"
+	"github.com/relab/hotstuff/modules"
+	"google.golang.org/protobuf/proto"
+)
+
+// unmarshalBatch returns the batch of client commands in the block.
+func unmarshalBatch(block *hotstuff.Block) (*clientpb.Batch, error) {
+	batch := new(clientpb.Batch)
+	err := proto.UnmarshalOptions{AllowPartial: true}.Unmarshal([]byte(block.Command()), batch)
+	return batch, err
+}
+
+// commandHash is the state machine that is used when no other state machine is configured.
+// Its state is a running hash of the data of the executed commands,
+// which lets the replicas check that they have executed the same commands.
+type commandHash struct {
+	mut  sync.Mutex
+	hash hash.Hash
+}
+
+func newCommandHash() *commandHash {
+	return &commandHash{hash: sha256.New()}
+}
+
+// Apply adds the data of the commands in the block to the hash. The result of each command is empty.
+func (ch *commandHash) Apply(block *hotstuff.Block) [][]byte {
+	batch, err := unmarshalBatch(block)
+	if err != nil {
+		return nil
+	}
+	ch.mut.Lock()
+	defer ch.mut.Unlock()
+	for _, cmd := range batch.GetCommands() {
+		_, _ = ch.hash.Write(cmd.GetData())
+	}
+	return make([][]byte, len(batch.GetCommands()))
+}
+
+// Snapshot returns the internal state of the hash function, and the current hash.
+func (ch *commandHash) Snapshot() (snapshot []byte, digest hotstuff.Hash) {
+	ch.mut.Lock()
+	defer ch.mut.Unlock()
+	// the hash functions of the standard library implement encoding.BinaryMarshaler.
+	snapshot, _ = ch.hash.(encoding.BinaryMarshaler).MarshalBinary()
+	copy(digest[:], ch.hash.Sum(nil))
+	return snapshot, digest
+}
+
+// Restore restores the internal state of the hash function.
+func (ch *commandHash) Restore(snapshot []byte) error {
+	ch.mut.Lock()
+	defer ch.mut.Unlock()
+	return ch.hash.(encoding.BinaryUnmarshaler).UnmarshalBinary(snapshot)
+}
+
+// heightState is the checkpoint state of the client server. It adds the number of committed blocks, and the changes
+// of the configuration, to the snapshots of the state machine, such that a replica that restores a snapshot reports
+// the same heights and uses the same epochs as the other replicas. The sequence number of the last decided
+// reconfiguration is included as well, such that the replica rejects the same reconfigurations as the other replicas.
+//
+// The epochs are restored by repeating the decided changes on the configuration that the replica connected with,
+// which must therefore be the configuration of the first epoch. The changes that have not been decided yet are
+// also included, such that the replica decides them like the other replicas.
+type heightState struct {
+	srv *clientSrv
+}
+
+// Snapshot returns the height and the changes of the configuration, followed by the snapshot of the state machine.
+// The digest covers both.
+func (s heightState) Snapshot() (snapshot []byte, digest hotstuff.Hash) {
+	s.srv.mut.Lock()
+	height := s.srv.height
+	s.srv.mut.Unlock()
+
+	prefix := appendUint64(nil, height)
+	prefix = appendUint32(prefix, uint32(len(s.srv.changes)))
+	for _, change := range s.srv.changes {
+		prefix = appendUint64(prefix, uint64(change.start))
+		prefix = appendMessage(prefix, change.cmd)
+	}
+	prefix = appendUint64(prefix, s.srv.lastReconf)
+	prefix = appendUint32(prefix, uint32(len(s.srv.pending)))
+	for _, change := range s.srv.pending {
+		prefix = appendUint64(prefix, uint64(change.start))
+		prefix = appendUint32(prefix, uint32(change.wait))
+		prefix = appendMessage(prefix, change.cmd)
+		prefix = appendMessage(prefix, change.result)
+	}
+
+	inner, innerDigest := s.srv.stateMachine.Snapshot()
+	digest = sha256.Sum256(append(prefix[:len(prefix):len(prefix)], innerDigest[:]...))
+	return append(prefix, inner...), digest
+}
+
+// Restore restores the height, the changes of the configuration, and the state machine.
+// The changes are applied to the configuration by ApplyRestored, once the state has been checked against the
+// checkpoint. The changes that the replica has applied already must be the first changes of the snapshot.
+func (s heightState) Restore(snapshot []byte) error {
+	r := snapshotReader{b: snapshot}
+	height := r.uint64()
+	changes := make([]decidedChange, r.count(12))
+	for i := range changes {
+		changes[i].start = hotstuff.View(r.uint64())
+		changes[i].cmd = new(clientpb.Command)
+		r.message(changes[i].cmd)
+	}
+	lastReconf := r.uint64()
+	pending := make([]pendingChange, r.count(20))
+	for i := range pending {
+		pending[i].start = hotstuff.View(r.uint64())
+		pending[i].wait = int(r.uint32())
+		pending[i].cmd = new(clientpb.Command)
+		r.message(pending[i].cmd)
+		pending[i].result = new(clientpb.CommandResult)
+		r.message(pending[i].result)
+	}
+	if r.err != nil {
+		return errors.New("malformed snapshot")
+	}
+
+	if len(changes) < s.srv.applied {
+		return errors.New("the snapshot does not include the changes of the configuration that have been applied")
+	}
+	for i, change := range s.srv.changes[:s.srv.applied] {
+		if change.start != changes[i].start || !proto.Equal(change.cmd, changes[i].cmd) {
+			return errors.New("the snapshot conflicts with the changes of the configuration that have been applied")
+		}
+	}
+
+	if err := s.srv.stateMachine.Restore(r.b); err != nil {
+		return err
+	}
+	s.srv.mut.Lock()
+	s.srv.height = height
+	s.srv.mut.Unlock()
+	s.srv.changes = changes
+	s.srv.lastReconf = lastReconf
+	s.srv.pending = pending
+	return nil
+}
+
+// ApplyRestored applies the changes of the restored snapshot to the configuration.
+func (s heightState) ApplyRestored() error {
+	return s.srv.applyRestored()
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+// appendMessage appends the length of the encoded message, followed by the message.
+// The encoding is deterministic, such that all replicas create the same snapshot.
+func appendMessage(b []byte, m proto.Message) []byte {
+	data, _ := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	b = appendUint32(b, uint32(len(data)))
+	return append(b, data...)
+}
+
+// snapshotReader reads the values that were appended to a snapshot.
+// If the snapshot is too short, or a message cannot be decoded, err is set, and the remaining values are zero.
+type snapshotReader struct {
+	b   []byte
+	err error
+}
+
+func (r *snapshotReader) next(n int) []byte {
+	if r.err != nil || len(r.b) < n {
+		r.err = errors.New("snapshot is too short")
+		return nil
+	}
+	b := r.b[:n]
+	r.b = r.b[n:]
+	return b
+}
+
+func (r *snapshotReader) uint32() uint32 {
+	if b := r.next(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *snapshotReader) uint64() uint64 {
+	if b := r.next(8); b != nil {
+		return binary.BigEndian.Uint64(b)
+	}
+	return 0
+}
+
+// count reads the number of the following entries, each of which takes at least size bytes.
+func (r *snapshotReader) count(size int) int {
+	n := int(r.uint32())
+	if n > len(r.b)/size {
+		r.err = errors.New("snapshot is too short")
+		return 0
+	}
+	return n
+}
+
+func (r *snapshotReader) message(m proto.Message) {
+	data := r.next(int(r.uint32()))
+	if r.err == nil {
+		r.err = proto.Unmarshal(data, m)
+	}
+}
+
+var (
+	_ modules.StateMachine = (*commandHash)(nil)
+	_ checkpoint.Applier   = heightState{}
+)
@@ -0,0 +1,142 @@
+package safetyrules
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/internal/localrpc"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	"github.com/relab/hotstuff/internal/proto/safetyrulespb"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+	"google.golang.org/grpc"
+)
+
+// callTimeout is the maximum time to wait for the server to answer a request.
+const callTimeout = 5 * time.Second
+
+type remote struct {
+	logger logging.Logger
+	opts   *modules.Options
+
+	conn   *grpc.ClientConn
+	client safetyrulespb.SafetyRulesClient
+
+	mut      sync.Mutex
+	lastVote hotstuff.View // the last vote that the server is known to have signed
+}
+
+// NewRemote returns a SafetyRules module that forwards all requests to a Server at the given address.
+// The address is the path to a Unix socket prefixed by "unix://".
+// The connection is established lazily, so the server does not need to be running yet.
+//
+// If a SafetyStateStore is present, the server is told about the stored last vote when the module is initialized,
+// such that a restarted server cannot be made to vote again in a view that the replica has already voted in.
+func NewRemote(address string) (modules.SafetyRules, error) {
+	conn, err := localrpc.Dial(address)
+	if err != nil {
+		return nil, fmt.Errorf("safetyrules: failed to connect to %s: %w", address, err)
+	}
+	return &remote{
+		conn:   conn,
+		client: safetyrulespb.NewSafetyRulesClient(conn),
+	}, nil
+}
+
+// InitModule initializes the module.
+func (r *remote) InitModule(mods *modules.Core) {
+	mods.Get(
+		&r.logger,
+		&r.opts,
+	)
+
+	var view hotstuff.View
+	var safetyState modules.SafetyStateStore
+	if mods.TryGet(&safetyState) {
+		if state, ok := safetyState.LoadConsensusState(); ok {
+			view = state.LastVote
+		}
+	}
+	// this also fetches the server's last vote, in case the replica has restarted without its state.
+	if err := r.StopVoting(view); err != nil {
+		r.logger.Warnf("Failed to synchronize last vote with safety rules server: %v", err)
+	}
+}
+
+// SignVote returns a partial certificate for the block, unless a vote or timeout has already been signed
+// in the block's view or a later view, or the block's QC is older than the locked QC.
+// The qcBlock must be the block that is certified by the block's QC.
+func (r *remote) SignVote(block, qcBlock *hotstuff.Block) (hotstuff.PartialCert, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+
+	req := &safetyrulespb.VoteRequest{Block: hotstuffpb.BlockToProto(block)}
+	if qcBlock != nil {
+		req.QCBlock = hotstuffpb.BlockToProto(qcBlock)
+	}
+	pc, err := r.client.SignVote(ctx, req, grpc.WaitForReady(true))
+	if err != nil {
+		return hotstuff.PartialCert{}, err
+	}
+	r.updateLastVote(block.View())
+	return hotstuffpb.PartialCertFromProto(pc), nil
+}
+
+// SignTimeout returns the timeout message with its signatures filled in.
+// No votes will be signed for the timeout's view or earlier views afterwards.
+func (r *remote) SignTimeout(timeout hotstuff.TimeoutMsg) (hotstuff.TimeoutMsg, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+
+	signed, err := r.client.SignTimeout(ctx, &safetyrulespb.TimeoutRequest{
+		Timeout:     hotstuffpb.TimeoutMsgToProto(timeout),
+		UseQCViewTC: r.opts.ShouldUseQCViewTC(),
+		UseAggQC:    r.opts.ShouldUseAggQC(),
+	}, grpc.WaitForReady(true))
+	if err != nil {
+		return hotstuff.TimeoutMsg{}, err
+	}
+	r.updateLastVote(timeout.View)
+	timeout = hotstuffpb.TimeoutMsgFromProto(signed)
+	timeout.ID = r.opts.ID()
+	return timeout, nil
+}
+
+// StopVoting ensures that no votes are signed for `view` or earlier views.
+func (r *remote) StopVoting(view hotstuff.View) error {
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+
+	lastVote, err := r.client.StopVoting(ctx, &safetyrulespb.View{View: uint64(view)}, grpc.WaitForReady(true))
+	if err != nil {
+		return err
+	}
+	r.updateLastVote(hotstuff.View(lastVote.GetView()))
+	return nil
+}
+
+// LastVote returns the latest view in which the server is known to have signed a vote or timeout.
+func (r *remote) LastVote() hotstuff.View {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	return r.lastVote
+}
+
+func (r *remote) updateLastVote(view hotstuff.View) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	if r.lastVote < view {
+		r.lastVote = view
+	}
+}
+
+// Close closes the connection to the server.
+func (r *remote) Close() error {
+	return r.conn.Close()
+}
+
+var _ io.Closer = (*remote)(nil)
@@ -0,0 +1,192 @@
+// Package safetyrules provides implementations of the modules.SafetyRules interface.
+//
+// The SafetyRules module is the only module that signs votes and timeouts on behalf of the replica.
+// It ensures that a replica never signs two votes in the same view, and that it does not vote in a view
+// after it has timed out in that view. It also keeps a lock on the highest QC that is certified by a block
+// that it has voted for, and refuses to vote for blocks whose QC is older than the lock. This follows the design of LibraBFT, where these rules are kept
+// apart from the rest of the consensus logic so that they can be audited separately,
+// and so that the signing key can be isolated from the networked parts of the replica.
+//
+// The rules can run in-process, as returned by New, or in a separate process that is accessed through
+// a local gRPC connection over a Unix socket. In the latter case, the process holding the key
+// runs a Server, and the replica uses the module returned by NewRemote.
+package safetyrules
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/modules"
+)
+
+type safetyRules struct {
+	crypto modules.CryptoBase
+	opts   *modules.Options
+
+	mut        sync.Mutex
+	lastVote   hotstuff.View
+	lockedView hotstuff.View // the view of the highest QC that is certified by a block that was voted for
+
+	// store is called with the new state before a signature is returned, if it is set.
+	store func(lastVote, lockedView hotstuff.View) error
+}
+
+// New returns a SafetyRules module that signs messages using the replica's own crypto module.
+// If a SafetyStateStore is present, the last vote and the lock are loaded from it,
+// and they are stored in it before any signature is returned.
+func New() modules.SafetyRules {
+	return &safetyRules{}
+}
+
+// InitModule initializes the module.
+func (sr *safetyRules) InitModule(mods *modules.Core) {
+	mods.Get(
+		&sr.crypto,
+		&sr.opts,
+	)
+
+	var safetyState modules.SafetyStateStore
+	if !mods.TryGet(&safetyState) {
+		return
+	}
+	if state, ok := safetyState.LoadConsensusState(); ok {
+		sr.lastVote = state.LastVote
+		sr.lockedView = state.LockedView
+	}
+	sr.store = func(lastVote, lockedView hotstuff.View) error {
+		// the rest of the consensus state is stored by the consensus module.
+		state, ok := safetyState.LoadConsensusState()
+		if !ok {
+			state.BExec = hotstuff.GetGenesis()
+		}
+		state.LastVote = lastVote
+		state.LockedView = lockedView
+		return safetyState.StoreConsensusState(state)
+	}
+}
+
+// SignVote returns a partial certificate for the block, unless a vote or timeout has already been signed
+// in the block's view or a later view, or the block's QC is older than the locked QC.
+// The qcBlock must be the block that is certified by the block's QC.
+func (sr *safetyRules) SignVote(block, qcBlock *hotstuff.Block) (hotstuff.PartialCert, error) {
+	sr.mut.Lock()
+	defer sr.mut.Unlock()
+
+	if block.View() <= sr.lastVote {
+		return hotstuff.PartialCert{}, fmt.Errorf("safetyrules: already voted in view %d", sr.lastVote)
+	}
+	if qcBlock == nil || qcBlock.Hash() != block.QuorumCert().BlockHash() {
+		return hotstuff.PartialCert{}, fmt.Errorf("safetyrules: missing the block certified by the QC of %v", block)
+	}
+	if block.QuorumCert().View() < sr.lockedView {
+		return hotstuff.PartialCert{}, fmt.Errorf("safetyrules: QC of view %d is older than the locked QC of view %d",
+			block.QuorumCert().View(), sr.lockedView)
+	}
+	sig, err := sr.signVote(block)
+	if err != nil {
+		return hotstuff.PartialCert{}, err
+	}
+	lockedView := sr.lockedView
+	if lockedView < qcBlock.QuorumCert().View() {
+		lockedView = qcBlock.QuorumCert().View()
+	}
+	if err := sr.storeState(block.View(), lockedView); err != nil {
+		return hotstuff.PartialCert{}, err
+	}
+	return hotstuff.NewPartialCert(sig, block.Hash()), nil
+}
+
+// storeState stores the new state, if needed, and then updates it.
+// The caller must hold the lock.
+func (sr *safetyRules) storeState(lastVote, lockedView hotstuff.View) error {
+	if sr.store != nil && (lastVote != sr.lastVote || lockedView != sr.lockedView) {
+		if err := sr.store(lastVote, lockedView); err != nil {
+			return fmt.Errorf("safetyrules: failed to store state: %w", err)
+		}
+	}
+	sr.lastVote = lastVote
+	sr.lockedView = lockedView
+	return nil
+}
+
+// SignTimeout returns the timeout message with its signatures filled in.
+// No votes will be signed for the timeout's view or earlier views afterwards.
+func (sr *safetyRules) SignTimeout(timeout hotstuff.TimeoutMsg) (hotstuff.TimeoutMsg, error) {
+	return sr.signTimeout(timeout, sr.opts.ShouldUseQCViewTC(), sr.opts.ShouldUseAggQC())
+}
+
+func (sr *safetyRules) signTimeout(timeout hotstuff.TimeoutMsg, useQCViewTC, useAggQC bool) (hotstuff.TimeoutMsg, error) {
+	sr.mut.Lock()
+	defer sr.mut.Unlock()
+
+	timeout.ID = sr.opts.ID()
+
+	viewMsg := timeout.View.ToBytes()
+	if useQCViewTC {
+		viewMsg = hotstuff.TimeoutQCViewBytes(timeout.ID, timeout.View, timeout.HighQCView())
+	}
+	sig, err := sr.signTimeoutMsg(timeout, viewMsg)
+	if err != nil {
+		return hotstuff.TimeoutMsg{}, fmt.Errorf("safetyrules: failed to sign view: %w", err)
+	}
+	timeout.ViewSignature = sig
+
+	if useAggQC {
+		// generate a second signature that will become part of the aggregateQC
+		sig, err := sr.signTimeoutMsg(timeout, timeout.ToBytes())
+		if err != nil {
+			return hotstuff.TimeoutMsg{}, fmt.Errorf("safetyrules: failed to sign timeout message: %w", err)
+		}
+		timeout.MsgSignature = sig
+	}
+
+	if sr.lastVote < timeout.View {
+		if err := sr.storeState(timeout.View, sr.lockedView); err != nil {
+			return hotstuff.TimeoutMsg{}, err
+		}
+	}
+	return timeout, nil
+}
+
+// signVote signs the block as a vote, through the crypto module's SafetySigner methods if it has them.
+func (sr *safetyRules) signVote(block *hotstuff.Block) (hotstuff.QuorumSignature, error) {
+	if signer, ok := sr.crypto.(modules.SafetySigner); ok {
+		return signer.SignVote(block)
+	}
+	return sr.crypto.Sign(block.ToBytes())
+}
+
+// signTimeoutMsg signs a message that belongs to the timeout,
+// through the crypto module's SafetySigner methods if it has them.
+func (sr *safetyRules) signTimeoutMsg(timeout hotstuff.TimeoutMsg, message []byte) (hotstuff.QuorumSignature, error) {
+	if signer, ok := sr.crypto.(modules.SafetySigner); ok {
+		return signer.SignTimeout(timeout, message)
+	}
+	return sr.crypto.Sign(message)
+}
+
+// StopVoting ensures that no votes are signed for `view` or earlier views.
+func (sr *safetyRules) StopVoting(view hotstuff.View) error {
+	_, err := sr.stopVoting(view)
+	return err
+}
+
+func (sr *safetyRules) stopVoting(view hotstuff.View) (hotstuff.View, error) {
+	sr.mut.Lock()
+	defer sr.mut.Unlock()
+
+	if sr.lastVote < view {
+		if err := sr.storeState(view, sr.lockedView); err != nil {
+			return sr.lastVote, err
+		}
+	}
+	return sr.lastVote, nil
+}
+
+// LastVote returns the latest view in which a vote or timeout was signed.
+func (sr *safetyRules) LastVote() hotstuff.View {
+	sr.mut.Lock()
+	defer sr.mut.Unlock()
+	return sr.lastVote
+}
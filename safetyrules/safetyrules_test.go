@@ -0,0 +1,177 @@
+package safetyrules_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/crypto/ecdsa"
+	"github.com/relab/hotstuff/internal/localrpc"
+	"github.com/relab/hotstuff/internal/testutil"
+	"github.com/relab/hotstuff/modules"
+	"github.com/relab/hotstuff/safetyrules"
+	"github.com/relab/hotstuff/safetystate"
+)
+
+func newBlock(view hotstuff.View) *hotstuff.Block {
+	return extend(hotstuff.GetGenesis(), view)
+}
+
+// extend returns a block in the given view that extends the parent, and has a QC for the parent.
+func extend(parent *hotstuff.Block, view hotstuff.View) *hotstuff.Block {
+	return hotstuff.NewBlock(parent.Hash(), hotstuff.NewQuorumCert(nil, parent.View(), parent.Hash()), "foo", view, 1)
+}
+
+// testRules checks that votes are only signed when they are safe, and that signed votes can be verified.
+func testRules(t *testing.T, rules modules.SafetyRules, verifier modules.Crypto) {
+	t.Helper()
+
+	genesis := hotstuff.GetGenesis()
+	block := newBlock(1)
+	pc, err := rules.SignVote(block, genesis)
+	if err != nil {
+		t.Fatalf("failed to sign vote: %v", err)
+	}
+	if pc.BlockHash() != block.Hash() || !verifier.Verify(pc.Signature(), block.ToBytes()) {
+		t.Error("failed to verify vote")
+	}
+	if _, err := rules.SignVote(newBlock(1), genesis); err == nil {
+		t.Error("expected a second vote in the same view to be refused")
+	}
+
+	if err := rules.StopVoting(3); err != nil {
+		t.Fatalf("failed to stop voting: %v", err)
+	}
+	if _, err := rules.SignVote(newBlock(3), genesis); err == nil {
+		t.Error("expected a vote in a stopped view to be refused")
+	}
+
+	timeout, err := rules.SignTimeout(hotstuff.TimeoutMsg{View: 4, SyncInfo: hotstuff.NewSyncInfo().WithQC(block.QuorumCert())})
+	if err != nil {
+		t.Fatalf("failed to sign timeout: %v", err)
+	}
+	if timeout.ID != 1 || !verifier.Verify(timeout.ViewSignature, timeout.View.ToBytes()) {
+		t.Error("failed to verify timeout signature")
+	}
+	if _, err := rules.SignVote(newBlock(4), genesis); err == nil {
+		t.Error("expected a vote after a timeout in the same view to be refused")
+	}
+
+	b5 := newBlock(5)
+	if _, err := rules.SignVote(b5, genesis); err != nil {
+		t.Errorf("failed to sign vote in a later view: %v", err)
+	}
+	if rules.LastVote() != 5 {
+		t.Errorf("got last vote %d, want 5", rules.LastVote())
+	}
+
+	if _, err := rules.SignVote(newBlock(6), nil); err == nil {
+		t.Error("expected a vote without the QC block to be refused")
+	}
+	if _, err := rules.SignVote(newBlock(6), b5); err == nil {
+		t.Error("expected a vote with the wrong QC block to be refused")
+	}
+
+	// voting for b7 locks on the QC of b6, which certifies b5.
+	b6 := extend(b5, 6)
+	if _, err := rules.SignVote(b6, b5); err != nil {
+		t.Fatalf("failed to sign vote: %v", err)
+	}
+	b7 := extend(b6, 7)
+	if _, err := rules.SignVote(b7, b6); err != nil {
+		t.Fatalf("failed to sign vote: %v", err)
+	}
+	if _, err := rules.SignVote(newBlock(8), genesis); err == nil {
+		t.Error("expected a vote for a block with a QC older than the lock to be refused")
+	}
+	if _, err := rules.SignVote(extend(b5, 9), b5); err != nil {
+		t.Errorf("failed to sign vote for a block with a QC for the locked block: %v", err)
+	}
+}
+
+func TestSafetyRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, 1)
+	hl := builders.Build()
+
+	var rules modules.SafetyRules
+	hl[0].Get(&rules)
+	testRules(t, rules, hl.Verifiers()[0])
+}
+
+func TestSafetyRulesRestart(t *testing.T) {
+	key := testutil.GenerateECDSAKey(t)
+	dir := t.TempDir()
+	ctrl := gomock.NewController(t)
+
+	// start creates the safety rules of a replica whose state is stored in dir.
+	start := func() (modules.SafetyRules, modules.Crypto) {
+		store, err := safetystate.New(dir, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		builders := testutil.CreateBuilders(t, ctrl, 1, key)
+		builders[0].Add(store, safetyrules.New())
+		hl := builders.Build()
+		var rules modules.SafetyRules
+		hl[0].Get(&rules)
+		return rules, hl.Verifiers()[0]
+	}
+
+	rules, verifier := start()
+	testRules(t, rules, verifier)
+
+	// the restarted replica must remember the last vote and the lock.
+	rules, _ = start()
+	if _, err := rules.SignVote(newBlock(9), hotstuff.GetGenesis()); err == nil {
+		t.Error("expected a vote in the last view to be refused after a restart")
+	}
+	if _, err := rules.SignVote(newBlock(10), hotstuff.GetGenesis()); err == nil {
+		t.Error("expected a vote for a block with a QC older than the lock to be refused after a restart")
+	}
+}
+
+func TestRemoteSafetyRules(t *testing.T) {
+	key := testutil.GenerateECDSAKey(t)
+	dir := t.TempDir()
+	stateFile := filepath.Join(dir, "state")
+
+	startServer := func() *safetyrules.Server {
+		lis, err := localrpc.Listen("unix://" + filepath.Join(dir, "safetyrules.sock"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		srv, err := safetyrules.NewServer(1, key, ecdsa.New(), stateFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		go func() { _ = srv.Serve(lis) }()
+		return srv
+	}
+
+	srv := startServer()
+	rules, err := safetyrules.NewRemote("unix://" + filepath.Join(dir, "safetyrules.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, 1, key)
+	builders[0].Add(rules)
+	hl := builders.Build()
+
+	testRules(t, rules, hl.Verifiers()[0])
+
+	// the restarted server must remember the last vote and the lock.
+	srv.Stop()
+	srv = startServer()
+	defer srv.Stop()
+
+	if _, err := rules.SignVote(newBlock(9), hotstuff.GetGenesis()); err == nil {
+		t.Error("expected a vote in the last view to be refused after a restart")
+	}
+	if _, err := rules.SignVote(newBlock(10), hotstuff.GetGenesis()); err == nil {
+		t.Error("expected a vote for a block with a QC older than the lock to be refused after a restart")
+	}
+}
@@ -0,0 +1,111 @@
+package safetyrules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/crypto"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	"github.com/relab/hotstuff/internal/proto/safetyrulespb"
+	"github.com/relab/hotstuff/modules"
+	"github.com/relab/hotstuff/safetystate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Server serves the safety rules of a single replica to that replica.
+// The server holds the replica's private key, and is meant to run in a separate process from the replica.
+type Server struct {
+	rules  *safetyRules
+	server *grpc.Server
+}
+
+// NewServer returns a server that signs votes and timeouts for the replica with the given ID,
+// using the given private key and crypto implementation.
+// The last vote and the locked QC are stored in the state file before any signature is returned,
+// and are loaded from it if it exists, such that a restarted server does not sign conflicting messages.
+func NewServer(id hotstuff.ID, privateKey hotstuff.PrivateKey, cryptoImpl modules.CryptoBase, stateFile string) (*Server, error) {
+	rules := &safetyRules{
+		crypto: cryptoImpl,
+		opts:   crypto.NewStandalone(id, privateKey, cryptoImpl),
+		store: func(lastVote, lockedView hotstuff.View) error {
+			b, err := proto.Marshal(&safetyrulespb.State{LastVote: uint64(lastVote), LockedView: uint64(lockedView)})
+			if err != nil {
+				return err
+			}
+			return safetystate.WriteFile(stateFile, b)
+		},
+	}
+
+	b, err := os.ReadFile(stateFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("safetyrules: failed to read state file: %w", err)
+	}
+	if err == nil {
+		var state safetyrulespb.State
+		if err := proto.Unmarshal(b, &state); err != nil {
+			return nil, fmt.Errorf("safetyrules: failed to unmarshal state file: %w", err)
+		}
+		rules.lastVote = hotstuff.View(state.GetLastVote())
+		rules.lockedView = hotstuff.View(state.GetLockedView())
+	}
+
+	srv := &Server{
+		rules:  rules,
+		server: grpc.NewServer(),
+	}
+	safetyrulespb.RegisterSafetyRulesServer(srv.server, serviceImpl{rules: rules})
+	return srv, nil
+}
+
+// Serve accepts connections on the listener and serves requests until Stop is called.
+func (srv *Server) Serve(lis net.Listener) error {
+	return srv.server.Serve(lis)
+}
+
+// Stop stops the server.
+func (srv *Server) Stop() {
+	srv.server.Stop()
+}
+
+type serviceImpl struct {
+	safetyrulespb.UnimplementedSafetyRulesServer
+
+	rules *safetyRules
+}
+
+func (impl serviceImpl) SignVote(_ context.Context, req *safetyrulespb.VoteRequest) (*hotstuffpb.PartialCert, error) {
+	var qcBlock *hotstuff.Block
+	if req.GetQCBlock() != nil {
+		qcBlock = hotstuffpb.BlockFromProto(req.GetQCBlock())
+	}
+	pc, err := impl.rules.SignVote(hotstuffpb.BlockFromProto(req.GetBlock()), qcBlock)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return hotstuffpb.PartialCertToProto(pc), nil
+}
+
+func (impl serviceImpl) SignTimeout(_ context.Context, req *safetyrulespb.TimeoutRequest) (*hotstuffpb.TimeoutMsg, error) {
+	timeout := hotstuffpb.TimeoutMsgFromProto(req.GetTimeout())
+	timeout, err := impl.rules.signTimeout(timeout, req.GetUseQCViewTC(), req.GetUseAggQC())
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return hotstuffpb.TimeoutMsgToProto(timeout), nil
+}
+
+func (impl serviceImpl) StopVoting(_ context.Context, view *safetyrulespb.View) (*safetyrulespb.View, error) {
+	lastVote, err := impl.rules.stopVoting(hotstuff.View(view.GetView()))
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return &safetyrulespb.View{View: uint64(lastVote)}, nil
+}
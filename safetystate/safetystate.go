@@ -0,0 +1,213 @@
+// Package safetystate provides an implementation of the modules.SafetyStateStore interface
+// that stores the safety state in files on disk.
+package safetystate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	"github.com/relab/hotstuff/modules"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	consensusFile    = "consensus.state"
+	synchronizerFile = "synchronizer.state"
+)
+
+// fileStore stores each part of the safety state in its own file.
+// A file is replaced atomically by writing the new state to a temporary file,
+// syncing it, and then renaming it over the old file.
+type fileStore struct {
+	mut sync.Mutex
+	dir string
+	id  hotstuff.ID
+
+	consensus    *hotstuffpb.ConsensusState
+	synchronizer *hotstuffpb.SynchronizerState
+}
+
+// New returns a safety state store that stores its state in the given directory.
+// Any state that was previously stored in the directory is loaded.
+// The id is the ID of the replica that owns the state.
+func New(dir string, id hotstuff.ID) (modules.SafetyStateStore, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("safetystate: failed to create directory: %w", err)
+	}
+
+	s := &fileStore{dir: dir, id: id}
+
+	var consensusState hotstuffpb.ConsensusState
+	ok, err := s.read(consensusFile, &consensusState)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		s.consensus = &consensusState
+	}
+
+	var synchronizerState hotstuffpb.SynchronizerState
+	ok, err = s.read(synchronizerFile, &synchronizerState)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		s.synchronizer = &synchronizerState
+	}
+
+	return s, nil
+}
+
+// LoadConsensusState returns the stored consensus state, if any.
+func (s *fileStore) LoadConsensusState() (state modules.ConsensusState, ok bool) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if s.consensus == nil {
+		return state, false
+	}
+	return modules.ConsensusState{
+		LastVote:   hotstuff.View(s.consensus.GetLastVote()),
+		BExec:      hotstuffpb.BlockFromProto(s.consensus.GetBExec()),
+		LockedView: hotstuff.View(s.consensus.GetLockedView()),
+	}, true
+}
+
+// StoreConsensusState stores the consensus state.
+func (s *fileStore) StoreConsensusState(state modules.ConsensusState) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	pb := &hotstuffpb.ConsensusState{
+		LastVote:   uint64(state.LastVote),
+		BExec:      hotstuffpb.BlockToProto(state.BExec),
+		LockedView: uint64(state.LockedView),
+	}
+	err := s.write(consensusFile, pb)
+	if err != nil {
+		return err
+	}
+	s.consensus = pb
+	return nil
+}
+
+// LoadSynchronizerState returns the stored synchronizer state, if any.
+func (s *fileStore) LoadSynchronizerState() (state modules.SynchronizerState, ok bool) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if s.synchronizer == nil {
+		return state, false
+	}
+	state = modules.SynchronizerState{
+		CurrentView: hotstuff.View(s.synchronizer.GetCurrentView()),
+		HighQC:      hotstuffpb.QuorumCertFromProto(s.synchronizer.GetHighQC()),
+		HighTC:      hotstuffpb.TimeoutCertFromProto(s.synchronizer.GetHighTC()),
+	}
+	if s.synchronizer.LastTimeout != nil {
+		timeoutMsg := hotstuffpb.TimeoutMsgFromProto(s.synchronizer.GetLastTimeout())
+		timeoutMsg.ID = s.id
+		state.LastTimeout = &timeoutMsg
+	}
+	return state, true
+}
+
+// StoreSynchronizerState stores the synchronizer state.
+func (s *fileStore) StoreSynchronizerState(state modules.SynchronizerState) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	pb := &hotstuffpb.SynchronizerState{
+		CurrentView: uint64(state.CurrentView),
+		HighQC:      hotstuffpb.QuorumCertToProto(state.HighQC),
+		HighTC:      hotstuffpb.TimeoutCertToProto(state.HighTC),
+	}
+	if state.LastTimeout != nil {
+		pb.LastTimeout = hotstuffpb.TimeoutMsgToProto(*state.LastTimeout)
+	}
+	err := s.write(synchronizerFile, pb)
+	if err != nil {
+		return err
+	}
+	s.synchronizer = pb
+	return nil
+}
+
+// read reads the message stored in the named file.
+// It returns false if the file does not exist.
+func (s *fileStore) read(name string, msg proto.Message) (ok bool, err error) {
+	b, err := os.ReadFile(filepath.Join(s.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("safetystate: failed to read %s: %w", name, err)
+	}
+	err = proto.Unmarshal(b, msg)
+	if err != nil {
+		return false, fmt.Errorf("safetystate: failed to unmarshal %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// write atomically replaces the named file with the message, and syncs it to stable storage.
+func (s *fileStore) write(name string, msg proto.Message) error {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("safetystate: failed to marshal %s: %w", name, err)
+	}
+	return WriteFile(filepath.Join(s.dir, name), b)
+}
+
+// WriteFile atomically replaces the named file with the data, and syncs it to stable storage.
+// The data is written to a temporary file in the same directory, which is synced and renamed over the named file,
+// and the directory is synced to ensure that the rename is durable.
+func WriteFile(name string, data []byte) error {
+	dirName, base := filepath.Split(name)
+	if dirName == "" {
+		dirName = "."
+	}
+
+	tmp, err := os.CreateTemp(dirName, base+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("safetystate: failed to create temporary file: %w", err)
+	}
+	// this fails after the rename, but ensures that the temporary file is removed if something else fails.
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("safetystate: failed to write %s: %w", base, err)
+	}
+
+	err = os.Rename(tmp.Name(), name)
+	if err != nil {
+		return fmt.Errorf("safetystate: failed to replace %s: %w", base, err)
+	}
+
+	// sync the directory to ensure that the rename is durable.
+	dir, err := os.Open(dirName)
+	if err != nil {
+		return fmt.Errorf("safetystate: failed to open directory: %w", err)
+	}
+	defer dir.Close()
+	err = dir.Sync()
+	if err != nil {
+		return fmt.Errorf("safetystate: failed to sync directory: %w", err)
+	}
+	return nil
+}
+
+var _ modules.SafetyStateStore = (*fileStore)(nil)
@@ -0,0 +1,82 @@
+package safetystate_test
+
+import (
+	"testing"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/modules"
+	"github.com/relab/hotstuff/safetystate"
+)
+
+func TestEmptyStore(t *testing.T) {
+	store, err := safetystate.New(t.TempDir(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.LoadConsensusState(); ok {
+		t.Error("empty store returned consensus state")
+	}
+	if _, ok := store.LoadSynchronizerState(); ok {
+		t.Error("empty store returned synchronizer state")
+	}
+}
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+	genesis := hotstuff.GetGenesis()
+	block := hotstuff.NewBlock(genesis.Hash(), hotstuff.NewQuorumCert(nil, 0, genesis.Hash()), "foo", 1, 1)
+
+	store, err := safetystate.New(dir, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.StoreConsensusState(modules.ConsensusState{LastVote: 3, BExec: block, LockedView: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.StoreSynchronizerState(modules.SynchronizerState{
+		CurrentView: 4,
+		HighQC:      hotstuff.NewQuorumCert(nil, 1, block.Hash()),
+		HighTC:      hotstuff.NewTimeoutCert(nil, 3),
+		LastTimeout: &hotstuff.TimeoutMsg{ID: 1, View: 4, SyncInfo: hotstuff.NewSyncInfo()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = safetystate.New(dir, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cs, ok := store.LoadConsensusState()
+	if !ok {
+		t.Fatal("consensus state was not restored")
+	}
+	if cs.LastVote != 3 {
+		t.Errorf("got lastVote %d, want 3", cs.LastVote)
+	}
+	if cs.BExec.Hash() != block.Hash() {
+		t.Error("restored bExec has wrong hash")
+	}
+	if cs.LockedView != 2 {
+		t.Errorf("got lockedView %d, want 2", cs.LockedView)
+	}
+
+	ss, ok := store.LoadSynchronizerState()
+	if !ok {
+		t.Fatal("synchronizer state was not restored")
+	}
+	if ss.CurrentView != 4 {
+		t.Errorf("got view %d, want 4", ss.CurrentView)
+	}
+	if ss.HighQC.View() != 1 || ss.HighQC.BlockHash() != block.Hash() {
+		t.Errorf("restored highQC is wrong: %v", ss.HighQC)
+	}
+	if ss.HighTC.View() != 3 {
+		t.Errorf("got highTC view %d, want 3", ss.HighTC.View())
+	}
+	if ss.LastTimeout == nil || ss.LastTimeout.View != 4 || ss.LastTimeout.ID != 1 {
+		t.Errorf("restored lastTimeout is wrong: %v", ss.LastTimeout)
+	}
+}
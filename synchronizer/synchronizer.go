@@ -22,6 +22,10 @@ type Synchronizer struct {
 	leaderRotation modules.LeaderRotation
 	logger         logging.Logger
 	opts           *modules.Options
+	safetyRules    modules.SafetyRules
+	safetyState    modules.SafetyStateStore
+	verifier       modules.Verifier
+	leaderElection modules.LeaderElection // set if the leader rotation does not reveal the leaders in advance
 
 	currentView hotstuff.View
 	highTC      hotstuff.TimeoutCert
@@ -41,8 +45,17 @@ type Synchronizer struct {
 
 	// map of collected timeout messages per view
 	timeouts map[hotstuff.View]map[hotstuff.ID]hotstuff.TimeoutMsg
+
+	catchingUp bool // true while catchUp is fetching blocks
 }
 
+// maxCatchUpBlocks is the maximum number of blocks that are fetched by catchUp.
+// A faulty replica can otherwise keep the synchronizer fetching fabricated ancestors of a block.
+const maxCatchUpBlocks = 1000
+
+// catchUpTimeout is how long catchUp tries to fetch the missing blocks.
+const catchUpTimeout = 10 * time.Second
+
 // InitModule initializes the synchronizer.
 func (s *Synchronizer) InitModule(mods *modules.Core) {
 	mods.Get(
@@ -54,8 +67,15 @@ func (s *Synchronizer) InitModule(mods *modules.Core) {
 		&s.leaderRotation,
 		&s.logger,
 		&s.opts,
+		&s.safetyRules,
 	)
 
+	if s.opts.VerificationWorkers() > 0 {
+		mods.Get(&s.verifier)
+	}
+
+	s.leaderElection, _ = s.leaderRotation.(modules.LeaderElection)
+
 	s.eventLoop.RegisterHandler(TimeoutEvent{}, func(event any) {
 		timeoutView := event.(TimeoutEvent).View
 		if s.currentView == timeoutView {
@@ -73,6 +93,10 @@ func (s *Synchronizer) InitModule(mods *modules.Core) {
 		s.OnRemoteTimeout(timeoutMsg)
 	})
 
+	s.eventLoop.RegisterHandler(catchUpEvent{}, func(event any) {
+		s.onCatchUp(event.(catchUpEvent))
+	})
+
 	var err error
 	s.highQC, err = s.crypto.CreateQuorumCert(hotstuff.GetGenesis(), []hotstuff.PartialCert{})
 	if err != nil {
@@ -82,6 +106,12 @@ func (s *Synchronizer) InitModule(mods *modules.Core) {
 	if err != nil {
 		panic(fmt.Errorf("unable to create empty timeout cert for view 0: %v", err))
 	}
+
+	if mods.TryGet(&s.safetyState) {
+		if state, ok := s.safetyState.LoadSynchronizerState(); ok {
+			s.restoreState(state)
+		}
+	}
 }
 
 // New creates a new Synchronizer.
@@ -115,7 +145,7 @@ func (s *Synchronizer) Start(ctx context.Context) {
 	}()
 
 	// start the initial proposal
-	if s.currentView == 1 && s.leaderRotation.GetLeader(s.currentView) == s.opts.ID() {
+	if s.currentView == 1 && s.isLeader(s.currentView) {
 		s.consensus.Propose(s.SyncInfo())
 	}
 }
@@ -166,31 +196,25 @@ func (s *Synchronizer) OnLocalTimeout() {
 	view := s.currentView
 	s.logger.Debugf("OnLocalTimeout: %v", view)
 
-	sig, err := s.crypto.Sign(view.ToBytes())
+	timeoutMsg, err := s.safetyRules.SignTimeout(hotstuff.TimeoutMsg{
+		ID:       s.opts.ID(),
+		View:     view,
+		SyncInfo: s.SyncInfo(),
+	})
 	if err != nil {
-		s.logger.Warnf("Failed to sign view: %v", err)
+		s.logger.Warnf("Failed to sign timeout message: %v", err)
 		return
 	}
-	timeoutMsg := hotstuff.TimeoutMsg{
-		ID:            s.opts.ID(),
-		View:          view,
-		SyncInfo:      s.SyncInfo(),
-		ViewSignature: sig,
-	}
-
-	if s.opts.ShouldUseAggQC() {
-		// generate a second signature that will become part of the aggregateQC
-		sig, err := s.crypto.Sign(timeoutMsg.ToBytes())
-		if err != nil {
-			s.logger.Warnf("Failed to sign timeout message: %v", err)
-			return
-		}
-		timeoutMsg.MsgSignature = sig
-	}
 	s.lastTimeout = &timeoutMsg
 	// stop voting for current view
 	s.consensus.StopVoting(s.currentView)
 
+	if err := s.storeState(); err != nil {
+		s.logger.Warnf("Failed to store safety state: %v", err)
+		s.lastTimeout = nil
+		return
+	}
+
 	s.configuration.Timeout(timeoutMsg)
 	s.OnRemoteTimeout(timeoutMsg)
 }
@@ -206,14 +230,26 @@ func (s *Synchronizer) OnRemoteTimeout(timeout hotstuff.TimeoutMsg) {
 		}
 	}()
 
-	verifier := s.crypto
-	if !verifier.Verify(timeout.ViewSignature, timeout.View.ToBytes()) {
-		return
+	if !timeout.Verified {
+		if s.verifier != nil {
+			// the timeout is handled again once the verifier has verified it.
+			timeout.Verified = true
+			s.verifier.Verify(timeout, func() bool { return s.verifyTimeout(timeout) })
+			return
+		}
+		if !s.verifyTimeout(timeout) {
+			return
+		}
 	}
 	s.logger.Debug("OnRemoteTimeout: ", timeout)
 
 	s.AdvanceView(timeout.SyncInfo)
 
+	if !modules.IsMember(s.configuration, timeout.ID, timeout.View) {
+		// the timeout cannot be part of a timeout certificate for this view.
+		return
+	}
+
 	timeouts, ok := s.timeouts[timeout.View]
 	if !ok {
 		timeouts = make(map[hotstuff.ID]hotstuff.TimeoutMsg)
@@ -224,7 +260,7 @@ func (s *Synchronizer) OnRemoteTimeout(timeout hotstuff.TimeoutMsg) {
 		timeouts[timeout.ID] = timeout
 	}
 
-	if len(timeouts) < s.configuration.QuorumSize() {
+	if len(timeouts) < modules.QuorumSizeAt(s.configuration, timeout.View) {
 		return
 	}
 
@@ -257,6 +293,28 @@ func (s *Synchronizer) OnRemoteTimeout(timeout hotstuff.TimeoutMsg) {
 	s.AdvanceView(si)
 }
 
+// verifyTimeout verifies the signature of the view in the timeout message.
+func (s *Synchronizer) verifyTimeout(timeout hotstuff.TimeoutMsg) bool {
+	if s.opts.ShouldUseQCViewTC() {
+		return s.verifyQCViewTimeout(timeout)
+	}
+	return s.crypto.VerifyInEpoch(modules.EpochAt(s.configuration, timeout.View), timeout.ViewSignature, timeout.View.ToBytes())
+}
+
+// verifyQCViewTimeout verifies a timeout message whose signature also covers the view of the sender's highQC.
+// The highQC itself must also be valid, as it is used to justify the proposal in the next view.
+func (s *Synchronizer) verifyQCViewTimeout(timeout hotstuff.TimeoutMsg) bool {
+	qc, ok := timeout.SyncInfo.QC()
+	if !ok || qc.View() >= timeout.View {
+		return false
+	}
+	msg := hotstuff.TimeoutQCViewBytes(timeout.ID, timeout.View, qc.View())
+	if !s.crypto.VerifyInEpoch(modules.EpochAt(s.configuration, timeout.View), timeout.ViewSignature, msg) {
+		return false
+	}
+	return s.crypto.VerifyQuorumCert(qc)
+}
+
 // OnNewView handles an incoming consensus.NewViewMsg
 func (s *Synchronizer) OnNewView(newView hotstuff.NewViewMsg) {
 	s.AdvanceView(newView.SyncInfo)
@@ -307,6 +365,7 @@ func (s *Synchronizer) AdvanceView(syncInfo hotstuff.SyncInfo) {
 	}
 
 	if haveQC {
+		s.catchUp(qc)
 		s.updateHighQC(qc)
 		// if there is both a TC and a QC, we use the QC if its view is greater or equal to the TC.
 		if qc.View() >= v {
@@ -334,14 +393,126 @@ func (s *Synchronizer) AdvanceView(syncInfo hotstuff.SyncInfo) {
 	s.newCtx(duration)
 	s.timer.Reset(duration)
 
+	// the new view must be stored before we send any messages in it.
+	if err := s.storeState(); err != nil {
+		s.logger.Errorf("Failed to store safety state: %v", err)
+		return
+	}
+
 	s.logger.Debugf("advanced to view %d", s.currentView)
 	s.eventLoop.AddEvent(ViewChangeEvent{View: s.currentView, Timeout: timeout})
 
-	leader := s.leaderRotation.GetLeader(s.currentView)
-	if leader == s.opts.ID() {
+	if s.opts.ShouldUseQCViewTC() {
+		// after a timeout, the proposal must extend a QC that is at least as high as the highQC views in the TC.
+		// Our highQC includes the QCs of all timeouts that we have received, so we use it instead.
+		syncInfo = syncInfo.WithQC(s.highQC)
+	}
+
+	if s.isLeader(s.currentView) {
 		s.consensus.Propose(syncInfo)
-	} else if replica, ok := s.configuration.Replica(leader); ok {
-		replica.NewView(syncInfo)
+		return
+	}
+	// if the leaders are not known in advance, the other replicas create the certificates of the view themselves,
+	// from the votes and timeouts that are sent to all replicas.
+	if s.leaderElection == nil {
+		if replica, ok := s.configuration.Replica(s.leaderRotation.GetLeader(s.currentView)); ok {
+			replica.NewView(syncInfo)
+		}
+	}
+}
+
+// isLeader returns true if this replica is a leader of the view.
+func (s *Synchronizer) isLeader(view hotstuff.View) bool {
+	if s.leaderElection != nil {
+		_, ok := s.leaderElection.ProveLeader(view)
+		return ok
+	}
+	return s.leaderRotation.GetLeader(view) == s.opts.ID()
+}
+
+// catchUp starts to fetch the chain of blocks leading up to the block certified by qc, if the chain is missing
+// and the replica lags more than ChainLength() views behind its last committed block.
+// Fetching the missing blocks one at a time would require a separate request for each block,
+// whereas catchUp fetches them in batches with FetchRange. The blocks are fetched in the background,
+// and the chain is verified and stored by onCatchUp. The qc must already be verified.
+func (s *Synchronizer) catchUp(qc hotstuff.QuorumCert) {
+	if s.catchingUp {
+		return
+	}
+	// the block itself may already have been fetched when the qc was verified.
+	if block, ok := s.blockChain.LocalGet(qc.BlockHash()); ok {
+		if _, ok := s.blockChain.LocalGet(block.Parent()); ok {
+			return
+		}
+	}
+	ancestor := s.consensus.CommittedBlock()
+	if qc.View() <= ancestor.View()+hotstuff.View(s.consensus.ChainLength()) {
+		return
+	}
+	s.logger.Debugf("catchUp: fetching blocks from view %d to %d", ancestor.View(), qc.View())
+
+	s.catchingUp = true
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), catchUpTimeout)
+		defer cancel()
+		s.eventLoop.AddEvent(catchUpEvent{qc: qc, chain: s.fetchChain(ctx, ancestor.Hash(), qc)})
+	}()
+}
+
+// fetchChain fetches the blocks from newest to oldest, until it reaches a block that is already stored.
+// It returns nil if the chain could not be fetched, if it is longer than maxCatchUpBlocks,
+// or if the blocks do not form a chain. It does not access the state of the synchronizer.
+func (s *Synchronizer) fetchChain(ctx context.Context, ancestor hotstuff.Hash, qc hotstuff.QuorumCert) []*hotstuff.Block {
+	var chain []*hotstuff.Block
+	for target := qc.BlockHash(); ; {
+		blocks, ok := s.configuration.FetchRange(ctx, ancestor, target)
+		if !ok {
+			s.logger.Info("catchUp: failed to fetch blocks")
+			return nil
+		}
+		if blocks[len(blocks)-1].Hash() != target {
+			s.logger.Info("catchUp: received wrong blocks")
+			return nil
+		}
+		chain = append(blocks, chain...)
+		target = blocks[0].Parent()
+		if _, ok := s.blockChain.LocalGet(target); ok {
+			break
+		}
+		if len(chain) >= maxCatchUpBlocks {
+			// the replica must instead catch up from a checkpoint, if they are enabled.
+			s.logger.Infof("catchUp: more than %d blocks are missing", maxCatchUpBlocks)
+			return nil
+		}
+	}
+
+	for i, block := range chain {
+		if i > 0 && chain[i-1].Hash() != block.Parent() {
+			s.logger.Info("catchUp: received blocks do not form a chain")
+			return nil
+		}
+	}
+	return chain
+}
+
+// onCatchUp stores the chain of blocks that was fetched by catchUp.
+// The blocks are stored from oldest to newest, such that each QC can be checked against a known block
+// without fetching it.
+func (s *Synchronizer) onCatchUp(event catchUpEvent) {
+	s.catchingUp = false
+	for _, block := range event.chain {
+		if _, ok := s.blockChain.LocalGet(block.QuorumCert().BlockHash()); !ok {
+			s.logger.Info("catchUp: QC references an unknown block")
+			return
+		}
+		if !s.crypto.VerifyQuorumCert(block.QuorumCert()) {
+			s.logger.Info("catchUp: QC could not be verified")
+			return
+		}
+		s.blockChain.Store(block)
+	}
+	if len(event.chain) > 0 {
+		s.updateHighQC(event.qc)
 	}
 }
 
@@ -375,6 +546,33 @@ func (s *Synchronizer) updateHighTC(tc hotstuff.TimeoutCert) {
 	}
 }
 
+// storeState writes the state that must survive a restart to the safety state store, if there is one.
+func (s *Synchronizer) storeState() error {
+	if s.safetyState == nil {
+		return nil
+	}
+	return s.safetyState.StoreSynchronizerState(modules.SynchronizerState{
+		CurrentView: s.currentView,
+		HighQC:      s.highQC,
+		HighTC:      s.highTC,
+		LastTimeout: s.lastTimeout,
+	})
+}
+
+// restoreState restores the state that was loaded from the safety state store.
+func (s *Synchronizer) restoreState(state modules.SynchronizerState) {
+	s.currentView = state.CurrentView
+	s.highTC = state.HighTC
+	s.lastTimeout = state.LastTimeout
+	if block, ok := s.blockChain.LocalGet(state.HighQC.BlockHash()); ok {
+		s.highQC = state.HighQC
+		s.leafBlock = block
+	} else {
+		s.logger.Warn("Block referenced by the stored highQC is missing; falling back to genesis")
+	}
+	s.logger.Infof("Restored safety state in view %d", s.currentView)
+}
+
 func (s *Synchronizer) newCtx(duration time.Duration) {
 	s.cancelCtx()
 	s.viewCtx, s.cancelCtx = context.WithTimeout(context.Background(), duration)
@@ -392,3 +590,10 @@ type ViewChangeEvent struct {
 type TimeoutEvent struct {
 	View hotstuff.View
 }
+
+// catchUpEvent is added to the event loop when catchUp has fetched the blocks leading up to the block of qc.
+// The chain is nil if the blocks could not be fetched.
+type catchUpEvent struct {
+	qc    hotstuff.QuorumCert
+	chain []*hotstuff.Block
+}
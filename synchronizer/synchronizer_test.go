@@ -1,15 +1,19 @@
 package synchronizer_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/eventloop"
 
 	"github.com/golang/mock/gomock"
 	"github.com/relab/hotstuff/internal/mocks"
 	"github.com/relab/hotstuff/internal/testutil"
 	"github.com/relab/hotstuff/modules"
 	. "github.com/relab/hotstuff/synchronizer"
+	"github.com/relab/hotstuff/twins"
 )
 
 func TestAdvanceViewQC(t *testing.T) {
@@ -68,3 +72,90 @@ func TestAdvanceViewTC(t *testing.T) {
 		t.Errorf("wrong view: expected: %v, got: %v", 2, s.View())
 	}
 }
+
+// fetchConfig is a configuration that fetches blocks from the block chain of another replica.
+type fetchConfig struct {
+	modules.Configuration
+	blocks modules.BlockChain
+}
+
+func (c *fetchConfig) Fetch(_ context.Context, hash hotstuff.Hash) (*hotstuff.Block, bool) {
+	return c.blocks.LocalGet(hash)
+}
+
+func (c *fetchConfig) FetchRange(_ context.Context, ancestor, target hotstuff.Hash) ([]*hotstuff.Block, bool) {
+	// return a few blocks at a time, such that the chain must be fetched in several batches.
+	blocks := modules.LocalRange(c.blocks, ancestor, target, 3)
+	return blocks, len(blocks) > 0
+}
+
+// TestCatchUp checks that a replica that lags behind fetches the missing chain of blocks when it receives a QC.
+func TestCatchUp(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	network := twins.NewSimpleNetwork()
+	builders := make(testutil.BuilderList, n)
+	cfg := &fetchConfig{Configuration: network.NewConfiguration()}
+	for i := range builders {
+		id := hotstuff.ID(i + 1)
+		key := testutil.GenerateECDSAKey(t)
+		builder := network.GetNodeBuilder(twins.NodeID{ReplicaID: id, NetworkID: uint32(id)}, key)
+		testutil.TestModules(t, ctrl, id, key, &builder)
+		if i == 0 {
+			builder.Add(cfg)
+		} else {
+			builder.Add(network.NewConfiguration())
+		}
+		builders[i] = &builder
+	}
+	s := New(testutil.FixedTimeout(1000))
+	hs := mocks.NewMockConsensus(ctrl)
+	builders[0].Add(s, hs)
+
+	hl := builders.Build()
+	signers := hl.Signers()
+
+	var (
+		eventLoop  *eventloop.EventLoop
+		blockChain modules.BlockChain
+	)
+	hl[0].Get(&eventLoop, &blockChain)
+	hl[1].Get(&cfg.blocks)
+
+	// only the second replica has the chain of blocks.
+	var chain []*hotstuff.Block
+	qc := hotstuff.NewQuorumCert(nil, 0, hotstuff.GetGenesis().Hash())
+	parent := hotstuff.GetGenesis().Hash()
+	for view := hotstuff.View(1); view <= 10; view++ {
+		block := hotstuff.NewBlock(parent, qc, "foo", view, 2)
+		cfg.blocks.Store(block)
+		chain = append(chain, block)
+		qc = testutil.CreateQC(t, block, signers)
+		parent = block.Hash()
+	}
+
+	hs.EXPECT().CommittedBlock().AnyTimes().Return(hotstuff.GetGenesis())
+	hs.EXPECT().ChainLength().AnyTimes().Return(3)
+	hs.EXPECT().Propose(gomock.Any()).AnyTimes()
+
+	s.AdvanceView(hotstuff.NewSyncInfo().WithQC(qc))
+
+	// the blocks are fetched in the background, and stored when the event loop handles the result.
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, ok := blockChain.LocalGet(chain[len(chain)-2].Hash()); ok {
+			break
+		}
+		if !eventLoop.Tick() {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	for _, block := range chain {
+		if _, ok := blockChain.LocalGet(block.Hash()); !ok {
+			t.Fatalf("block in view %d was not fetched", block.View())
+		}
+	}
+	if s.View() != 11 {
+		t.Errorf("wrong view: expected: %v, got: %v", 11, s.View())
+	}
+}
@@ -5,5 +5,6 @@ package hotstuff
 import (
 	_ "github.com/golang/mock/mockgen"
 	_ "github.com/relab/gorums/cmd/protoc-gen-gorums"
+	_ "google.golang.org/grpc/cmd/protoc-gen-go-grpc"
 	_ "google.golang.org/protobuf/cmd/protoc-gen-go"
 )
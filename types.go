@@ -7,6 +7,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -91,6 +92,16 @@ func (v View) ToBytes() []byte {
 	return viewBytes[:]
 }
 
+// Epoch is a number that identifies a range of consecutive views in which the set of replicas and their keys is fixed.
+type Epoch uint64
+
+// ToBytes returns the epoch as bytes.
+func (e Epoch) ToBytes() []byte {
+	var epochBytes [8]byte
+	binary.LittleEndian.PutUint64(epochBytes[:], uint64(e))
+	return epochBytes[:]
+}
+
 // Hash is a SHA256 hash
 type Hash [32]byte
 
@@ -249,17 +260,24 @@ type QuorumCert struct {
 	signature QuorumSignature
 	view      View
 	hash      Hash
+	epoch     Epoch
 }
 
 // NewQuorumCert creates a new quorum cert from the given values.
 func NewQuorumCert(signature QuorumSignature, view View, hash Hash) QuorumCert {
-	return QuorumCert{signature, view, hash}
+	return QuorumCert{signature: signature, view: view, hash: hash}
+}
+
+// NewQuorumCertWithEpoch creates a new quorum cert whose signature was created with the keys of the given epoch.
+func NewQuorumCertWithEpoch(signature QuorumSignature, view View, hash Hash, epoch Epoch) QuorumCert {
+	return QuorumCert{signature: signature, view: view, hash: hash, epoch: epoch}
 }
 
 // ToBytes returns a byte representation of the quorum certificate.
 func (qc QuorumCert) ToBytes() []byte {
 	b := qc.view.ToBytes()
 	b = append(b, qc.hash[:]...)
+	b = append(b, qc.epoch.ToBytes()...)
 	if qc.signature != nil {
 		b = append(b, qc.signature.ToBytes()...)
 	}
@@ -281,9 +299,14 @@ func (qc QuorumCert) View() View {
 	return qc.view
 }
 
+// Epoch returns the epoch whose keys were used to sign the QC.
+func (qc QuorumCert) Epoch() Epoch {
+	return qc.epoch
+}
+
 // Equals returns true if the other QC equals this QC.
 func (qc QuorumCert) Equals(other QuorumCert) bool {
-	if qc.view != other.view {
+	if qc.view != other.view || qc.epoch != other.epoch {
 		return false
 	}
 	if qc.hash != other.hash {
@@ -303,22 +326,162 @@ func (qc QuorumCert) String() string {
 	return fmt.Sprintf("QC{ hash: %.6s, IDs: [ %s] }", qc.hash, &sb)
 }
 
+// Checkpoint describes the application state that was produced by executing the committed blocks
+// up to and including a block.
+type Checkpoint struct {
+	height uint64
+	view   View
+	block  Hash
+	state  Hash
+}
+
+// NewCheckpoint creates a new checkpoint for the block with the given view and hash,
+// which is the committed block with the given height.
+func NewCheckpoint(height uint64, view View, block, state Hash) Checkpoint {
+	return Checkpoint{height: height, view: view, block: block, state: state}
+}
+
+// Height returns the number of committed blocks up to and including the block of the checkpoint.
+func (cp Checkpoint) Height() uint64 {
+	return cp.height
+}
+
+// View returns the view of the block of the checkpoint.
+func (cp Checkpoint) View() View {
+	return cp.view
+}
+
+// BlockHash returns the hash of the block of the checkpoint.
+func (cp Checkpoint) BlockHash() Hash {
+	return cp.block
+}
+
+// StateHash returns the digest of the application state after the block was executed.
+func (cp Checkpoint) StateHash() Hash {
+	return cp.state
+}
+
+// ToBytes returns a byte representation of the checkpoint.
+func (cp Checkpoint) ToBytes() []byte {
+	var heightBytes [8]byte
+	binary.LittleEndian.PutUint64(heightBytes[:], cp.height)
+	b := append(heightBytes[:], cp.view.ToBytes()...)
+	b = append(b, cp.block[:]...)
+	return append(b, cp.state[:]...)
+}
+
+func (cp Checkpoint) String() string {
+	return fmt.Sprintf("Checkpoint{ height: %d, view: %d, block: %.6s, state: %.6s }", cp.height, cp.view, cp.block, cp.state)
+}
+
+// CheckpointCert is a certificate for a checkpoint created by a quorum of replicas.
+type CheckpointCert struct {
+	checkpoint Checkpoint
+	signature  QuorumSignature
+	epoch      Epoch
+}
+
+// NewCheckpointCert creates a new checkpoint certificate whose signature was created with the keys of the given epoch.
+func NewCheckpointCert(checkpoint Checkpoint, signature QuorumSignature, epoch Epoch) CheckpointCert {
+	return CheckpointCert{checkpoint: checkpoint, signature: signature, epoch: epoch}
+}
+
+// Checkpoint returns the checkpoint that was signed.
+func (cc CheckpointCert) Checkpoint() Checkpoint {
+	return cc.checkpoint
+}
+
+// Signature returns the threshold signature.
+func (cc CheckpointCert) Signature() QuorumSignature {
+	return cc.signature
+}
+
+// Epoch returns the epoch whose keys were used to sign the certificate.
+func (cc CheckpointCert) Epoch() Epoch {
+	return cc.epoch
+}
+
+func (cc CheckpointCert) String() string {
+	var sb strings.Builder
+	if cc.signature != nil {
+		_ = writeParticipants(&sb, cc.signature.Participants())
+	}
+	return fmt.Sprintf("CheckpointCert{ height: %d, block: %.6s, IDs: [ %s] }", cc.checkpoint.height, cc.checkpoint.block, &sb)
+}
+
+// CommitProof proves that a block was committed.
+// It holds a chain of blocks that starts with the committed block, where each block is the parent of the next.
+// The QCs embedded in the blocks certify enough of the chain to satisfy the commit rule of the consensus protocol,
+// when the rule is applied to the last block of the chain.
+type CommitProof struct {
+	blocks []*Block
+}
+
+// NewCommitProof creates a new commit proof from a chain of blocks, starting with the committed block.
+func NewCommitProof(blocks []*Block) CommitProof {
+	return CommitProof{blocks: blocks}
+}
+
+// Block returns the committed block, or nil if the proof is empty.
+func (cp CommitProof) Block() *Block {
+	if len(cp.blocks) == 0 {
+		return nil
+	}
+	return cp.blocks[0]
+}
+
+// Blocks returns the chain of blocks, starting with the committed block.
+func (cp CommitProof) Blocks() []*Block {
+	return cp.blocks
+}
+
+func (cp CommitProof) String() string {
+	if len(cp.blocks) == 0 {
+		return "CommitProof{}"
+	}
+	last := cp.blocks[len(cp.blocks)-1]
+	return fmt.Sprintf("CommitProof{ block: %.6s, blocks: %d, qc: %s }", cp.blocks[0].Hash(), len(cp.blocks), last.QuorumCert())
+}
+
 // TimeoutCert (TC) is a certificate created by a quorum of timeout messages.
 type TimeoutCert struct {
 	signature QuorumSignature
 	view      View
+	// qcViews maps each signer to the view of the highQC that it included in its timeout message.
+	// It is nil unless the TC was created from timeout messages that sign the view of the sender's highQC.
+	qcViews map[ID]View
 }
 
 // NewTimeoutCert returns a new timeout certificate.
 func NewTimeoutCert(signature QuorumSignature, view View) TimeoutCert {
-	return TimeoutCert{signature, view}
+	return TimeoutCert{signature: signature, view: view}
+}
+
+// NewTimeoutCertWithQCViews returns a new timeout certificate that records the highQC view of each signer.
+//
+// This is used by the Jolteon protocol, where the TC proves the view of the highest QC known to a quorum.
+func NewTimeoutCertWithQCViews(signature QuorumSignature, view View, qcViews map[ID]View) TimeoutCert {
+	return TimeoutCert{signature: signature, view: view, qcViews: qcViews}
 }
 
 // ToBytes returns a byte representation of the timeout certificate.
 func (tc TimeoutCert) ToBytes() []byte {
 	var viewBytes [8]byte
 	binary.LittleEndian.PutUint64(viewBytes[:], uint64(tc.view))
-	return append(viewBytes[:], tc.signature.ToBytes()...)
+	b := viewBytes[:]
+	if tc.signature != nil {
+		b = append(b, tc.signature.ToBytes()...)
+	}
+	ids := make([]ID, 0, len(tc.qcViews))
+	for id := range tc.qcViews {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		b = append(b, id.ToBytes()...)
+		b = append(b, tc.qcViews[id].ToBytes()...)
+	}
+	return b
 }
 
 // Signature returns the threshold signature.
@@ -331,11 +494,29 @@ func (tc TimeoutCert) View() View {
 	return tc.view
 }
 
+// QCViews returns the highQC view of each signer, or nil if the TC does not record them.
+func (tc TimeoutCert) QCViews() map[ID]View {
+	return tc.qcViews
+}
+
+// HighQCView returns the highest QC view recorded in the TC.
+func (tc TimeoutCert) HighQCView() (highQCView View) {
+	for _, view := range tc.qcViews {
+		if view > highQCView {
+			highQCView = view
+		}
+	}
+	return highQCView
+}
+
 func (tc TimeoutCert) String() string {
 	var sb strings.Builder
 	if tc.signature != nil {
 		_ = writeParticipants(&sb, tc.Signature().Participants())
 	}
+	if tc.qcViews != nil {
+		return fmt.Sprintf("TC{ view: %d, highQC view: %d, IDs: [ %s] }", tc.view, tc.HighQCView(), &sb)
+	}
 	return fmt.Sprintf("TC{ view: %d, IDs: [ %s] }", tc.view, &sb)
 }
 
@@ -0,0 +1,97 @@
+// Package verifier implements a pool of workers that verify the signatures of incoming messages.
+//
+// Without the verifier, the signatures of proposals and timeout messages are verified by the event loop,
+// which handles one event at a time. With many replicas, the event loop spends most of its time verifying signatures,
+// and it cannot keep up with the incoming messages. With the verifier, the handlers of votes, proposals,
+// and timeout messages pass the messages to a pool of workers instead, which verify them concurrently.
+// The workers add the verified messages back to the event loop, and the handlers then process them
+// without verifying them again.
+//
+// The verifier is enabled with the `--verification-workers` flag:
+//
+//	./hotstuff run --verification-workers 4
+package verifier
+
+import (
+	"context"
+
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+)
+
+// task is a message that waits to be verified.
+type task struct {
+	event  any
+	verify func() bool
+}
+
+// Verifier is a pool of workers that verify incoming messages and add the verified messages to the event loop.
+type Verifier struct {
+	eventLoop *eventloop.EventLoop
+	logger    logging.Logger
+	opts      *modules.Options
+
+	tasks chan task
+}
+
+// New returns a new verifier, which can hold up to bufferSize messages that wait to be verified.
+func New(bufferSize uint) *Verifier {
+	return &Verifier{
+		tasks: make(chan task, bufferSize),
+	}
+}
+
+// InitModule gives the module access to the other modules.
+func (v *Verifier) InitModule(mods *modules.Core) {
+	mods.Get(
+		&v.eventLoop,
+		&v.logger,
+		&v.opts,
+	)
+}
+
+// Start starts as many workers as given by the VerificationWorkers option.
+// The workers stop when the context is cancelled. It must be called before the event loop is started.
+func (v *Verifier) Start(ctx context.Context) {
+	workers := v.opts.VerificationWorkers()
+	if workers < 1 {
+		workers = 1
+	}
+	for i := 0; i < workers; i++ {
+		go v.run(ctx)
+	}
+}
+
+func (v *Verifier) run(ctx context.Context) {
+	for {
+		select {
+		case t := <-v.tasks:
+			v.handle(t)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Verify runs the verify function on a worker, and adds the event to the event loop if the function returns true.
+// If all workers are busy and the buffer is full, the message is verified by the caller instead,
+// which slows down the event loop until the workers have caught up.
+func (v *Verifier) Verify(event any, verify func() bool) {
+	t := task{event: event, verify: verify}
+	select {
+	case v.tasks <- t:
+	default:
+		v.logger.Debugf("Verifier: buffer is full, verifying %T on the caller", event)
+		v.handle(t)
+	}
+}
+
+func (v *Verifier) handle(t task) {
+	// the verify function logs the reason if the message is invalid
+	if t.verify() {
+		v.eventLoop.AddEvent(t.event)
+	}
+}
+
+var _ modules.Verifier = (*Verifier)(nil)
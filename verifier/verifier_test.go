@@ -0,0 +1,88 @@
+package verifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/logging"
+	"github.com/relab/hotstuff/modules"
+)
+
+type testEvent struct {
+	n int
+}
+
+func newVerifier(t *testing.T, bufferSize uint, workers int) (*Verifier, *eventloop.EventLoop) {
+	t.Helper()
+	v := New(bufferSize)
+	el := eventloop.New(10)
+	builder := modules.NewBuilder(1, nil)
+	builder.Options().SetVerificationWorkers(workers)
+	builder.Add(el, logging.New("test"), v)
+	builder.Build()
+	return v, el
+}
+
+// collect runs the event loop until the expected number of events have been handled, or the timeout expires.
+func collect(el *eventloop.EventLoop, want int, timeout time.Duration) []int {
+	var got []int
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	el.RegisterHandler(testEvent{}, func(event any) {
+		got = append(got, event.(testEvent).n)
+		if len(got) == want {
+			cancel()
+		}
+	})
+	el.Run(ctx)
+	return got
+}
+
+func TestVerify(t *testing.T) {
+	v, el := newVerifier(t, 10, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	v.Start(ctx)
+
+	for i := 0; i < 6; i++ {
+		i := i
+		v.Verify(testEvent{i}, func() bool { return i%2 == 0 })
+	}
+
+	got := collect(el, 3, time.Second)
+	// wait a little longer, in case an invalid event was added
+	got = append(got, collect(el, 1, 100*time.Millisecond)...)
+	if len(got) != 3 {
+		t.Fatalf("got %d events, want 3: %v", len(got), got)
+	}
+	for _, n := range got {
+		if n%2 != 0 {
+			t.Errorf("event %d was added, but could not be verified", n)
+		}
+	}
+}
+
+func TestVerifyFullBuffer(t *testing.T) {
+	// the workers are not started, so the events must be verified by the caller once the buffer is full.
+	v, el := newVerifier(t, 1, 1)
+
+	v.Verify(testEvent{0}, func() bool { return true })
+	v.Verify(testEvent{1}, func() bool { return true })
+	v.Verify(testEvent{2}, func() bool { return false })
+
+	if !el.Tick() {
+		t.Fatal("expected the event to be verified by the caller")
+	}
+	if el.Tick() {
+		t.Error("expected only one event to be added")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	v.Start(ctx)
+	if got := collect(el, 1, time.Second); len(got) != 1 || got[0] != 0 {
+		t.Errorf("got %v, want the buffered event [0]", got)
+	}
+}